@@ -0,0 +1,112 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cliutil
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+var (
+	// scrypt parameters used to encrypt ETH keys, lowered by unit tests
+	keystoreScryptN = keystore.StandardScryptN
+	keystoreScryptP = keystore.StandardScryptP
+)
+
+// isEthKeystore returns true if the ETH key file data is a JSON keystore
+// instead of a hex encoded key.
+func isEthKeystore(fileData []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(fileData), []byte("{"))
+}
+
+// decryptEthKeystore decrypts a V3 JSON keystore.
+func decryptEthKeystore(fileData []byte, passphrase *Passphrase) (*ecdsa.PrivateKey, error) {
+	password, err := passphrase.Get(false)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(fileData, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt ETH key file: %w", err)
+	}
+
+	return key.PrivateKey, nil
+}
+
+// encryptEthKey returns the key encrypted as a V3 JSON keystore.
+func encryptEthKey(privKey *ecdsa.PrivateKey, password string) ([]byte, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &keystore.Key{
+		Id:         id,
+		Address:    ethcrypto.PubkeyToAddress(privKey.PublicKey),
+		PrivateKey: privKey,
+	}
+
+	return keystore.EncryptKey(key, password, keystoreScryptN, keystoreScryptP)
+}
+
+// EncryptEthKeyFile replaces a plaintext, hex encoded ETH key file with a V3
+// JSON keystore encrypted with the passphrase. The new file is written next to
+// the existing one and then moved into place, so an interrupted migration does
+// not lose the key.
+func EncryptEthKeyFile(ethPrivKeyFile string, passphrase *Passphrase) error {
+	fileData, err := os.ReadFile(filepath.Clean(ethPrivKeyFile))
+	if err != nil {
+		return fmt.Errorf("failed to read eth-privkey file: %w", err)
+	}
+
+	if isEthKeystore(fileData) {
+		return fmt.Errorf("ETH key file %s is already encrypted", ethPrivKeyFile)
+	}
+
+	privKey, err := ethcrypto.HexToECDSA(strings.TrimSpace(string(fileData)))
+	if err != nil {
+		return err
+	}
+
+	password, err := passphrase.Get(true)
+	if err != nil {
+		return err
+	}
+
+	keyJSON, err := encryptEthKey(privKey, password)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the new file can be decrypted before replacing the old one
+	decrypted, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return err
+	}
+	if !decrypted.PrivateKey.Equal(privKey) {
+		return errors.New("encrypted ETH key does not match the original key")
+	}
+
+	tmpFile := ethPrivKeyFile + ".tmp"
+	if err = os.WriteFile(tmpFile, keyJSON, 0600); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpFile, ethPrivKeyFile); err != nil {
+		_ = os.Remove(tmpFile)
+		return err
+	}
+
+	return nil
+}
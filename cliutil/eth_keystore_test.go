@@ -0,0 +1,70 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cliutil
+
+import (
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common"
+)
+
+const testKeyHex = "87c546d6cb8ec705bea47e2ab40f42a768b1e5900686b0cecc68c0e8b74cd789"
+
+func init() {
+	// Keep the tests fast, the standard scrypt parameters take ~1s per key
+	keystoreScryptN, keystoreScryptP = keystore.LightScryptN, keystore.LightScryptP
+}
+
+func TestGetEthereumPrivateKey_newEncryptedKey(t *testing.T) {
+	t.Setenv(EthKeyPasswordEnv, "secret")
+	keyPath := getKeyPath(t)
+
+	key, err := GetEthereumPrivateKey(keyPath, common.Stagenet, false, false, NewEthKeyPassphrase("", false))
+	require.NoError(t, err)
+
+	fileData, err := os.ReadFile(keyPath)
+	require.NoError(t, err)
+	require.True(t, isEthKeystore(fileData))
+
+	// reading the key back requires the passphrase
+	key2, err := GetEthereumPrivateKey(keyPath, common.Stagenet, false, false, NewEthKeyPassphrase("", false))
+	require.NoError(t, err)
+	require.True(t, key.Equal(key2))
+
+	t.Setenv(EthKeyPasswordEnv, "")
+	_, err = GetEthereumPrivateKey(keyPath, common.Stagenet, false, false, nil)
+	require.ErrorIs(t, err, errNoPassphrase)
+
+	t.Setenv(EthKeyPasswordEnv, "wrong")
+	_, err = GetEthereumPrivateKey(keyPath, common.Stagenet, false, false, NewEthKeyPassphrase("", false))
+	require.ErrorContains(t, err, "could not decrypt key with given password")
+}
+
+func TestEncryptEthKeyFile(t *testing.T) {
+	t.Setenv(EthKeyPasswordEnv, "")
+	keyPath := getKeyPath(t)
+	require.NoError(t, os.WriteFile(keyPath, []byte(testKeyHex+"\n"), 0600))
+
+	passphrase := NewEthKeyPassphrase(writePasswordFile(t, "secret"), false)
+	require.NoError(t, EncryptEthKeyFile(keyPath, passphrase))
+
+	// the file is now a keystore and decrypts to the original key
+	key, err := GetEthereumPrivateKey(keyPath, common.Mainnet, false, false, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, testKeyHex, hex.EncodeToString(ethcrypto.FromECDSA(key)))
+
+	info, err := os.Stat(keyPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// encrypting twice is an error
+	err = EncryptEthKeyFile(keyPath, passphrase)
+	require.ErrorContains(t, err, "is already encrypted")
+}
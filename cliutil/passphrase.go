@@ -0,0 +1,119 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cliutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EthKeyPasswordEnv is the environment variable that the passphrase of an
+	// encrypted ETH key file is read from when no password file is given.
+	EthKeyPasswordEnv = "SWAPD_ETH_PRIVKEY_PASSWORD"
+
+	// DBPasswordEnv is the environment variable that the passphrase of the
+	// encrypted recovery database is read from when no password file is given.
+	DBPasswordEnv = "SWAPD_DB_PASSWORD"
+)
+
+var (
+	errNoPassphrase       = errors.New("no passphrase was provided")
+	errEmptyPassphrase    = errors.New("passphrase cannot be empty")
+	errPassphraseMismatch = errors.New("passphrases do not match")
+)
+
+// Passphrase describes where a passphrase used to encrypt data at rest is read
+// from.
+type Passphrase struct {
+	Description  string // What the passphrase protects, used in prompts and errors
+	PasswordFile string // Optional, file whose first line is the passphrase
+	EnvVar       string // Optional, environment variable holding the passphrase
+	AllowPrompt  bool   // Prompt on the terminal if no other source is configured
+}
+
+// NewEthKeyPassphrase returns the passphrase source of an encrypted ETH key file.
+func NewEthKeyPassphrase(passwordFile string, allowPrompt bool) *Passphrase {
+	return &Passphrase{
+		Description:  "ETH key",
+		PasswordFile: passwordFile,
+		EnvVar:       EthKeyPasswordEnv,
+		AllowPrompt:  allowPrompt,
+	}
+}
+
+// NewDBPassphrase returns the passphrase source of the encrypted recovery
+// database.
+func NewDBPassphrase(passwordFile string, allowPrompt bool) *Passphrase {
+	return &Passphrase{
+		Description:  "recovery database",
+		PasswordFile: passwordFile,
+		EnvVar:       DBPasswordEnv,
+		AllowPrompt:  allowPrompt,
+	}
+}
+
+// IsConfigured returns true if the passphrase can be read without prompting
+// the user.
+func (p *Passphrase) IsConfigured() bool {
+	if p == nil {
+		return false
+	}
+	return p.PasswordFile != "" || p.envValue() != ""
+}
+
+func (p *Passphrase) envValue() string {
+	if p.EnvVar == "" {
+		return ""
+	}
+	return os.Getenv(p.EnvVar)
+}
+
+// Get returns the passphrase from the password file, the environment variable
+// or a terminal prompt, in that order of preference. When confirm is true, a
+// prompted passphrase has to be entered twice.
+func (p *Passphrase) Get(confirm bool) (string, error) {
+	if p == nil {
+		return "", errNoPassphrase
+	}
+
+	var passphrase string
+	switch {
+	case p.PasswordFile != "":
+		data, err := os.ReadFile(filepath.Clean(p.PasswordFile))
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s password file: %w", p.Description, err)
+		}
+		passphrase = strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r")
+	case p.envValue() != "":
+		passphrase = p.envValue()
+	case p.AllowPrompt:
+		var err error
+		passphrase, err = promptPassphrase(fmt.Sprintf("%s passphrase: ", p.Description))
+		if err != nil {
+			return "", err
+		}
+		if confirm {
+			again, err := promptPassphrase(fmt.Sprintf("Repeat %s passphrase: ", p.Description))
+			if err != nil {
+				return "", err
+			}
+			if again != passphrase {
+				return "", errPassphraseMismatch
+			}
+		}
+	default:
+		return "", fmt.Errorf("%w for the %s (use a password file or set %s)",
+			errNoPassphrase, p.Description, p.EnvVar)
+	}
+
+	if passphrase == "" {
+		return "", fmt.Errorf("%s %w", p.Description, errEmptyPassphrase)
+	}
+
+	return passphrase, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cliutil
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writePasswordFile(t *testing.T, password string) string {
+	passwordFile := path.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte(password+"\n"), 0600))
+	return passwordFile
+}
+
+func TestPassphrase_Get(t *testing.T) {
+	t.Setenv(EthKeyPasswordEnv, "")
+
+	p := NewEthKeyPassphrase(writePasswordFile(t, "file-secret"), false)
+	require.True(t, p.IsConfigured())
+	password, err := p.Get(true)
+	require.NoError(t, err)
+	require.Equal(t, "file-secret", password)
+
+	// the environment variable is only used if there is no password file
+	t.Setenv(EthKeyPasswordEnv, "env-secret")
+	password, err = p.Get(true)
+	require.NoError(t, err)
+	require.Equal(t, "file-secret", password)
+
+	p = NewEthKeyPassphrase("", false)
+	require.True(t, p.IsConfigured())
+	password, err = p.Get(true)
+	require.NoError(t, err)
+	require.Equal(t, "env-secret", password)
+}
+
+func TestPassphrase_Get_fail(t *testing.T) {
+	t.Setenv(EthKeyPasswordEnv, "")
+
+	var p *Passphrase
+	require.False(t, p.IsConfigured())
+	_, err := p.Get(false)
+	require.ErrorIs(t, err, errNoPassphrase)
+
+	p = NewEthKeyPassphrase("", false)
+	require.False(t, p.IsConfigured())
+	_, err = p.Get(false)
+	require.ErrorIs(t, err, errNoPassphrase)
+
+	p = NewEthKeyPassphrase(writePasswordFile(t, ""), false)
+	_, err = p.Get(false)
+	require.ErrorIs(t, err, errEmptyPassphrase)
+}
+
+func TestPassphrase_Get_envVarIsPerPassphrase(t *testing.T) {
+	t.Setenv(EthKeyPasswordEnv, "eth-secret")
+	t.Setenv(DBPasswordEnv, "")
+
+	require.True(t, NewEthKeyPassphrase("", false).IsConfigured())
+	require.False(t, NewDBPassphrase("", false).IsConfigured())
+
+	_, err := NewDBPassphrase("", false).Get(false)
+	require.ErrorIs(t, err, errNoPassphrase)
+	require.ErrorContains(t, err, DBPasswordEnv)
+}
@@ -27,7 +27,12 @@ var (
 	log = logging.Logger("cmd")
 )
 
-func createAndWriteEthKeyFile(ethPrivKeyFile string, env common.Environment, devXMRMaker, devXMRTaker bool) error {
+func createAndWriteEthKeyFile(
+	ethPrivKeyFile string,
+	env common.Environment,
+	devXMRMaker, devXMRTaker bool,
+	passphrase *Passphrase,
+) error {
 	var key *ecdsa.PrivateKey
 	var err error
 
@@ -43,10 +48,24 @@ func createAndWriteEthKeyFile(ethPrivKeyFile string, env common.Environment, dev
 		return err
 	}
 
-	privKeyStr := hexutil.Encode(ethcrypto.FromECDSA(key))
-	privKeyStr = strings.TrimPrefix(privKeyStr, "0x")
+	// New keys are only encrypted if a passphrase was configured, so unattended
+	// (dev) setups keep working without any additional flags.
+	var fileData []byte
+	if passphrase.IsConfigured() {
+		password, err := passphrase.Get(true)
+		if err != nil {
+			return err
+		}
+		fileData, err = encryptEthKey(key, password)
+		if err != nil {
+			return err
+		}
+	} else {
+		privKeyStr := hexutil.Encode(ethcrypto.FromECDSA(key))
+		fileData = []byte(strings.TrimPrefix(privKeyStr, "0x"))
+	}
 
-	if err := os.WriteFile(ethPrivKeyFile, []byte(privKeyStr), 0600); err != nil {
+	if err := os.WriteFile(ethPrivKeyFile, fileData, 0600); err != nil {
 		return err
 	}
 
@@ -57,10 +76,14 @@ func createAndWriteEthKeyFile(ethPrivKeyFile string, env common.Environment, dev
 }
 
 // GetEthereumPrivateKey reads or creates and returns an ethereum private key for the given the CLI options.
-func GetEthereumPrivateKey(ethPrivKeyFile string, env common.Environment, devXMRMaker, devXMRTaker bool) (
-	*ecdsa.PrivateKey,
-	error,
-) {
+// The key file can either be a hex encoded key or a V3 JSON keystore, which is decrypted using the
+// passphrase. A newly created key file is encrypted if the passphrase is configured.
+func GetEthereumPrivateKey(
+	ethPrivKeyFile string,
+	env common.Environment,
+	devXMRMaker, devXMRTaker bool,
+	passphrase *Passphrase,
+) (*ecdsa.PrivateKey, error) {
 	if ethPrivKeyFile == "" {
 		panic("missing required parameter ethPrivKeyFile")
 	}
@@ -70,7 +93,7 @@ func GetEthereumPrivateKey(ethPrivKeyFile string, env common.Environment, devXMR
 		return nil, err
 	}
 	if !exists {
-		if err = createAndWriteEthKeyFile(ethPrivKeyFile, env, devXMRMaker, devXMRTaker); err != nil {
+		if err = createAndWriteEthKeyFile(ethPrivKeyFile, env, devXMRMaker, devXMRTaker, passphrase); err != nil {
 			return nil, err
 		}
 	}
@@ -79,10 +102,22 @@ func GetEthereumPrivateKey(ethPrivKeyFile string, env common.Environment, devXMR
 	if err != nil {
 		return nil, fmt.Errorf("failed to read eth-privkey file: %w", err)
 	}
-	ethPrivKeyHex := strings.TrimSpace(string(fileData))
-	privkey, err := ethcrypto.HexToECDSA(ethPrivKeyHex)
-	if err != nil {
-		return nil, err
+	var privkey *ecdsa.PrivateKey
+	if isEthKeystore(fileData) {
+		privkey, err = decryptEthKeystore(fileData, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ethPrivKeyHex := strings.TrimSpace(string(fileData))
+		privkey, err = ethcrypto.HexToECDSA(ethPrivKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		if env != common.Development {
+			log.Warnf("ETH key file %s is not encrypted, use \"swapd encrypt-eth-key\" to encrypt it",
+				ethPrivKeyFile)
+		}
 	}
 
 	if exists {
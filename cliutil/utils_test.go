@@ -30,7 +30,7 @@ func TestGetEthereumPrivateKey_devXMRMaker(t *testing.T) {
 	devXMRMaker := true
 	devXMRTaker := false
 	keyPath := getKeyPath(t)
-	key, err := GetEthereumPrivateKey(keyPath, common.Development, devXMRMaker, devXMRTaker)
+	key, err := GetEthereumPrivateKey(keyPath, common.Development, devXMRMaker, devXMRTaker, nil)
 	require.NoError(t, err)
 	expectedKeyHex := hex.EncodeToString(ethcrypto.FromECDSA(key))
 	require.Equal(t, common.DefaultPrivKeyXMRMaker, expectedKeyHex)
@@ -41,7 +41,7 @@ func TestGetEthereumPrivateKey_devXMRTaker(t *testing.T) {
 	devXMRMaker := false
 	devXMRTaker := true
 	keyPath := getKeyPath(t)
-	key, err := GetEthereumPrivateKey(keyPath, common.Development, devXMRMaker, devXMRTaker)
+	key, err := GetEthereumPrivateKey(keyPath, common.Development, devXMRMaker, devXMRTaker, nil)
 	require.NoError(t, err)
 	expectedKeyHex := hex.EncodeToString(ethcrypto.FromECDSA(key))
 	require.Equal(t, common.DefaultPrivKeyXMRTaker, hex.EncodeToString(ethcrypto.FromECDSA(key)))
@@ -52,7 +52,7 @@ func TestGetEthereumPrivateKey_nonDev_newKey(t *testing.T) {
 	devXMRMaker := true // ignored, using stagenet
 	devXMRTaker := true // ignored, using stagenet
 	keyPath := getKeyPath(t)
-	key, err := GetEthereumPrivateKey(keyPath, common.Stagenet, devXMRMaker, devXMRTaker)
+	key, err := GetEthereumPrivateKey(keyPath, common.Stagenet, devXMRMaker, devXMRTaker, nil)
 	expectedKeyHex := hex.EncodeToString(ethcrypto.FromECDSA(key))
 	require.NoError(t, err)
 	verifyKeyFile(t, keyPath, expectedKeyHex)
@@ -64,7 +64,7 @@ func TestGetEthereumPrivateKey_fromFile(t *testing.T) {
 	fileData := []byte(fmt.Sprintf("  %s\n", keyHex)) // add whitespace that we should ignore
 	keyPath := getKeyPath(t)
 	require.NoError(t, os.WriteFile(keyPath, fileData, 0600))
-	key, err := GetEthereumPrivateKey(keyPath, common.Mainnet, false, false)
+	key, err := GetEthereumPrivateKey(keyPath, common.Mainnet, false, false, nil)
 	require.NoError(t, err)
 	require.Equal(t, keyHex, hex.EncodeToString(ethcrypto.FromECDSA(key)))
 }
@@ -75,7 +75,7 @@ func TestGetEthereumPrivateKey_fromFileFail(t *testing.T) {
 	require.NoError(t, err)
 	keyFile := path.Join(t.TempDir(), "eth.key")
 	require.NoError(t, os.WriteFile(keyFile, keyBytes, 0600)) // key is binary instead of hex
-	_, err = GetEthereumPrivateKey(keyFile, common.Mainnet, false, false)
+	_, err = GetEthereumPrivateKey(keyFile, common.Mainnet, false, false, nil)
 	require.ErrorContains(t, err, "invalid hex character")
 }
 
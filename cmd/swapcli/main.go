@@ -8,6 +8,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -48,6 +49,12 @@ const (
 	flagTo             = "to"
 	flagAmount         = "amount"
 	flagGasLimit       = "gas-limit"
+	flagXMRPriority    = "xmr-priority"
+	flagArchive        = "archive"
+	flagChainID        = "chain-id"
+	flagContractAddr   = "contract-address"
+	flagFromBlock      = "from-block"
+	flagToBlock        = "to-block"
 )
 
 func cliApp() *cli.App {
@@ -194,6 +201,11 @@ func cliApp() *cli.App {
 						Name:  flagUseRelayer,
 						Usage: "Use the relayer even if the receiving account has enough ETH to claim",
 					},
+					&cli.Uint64Flag{
+						Name:  flagChainID,
+						Usage: "Chain ID of the EVM chain to swap on, if not the chain of swapd's p2p network",
+					},
+					xmrPriorityFlag,
 					swapdPortFlag,
 				},
 			},
@@ -337,6 +349,7 @@ func cliApp() *cli.App {
 						Usage:    "Amount of XMR to send",
 						Required: true,
 					},
+					xmrPriorityFlag,
 					swapdPortFlag,
 				},
 			},
@@ -350,6 +363,7 @@ func cliApp() *cli.App {
 						Usage:    "Address to sweep the XMR to",
 						Required: true,
 					},
+					xmrPriorityFlag,
 					swapdPortFlag,
 				},
 			},
@@ -388,6 +402,41 @@ func cliApp() *cli.App {
 					swapdPortFlag,
 				},
 			},
+			{
+				Name: "contract-events",
+				Usage: "Show the lifecycle of the swaps of a SwapCreator contract in a block range.\n" +
+					"Decodes the contract's New, Ready, Claimed and Refunded events, joins them by swap ID\n" +
+					"and flags the swaps that are still pending or ready after their timeout2.",
+				Action: runContractEvents,
+				Flags: []cli.Flag{
+					&cli.Uint64Flag{
+						Name:  flagChainID,
+						Usage: "Chain ID of the EVM chain to scan, if not the chain of swapd's p2p network",
+					},
+					&cli.StringFlag{
+						Name:  flagContractAddr,
+						Usage: "Address of the SwapCreator contract (default: the contract used by swapd on the chain)",
+					},
+					&cli.Uint64Flag{
+						Name:     flagFromBlock,
+						Usage:    "First block of the range to scan",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:  flagToBlock,
+						Usage: "Last block of the range to scan (default: the latest block)",
+					},
+					swapdPortFlag,
+				},
+			},
+			{
+				Name:   "relayer-stats",
+				Usage:  "Show the totals of the claims that swapd relayed for other nodes",
+				Action: runRelayerStats,
+				Flags: []cli.Flag{
+					swapdPortFlag,
+				},
+			},
 			{
 				Name:   "version",
 				Usage:  "Get the client and server versions",
@@ -396,6 +445,21 @@ func cliApp() *cli.App {
 					swapdPortFlag,
 				},
 			},
+			{
+				Name: "backup-db",
+				Usage: "Write a consistent snapshot of swapd's offers, swaps and recovery data to an archive file.\n" +
+					"The archive contains the swap secrets, keep it as safe as the swapd data directory.\n" +
+					"Use \"swapd restore-db\" to restore it.",
+				Action: runBackupDB,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     flagArchive,
+						Usage:    "Path of the new archive file, written by swapd",
+						Required: true,
+					},
+					swapdPortFlag,
+				},
+			},
 			{
 				Name:   "shutdown",
 				Usage:  "Shutdown swapd",
@@ -470,6 +534,36 @@ func cliApp() *cli.App {
 							swapdPortFlag,
 						},
 					},
+					{
+						Name: "claim-batch",
+						Usage: "Claim many swaps with one claimBatch() transaction per batch of up to 20 swaps.\n" +
+							"If no offer IDs are provided, all swaps that can be claimed now are claimed.\n" +
+							"WARNING: This should only be used as a last resort if the normal swap process fails\n" +
+							"and restarting the node does not resolve the issue.",
+						Action: runClaimBatch,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  flagOfferIDs,
+								Usage: "A comma-separated list of IDs of swaps to claim",
+							},
+							swapdPortFlag,
+						},
+					},
+					{
+						Name: "refund-batch",
+						Usage: "Refund many swaps with one refundBatch() transaction per batch of up to 20 swaps.\n" +
+							"If no offer IDs are provided, all swaps that can be refunded now are refunded.\n" +
+							"WARNING: This should only be used as a last resort if the normal swap process fails\n" +
+							"and restarting the node does not resolve the issue.",
+						Action: runRefundBatch,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  flagOfferIDs,
+								Usage: "A comma-separated list of IDs of swaps to refund",
+							},
+							swapdPortFlag,
+						},
+					},
 				},
 			},
 		},
@@ -484,6 +578,11 @@ var (
 		Value:   common.DefaultSwapdPort,
 		EnvVars: []string{"SWAPD_PORT"},
 	}
+	xmrPriorityFlag = &cli.StringFlag{
+		Name:  flagXMRPriority,
+		Usage: "Monero fee priority: one of [default|unimportant|normal|elevated|priority]",
+		Value: types.PriorityDefault.String(),
+	}
 )
 
 func main() {
@@ -735,17 +834,26 @@ func runMake(ctx *cli.Context) error {
 		fmt.Printf("\tTaker Max: %s %s\n", otherMax.Text('f'), symbol)
 	}
 
-	alwaysUseRelayer := ctx.Bool(flagUseRelayer)
+	xmrPriority, err := readXMRPriorityFlag(ctx)
+	if err != nil {
+		return err
+	}
+
+	offerExtra := types.NewOfferExtra(ctx.Bool(flagUseRelayer))
+	offerExtra.XMRPriority = xmrPriority
+
+	chainID := ctx.Uint64(flagChainID)
 
 	if !ctx.Bool(flagDetached) {
 		wsc := newClient(ctx)
 
-		resp, statusCh, err := wsc.MakeOfferAndSubscribe( //nolint:govet
+		resp, statusCh, err := wsc.MakeOfferForChainAndSubscribe( //nolint:govet
+			chainID,
 			min,
 			max,
 			exchangeRate,
 			ethAsset,
-			alwaysUseRelayer,
+			offerExtra,
 		)
 		if err != nil {
 			return err
@@ -763,7 +871,7 @@ func runMake(ctx *cli.Context) error {
 		return nil
 	}
 
-	resp, err := c.MakeOffer(min, max, exchangeRate, ethAsset, alwaysUseRelayer)
+	resp, err := c.MakeOfferForChain(chainID, min, max, exchangeRate, ethAsset, offerExtra)
 	if err != nil {
 		return err
 	}
@@ -861,6 +969,9 @@ func runGetOngoingSwap(ctx *cli.Context) error {
 			fmt.Printf("Second timeout: %s\n", info.Timeout2.Format(common.TimeFmtSecs))
 		}
 		fmt.Printf("Estimated time to completion: %s\n", info.EstimatedTimeToCompletion)
+		if info.LastTxError != "" {
+			fmt.Printf("Last transaction error: %s\n", info.LastTxError)
+		}
 	}
 
 	return nil
@@ -925,6 +1036,9 @@ func runGetPastSwap(ctx *cli.Context) error {
 			)
 		}
 		fmt.Printf("\n")
+		if info.MoneroFee != nil {
+			fmt.Printf("Monero fees: %s XMR\n", info.MoneroFee.AsMoneroString())
+		}
 		fmt.Printf("Exchange Rate: %s ETH/XMR\n", info.ExchangeRate)
 		fmt.Printf("Status: %s\n", info.Status)
 	}
@@ -963,15 +1077,11 @@ func runClearOffers(ctx *cli.Context) error {
 		return nil
 	}
 
-	var offerIDs []types.Hash
-	for _, offerIDStr := range strings.Split(ids, ",") {
-		id, err := types.HexToHash(strings.TrimSpace(offerIDStr))
-		if err != nil {
-			return errInvalidFlagValue(flagOfferIDs, err)
-		}
-		offerIDs = append(offerIDs, id)
+	offerIDs, err := parseOfferIDs(ids)
+	if err != nil {
+		return errInvalidFlagValue(flagOfferIDs, err)
 	}
-	err := c.ClearOffers(offerIDs)
+	err = c.ClearOffers(offerIDs)
 	if err != nil {
 		return err
 	}
@@ -1016,6 +1126,9 @@ func runGetStatus(ctx *cli.Context) error {
 
 	fmt.Printf("Start time: %s\n", resp.StartTime.Format(common.TimeFmtSecs))
 	fmt.Printf("Status=%s: %s\n", resp.Status, resp.Description)
+	if resp.LastTxError != "" {
+		fmt.Printf("Last transaction error: %s\n", resp.LastTxError)
+	}
 	return nil
 }
 
@@ -1051,6 +1164,74 @@ func runRefund(ctx *cli.Context) error {
 	return nil
 }
 
+func runClaimBatch(ctx *cli.Context) error {
+	offerIDs, err := parseOfferIDs(ctx.String(flagOfferIDs))
+	if err != nil {
+		return errInvalidFlagValue(flagOfferIDs, err)
+	}
+
+	c := newClient(ctx)
+	resp, err := c.ClaimBatch(offerIDs)
+	if err != nil {
+		return err
+	}
+
+	printBatchTransactions(resp)
+	return nil
+}
+
+func runRefundBatch(ctx *cli.Context) error {
+	offerIDs, err := parseOfferIDs(ctx.String(flagOfferIDs))
+	if err != nil {
+		return errInvalidFlagValue(flagOfferIDs, err)
+	}
+
+	c := newClient(ctx)
+	resp, err := c.RefundBatch(offerIDs)
+	if err != nil {
+		return err
+	}
+
+	printBatchTransactions(resp)
+	return nil
+}
+
+// parseOfferIDs parses a comma-separated list of offer IDs, returning nil for
+// an empty list
+func parseOfferIDs(ids string) ([]types.Hash, error) {
+	if ids == "" {
+		return nil, nil
+	}
+
+	var offerIDs []types.Hash
+	for _, offerIDStr := range strings.Split(ids, ",") {
+		id, err := types.HexToHash(strings.TrimSpace(offerIDStr))
+		if err != nil {
+			return nil, err
+		}
+		offerIDs = append(offerIDs, id)
+	}
+
+	return offerIDs, nil
+}
+
+func printBatchTransactions(resp *rpc.BatchTransactionResponse) {
+	if len(resp.Transactions) == 0 {
+		fmt.Println("No swaps to batch")
+		return
+	}
+
+	for i, tx := range resp.Transactions {
+		if i > 0 {
+			fmt.Println("---")
+		}
+		fmt.Printf("Transaction hash: %s\n", tx.TxHash)
+		for _, id := range tx.OfferIDs {
+			fmt.Printf("\tSwap: %s\n", id)
+		}
+	}
+}
+
 func runSetSwapTimeout(ctx *cli.Context) error {
 	duration := ctx.Uint("duration")
 	if duration == 0 {
@@ -1112,6 +1293,100 @@ func runGetVersions(ctx *cli.Context) error {
 	return nil
 }
 
+func runContractEvents(ctx *cli.Context) error {
+	req := &rpc.GetContractEventsRequest{
+		ChainID:   ctx.Uint64(flagChainID),
+		FromBlock: ctx.Uint64(flagFromBlock),
+	}
+
+	if ctx.IsSet(flagContractAddr) {
+		addr := ctx.String(flagContractAddr)
+		if !ethcommon.IsHexAddress(addr) {
+			return errInvalidFlagValue(flagContractAddr, fmt.Errorf("invalid address %q", addr))
+		}
+		contractAddr := ethcommon.HexToAddress(addr)
+		req.SwapCreatorAddr = &contractAddr
+	}
+
+	if ctx.IsSet(flagToBlock) {
+		toBlock := ctx.Uint64(flagToBlock)
+		req.ToBlock = &toBlock
+	}
+
+	c := newClient(ctx)
+	resp, err := c.GetContractEvents(req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Chain ID: %d\n", resp.ChainID)
+	fmt.Printf("Contract address: %s\n", resp.SwapCreatorAddr)
+	fmt.Printf("Blocks: %d-%d\n", resp.FromBlock, resp.ToBlock)
+	fmt.Println("Swaps:")
+	if len(resp.Swaps) == 0 {
+		fmt.Println("[none]")
+		return nil
+	}
+
+	numExpired := 0
+	for i, swap := range resp.Swaps {
+		if i > 0 {
+			fmt.Printf("---\n")
+		}
+
+		stage := swap.Stage
+		if swap.Expired {
+			stage += " (EXPIRED: past timeout2, only a refund is possible)"
+			numExpired++
+		}
+
+		fmt.Printf("Swap ID: %s\n", swap.SwapID)
+		if swap.Asset != nil {
+			fmt.Printf("Asset: %s\n", swap.Asset)
+			fmt.Printf("Value: %s (smallest unit)\n", swap.Value)
+			fmt.Printf("Timeout1: %s\n", swap.Timeout1.Format(common.TimeFmtSecs))
+			fmt.Printf("Timeout2: %s\n", swap.Timeout2.Format(common.TimeFmtSecs))
+		} else {
+			fmt.Printf("Created before block %d\n", resp.FromBlock)
+		}
+		fmt.Printf("Stage: %s\n", stage)
+		fmt.Printf("Events:\n")
+		for _, ev := range swap.Events {
+			fmt.Printf("\tblock %d %-8s tx=%s", ev.BlockNumber, ev.Name, ev.TxHash)
+			if ev.Secret != nil {
+				fmt.Printf(" secret=%s", ev.Secret)
+			}
+			fmt.Println()
+		}
+	}
+
+	if numExpired > 0 {
+		fmt.Printf("---\n%d of %d swaps are pending or ready past their timeout2\n", numExpired, len(resp.Swaps))
+	}
+
+	return nil
+}
+
+func runRelayerStats(ctx *cli.Context) error {
+	c := newClient(ctx)
+	stats, err := c.RelayerStats()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Claims relayed: %d\n", stats.ClaimsRelayed)
+	fmt.Printf("Claims rejected: %d\n", stats.ClaimsRejected)
+	fmt.Printf("Refunds relayed: %d\n", stats.RefundsRelayed)
+	fmt.Printf("Refunds rejected: %d\n", stats.RefundsRejected)
+	fmt.Printf("Gas spent: %s ETH\n", stats.GasSpentWei.AsEtherString())
+	fmt.Printf("ETH fees earned: %s ETH\n", stats.ETHFeesEarnedWei.AsEtherString())
+	for asset, earned := range stats.TokenFeesEarned {
+		fmt.Printf("%s fees earned: %s (smallest unit)\n", asset, earned)
+	}
+
+	return nil
+}
+
 func runShutdown(ctx *cli.Context) error {
 	c := newClient(ctx)
 	err := c.Shutdown()
@@ -1121,6 +1396,23 @@ func runShutdown(ctx *cli.Context) error {
 	return nil
 }
 
+func runBackupDB(ctx *cli.Context) error {
+	// swapd runs on the same host, but possibly in a different working directory
+	archivePath, err := filepath.Abs(ctx.String(flagArchive))
+	if err != nil {
+		return errInvalidFlagValue(flagArchive, err)
+	}
+
+	c := newClient(ctx)
+	resp, err := c.Backup(archivePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backed up %d offers and %d swaps to %s\n", resp.NumOffers, resp.NumSwaps, resp.Path)
+	return nil
+}
+
 func runGetContractSwapInfo(ctx *cli.Context) error {
 	offerID, err := types.HexToHash(ctx.String(flagOfferID))
 	if err != nil {
@@ -1183,9 +1475,15 @@ func runTransferXMR(ctx *cli.Context) error {
 		return err
 	}
 
+	priority, err := readXMRPriorityFlag(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &rpc.TransferXMRRequest{
-		To:     to,
-		Amount: amount,
+		To:       to,
+		Amount:   amount,
+		Priority: priority,
 	}
 
 	fmt.Printf("Transferring %s XMR to %s, waiting 1 block for confirmation\n", amount, to)
@@ -1217,8 +1515,14 @@ func runSweepXMR(ctx *cli.Context) error {
 		return err
 	}
 
+	priority, err := readXMRPriorityFlag(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &rpc.SweepXMRRequest{
-		To: to,
+		To:       to,
+		Priority: priority,
 	}
 
 	fmt.Printf("Sweeping %s XMR to %s, waiting 1 block for confirmation\n", balances.PiconeroBalance.AsMoneroString(), to)
@@ -1291,6 +1595,14 @@ func runSweepETH(ctx *cli.Context) error {
 	return nil
 }
 
+func readXMRPriorityFlag(ctx *cli.Context) (types.MoneroTxPriority, error) {
+	priority, err := types.NewMoneroTxPriority(ctx.String(flagXMRPriority))
+	if err != nil {
+		return types.PriorityDefault, errInvalidFlagValue(flagXMRPriority, err)
+	}
+	return priority, nil
+}
+
 func providesStrToVal(providesStr string) (coins.ProvidesCoin, error) {
 	var provides coins.ProvidesCoin
 
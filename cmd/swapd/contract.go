@@ -42,7 +42,7 @@ func getOrDeploySwapCreator(
 		// otherwise, load the contract from the given address
 		// and check that its bytecode is valid (ie. matches the
 		// bytecode of this repo's swap contract)
-		err = contracts.CheckSwapCreatorContractCode(ctx, ec.Raw(), swapCreatorAddr)
+		err = checkSwapCreatorContract(ctx, ec.Raw(), swapCreatorAddr)
 		if err != nil {
 			return ethcommon.Address{}, err
 		}
@@ -51,6 +51,24 @@ func getOrDeploySwapCreator(
 	return swapCreatorAddr, nil
 }
 
+// checkSwapCreatorContract checks that the contract at the address is a known
+// version of SwapCreator.sol, warning if it is an older version without some of
+// the features of this release.
+func checkSwapCreatorContract(ctx context.Context, ec *ethclient.Client, swapCreatorAddr ethcommon.Address) error {
+	version, err := contracts.GetSwapCreatorVersion(ctx, ec, swapCreatorAddr)
+	if err != nil {
+		return err
+	}
+
+	if version < contracts.SwapCreatorV2 {
+		log.Warnf("SwapCreator.sol at %s is a %s contract, which does not support permit token swaps, "+
+			"batch transactions, payout addresses, relayed refunds or ETH payouts to contract wallets",
+			swapCreatorAddr, version)
+	}
+
+	return nil
+}
+
 func deploySwapCreator(
 	ctx context.Context,
 	ec *ethclient.Client,
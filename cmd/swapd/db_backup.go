@@ -0,0 +1,81 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/athanorlabs/atomic-swap/daemon"
+	"github.com/athanorlabs/atomic-swap/db"
+)
+
+// getDBBackend returns the database backend selected with --db-backend.
+func getDBBackend(c *cli.Context, flag string) (db.Backend, error) {
+	backend, err := db.NewBackend(c.String(flag))
+	if err != nil {
+		return "", errFlagValueInvalid(flag, err)
+	}
+
+	return backend, nil
+}
+
+// runRestoreDB is the action of the restore-db command, which replaces the
+// database in the data directory with the content of a backup archive created
+// by `swapcli backup-db`.
+func runRestoreDB(c *cli.Context) error {
+	archivePath := c.String(flagArchive)
+	if archivePath == "" {
+		return errFlagValueEmpty(flagArchive)
+	}
+
+	backend, err := getDBBackend(c, flagDBBackend)
+	if err != nil {
+		return err
+	}
+
+	envConf, err := getEnvConfig(c, false, false)
+	if err != nil {
+		return err
+	}
+
+	archive, err := daemon.RestoreDatabase(envConf.DataDir, backend, archivePath, c.Bool(flagForce))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %d offers and %d swaps from the backup archive created at %s\n",
+		archive.NumOffers(), archive.NumSwaps(), archive.CreatedAt)
+	return nil
+}
+
+// runConvertDB is the action of the convert-db command, which copies the
+// database in the data directory to a different backend.
+func runConvertDB(c *cli.Context) error {
+	from, err := getDBBackend(c, flagDBBackend)
+	if err != nil {
+		return err
+	}
+
+	to, err := getDBBackend(c, flagTo)
+	if err != nil {
+		return err
+	}
+
+	envConf, err := getEnvConfig(c, false, false)
+	if err != nil {
+		return err
+	}
+
+	archive, err := daemon.ConvertDatabase(envConf.DataDir, from, to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Copied %d offers and %d swaps from the %s to the %s database.\n",
+		archive.NumOffers(), archive.NumSwaps(), from, to)
+	fmt.Printf("Start swapd with --%s=%s to use the new database.\n", flagDBBackend, to)
+	return nil
+}
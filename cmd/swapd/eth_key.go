@@ -0,0 +1,44 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/athanorlabs/atomic-swap/cliutil"
+)
+
+// getEthKeyPassphrase returns the passphrase source of an encrypted ETH key
+// file from the CLI options.
+func getEthKeyPassphrase(c *cli.Context) (*cliutil.Passphrase, error) {
+	passwordFile := c.String(flagEthPrivKeyPassword)
+	if c.IsSet(flagEthPrivKeyPassword) && passwordFile == "" {
+		return nil, errFlagValueEmpty(flagEthPrivKeyPassword)
+	}
+
+	return cliutil.NewEthKeyPassphrase(passwordFile, true), nil
+}
+
+// runEncryptEthKey is the action of the encrypt-eth-key command, which
+// migrates a plaintext ETH key file to an encrypted JSON keystore.
+func runEncryptEthKey(c *cli.Context) error {
+	ethPrivKeyFile := c.String(flagEthPrivKey)
+	if ethPrivKeyFile == "" {
+		return errFlagValueEmpty(flagEthPrivKey)
+	}
+
+	passphrase, err := getEthKeyPassphrase(c)
+	if err != nil {
+		return err
+	}
+
+	if err = cliutil.EncryptEthKeyFile(ethPrivKeyFile, passphrase); err != nil {
+		return err
+	}
+
+	fmt.Printf("Encrypted ETH key file %s\n", ethPrivKeyFile)
+	return nil
+}
@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	logging "github.com/ipfs/go-log/v2"
@@ -20,10 +22,16 @@ import (
 	"github.com/athanorlabs/atomic-swap/cliutil"
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
+	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 	"github.com/athanorlabs/atomic-swap/daemon"
+	"github.com/athanorlabs/atomic-swap/db"
+	"github.com/athanorlabs/atomic-swap/ethereum/block"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
 	"github.com/athanorlabs/atomic-swap/monero"
+	"github.com/athanorlabs/atomic-swap/protocol/backend"
+	"github.com/athanorlabs/atomic-swap/relayer"
 )
 
 const (
@@ -63,13 +71,34 @@ const (
 	flagMoneroWalletPath     = "wallet-file"
 	flagMoneroWalletPassword = "wallet-password"
 	flagMoneroWalletPort     = "wallet-port"
+	flagMoneroTxPriority     = "xmr-priority"
 	flagEthEndpoint          = "eth-endpoint"
+	flagEthChain             = "eth-chain"
+	flagEthChainsFile        = "eth-chains-file"
+	flagExtraEthChains       = "extra-eth-chain"
+	flagTokenAllowlist       = "token-allowlist"
+	flagTokenDenylist        = "token-denylist"
 	flagEthPrivKey           = "eth-privkey"
+	flagEthPayoutAddress     = "eth-payout-address"
+	flagEthPayoutFallback    = "eth-payout-fallback"
+	flagEthChainClock        = "eth-chain-clock"
+	flagEthPrivKeyPassword   = "eth-privkey-password-file"
+	flagDBPassword           = "db-password-file"
+	flagEncryptDB            = "encrypt-db"
+	flagArchive              = "archive"
+	flagForce                = "force"
+	flagDBBackend            = "db-backend"
+	flagTo                   = "to"
 	flagContractAddress      = "contract-address"
 	flagGasPrice             = "gas-price"
 	flagGasLimit             = "gas-limit"
 	flagUseExternalSigner    = "external-signer"
 	flagRelayer              = "relayer"
+	flagRelayerPeerLimit     = "relayer-max-claims-per-peer"
+	flagRelayerClaimLimit    = "relayer-max-claims"
+	flagRelayerSpendCap      = "relayer-daily-spend-cap"
+	flagRelayerHTTPAddr      = "relayer-http-addr"
+	flagRelayerHTTPOrigins   = "relayer-http-allowed-origins"
 
 	flagDevXMRTaker    = "dev-xmrtaker"
 	flagDevXMRMaker    = "dev-xmrmaker"
@@ -88,6 +117,56 @@ func cliApp() *cli.App {
 		Action:               runDaemon,
 		EnableBashCompletion: true,
 		Suggest:              true,
+		Commands: []*cli.Command{
+			{
+				Name:   "encrypt-eth-key",
+				Usage:  "Encrypt a plaintext ETH key file into a JSON keystore",
+				Action: runEncryptEthKey,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     flagEthPrivKey,
+						Usage:    "File containing the hex encoded ethereum private key to encrypt",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name: flagEthPrivKeyPassword,
+						Usage: fmt.Sprintf("File containing the new passphrase (default: $%s or prompt)",
+							cliutil.EthKeyPasswordEnv),
+					},
+				},
+			},
+			{
+				Name: "restore-db",
+				Usage: "Restore the database of --data-dir (or --env) from an archive created by" +
+					" \"swapcli backup-db\". swapd must not be running.",
+				Action: runRestoreDB,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     flagArchive,
+						Usage:    "Path of the backup archive",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name: flagForce,
+						Usage: "Restore even if the database contains swaps that are newer than the archive." +
+							" Their recovery data will be lost.",
+					},
+				},
+			},
+			{
+				Name: "convert-db",
+				Usage: "Copy the database of --data-dir (or --env) from the --db-backend backend to" +
+					" another backend. swapd must not be running.",
+				Action: runConvertDB,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     flagTo,
+						Usage:    "Destination database backend: one of badger or sqlite",
+						Required: true,
+					},
+				},
+			},
+		},
 		Flags: []cli.Flag{
 			&cli.UintFlag{
 				Name:    flagRPCPort,
@@ -143,19 +222,97 @@ func cliApp() *cli.App {
 				Usage:  "The port that the internal monero-wallet-rpc instance listens on",
 				Hidden: true, // flag is for integration tests and won't be supported long term
 			},
+			&cli.StringFlag{
+				Name:    flagMoneroTxPriority,
+				Usage:   "Default Monero fee priority: one of [default|unimportant|normal|elevated|priority]",
+				EnvVars: []string{"SWAPD_XMR_PRIORITY"},
+				Value:   types.PriorityDefault.String(),
+			},
 			&cli.StringFlag{
 				Name:    flagEthEndpoint,
 				Usage:   "Ethereum client endpoint",
 				Aliases: []string{"ethereum-endpoint"},
 				EnvVars: []string{"SWAPD_ETH_ENDPOINT"},
 			},
+			&cli.StringFlag{
+				Name: flagEthChain,
+				Usage: "Name or chain ID of the EVM chain to swap on, which must be a chain of --env" +
+					" (default: mainnet, sepolia or ganache)",
+				EnvVars: []string{"SWAPD_ETH_CHAIN"},
+			},
+			&cli.StringFlag{
+				Name:    flagEthChainsFile,
+				Usage:   "JSON file with definitions of additional EVM chains",
+				EnvVars: []string{"SWAPD_ETH_CHAINS_FILE"},
+			},
+			&cli.StringSliceFlag{
+				Name: flagExtraEthChains,
+				Usage: "Additional EVM chain to serve offers on, as CHAIN or CHAIN=ENDPOINT, where CHAIN is a" +
+					" name or chain ID of --env; can be passed multiple times",
+				EnvVars: []string{"SWAPD_EXTRA_ETH_CHAINS"},
+			},
+			&cli.StringSliceFlag{
+				Name:    flagTokenAllowlist,
+				Usage:   "ERC20 token addresses that can be swapped, all tokens if not set; comma separated",
+				EnvVars: []string{"SWAPD_TOKEN_ALLOWLIST"},
+			},
+			&cli.StringSliceFlag{
+				Name:    flagTokenDenylist,
+				Usage:   "ERC20 token addresses that cannot be swapped; comma separated",
+				EnvVars: []string{"SWAPD_TOKEN_DENYLIST"},
+			},
 			&cli.StringFlag{
 				Name:    flagEthPrivKey,
-				Usage:   "File containing ethereum private key as hex, new key is generated if missing",
+				Usage:   "File containing ethereum private key as hex or JSON keystore, new key is generated if missing",
 				Aliases: []string{"ethereum-privkey"},
 				EnvVars: []string{"SWAPD_ETH_PRIVKEY"},
 				Value:   fmt.Sprintf("{DATA-DIR}/%s", common.DefaultEthKeyFileName),
 			},
+			&cli.StringFlag{
+				Name: flagEthPrivKeyPassword,
+				Usage: fmt.Sprintf(
+					"File containing the passphrase of an encrypted --%s keystore (default: $%s or prompt)",
+					flagEthPrivKey, cliutil.EthKeyPasswordEnv,
+				),
+				EnvVars: []string{"SWAPD_ETH_PRIVKEY_PASSWORD_FILE"},
+			},
+			&cli.StringFlag{
+				Name:    flagEthPayoutAddress,
+				Usage:   "Ethereum address that claimed and refunded swap funds are sent to (default: the eth-privkey address)",
+				EnvVars: []string{"SWAPD_ETH_PAYOUT_ADDRESS"},
+			},
+			&cli.BoolFlag{
+				Name: flagEthPayoutFallback,
+				Usage: fmt.Sprintf(
+					"Claim and refund to the eth-privkey address if the swap contract can't pay the --%s or a relayer is used",
+					flagEthPayoutAddress,
+				),
+				EnvVars: []string{"SWAPD_ETH_PAYOUT_FALLBACK"},
+			},
+			&cli.BoolFlag{
+				Name:    flagEthChainClock,
+				Usage:   "Measure swap timeouts with the block timestamps of the ethereum chain instead of the local clock",
+				EnvVars: []string{"SWAPD_ETH_CHAIN_CLOCK"},
+			},
+			&cli.StringFlag{
+				Name:    flagDBBackend,
+				Usage:   "Database backend: one of badger or sqlite",
+				Value:   string(db.BackendBadger),
+				EnvVars: []string{"SWAPD_DB_BACKEND"},
+			},
+			&cli.StringFlag{
+				Name: flagDBPassword,
+				Usage: fmt.Sprintf(
+					"File containing the passphrase of the encrypted recovery database (default: $%s or prompt)",
+					cliutil.DBPasswordEnv,
+				),
+				EnvVars: []string{"SWAPD_DB_PASSWORD_FILE"},
+			},
+			&cli.BoolFlag{
+				Name: flagEncryptDB,
+				Usage: "Encrypt the swap recovery database with a passphrase. Implied if a database" +
+					" passphrase is provided by file or environment variable.",
+			},
 			&cli.StringFlag{
 				Name:  flagContractAddress,
 				Usage: "Address of instance of SwapCreator.sol already deployed on-chain",
@@ -203,11 +360,36 @@ func cliApp() *cli.App {
 			&cli.BoolFlag{
 				Name: flagRelayer,
 				Usage: fmt.Sprintf(
-					"Relay claims for XMR makers and earn %s ETH (minus gas fees) per transaction",
-					coins.RelayerFeeETH.Text('f'),
+					"Relay claims for XMR makers and earn fees of the gas cost plus %d%% per transaction",
+					relayer.FeeMarginPercent,
 				),
 				Value: false,
 			},
+			&cli.UintFlag{
+				Name:    flagRelayerPeerLimit,
+				Usage:   "Max claims to relay for each peer per hour (default: unlimited)",
+				EnvVars: []string{"SWAPD_RELAYER_MAX_CLAIMS_PER_PEER"},
+			},
+			&cli.UintFlag{
+				Name:    flagRelayerClaimLimit,
+				Usage:   "Max claims to relay for all peers per hour (default: unlimited)",
+				EnvVars: []string{"SWAPD_RELAYER_MAX_CLAIMS"},
+			},
+			&cli.StringFlag{
+				Name:    flagRelayerSpendCap,
+				Usage:   "Max ETH to spend on gas relaying claims per 24 hours (default: unlimited)",
+				EnvVars: []string{"SWAPD_RELAYER_DAILY_SPEND_CAP"},
+			},
+			&cli.StringFlag{
+				Name:    flagRelayerHTTPAddr,
+				Usage:   "IP:PORT to accept relay claim requests on over HTTP (default: p2p network only)",
+				EnvVars: []string{"SWAPD_RELAYER_HTTP_ADDR"},
+			},
+			&cli.StringSliceFlag{
+				Name:    flagRelayerHTTPOrigins,
+				Usage:   "Origins that browsers can submit relay claims over HTTP from, \"*\" for all (default: none)",
+				EnvVars: []string{"SWAPD_RELAYER_HTTP_ALLOWED_ORIGINS"},
+			},
 			&cli.StringFlag{
 				Name:   flagProfile,
 				Usage:  "BIND_IP:PORT to provide profiling information on",
@@ -274,10 +456,21 @@ func runDaemon(c *cli.Context) error {
 		return err
 	}
 
+	extraChains, err := createExtraChains(c, envConf, ec)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, chain := range extraChains {
+			chain.EthereumClient.Close()
+		}
+	}()
+
 	conf, err := createSwapdConf(c, envConf, mc, ec)
 	if err != nil {
 		return err
 	}
+	conf.ExtraChains = extraChains
 
 	err = daemon.RunSwapDaemon(c.Context, conf)
 	if err != nil && !errors.Is(err, context.Canceled) {
@@ -312,6 +505,10 @@ func getEnvConfig(c *cli.Context, devXMRMaker bool, devXMRTaker bool) (*common.C
 	log.Infof("starting swapd, environment: %s", env)
 	conf := common.ConfigDefaultsForEnv(env)
 
+	if err = setEthChain(c, conf); err != nil {
+		return nil, err
+	}
+
 	// cfg.DataDir already has a default set, so only override if the user explicitly set the flag
 	if c.IsSet(flagDataDir) {
 		conf.DataDir = c.String(flagDataDir) // override the value derived from `flagEnv`
@@ -352,13 +549,48 @@ func getEnvConfig(c *cli.Context, devXMRMaker bool, devXMRTaker bool) (*common.C
 		}
 
 		if conf.SwapCreatorAddr == (ethcommon.Address{}) {
-			return nil, fmt.Errorf("flag %q or %q is required for env=%s", flagDeploy, flagContractAddress, env)
+			return nil, fmt.Errorf("flag %q or %q is required for env=%s on chain %s",
+				flagDeploy, flagContractAddress, env, conf.EthChain.Name)
 		}
 	}
 
 	return conf, nil
 }
 
+// setEthChain registers the chains of the chains file and sets the chain of
+// the config, if these flags are set.
+func setEthChain(c *cli.Context, conf *common.Config) error {
+	if c.IsSet(flagEthChainsFile) {
+		chainsFile := c.String(flagEthChainsFile)
+		if chainsFile == "" {
+			return errFlagValueEmpty(flagEthChainsFile)
+		}
+		if err := common.LoadEthChains(chainsFile); err != nil {
+			return err
+		}
+	}
+
+	// the chains file can replace the definition of the default chain, so it's
+	// looked up again
+	chainNameOrID := strconv.FormatUint(conf.EthChain.ChainID, 10)
+	if c.IsSet(flagEthChain) {
+		chainNameOrID = c.String(flagEthChain)
+	}
+
+	chain, err := common.EthChainByNameOrID(chainNameOrID)
+	if err != nil {
+		return errFlagValueInvalid(flagEthChain, err)
+	}
+
+	if chain.Env != conf.Env {
+		return fmt.Errorf("ethereum chain %s is not a %s chain", chain, conf.Env)
+	}
+
+	conf.SetEthChain(chain)
+	log.Infof("using ethereum chain %s", chain)
+	return nil
+}
+
 // validateOrDeployContracts validates or deploys the swap creator. The SwapCreatorAddr field
 // of envConf should be all zeros if deploying and its value will be replaced by the new deployed
 // contract.
@@ -411,6 +643,11 @@ func createMoneroClient(c *cli.Context, envConf *common.Config) (monero.WalletCl
 		}
 	}
 
+	txPriority, err := types.NewMoneroTxPriority(c.String(flagMoneroTxPriority))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value passed to --%s: %w", flagMoneroTxPriority, err)
+	}
+
 	return monero.NewWalletClient(&monero.WalletClientConf{
 		Env:                 envConf.Env,
 		WalletFilePath:      walletFilePath,
@@ -418,6 +655,7 @@ func createMoneroClient(c *cli.Context, envConf *common.Config) (monero.WalletCl
 		MoneroWalletRPCPath: "", // look for it in "./monero-bin/monero-wallet-rpc" and then the user's path
 		WalletPassword:      c.String(flagMoneroWalletPassword),
 		WalletPort:          c.Uint(flagMoneroWalletPort),
+		TxPriority:          txPriority,
 	})
 }
 
@@ -457,8 +695,12 @@ func createEthClient(c *cli.Context, envConf *common.Config) (extethclient.EthCl
 			return nil, errFlagsMutuallyExclusive(flagDevXMRMaker, flagDevXMRTaker)
 		}
 
-		var err error
-		ethPrivKey, err = cliutil.GetEthereumPrivateKey(ethPrivKeyFile, env, devXMRMaker, devXMRTaker)
+		passphrase, err := getEthKeyPassphrase(c)
+		if err != nil {
+			return nil, err
+		}
+
+		ethPrivKey, err = cliutil.GetEthereumPrivateKey(ethPrivKeyFile, env, devXMRMaker, devXMRTaker, passphrase)
 		if err != nil {
 			return nil, err
 		}
@@ -469,6 +711,12 @@ func createEthClient(c *cli.Context, envConf *common.Config) (extethclient.EthCl
 		return nil, err
 	}
 
+	if extendedEC.ChainID().Uint64() != envConf.EthChain.ChainID {
+		extendedEC.Close()
+		return nil, fmt.Errorf("ethereum endpoint is on chain ID %s, but the configured chain is %s",
+			extendedEC.ChainID(), envConf.EthChain)
+	}
+
 	// TODO: add configs for different eth testnets + L2 and set gas limit based on those, if not set (#153)
 	extendedEC.SetGasPrice(uint64(c.Uint(flagGasPrice)))
 	extendedEC.SetGasLimit(uint64(c.Uint(flagGasLimit)))
@@ -476,6 +724,103 @@ func createEthClient(c *cli.Context, envConf *common.Config) (extethclient.EthCl
 	return extendedEC, nil
 }
 
+// parseExtraEthChain parses a value of the extra ethereum chain flag, which has
+// the form CHAIN or CHAIN=ENDPOINT. When no endpoint is given, the chain's
+// default endpoint is returned.
+func parseExtraEthChain(value string, env common.Environment) (*common.EthChain, string, error) {
+	nameOrID, endpoint, hasEndpoint := strings.Cut(value, "=")
+
+	chain, err := common.EthChainByNameOrID(nameOrID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if chain.Env != env {
+		return nil, "", fmt.Errorf("ethereum chain %s is not a %s chain", chain, env)
+	}
+
+	if !hasEndpoint {
+		endpoint = chain.EthEndpoint
+	}
+	if endpoint == "" {
+		return nil, "", fmt.Errorf("no endpoint given for ethereum chain %s, which has no default endpoint", chain)
+	}
+
+	if chain.SwapCreatorAddr == (ethcommon.Address{}) {
+		return nil, "", fmt.Errorf("ethereum chain %s has no swap creator contract", chain)
+	}
+
+	return chain, endpoint, nil
+}
+
+// createExtraChains creates the clients of the EVM chains that swapd serves
+// offers on in addition to the primary chain. The clients use the same private
+// key as the primary chain's client. Swap contracts are never deployed on the
+// extra chains, they must be in the chain registry.
+func createExtraChains(
+	c *cli.Context,
+	envConf *common.Config,
+	ec extethclient.EthClient,
+) (_ []*backend.ChainConfig, err error) {
+	values := c.StringSlice(flagExtraEthChains)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	if !ec.HasPrivateKey() {
+		return nil, errFlagsMutuallyExclusive(flagUseExternalSigner, flagExtraEthChains)
+	}
+
+	var chains []*backend.ChainConfig
+	defer func() {
+		if err != nil {
+			for _, chain := range chains {
+				chain.EthereumClient.Close()
+			}
+		}
+	}()
+
+	seen := map[uint64]bool{envConf.EthChain.ChainID: true}
+
+	for _, value := range values {
+		chain, endpoint, err := parseExtraEthChain(value, envConf.Env)
+		if err != nil {
+			return nil, errFlagValueInvalid(flagExtraEthChains, err)
+		}
+
+		if seen[chain.ChainID] {
+			return nil, errFlagValueInvalid(flagExtraEthChains, fmt.Errorf("duplicate ethereum chain %s", chain))
+		}
+		seen[chain.ChainID] = true
+
+		chainEC, err := extethclient.NewEthClient(c.Context, envConf.Env, endpoint, ec.PrivateKey())
+		if err != nil {
+			return nil, err
+		}
+
+		chains = append(chains, &backend.ChainConfig{
+			EthereumClient:  chainEC,
+			SwapCreatorAddr: chain.SwapCreatorAddr,
+		})
+
+		if chainEC.ChainID().Uint64() != chain.ChainID {
+			return nil, fmt.Errorf("ethereum endpoint %s is on chain ID %s, but the configured chain is %s",
+				endpoint, chainEC.ChainID(), chain)
+		}
+
+		chainEC.SetGasLimit(uint64(c.Uint(flagGasLimit)))
+
+		err = checkSwapCreatorContract(c.Context, chainEC.Raw(), chain.SwapCreatorAddr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid swap creator contract on ethereum chain %s: %w", chain, err)
+		}
+
+		log.Infof("using additional ethereum chain %s", chain)
+	}
+
+	return chains, nil
+}
+
 func createSwapdConf(
 	c *cli.Context,
 	envConf *common.Config,
@@ -511,18 +856,159 @@ func createSwapdConf(
 		}
 	}
 
+	dbPasswordFile := c.String(flagDBPassword)
+	if c.IsSet(flagDBPassword) && dbPasswordFile == "" {
+		return nil, errFlagValueEmpty(flagDBPassword)
+	}
+	dbPassphrase := cliutil.NewDBPassphrase(dbPasswordFile, true)
+
+	dbBackend, err := getDBBackend(c, flagDBBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenPolicy, err := getTokenPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
+	relayerLimits, err := getRelayerLimits(c)
+	if err != nil {
+		return nil, err
+	}
+
+	relayerHTTPAddr := ""
+	if c.IsSet(flagRelayerHTTPAddr) {
+		relayerHTTPAddr = c.String(flagRelayerHTTPAddr)
+		if relayerHTTPAddr == "" {
+			return nil, errFlagValueEmpty(flagRelayerHTTPAddr)
+		}
+		if !c.Bool(flagRelayer) {
+			return nil, fmt.Errorf("flag %q requires the %q flag", flagRelayerHTTPAddr, flagRelayer)
+		}
+	}
+
+	var relayerHTTPOrigins []string
+	if c.IsSet(flagRelayerHTTPOrigins) {
+		if relayerHTTPAddr == "" {
+			return nil, fmt.Errorf("flag %q requires the %q flag", flagRelayerHTTPOrigins, flagRelayerHTTPAddr)
+		}
+		relayerHTTPOrigins = c.StringSlice(flagRelayerHTTPOrigins)
+	}
+
+	payoutAddr, err := getPayoutAddress(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Bool(flagEthPayoutFallback) && payoutAddr == (ethcommon.Address{}) {
+		return nil, fmt.Errorf("flag %q requires the %q flag", flagEthPayoutFallback, flagEthPayoutAddress)
+	}
+
+	var clk clock.Clock
+	if c.Bool(flagEthChainClock) {
+		clk = block.NewChainClock(ec.Raw())
+	}
+
 	return &daemon.SwapdConfig{
-		EnvConf:        envConf,
-		Libp2pPort:     uint16(libp2pPort),
-		Libp2pKeyfile:  libp2pKeyFile,
-		RPCPort:        uint16(rpcPort),
-		IsRelayer:      c.Bool(flagRelayer),
-		NoTransferBack: c.Bool(flagNoTransferBack),
-		MoneroClient:   mc,
-		EthereumClient: ec,
+		EnvConf:                   envConf,
+		Libp2pPort:                uint16(libp2pPort),
+		Libp2pKeyfile:             libp2pKeyFile,
+		RPCPort:                   uint16(rpcPort),
+		IsRelayer:                 c.Bool(flagRelayer),
+		NoTransferBack:            c.Bool(flagNoTransferBack),
+		MoneroClient:              mc,
+		EthereumClient:            ec,
+		DBBackend:                 dbBackend,
+		TokenPolicy:               tokenPolicy,
+		RelayerLimits:             relayerLimits,
+		RelayerHTTPAddr:           relayerHTTPAddr,
+		RelayerHTTPAllowedOrigins: relayerHTTPOrigins,
+		PayoutAddress:             payoutAddr,
+		PayoutFallback:            c.Bool(flagEthPayoutFallback),
+		Clock:                     clk,
+		DBPassphrase:              dbPassphrase.Get,
+		EncryptDB:                 c.Bool(flagEncryptDB) || dbPassphrase.IsConfigured(),
 	}, nil
 }
 
+// getPayoutAddress returns the address set with the payout address flag, or
+// the zero address if it is not set.
+func getPayoutAddress(c *cli.Context) (ethcommon.Address, error) {
+	if !c.IsSet(flagEthPayoutAddress) {
+		return ethcommon.Address{}, nil
+	}
+
+	addrStr := c.String(flagEthPayoutAddress)
+	if !ethcommon.IsHexAddress(addrStr) {
+		return ethcommon.Address{}, fmt.Errorf("%q requires a valid ethereum address", flagEthPayoutAddress)
+	}
+
+	addr := ethcommon.HexToAddress(addrStr)
+	if addr == (ethcommon.Address{}) {
+		return ethcommon.Address{}, fmt.Errorf("%q can not be the zero address", flagEthPayoutAddress)
+	}
+
+	return addr, nil
+}
+
+// getTokenPolicy returns the ERC20 token allow and deny list set with flags,
+// or nil if neither list is set.
+func getTokenPolicy(c *cli.Context) (*extethclient.TokenPolicy, error) {
+	allowed, err := parseTokenAddresses(flagTokenAllowlist, c.StringSlice(flagTokenAllowlist))
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := parseTokenAddresses(flagTokenDenylist, c.StringSlice(flagTokenDenylist))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allowed) == 0 && len(denied) == 0 {
+		return nil, nil
+	}
+
+	return &extethclient.TokenPolicy{
+		Allowed: allowed,
+		Denied:  denied,
+	}, nil
+}
+
+// getRelayerLimits returns the limits on the claims that we relay for other
+// nodes set with flags, or nil if no limits are set.
+func getRelayerLimits(c *cli.Context) (*relayer.Limits, error) {
+	if !c.IsSet(flagRelayerPeerLimit) && !c.IsSet(flagRelayerClaimLimit) && !c.IsSet(flagRelayerSpendCap) {
+		return nil, nil
+	}
+
+	limits := &relayer.Limits{
+		MaxClaimsPerPeer: int(c.Uint(flagRelayerPeerLimit)),
+		MaxClaims:        int(c.Uint(flagRelayerClaimLimit)),
+	}
+
+	if c.IsSet(flagRelayerSpendCap) {
+		spendCap, err := cliutil.ReadPositiveUnsignedDecimalFlag(c, flagRelayerSpendCap)
+		if err != nil {
+			return nil, err
+		}
+		limits.DailySpendCap = coins.EtherToWei(spendCap).BigInt()
+	}
+
+	return limits, nil
+}
+
+func parseTokenAddresses(flag string, values []string) ([]ethcommon.Address, error) {
+	var addrs []ethcommon.Address
+	for _, value := range values {
+		if !ethcommon.IsHexAddress(value) {
+			return nil, fmt.Errorf("%q requires valid ethereum addresses, got %q", flag, value)
+		}
+		addrs = append(addrs, ethcommon.HexToAddress(value))
+	}
+
+	return addrs, nil
+}
+
 func maybeBackgroundMine(ctx context.Context, devXMRMaker bool, address *mcrypto.Address) error {
 	// if we're in dev-xmrmaker mode, start background mining blocks
 	// otherwise swaps won't succeed as they'll be waiting for blocks
@@ -543,6 +1029,10 @@ func errFlagValueEmpty(flag string) error {
 	return fmt.Errorf("flag %q requires a non-empty value", flag)
 }
 
+func errFlagValueInvalid(flag string, err error) error {
+	return fmt.Errorf("invalid value for flag %q: %w", flag, err)
+}
+
 func errFlagValueZero(flag string) error {
 	return fmt.Errorf("flag %q requires a non-zero value", flag)
 }
@@ -267,7 +267,7 @@ func TestDaemon_PersistOffers(t *testing.T) {
 	maxXMRAmt := one
 	xRate := coins.ToExchangeRate(one)
 
-	offerResp, err := client.MakeOffer(minXMRAmt, maxXMRAmt, xRate, types.EthAssetETH, false)
+	offerResp, err := client.MakeOffer(minXMRAmt, maxXMRAmt, xRate, types.EthAssetETH, nil)
 	require.NoError(t, err)
 
 	// shut down the daemon to verify that the offer still exists on restart
@@ -301,3 +301,34 @@ func TestDaemon_PersistOffers(t *testing.T) {
 	require.Equal(t, 1, len(resp.Offers))
 	require.Equal(t, offerResp.OfferID, resp.Offers[0].ID)
 }
+
+func TestParseExtraEthChain(t *testing.T) {
+	err := common.RegisterEthChain(&common.EthChain{
+		ChainID:         999101,
+		Name:            "test-extra-chain",
+		Env:             common.Stagenet,
+		EthEndpoint:     "http://127.0.0.1:1234",
+		SwapCreatorAddr: ethcommon.Address{1},
+		BlockTime:       time.Second,
+	})
+	require.NoError(t, err)
+
+	chain, endpoint, err := parseExtraEthChain("test-extra-chain", common.Stagenet)
+	require.NoError(t, err)
+	require.Equal(t, uint64(999101), chain.ChainID)
+	require.Equal(t, "http://127.0.0.1:1234", endpoint)
+
+	_, endpoint, err = parseExtraEthChain("999101=http://127.0.0.1:5678", common.Stagenet)
+	require.NoError(t, err)
+	require.Equal(t, "http://127.0.0.1:5678", endpoint)
+
+	_, _, err = parseExtraEthChain("test-extra-chain", common.Mainnet)
+	require.ErrorContains(t, err, "is not a mainnet chain")
+
+	_, _, err = parseExtraEthChain("test-extra-chain=", common.Stagenet)
+	require.ErrorContains(t, err, "no endpoint given")
+
+	// the default arbitrum-sepolia chain has no swap creator deployment
+	_, _, err = parseExtraEthChain("arbitrum-sepolia", common.Stagenet)
+	require.ErrorContains(t, err, "has no swap creator contract")
+}
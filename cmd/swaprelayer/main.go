@@ -0,0 +1,401 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package main provides the entrypoint of the swaprelayer executable, a node
+// that relays the claims of other swap nodes for a fee. Unlike swapd, it only
+// needs an ethereum key and endpoint, as it does not swap.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/urfave/cli/v2"
+
+	"github.com/athanorlabs/atomic-swap/cliutil"
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/relayer"
+	"github.com/athanorlabs/atomic-swap/relayernode"
+)
+
+const (
+	defaultLibp2pPort = 9910
+	defaultRPCPort    = common.DefaultSwapdPort + 10
+
+	// relayers keep their key files in a subdirectory of the environment's
+	// data directory, so that they can run next to swapd
+	relayerDataDirName = "relayer"
+
+	flagEnv                = "env"
+	flagDataDir            = "data-dir"
+	flagLibp2pKey          = "libp2p-key"
+	flagLibp2pPort         = "libp2p-port"
+	flagBootnodes          = "bootnodes"
+	flagRPCPort            = "rpc-port"
+	flagEthEndpoint        = "eth-endpoint"
+	flagEthChain           = "eth-chain"
+	flagEthChainsFile      = "eth-chains-file"
+	flagEthPrivKey         = "eth-privkey"
+	flagEthPrivKeyPassword = "eth-privkey-password-file"
+	flagContractAddress    = "contract-address"
+	flagGasPrice           = "gas-price"
+	flagMaxClaimsPerPeer   = "max-claims-per-peer"
+	flagMaxClaims          = "max-claims"
+	flagDailySpendCap      = "daily-spend-cap"
+	flagHTTPAddr           = "http-addr"
+	flagHTTPOrigins        = "http-allowed-origins"
+)
+
+var log = logging.Logger("cmd")
+
+func cliApp() *cli.App {
+	return &cli.App{
+		Name:                 "swaprelayer",
+		Usage:                "A relayer of claims for the atomic swap p2p network",
+		Version:              cliutil.GetVersion(),
+		Action:               runRelayer,
+		EnableBashCompletion: true,
+		Suggest:              true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagEnv,
+				Usage:   "Environment to use: one of mainnet, stagenet, or dev",
+				Value:   "mainnet",
+				EnvVars: []string{"SWAPD_ENV"},
+			},
+			&cli.StringFlag{
+				Name:  flagDataDir,
+				Usage: "Path to store the relayer's keys",
+				Value: fmt.Sprintf("{HOME}/.atomicswap/{ENV}/%s", relayerDataDirName), // For --help only
+			},
+			&cli.StringFlag{
+				Name:  flagLibp2pKey,
+				Usage: "libp2p private key",
+				Value: fmt.Sprintf("{DATA_DIR}/%s", common.DefaultLibp2pKeyFileName),
+			},
+			&cli.UintFlag{
+				Name:    flagLibp2pPort,
+				Usage:   "libp2p port to listen on",
+				EnvVars: []string{"SWAPD_LIBP2P_PORT"},
+				Value:   defaultLibp2pPort,
+			},
+			&cli.StringSliceFlag{
+				Name:    flagBootnodes,
+				Aliases: []string{"bn"},
+				Usage:   "libp2p bootnode, comma separated if passing multiple to a single flag",
+				EnvVars: []string{"SWAPD_BOOTNODES"},
+			},
+			&cli.UintFlag{
+				Name:    flagRPCPort,
+				Usage:   "Port for the relayer RPC server to run on",
+				Value:   defaultRPCPort,
+				EnvVars: []string{"SWAPD_RPC_PORT"},
+			},
+			&cli.StringFlag{
+				Name:    flagEthEndpoint,
+				Usage:   "Ethereum client endpoint",
+				EnvVars: []string{"SWAPD_ETH_ENDPOINT"},
+			},
+			&cli.StringFlag{
+				Name:    flagEthChain,
+				Usage:   "Name or chain ID of the ethereum chain (default: the primary chain of --env)",
+				EnvVars: []string{"SWAPD_ETH_CHAIN"},
+			},
+			&cli.StringFlag{
+				Name:    flagEthChainsFile,
+				Usage:   "JSON file with additional ethereum chain definitions",
+				EnvVars: []string{"SWAPD_ETH_CHAINS_FILE"},
+			},
+			&cli.StringFlag{
+				Name:  flagEthPrivKey,
+				Usage: "File containing an ethereum private key as hex or an encrypted JSON keystore",
+				Value: fmt.Sprintf("{DATA_DIR}/%s", common.DefaultEthKeyFileName), // For --help only
+			},
+			&cli.StringFlag{
+				Name: flagEthPrivKeyPassword,
+				Usage: fmt.Sprintf(
+					"File containing the password of an encrypted --%s keystore (default: $%s or prompt)",
+					flagEthPrivKey, cliutil.EthKeyPasswordEnv,
+				),
+				EnvVars: []string{"SWAPD_ETH_PRIVKEY_PASSWORD_FILE"},
+			},
+			&cli.StringFlag{
+				Name:  flagContractAddress,
+				Usage: "Address of instance of SwapCreator.sol whose claims to relay (default: the chain's contract)",
+			},
+			&cli.UintFlag{
+				Name:  flagGasPrice,
+				Usage: "Ethereum gas price to use for transactions (in gwei). If not set, the gas price is set via oracle.",
+			},
+			&cli.UintFlag{
+				Name:    flagMaxClaimsPerPeer,
+				Usage:   "Max claims to relay for each peer per hour (default: unlimited)",
+				EnvVars: []string{"SWAPD_RELAYER_MAX_CLAIMS_PER_PEER"},
+			},
+			&cli.UintFlag{
+				Name:    flagMaxClaims,
+				Usage:   "Max claims to relay for all peers per hour (default: unlimited)",
+				EnvVars: []string{"SWAPD_RELAYER_MAX_CLAIMS"},
+			},
+			&cli.StringFlag{
+				Name:    flagDailySpendCap,
+				Usage:   "Max ETH to spend on gas relaying claims per 24 hours (default: unlimited)",
+				EnvVars: []string{"SWAPD_RELAYER_DAILY_SPEND_CAP"},
+			},
+			&cli.StringFlag{
+				Name:    flagHTTPAddr,
+				Usage:   "IP:PORT to accept relay claim requests on over HTTP (default: p2p network only)",
+				EnvVars: []string{"SWAPD_RELAYER_HTTP_ADDR"},
+			},
+			&cli.StringSliceFlag{
+				Name:    flagHTTPOrigins,
+				Usage:   "Origins that browsers can submit relay claims over HTTP from, \"*\" for all (default: none)",
+				EnvVars: []string{"SWAPD_RELAYER_HTTP_ALLOWED_ORIGINS"},
+			},
+			&cli.StringFlag{
+				Name:    cliutil.FlagLogLevel,
+				Usage:   "Set log level: one of [error|warn|info|debug]",
+				EnvVars: []string{"SWAPD_LOG_LEVEL"},
+				Value:   "info",
+			},
+		},
+	}
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go cliutil.SignalHandler(ctx, cancel, log)
+
+	err := cliApp().RunContext(ctx, os.Args)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runRelayer(c *cli.Context) error {
+	// Fail if any non-flag arguments were passed
+	if c.Args().Present() {
+		return fmt.Errorf("unknown command %q", c.Args().First())
+	}
+
+	if err := cliutil.SetLogLevelsFromContext(c); err != nil {
+		return err
+	}
+
+	envConf, err := getEnvConfig(c)
+	if err != nil {
+		return err
+	}
+
+	ec, err := createEthClient(c, envConf)
+	if err != nil {
+		return err
+	}
+	defer ec.Close()
+
+	version, err := contracts.GetSwapCreatorVersion(c.Context, ec.Raw(), envConf.SwapCreatorAddr)
+	if err != nil {
+		return err
+	}
+	if version < contracts.SwapCreatorV2 {
+		log.Warnf("SwapCreator.sol at %s is a %s contract, which does not support relayed refunds",
+			envConf.SwapCreatorAddr, version)
+	}
+
+	libp2pKeyFile := ""
+	if c.IsSet(flagLibp2pKey) {
+		libp2pKeyFile = c.String(flagLibp2pKey)
+		if libp2pKeyFile == "" {
+			return errFlagValueEmpty(flagLibp2pKey)
+		}
+	}
+
+	limits, err := getLimits(c)
+	if err != nil {
+		return err
+	}
+
+	httpAddr := ""
+	if c.IsSet(flagHTTPAddr) {
+		httpAddr = c.String(flagHTTPAddr)
+		if httpAddr == "" {
+			return errFlagValueEmpty(flagHTTPAddr)
+		}
+	}
+
+	var httpOrigins []string
+	if c.IsSet(flagHTTPOrigins) {
+		if httpAddr == "" {
+			return fmt.Errorf("flag %q requires the %q flag", flagHTTPOrigins, flagHTTPAddr)
+		}
+		httpOrigins = c.StringSlice(flagHTTPOrigins)
+	}
+
+	log.Infof("starting relayer with address %s", ec.Address())
+	err = relayernode.RunRelayerNode(c.Context, &relayernode.Config{
+		EnvConf:            envConf,
+		EthereumClient:     ec,
+		Libp2pPort:         uint16(c.Uint(flagLibp2pPort)),
+		Libp2pKeyfile:      libp2pKeyFile,
+		RPCPort:            uint16(c.Uint(flagRPCPort)),
+		Limits:             limits,
+		HTTPAddr:           httpAddr,
+		HTTPAllowedOrigins: httpOrigins,
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
+
+// getEnvConfig returns the environment specific config, adjusting all values
+// changed by command line options.
+func getEnvConfig(c *cli.Context) (*common.Config, error) {
+	env, err := common.NewEnv(c.String(flagEnv))
+	if err != nil {
+		return nil, err
+	}
+
+	if env == common.Bootnode {
+		return nil, fmt.Errorf("%q is not a valid environment for swaprelayer", env.String())
+	}
+
+	conf := common.ConfigDefaultsForEnv(env)
+
+	if c.IsSet(flagEthChainsFile) {
+		chainsFile := c.String(flagEthChainsFile)
+		if chainsFile == "" {
+			return nil, errFlagValueEmpty(flagEthChainsFile)
+		}
+		if err = common.LoadEthChains(chainsFile); err != nil {
+			return nil, err
+		}
+	}
+
+	chainNameOrID := strconv.FormatUint(conf.EthChain.ChainID, 10)
+	if c.IsSet(flagEthChain) {
+		chainNameOrID = c.String(flagEthChain)
+	}
+	chain, err := common.EthChainByNameOrID(chainNameOrID)
+	if err != nil {
+		return nil, errFlagValueInvalid(flagEthChain, err)
+	}
+	if chain.Env != env {
+		return nil, fmt.Errorf("ethereum chain %s is not a %s chain", chain, env)
+	}
+	conf.SetEthChain(chain)
+
+	if c.IsSet(flagDataDir) {
+		conf.DataDir = c.String(flagDataDir)
+		if conf.DataDir == "" {
+			return nil, errFlagValueEmpty(flagDataDir)
+		}
+	} else {
+		conf.DataDir = path.Join(conf.DataDir, relayerDataDirName)
+	}
+	if err = common.MakeDir(conf.DataDir); err != nil {
+		return nil, err
+	}
+
+	if c.IsSet(flagBootnodes) {
+		conf.Bootnodes = cliutil.ExpandBootnodes(c.StringSlice(flagBootnodes))
+	}
+
+	if contractAddrStr := c.String(flagContractAddress); contractAddrStr != "" {
+		if !ethcommon.IsHexAddress(contractAddrStr) {
+			return nil, fmt.Errorf("%q requires a valid ethereum address", flagContractAddress)
+		}
+		conf.SwapCreatorAddr = ethcommon.HexToAddress(contractAddrStr)
+	}
+	if conf.SwapCreatorAddr == (ethcommon.Address{}) {
+		return nil, fmt.Errorf("flag %q is required for env=%s on chain %s", flagContractAddress, env, chain.Name)
+	}
+
+	return conf, nil
+}
+
+func createEthClient(c *cli.Context, envConf *common.Config) (extethclient.EthClient, error) {
+	ethEndpoint := envConf.EthEndpoint
+	if c.IsSet(flagEthEndpoint) {
+		ethEndpoint = c.String(flagEthEndpoint)
+	}
+	if ethEndpoint == "" {
+		return nil, errFlagValueEmpty(flagEthEndpoint)
+	}
+
+	ethPrivKeyFile := envConf.EthKeyFileName()
+	if c.IsSet(flagEthPrivKey) {
+		ethPrivKeyFile = c.String(flagEthPrivKey)
+		if ethPrivKeyFile == "" {
+			return nil, errFlagValueEmpty(flagEthPrivKey)
+		}
+	}
+
+	passwordFile := c.String(flagEthPrivKeyPassword)
+	if c.IsSet(flagEthPrivKeyPassword) && passwordFile == "" {
+		return nil, errFlagValueEmpty(flagEthPrivKeyPassword)
+	}
+	passphrase := cliutil.NewEthKeyPassphrase(passwordFile, true)
+
+	ethPrivKey, err := cliutil.GetEthereumPrivateKey(ethPrivKeyFile, envConf.Env, false, false, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := extethclient.NewEthClient(c.Context, envConf.Env, ethEndpoint, ethPrivKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ec.ChainID().Uint64() != envConf.EthChain.ChainID {
+		ec.Close()
+		return nil, fmt.Errorf("ethereum endpoint is on chain ID %s, but the configured chain is %s",
+			ec.ChainID(), envConf.EthChain)
+	}
+
+	ec.SetGasPrice(uint64(c.Uint(flagGasPrice)))
+	return ec, nil
+}
+
+// getLimits returns the limits on the claims that we relay set with flags, or
+// nil if no limits are set.
+func getLimits(c *cli.Context) (*relayer.Limits, error) {
+	if !c.IsSet(flagMaxClaimsPerPeer) && !c.IsSet(flagMaxClaims) && !c.IsSet(flagDailySpendCap) {
+		return nil, nil
+	}
+
+	limits := &relayer.Limits{
+		MaxClaimsPerPeer: int(c.Uint(flagMaxClaimsPerPeer)),
+		MaxClaims:        int(c.Uint(flagMaxClaims)),
+	}
+
+	if c.IsSet(flagDailySpendCap) {
+		spendCap, err := cliutil.ReadPositiveUnsignedDecimalFlag(c, flagDailySpendCap)
+		if err != nil {
+			return nil, err
+		}
+		limits.DailySpendCap = coins.EtherToWei(spendCap).BigInt()
+	}
+
+	return limits, nil
+}
+
+func errFlagValueEmpty(flag string) error {
+	return fmt.Errorf("flag %q requires a non-empty value", flag)
+}
+
+func errFlagValueInvalid(flag string, err error) error {
+	return fmt.Errorf("invalid value for flag %q: %w", flag, err)
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/cliutil"
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/daemon"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/rpcclient"
+	"github.com/athanorlabs/atomic-swap/tests"
+)
+
+func getFreePort(t *testing.T) uint16 {
+	port, err := common.GetFreeTCPPort()
+	require.NoError(t, err)
+	return uint16(port)
+}
+
+func TestSwapRelayer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pk := tests.GetMakerTestKey(t)
+	ec := extethclient.CreateTestClient(t, pk)
+	swapCreatorAddr, _ := contracts.DevDeploySwapCreator(t, ec.Raw(), pk)
+
+	rpcPort := getFreePort(t)
+	flags := []string{
+		"swaprelayer",
+		fmt.Sprintf("--%s=dev", flagEnv),
+		fmt.Sprintf("--%s=debug", cliutil.FlagLogLevel),
+		fmt.Sprintf("--%s=%s", flagDataDir, t.TempDir()),
+		fmt.Sprintf("--%s=%d", flagRPCPort, rpcPort),
+		fmt.Sprintf("--%s=0", flagLibp2pPort),
+		fmt.Sprintf("--%s=%s", flagContractAddress, swapCreatorAddr),
+		fmt.Sprintf("--%s=3", flagMaxClaimsPerPeer),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		err := cliApp().RunContext(ctx, flags)
+		assert.NoError(t, err)
+	}()
+
+	daemon.WaitForSwapdStart(t, rpcPort)
+
+	cli := rpcclient.NewClient(ctx, rpcPort)
+	versionResp, err := cli.Version()
+	require.NoError(t, err)
+	require.Equal(t, swapCreatorAddr, *versionResp.SwapCreatorAddr)
+
+	stats, err := cli.RelayerStats()
+	require.NoError(t, err)
+	require.Zero(t, stats.ClaimsRelayed)
+	require.Zero(t, stats.ClaimsRejected)
+
+	// relayer-only nodes do not swap
+	_, err = cli.QueryAll(coins.ProvidesXMR, 1)
+	require.ErrorContains(t, err, "unsupported by nodes that do not swap")
+
+	require.NoError(t, cli.Shutdown())
+	wg.Wait()
+}
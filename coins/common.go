@@ -4,8 +4,6 @@
 package coins
 
 import (
-	"math/big"
-
 	"github.com/cockroachdb/apd/v3"
 	logging "github.com/ipfs/go-log/v2"
 )
@@ -22,12 +20,37 @@ const (
 	MaxCoinPrecision = 100
 )
 
-// RelayerFeeWei and RelayerFeeETH are the fixed 0.01 ETH fee for using a swap
-// relayer to claim.
-var (
-	RelayerFeeWei = big.NewInt(1e16)
-	RelayerFeeETH = NewWeiAmount(RelayerFeeWei).AsEther()
-)
+// RelayerFeeTokenAmount returns the relayer fee of token swaps, which is the
+// value of the passed ETH fee in the token at the passed USD prices of ETH and
+// the token. The fee is rounded to the token's decimals.
+func RelayerFeeTokenAmount(
+	feeWei *WeiAmount,
+	ethUSDPrice *apd.Decimal,
+	tokenUSDPrice *apd.Decimal,
+	token *ERC20TokenInfo,
+) (*ERC20TokenAmount, error) {
+	if ethUSDPrice.Sign() <= 0 || tokenUSDPrice.Sign() <= 0 {
+		return nil, errNonPositivePrice
+	}
+
+	fee := new(apd.Decimal)
+	_, err := decimalCtx.Mul(fee, feeWei.AsEther(), ethUSDPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = decimalCtx.Quo(fee, fee, tokenUSDPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err = roundToDecimalPlace(fee, token.NumDecimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTokenAmountFromDecimals(fee, token), nil
+}
 
 var (
 	// decimalCtx is the apd context used for math operations on our coins
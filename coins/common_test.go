@@ -6,8 +6,10 @@ package coins
 import (
 	"testing"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -21,3 +23,23 @@ func TestDecimalCtx(t *testing.T) {
 	c.Precision = 3
 	assert.Equal(t, decimalCtx.Precision, uint32(MaxCoinPrecision))
 }
+
+func TestRelayerFeeTokenAmount(t *testing.T) {
+	token := NewERC20TokenInfo(ethcommon.Address{0x1}, 6, "Stable Token", "STBL")
+	feeWei := EtherToWei(StrToDecimal("0.01"))
+
+	// 0.01 ETH at $1850.123 per ETH is $18.50123, which is 18.50123 tokens at
+	// $1 per token
+	fee, err := RelayerFeeTokenAmount(feeWei, StrToDecimal("1850.123"), StrToDecimal("1"), token)
+	require.NoError(t, err)
+	assert.Equal(t, "18.50123", fee.AsStdString())
+	assert.Equal(t, "18501230", fee.BigInt().String())
+
+	// the fee is rounded to the token's 6 decimals
+	fee, err = RelayerFeeTokenAmount(feeWei, StrToDecimal("1000"), StrToDecimal("3"), token)
+	require.NoError(t, err)
+	assert.Equal(t, "3.333333", fee.AsStdString())
+
+	_, err = RelayerFeeTokenAmount(feeWei, StrToDecimal("1000"), StrToDecimal("0"), token)
+	require.ErrorIs(t, err, errNonPositivePrice)
+}
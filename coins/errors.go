@@ -10,6 +10,7 @@ import (
 var (
 	errNegativePiconeros = errors.New("negative piconero values are not supported")
 	errNegativeWei       = errors.New("negative Wei values are not supported")
+	errNonPositivePrice  = errors.New("prices must be positive")
 	// ErrInvalidCoin is generated when a ProvidesCoin type has an invalid string
 	ErrInvalidCoin = errors.New("invalid ProvidesCoin")
 )
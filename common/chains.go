@@ -0,0 +1,343 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/athanorlabs/atomic-swap/common/vjson"
+)
+
+// Chain IDs of the EVM L2 chains in the default chain registry
+const (
+	ArbitrumChainID        = 42161
+	OptimismChainID        = 10
+	BaseChainID            = 8453
+	ArbitrumSepoliaChainID = 421614
+	OptimismSepoliaChainID = 11155420
+	BaseSepoliaChainID     = 84532
+)
+
+var (
+	// https://data.chain.link/ethereum/mainnet/crypto-usd/eth-usd
+	mainnetETHToUSDPriceFeed = ethcommon.HexToAddress("0x5f4ec3df9cbd43714fe2740f5e3616155c5b8419")
+
+	// https://data.chain.link/ethereum/mainnet/crypto-usd/xmr-usd
+	mainnetXMRToUSDPriceFeed = ethcommon.HexToAddress("0xfa66458cce7dd15d8650015c4fce4d278271618f")
+
+	// Chainlink USD price feeds of mainnet stablecoins, keyed by token address
+	mainnetTokenToUSDPriceFeeds = map[ethcommon.Address]ethcommon.Address{
+		// USDC: https://data.chain.link/ethereum/mainnet/stablecoins/usdc-usd
+		ethcommon.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"): ethcommon.HexToAddress("0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6"), //nolint:lll
+		// USDT: https://data.chain.link/ethereum/mainnet/stablecoins/usdt-usd
+		ethcommon.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"): ethcommon.HexToAddress("0x3E7d1eAB13ad0104d2750B8863b489D65364e32D"), //nolint:lll
+		// DAI: https://data.chain.link/ethereum/mainnet/stablecoins/dai-usd
+		ethcommon.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"): ethcommon.HexToAddress("0xAed0c38402a5d19df6E4c03F4E2DceD6e29c1ee9"), //nolint:lll
+	}
+
+	defaultSwapCreatorAddr = ethcommon.HexToAddress("0x377ed3a60007048DF00135637521170628De89E5")
+)
+
+// EthChain contains the chain specific settings of an EVM chain that swapd can
+// use. Chains without a SwapCreator deployment have a zero SwapCreatorAddr.
+// Chains without Chainlink price feeds have zero feed addresses. They get their
+// prices from the Ethereum mainnet feeds if their native coin is ETH, and have
+// no prices otherwise.
+type EthChain struct {
+	ChainID         uint64
+	Name            string      // used in the p2p protocol ID, so peers only see offers for the same chain
+	Env             Environment // the environment (Monero network) that swaps on the chain use
+	EthEndpoint     string      // default endpoint, can be empty
+	SwapCreatorAddr ethcommon.Address
+	BlockTime       time.Duration
+	ETHUSDPriceFeed ethcommon.Address // price feed of the chain's native coin in USD
+	XMRUSDPriceFeed ethcommon.Address
+	NativeCoinETH   bool // the chain's native coin is ETH, so mainnet ETH prices apply
+
+	// TokenUSDPriceFeeds maps token addresses to the Chainlink USD price feeds
+	// of the tokens on the chain. Relayers use them to price the fees of
+	// token swaps. The map must not be modified.
+	TokenUSDPriceFeeds map[ethcommon.Address]ethcommon.Address
+}
+
+// HasPriceFeeds returns true if the chain has its own Chainlink price feeds.
+func (c *EthChain) HasPriceFeeds() bool {
+	return c.ETHUSDPriceFeed != (ethcommon.Address{}) && c.XMRUSDPriceFeed != (ethcommon.Address{})
+}
+
+// String returns the chain name followed by the chain ID in parentheses.
+func (c *EthChain) String() string {
+	return fmt.Sprintf("%s (chain ID %d)", c.Name, c.ChainID)
+}
+
+func (c *EthChain) validate() error {
+	switch {
+	case c.ChainID == 0:
+		return errors.New("chain ID is required")
+	case c.Name == "":
+		return fmt.Errorf("name of chain ID %d is required", c.ChainID)
+	case strings.ContainsAny(c.Name, "/ \t\n"):
+		return fmt.Errorf("invalid chain name %q", c.Name)
+	case c.Env != Mainnet && c.Env != Stagenet && c.Env != Development:
+		return fmt.Errorf("invalid environment for chain %s", c.Name)
+	case c.BlockTime <= 0:
+		return fmt.Errorf("block time of chain %s must be positive", c.Name)
+	}
+
+	return nil
+}
+
+// ethChains is the registry of supported chains keyed by chain ID
+var (
+	ethChains   = make(map[uint64]*EthChain)
+	ethChainsMu sync.RWMutex
+)
+
+func init() {
+	defaultChains := []*EthChain{
+		{
+			ChainID:         MainnetChainID,
+			Name:            "mainnet",
+			Env:             Mainnet,
+			EthEndpoint:     "", // No mainnet default (permissionless URLs are not reliable)
+			SwapCreatorAddr: defaultSwapCreatorAddr,
+			BlockTime:       12 * time.Second,
+			NativeCoinETH:   true,
+			ETHUSDPriceFeed: mainnetETHToUSDPriceFeed,
+			XMRUSDPriceFeed: mainnetXMRToUSDPriceFeed,
+
+			TokenUSDPriceFeeds: mainnetTokenToUSDPriceFeeds,
+		},
+		{
+			ChainID:       ArbitrumChainID,
+			Name:          "arbitrum",
+			Env:           Mainnet,
+			BlockTime:     250 * time.Millisecond,
+			NativeCoinETH: true,
+		},
+		{
+			ChainID:       OptimismChainID,
+			Name:          "optimism",
+			Env:           Mainnet,
+			BlockTime:     2 * time.Second,
+			NativeCoinETH: true,
+		},
+		{
+			ChainID:       BaseChainID,
+			Name:          "base",
+			Env:           Mainnet,
+			BlockTime:     2 * time.Second,
+			NativeCoinETH: true,
+		},
+		{
+			ChainID:         SepoliaChainID,
+			Name:            "sepolia",
+			Env:             Stagenet,
+			EthEndpoint:     "https://rpc.sepolia.org/",
+			SwapCreatorAddr: defaultSwapCreatorAddr,
+			BlockTime:       12 * time.Second,
+			NativeCoinETH:   true,
+		},
+		{
+			ChainID:       ArbitrumSepoliaChainID,
+			Name:          "arbitrum-sepolia",
+			Env:           Stagenet,
+			EthEndpoint:   "https://sepolia-rollup.arbitrum.io/rpc",
+			BlockTime:     250 * time.Millisecond,
+			NativeCoinETH: true,
+		},
+		{
+			ChainID:       OptimismSepoliaChainID,
+			Name:          "optimism-sepolia",
+			Env:           Stagenet,
+			EthEndpoint:   "https://sepolia.optimism.io",
+			BlockTime:     2 * time.Second,
+			NativeCoinETH: true,
+		},
+		{
+			ChainID:       BaseSepoliaChainID,
+			Name:          "base-sepolia",
+			Env:           Stagenet,
+			EthEndpoint:   "https://sepolia.base.org",
+			BlockTime:     2 * time.Second,
+			NativeCoinETH: true,
+		},
+		{
+			ChainID:     GanacheChainID,
+			Name:        "ganache",
+			Env:         Development,
+			EthEndpoint: DefaultGanacheEndpoint,
+			BlockTime:   time.Second,
+		},
+		{
+			ChainID:     HardhatChainID,
+			Name:        "hardhat",
+			Env:         Development,
+			EthEndpoint: DefaultGanacheEndpoint,
+			BlockTime:   time.Second,
+		},
+	}
+
+	for _, chain := range defaultChains {
+		if err := RegisterEthChain(chain); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterEthChain adds the chain to the registry of supported chains,
+// replacing any existing chain with the same chain ID.
+func RegisterEthChain(chain *EthChain) error {
+	if err := chain.validate(); err != nil {
+		return err
+	}
+
+	ethChainsMu.Lock()
+	defer ethChainsMu.Unlock()
+
+	for id, c := range ethChains {
+		if id != chain.ChainID && c.Name == chain.Name {
+			return fmt.Errorf("chain name %q is already used by chain ID %d", chain.Name, id)
+		}
+	}
+
+	chainCopy := *chain
+	ethChains[chain.ChainID] = &chainCopy
+	return nil
+}
+
+// EthChainByID returns a copy of the registered chain with the given chain ID.
+func EthChainByID(chainID uint64) (*EthChain, error) {
+	ethChainsMu.RLock()
+	defer ethChainsMu.RUnlock()
+
+	chain, ok := ethChains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("unsupported ethereum chain ID %d", chainID)
+	}
+
+	chainCopy := *chain
+	return &chainCopy, nil
+}
+
+// EthChainByNameOrID returns a copy of the registered chain with the given
+// name or decimal chain ID.
+func EthChainByNameOrID(nameOrID string) (*EthChain, error) {
+	if chainID, err := strconv.ParseUint(nameOrID, 10, 64); err == nil {
+		return EthChainByID(chainID)
+	}
+
+	ethChainsMu.RLock()
+	defer ethChainsMu.RUnlock()
+
+	for _, chain := range ethChains {
+		if chain.Name == strings.ToLower(nameOrID) {
+			chainCopy := *chain
+			return &chainCopy, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown ethereum chain %q", nameOrID)
+}
+
+// DefaultEthChainForEnv returns the chain used by the environment when no
+// chain is configured.
+func DefaultEthChainForEnv(env Environment) *EthChain {
+	var chainID uint64
+	switch env {
+	case Mainnet:
+		chainID = MainnetChainID
+	case Stagenet:
+		chainID = SepoliaChainID
+	case Development:
+		chainID = GanacheChainID
+	default:
+		panic("invalid environment")
+	}
+
+	chain, err := EthChainByID(chainID)
+	if err != nil {
+		panic(err) // default chains are always registered
+	}
+
+	return chain
+}
+
+// ethChainJSON is the definition of a chain in an EVM chains file
+type ethChainJSON struct {
+	ChainID         uint64             `json:"chainID" validate:"required"`
+	Name            string             `json:"name" validate:"required"`
+	Env             string             `json:"env" validate:"required"`
+	EthEndpoint     string             `json:"ethEndpoint"`
+	SwapCreatorAddr *ethcommon.Address `json:"swapCreatorAddr"`
+	BlockTime       string             `json:"blockTime" validate:"required"`
+	ETHUSDPriceFeed *ethcommon.Address `json:"ethUSDPriceFeed"`
+	XMRUSDPriceFeed *ethcommon.Address `json:"xmrUSDPriceFeed"`
+	NativeCoinETH   bool               `json:"nativeCoinETH"`
+
+	TokenUSDPriceFeeds map[ethcommon.Address]ethcommon.Address `json:"tokenUSDPriceFeeds"`
+}
+
+type ethChainsFile struct {
+	Chains []*ethChainJSON `json:"chains" validate:"dive,required"`
+}
+
+// LoadEthChains registers the chains defined in the JSON file at the given
+// path. Chains with the chain ID of an already registered chain replace it.
+func LoadEthChains(chainsFile string) error {
+	data, err := os.ReadFile(filepath.Clean(chainsFile))
+	if err != nil {
+		return err
+	}
+
+	f := new(ethChainsFile)
+	if err = vjson.UnmarshalStruct(data, f); err != nil {
+		return fmt.Errorf("invalid chains file %s: %w", chainsFile, err)
+	}
+
+	for _, c := range f.Chains {
+		chain := &EthChain{
+			ChainID:            c.ChainID,
+			Name:               c.Name,
+			EthEndpoint:        c.EthEndpoint,
+			NativeCoinETH:      c.NativeCoinETH,
+			TokenUSDPriceFeeds: c.TokenUSDPriceFeeds,
+		}
+
+		chain.Env, err = NewEnv(c.Env)
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", c.Name, err)
+		}
+
+		chain.BlockTime, err = time.ParseDuration(c.BlockTime)
+		if err != nil {
+			return fmt.Errorf("chain %s: invalid block time: %w", c.Name, err)
+		}
+
+		if c.SwapCreatorAddr != nil {
+			chain.SwapCreatorAddr = *c.SwapCreatorAddr
+		}
+		if c.ETHUSDPriceFeed != nil {
+			chain.ETHUSDPriceFeed = *c.ETHUSDPriceFeed
+		}
+		if c.XMRUSDPriceFeed != nil {
+			chain.XMRUSDPriceFeed = *c.XMRUSDPriceFeed
+		}
+
+		if err = RegisterEthChain(chain); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package common
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultEthChainForEnv(t *testing.T) {
+	for _, env := range []Environment{Development, Stagenet, Mainnet} {
+		chain := DefaultEthChainForEnv(env)
+		require.Equal(t, env, chain.Env)
+		require.Equal(t, chain.Name, ChainNameFromEnv(env))
+		require.Equal(t, chain.SwapCreatorAddr, ConfigDefaultsForEnv(env).SwapCreatorAddr)
+	}
+
+	require.Equal(t, "mainnet", ChainNameFromEnv(Mainnet))
+	require.Equal(t, "sepolia", ChainNameFromEnv(Stagenet))
+	require.Equal(t, "ganache", ChainNameFromEnv(Development))
+	require.Equal(t, "bootnode", ChainNameFromEnv(Bootnode))
+}
+
+func TestEthChainByNameOrID(t *testing.T) {
+	chain, err := EthChainByNameOrID("Arbitrum")
+	require.NoError(t, err)
+	require.Equal(t, uint64(ArbitrumChainID), chain.ChainID)
+	require.Equal(t, Mainnet, chain.Env)
+	require.False(t, chain.HasPriceFeeds())
+	require.True(t, chain.NativeCoinETH)
+
+	chain, err = EthChainByNameOrID("84532")
+	require.NoError(t, err)
+	require.Equal(t, "base-sepolia", chain.Name)
+
+	// returned chains are copies
+	chain.Name = "modified"
+	chain, err = EthChainByID(BaseSepoliaChainID)
+	require.NoError(t, err)
+	require.Equal(t, "base-sepolia", chain.Name)
+
+	_, err = EthChainByNameOrID("unknown")
+	require.ErrorContains(t, err, `unknown ethereum chain "unknown"`)
+
+	_, err = EthChainByNameOrID("999999")
+	require.ErrorContains(t, err, "unsupported ethereum chain ID 999999")
+}
+
+func TestRegisterEthChain_invalid(t *testing.T) {
+	chain := &EthChain{
+		ChainID:   999998,
+		Name:      "mainnet",
+		Env:       Mainnet,
+		BlockTime: time.Second,
+	}
+	require.ErrorContains(t, RegisterEthChain(chain), `chain name "mainnet" is already used by chain ID 1`)
+
+	chain.Name = "test/chain"
+	require.ErrorContains(t, RegisterEthChain(chain), "invalid chain name")
+
+	chain.Name = "test-chain"
+	chain.BlockTime = 0
+	require.ErrorContains(t, RegisterEthChain(chain), "block time of chain test-chain must be positive")
+
+	chain.BlockTime = time.Second
+	chain.Env = Bootnode
+	require.ErrorContains(t, RegisterEthChain(chain), "invalid environment")
+}
+
+func TestLoadEthChains(t *testing.T) {
+	const chainsJSON = `{
+		"chains": [
+			{
+				"chainID": 100,
+				"name": "gnosis",
+				"env": "mainnet",
+				"ethEndpoint": "https://rpc.gnosischain.com",
+				"swapCreatorAddr": "0x1000000000000000000000000000000000000001",
+				"blockTime": "5s",
+				"ethUSDPriceFeed": "0x2000000000000000000000000000000000000002",
+				"xmrUSDPriceFeed": "0x3000000000000000000000000000000000000003",
+				"tokenUSDPriceFeeds": {
+					"0x4000000000000000000000000000000000000004": "0x5000000000000000000000000000000000000005"
+				}
+			}
+		]
+	}`
+
+	chainsFile := path.Join(t.TempDir(), "chains.json")
+	require.NoError(t, os.WriteFile(chainsFile, []byte(chainsJSON), 0600))
+	require.NoError(t, LoadEthChains(chainsFile))
+
+	chain, err := EthChainByNameOrID("gnosis")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), chain.ChainID)
+	require.Equal(t, Mainnet, chain.Env)
+	require.Equal(t, "https://rpc.gnosischain.com", chain.EthEndpoint)
+	require.Equal(t, ethcommon.HexToAddress("0x1000000000000000000000000000000000000001"), chain.SwapCreatorAddr)
+	require.Equal(t, 5*time.Second, chain.BlockTime)
+	require.True(t, chain.HasPriceFeeds())
+	require.False(t, chain.NativeCoinETH)
+	require.Equal(t,
+		ethcommon.HexToAddress("0x5000000000000000000000000000000000000005"),
+		chain.TokenUSDPriceFeeds[ethcommon.HexToAddress("0x4000000000000000000000000000000000000004")],
+	)
+
+	conf := MainnetConfig()
+	conf.SetEthChain(chain)
+	require.Equal(t, chain.EthEndpoint, conf.EthEndpoint)
+	require.Equal(t, chain.SwapCreatorAddr, conf.SwapCreatorAddr)
+}
+
+func TestLoadEthChains_invalid(t *testing.T) {
+	chainsFile := path.Join(t.TempDir(), "chains.json")
+	const chainsJSON = `{"chains": [{"chainID": 101, "name": "test", "env": "mainnet", "blockTime": "fast"}]}`
+	require.NoError(t, os.WriteFile(chainsFile, []byte(chainsJSON), 0600))
+	require.ErrorContains(t, LoadEthChains(chainsFile), "chain test: invalid block time")
+
+	_, err := EthChainByID(101)
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package clock provides the source of time that swap timeouts are measured
+// against. Swapd uses the local clock by default, but a swap's timeouts are
+// enforced by the timestamps of ethereum blocks, so a clock can follow the
+// chain instead, and tests can use a mock clock to expire timeouts instantly.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the current time and creates timers that fire at a later time
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event timer, like time.Timer
+type Timer interface {
+	// C returns the channel that the time is sent on when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer
+	// already fired or was already stopped.
+	Stop() bool
+}
+
+// Local returns the clock of the local system
+func Local() Clock {
+	return localClock{}
+}
+
+type localClock struct{}
+
+func (localClock) Now() time.Time {
+	return time.Now()
+}
+
+func (localClock) NewTimer(d time.Duration) Timer {
+	return &localTimer{timer: time.NewTimer(d)}
+}
+
+type localTimer struct {
+	timer *time.Timer
+}
+
+func (t *localTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *localTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// Until returns the duration until t according to the clock
+func Until(c Clock, t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// WaitUntil blocks until the clock reaches t or the context is done
+func WaitUntil(ctx context.Context, c Clock, t time.Time) error {
+	timer := c.NewTimer(Until(c, t))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitUntil_local(t *testing.T) {
+	c := Local()
+	target := c.Now().Add(50 * time.Millisecond)
+	require.NoError(t, WaitUntil(context.Background(), c, target))
+	require.False(t, c.Now().Before(target))
+
+	// times in the past return immediately
+	require.NoError(t, WaitUntil(context.Background(), c, target.Add(-time.Hour)))
+}
+
+func TestWaitUntil_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := WaitUntil(ctx, Local(), time.Now().Add(24*time.Hour))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMock(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	m := NewMock(start)
+	require.Equal(t, start, m.Now())
+
+	timerA := m.NewTimer(time.Minute)
+	timerB := m.NewTimer(time.Hour)
+	timerC := m.NewTimer(2 * time.Minute)
+	require.Equal(t, 3, m.PendingTimers())
+
+	require.True(t, timerC.Stop())
+	require.False(t, timerC.Stop())
+
+	m.Add(time.Minute)
+	require.Equal(t, start.Add(time.Minute), m.Now())
+	require.Equal(t, start.Add(time.Minute), <-timerA.C())
+	require.False(t, timerA.Stop())
+	require.Equal(t, 1, m.PendingTimers())
+
+	select {
+	case <-timerB.C():
+		t.Fatal("timer fired before its deadline")
+	case <-timerC.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+
+	// WaitUntil returns once another goroutine advances the clock
+	errCh := make(chan error)
+	go func() {
+		errCh <- WaitUntil(context.Background(), m, start.Add(2*time.Hour))
+	}()
+	require.Eventually(t, func() bool { return m.PendingTimers() == 2 }, time.Second, time.Millisecond)
+	m.Add(2 * time.Hour)
+	require.NoError(t, <-errCh)
+	<-timerB.C()
+	require.Zero(t, m.PendingTimers())
+
+	// timers without a duration fire immediately
+	<-m.NewTimer(0).C()
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build !prod
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// This file is only for test support. Use the build tag "prod" to prevent
+// symbols in this file from consuming space in production binaries.
+
+// Mock is a Clock whose time only moves when the test advances it
+type Mock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*mockTimer
+}
+
+var _ Clock = (*Mock)(nil)
+
+// NewMock returns a mock clock whose time starts at start
+func NewMock(start time.Time) *Mock {
+	return &Mock{now: start}
+}
+
+// Now returns the mock clock's current time
+func (m *Mock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// NewTimer returns a timer that fires once the mock clock was advanced by d.
+// Timers with a non-positive duration fire immediately.
+func (m *Mock) NewTimer(d time.Duration) Timer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := &mockTimer{
+		mock:     m,
+		deadline: m.now.Add(d),
+		ch:       make(chan time.Time, 1),
+	}
+
+	if d <= 0 {
+		t.ch <- m.now
+		return t
+	}
+
+	m.timers = append(m.timers, t)
+	return t
+}
+
+// Add advances the mock clock by d, firing the timers that are due
+func (m *Mock) Add(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+
+	pending := m.timers[:0]
+	for _, t := range m.timers {
+		if t.deadline.After(m.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- m.now
+	}
+	m.timers = pending
+}
+
+// PendingTimers returns the number of timers that have not fired or been
+// stopped yet, so tests can wait for the code under test to start its timers
+// before advancing the clock.
+func (m *Mock) PendingTimers() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.timers)
+}
+
+type mockTimer struct {
+	mock     *Mock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (t *mockTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *mockTimer) Stop() bool {
+	m := t.mock
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, pending := range m.timers {
+		if pending == t {
+			m.timers = append(m.timers[:i], m.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -55,14 +55,24 @@ type Config struct {
 	MoneroNodes     []*MoneroNode
 	SwapCreatorAddr ethcommon.Address
 	Bootnodes       []string
+	EthChain        *EthChain // nil for bootnodes
+}
+
+// SetEthChain sets the chain and replaces the ethereum endpoint and swap
+// creator address with the defaults of the chain.
+func (c *Config) SetEthChain(chain *EthChain) {
+	c.EthChain = chain
+	c.EthEndpoint = chain.EthEndpoint
+	c.SwapCreatorAddr = chain.SwapCreatorAddr
 }
 
 // MainnetConfig is the mainnet ethereum and monero configuration
 func MainnetConfig() *Config {
+	chain := DefaultEthChainForEnv(Mainnet)
 	return &Config{
 		Env:         Mainnet,
 		DataDir:     path.Join(baseDir, "mainnet"),
-		EthEndpoint: "", // No mainnet default (permissionless URLs are not reliable)
+		EthEndpoint: chain.EthEndpoint,
 		MoneroNodes: []*MoneroNode{
 			{
 				Host: "node.sethforprivacy.com",
@@ -81,17 +91,19 @@ func MainnetConfig() *Config {
 				Port: DefaultMoneroDaemonMainnetPort,
 			},
 		},
-		SwapCreatorAddr: ethcommon.HexToAddress("0x377ed3a60007048DF00135637521170628De89E5"),
+		SwapCreatorAddr: chain.SwapCreatorAddr,
 		Bootnodes:       publicBootnodes,
+		EthChain:        chain,
 	}
 }
 
 // StagenetConfig is the monero stagenet and ethereum Sepolia configuration
 func StagenetConfig() *Config {
+	chain := DefaultEthChainForEnv(Stagenet)
 	return &Config{
 		Env:         Stagenet,
 		DataDir:     path.Join(baseDir, "stagenet"),
-		EthEndpoint: "https://rpc.sepolia.org/",
+		EthEndpoint: chain.EthEndpoint,
 		MoneroNodes: []*MoneroNode{
 			{
 				Host: "node.sethforprivacy.com",
@@ -106,23 +118,26 @@ func StagenetConfig() *Config {
 				Port: 38081,
 			},
 		},
-		SwapCreatorAddr: ethcommon.HexToAddress("0x377ed3a60007048DF00135637521170628De89E5"),
+		SwapCreatorAddr: chain.SwapCreatorAddr,
 		Bootnodes:       publicBootnodes,
+		EthChain:        chain,
 	}
 }
 
 // DevelopmentConfig is the monero and ethereum development environment configuration
 func DevelopmentConfig() *Config {
+	chain := DefaultEthChainForEnv(Development)
 	return &Config{
 		Env:         Development,
 		DataDir:     path.Join(baseDir, "dev"),
-		EthEndpoint: DefaultGanacheEndpoint,
+		EthEndpoint: chain.EthEndpoint,
 		MoneroNodes: []*MoneroNode{
 			{
 				Host: "127.0.0.1",
 				Port: DefaultMoneroDaemonMainnetPort,
 			},
 		},
+		EthChain: chain,
 	}
 }
 
@@ -199,21 +214,13 @@ func DefaultMoneroPortFromEnv(env Environment) uint {
 	}
 }
 
-// ChainNameFromEnv returns the expected chainID that we should find on the
-// ethereum endpoint when running int the passed environment.
+// ChainNameFromEnv returns the name of the default ethereum chain of the passed
+// environment.
 func ChainNameFromEnv(env Environment) string {
-	//nolint:goconst
-	switch env {
-	case Development:
-		return "ganache"
-	case Stagenet:
-		return "sepolia"
-	case Mainnet:
-		return "mainnet"
-	case Bootnode:
+	if env == Bootnode {
 		// bootnodes work across chains, so they get their own name
 		return "bootnode"
-	default:
-		panic("invalid environment")
 	}
+
+	return DefaultEthChainForEnv(env).Name
 }
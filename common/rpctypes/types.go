@@ -78,11 +78,13 @@ type TakeOfferRequest struct {
 
 // MakeOfferRequest ...
 type MakeOfferRequest struct {
-	MinAmount    *apd.Decimal        `json:"minAmount" validate:"required"`
-	MaxAmount    *apd.Decimal        `json:"maxAmount" validate:"required"`
-	ExchangeRate *coins.ExchangeRate `json:"exchangeRate" validate:"required"`
-	EthAsset     types.EthAsset      `json:"ethAsset,omitempty"`
-	UseRelayer   bool                `json:"useRelayer,omitempty"`
+	MinAmount    *apd.Decimal           `json:"minAmount" validate:"required"`
+	MaxAmount    *apd.Decimal           `json:"maxAmount" validate:"required"`
+	ExchangeRate *coins.ExchangeRate    `json:"exchangeRate" validate:"required"`
+	EthAsset     types.EthAsset         `json:"ethAsset,omitempty"`
+	UseRelayer   bool                   `json:"useRelayer,omitempty"`
+	XMRPriority  types.MoneroTxPriority `json:"xmrPriority,omitempty"`
+	ChainID      uint64                 `json:"chainID,omitempty"` // zero for the chain of the p2p network
 }
 
 // MakeOfferResponse ...
@@ -0,0 +1,86 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package types
+
+import (
+	"fmt"
+)
+
+// MoneroTxPriority is the fee priority used by monero-wallet-rpc when
+// constructing a transfer or sweep. Higher priorities pay a larger fee
+// multiplier in exchange for faster inclusion when the mempool is congested.
+type MoneroTxPriority uint32
+
+// MoneroTxPriority values. The numeric values match the priority values
+// accepted by monero-wallet-rpc.
+const (
+	// PriorityDefault leaves the priority selection to the wallet, which
+	// typically picks PriorityUnimportant or PriorityNormal depending on the
+	// state of the mempool.
+	PriorityDefault MoneroTxPriority = iota
+	// PriorityUnimportant is the lowest fee multiplier (1x).
+	PriorityUnimportant
+	// PriorityNormal is a 5x fee multiplier.
+	PriorityNormal
+	// PriorityElevated is a 25x fee multiplier.
+	PriorityElevated
+	// PriorityHighest is a 1000x fee multiplier. monero-wallet-cli calls this
+	// priority level "priority".
+	PriorityHighest
+)
+
+// NewMoneroTxPriority converts a priority name, as used by monero-wallet-cli,
+// to a MoneroTxPriority.
+func NewMoneroTxPriority(s string) (MoneroTxPriority, error) {
+	switch s {
+	case "", "default":
+		return PriorityDefault, nil
+	case "unimportant", "low":
+		return PriorityUnimportant, nil
+	case "normal":
+		return PriorityNormal, nil
+	case "elevated":
+		return PriorityElevated, nil
+	case "priority", "highest":
+		return PriorityHighest, nil
+	default:
+		return PriorityDefault, fmt.Errorf("invalid monero transaction priority %q", s)
+	}
+}
+
+// String returns the monero-wallet-cli name of the priority.
+func (p MoneroTxPriority) String() string {
+	switch p {
+	case PriorityDefault:
+		return "default"
+	case PriorityUnimportant:
+		return "unimportant"
+	case PriorityNormal:
+		return "normal"
+	case PriorityElevated:
+		return "elevated"
+	case PriorityHighest:
+		return "priority"
+	default:
+		return unknownString
+	}
+}
+
+// MarshalText returns the name of the priority
+func (p MoneroTxPriority) MarshalText() ([]byte, error) {
+	if p > PriorityHighest {
+		return nil, fmt.Errorf("cannot marshal monero transaction priority %d", p)
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText assigns the priority from its name
+func (p *MoneroTxPriority) UnmarshalText(data []byte) error {
+	p2, err := NewMoneroTxPriority(string(data))
+	if err != nil {
+		return err
+	}
+	*p = p2
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalMoneroTxPriority(t *testing.T) {
+	type S struct {
+		Priority MoneroTxPriority `json:"priority"`
+	}
+
+	const jsonText = `{
+		"priority": "elevated"
+	}`
+
+	s := new(S)
+	err := json.Unmarshal([]byte(jsonText), s)
+	require.NoError(t, err)
+	require.Equal(t, PriorityElevated, s.Priority)
+
+	jsonData, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.JSONEq(t, jsonText, string(jsonData))
+}
+
+func TestUnmarshalMoneroTxPriority_fail(t *testing.T) {
+	type S struct {
+		Priority MoneroTxPriority `json:"priority"`
+	}
+
+	s := new(S)
+	err := json.Unmarshal([]byte(`{"priority": "urgent"}`), s)
+	require.ErrorContains(t, err, `invalid monero transaction priority "urgent"`)
+
+	s.Priority = 99 // not a valid value
+	_, err = json.Marshal(s)
+	require.ErrorContains(t, err, `cannot marshal monero transaction priority 99`)
+}
+
+func TestNewMoneroTxPriority(t *testing.T) {
+	for p := PriorityDefault; p <= PriorityHighest; p++ {
+		parsed, err := NewMoneroTxPriority(p.String())
+		require.NoError(t, err)
+		require.Equal(t, p, parsed)
+	}
+
+	p, err := NewMoneroTxPriority("")
+	require.NoError(t, err)
+	require.Equal(t, PriorityDefault, p)
+}
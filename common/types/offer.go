@@ -27,6 +27,10 @@ var (
 	maxOfferValue = apd.New(1, 3) // 1000 XMR
 )
 
+// ErrMinAmountBelowRelayerFee is returned by CheckMinAmount when the offer's min
+// amount is not greater than the relayer fee
+var ErrMinAmountBelowRelayerFee = errors.New("offer is below the relayer fee")
+
 var (
 	errOfferVersionMissing = errors.New(`required "version" field missing in offer`)
 	errOfferIDNotSet       = errors.New(`"offerID" is not set`)
@@ -44,15 +48,32 @@ type Offer struct {
 	ExchangeRate *coins.ExchangeRate `json:"exchangeRate" validate:"required"`
 	EthAsset     EthAsset            `json:"ethAsset"`
 	Nonce        uint64              `json:"nonce" validate:"required"`
+	// ChainID is the EVM chain of the swap. Zero is the chain of the p2p
+	// network the offer is advertised on.
+	ChainID uint64 `json:"chainID,omitempty"`
 }
 
 // NewOffer creates and returns an Offer with an initialised ID and Version fields
+// for a swap on the chain of the p2p network.
 func NewOffer(
 	coin coins.ProvidesCoin,
 	minAmount *apd.Decimal,
 	maxAmount *apd.Decimal,
 	exRate *coins.ExchangeRate,
 	ethAsset EthAsset,
+) *Offer {
+	return NewOfferForChain(0, coin, minAmount, maxAmount, exRate, ethAsset)
+}
+
+// NewOfferForChain creates and returns an Offer with an initialised ID and
+// Version fields for a swap on the given EVM chain.
+func NewOfferForChain(
+	chainID uint64,
+	coin coins.ProvidesCoin,
+	minAmount *apd.Decimal,
+	maxAmount *apd.Decimal,
+	exRate *coins.ExchangeRate,
+	ethAsset EthAsset,
 ) *Offer {
 	var n [8]byte
 	if _, err := rand.Read(n[:]); err != nil {
@@ -74,6 +95,7 @@ func NewOffer(
 		ExchangeRate: exRate,
 		EthAsset:     ethAsset,
 		Nonce:        binary.BigEndian.Uint64(n[:]),
+		ChainID:      chainID,
 	}
 
 	offer.setID()
@@ -100,12 +122,31 @@ func (o *Offer) hash() Hash {
 	b = append(b, []byte(o.EthAsset.String())...)
 	b = append(b, []byte(",")...)
 	b = append(b, []byte(fmt.Sprintf("%d", o.Nonce))...)
+	// the chain ID is only included when set, so the IDs of offers made before
+	// offers had a chain ID don't change
+	if o.ChainID != 0 {
+		b = append(b, []byte(fmt.Sprintf(",%d", o.ChainID))...)
+	}
 	return sha3.Sum256(b)
 }
 
+// UpgradeOffer returns a copy of an offer of an older version with the current
+// version. The version is part of the offer ID, so the upgraded offer has a
+// different ID.
+func UpgradeOffer(o *Offer) (*Offer, error) {
+	upgraded := *o
+	upgraded.Version = *CurOfferVersion
+	upgraded.ID = upgraded.hash()
+	if err := upgraded.validate(); err != nil {
+		return nil, err
+	}
+	return &upgraded, nil
+}
+
 // String ...
 func (o *Offer) String() string {
-	return fmt.Sprintf("OfferID:%s Provides:%s MinAmount:%s MaxAmount:%s ExchangeRate:%s EthAsset:%s Nonce:%d",
+	return fmt.Sprintf(
+		"OfferID:%s Provides:%s MinAmount:%s MaxAmount:%s ExchangeRate:%s EthAsset:%s Nonce:%d ChainID:%d",
 		o.ID,
 		o.Provides,
 		o.MinAmount.String(),
@@ -113,6 +154,7 @@ func (o *Offer) String() string {
 		o.ExchangeRate.String(),
 		o.EthAsset,
 		o.Nonce,
+		o.ChainID,
 	)
 }
 
@@ -148,24 +190,6 @@ func (o *Offer) validate() error {
 		return errExchangeRateNil
 	}
 
-	// We want to prevent offers whose claim value is so low that a relayer
-	// can't be used to complete the swap if the maker does not have sufficient
-	// ETH to make the claim themselves. While relayers are not used with ERC20
-	// swaps, we still want a minimum swap amount, so we use the same value.
-	minAmtAsETH, err := o.ExchangeRate.ToETH(o.MinAmount)
-	if err != nil {
-		return err
-	}
-	if minAmtAsETH.Cmp(coins.RelayerFeeETH) <= 0 {
-		return fmt.Errorf(
-			"min amount must be greater than %s ETH when converted (%s XMR * %s = %s ETH)",
-			coins.RelayerFeeETH.Text('f'),
-			o.MaxAmount.Text('f'),
-			o.ExchangeRate,
-			minAmtAsETH.Text('f'),
-		)
-	}
-
 	if o.MaxAmount.Cmp(maxOfferValue) > 0 {
 		return fmt.Errorf("%s XMR exceeds max offer amount of %s XMR",
 			o.MaxAmount.Text('f'), maxOfferValue.Text('f'))
@@ -178,6 +202,33 @@ func (o *Offer) validate() error {
 	return nil
 }
 
+// CheckMinAmount returns an error if the offer's min amount, converted to the
+// offer's ETH asset, is not greater than the passed relayer fee. We want to
+// prevent offers whose claim value is so low that a relayer can't be used to
+// complete the swap if the maker does not have sufficient ETH to make the claim
+// themselves. Relayer fees follow the gas price, so the fee is an estimate.
+func (o *Offer) CheckMinAmount(relayerFee coins.EthAssetAmount) error {
+	minAmtAsAsset, err := o.ExchangeRate.ToETH(o.MinAmount)
+	if err != nil {
+		return err
+	}
+
+	if minAmtAsAsset.Cmp(relayerFee.AsStd()) <= 0 {
+		return fmt.Errorf(
+			"%w: min amount must be greater than %s %s when converted (%s XMR * %s = %s %s)",
+			ErrMinAmountBelowRelayerFee,
+			relayerFee.AsStdString(),
+			relayerFee.StdSymbol(),
+			o.MinAmount.Text('f'),
+			o.ExchangeRate,
+			minAmtAsAsset.Text('f'),
+			relayerFee.StdSymbol(),
+		)
+	}
+
+	return nil
+}
+
 // UnmarshalOffer deserializes a JSON offer, checking the version for compatibility before
 // attempting to deserialize the whole blob.
 func UnmarshalOffer(jsonData []byte) (*Offer, error) {
@@ -234,6 +285,10 @@ type OfferExtra struct {
 	// prevent the relayer from being used if there are insufficient ETH funds
 	// to claim.
 	UseRelayer bool `json:"useRelayer,omitempty"`
+
+	// XMRPriority is the fee priority used when locking XMR for swaps of the
+	// offer. PriorityDefault uses swapd's global Monero fee priority.
+	XMRPriority MoneroTxPriority `json:"xmrPriority,omitempty"`
 }
 
 // NewOfferExtra creates an OfferExtra instance
@@ -4,6 +4,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -119,6 +120,77 @@ func TestOffer_MarshalJSON_RoundTrip(t *testing.T) {
 	assert.EqualValues(t, offer1, &offer2)
 }
 
+func TestOffer_ChainID(t *testing.T) {
+	min := apd.New(100, 0)
+	max := apd.New(200, 0)
+	rate := coins.ToExchangeRate(apd.New(15, -1)) // 1.5
+
+	offer := NewOffer(coins.ProvidesXMR, min, max, rate, EthAssetETH)
+	offerJSON, err := vjson.MarshalStruct(offer)
+	require.NoError(t, err)
+	require.NotContains(t, string(offerJSON), "chainID")
+
+	// the chain ID is part of the offer ID
+	l2Offer := NewOfferForChain(42161, coins.ProvidesXMR, min, max, rate, EthAssetETH)
+	l2Offer.ID = Hash{}
+	l2Offer.Nonce = offer.Nonce
+	l2Offer.setID()
+	require.NotEqual(t, offer.ID, l2Offer.ID)
+
+	offerJSON, err = vjson.MarshalStruct(l2Offer)
+	require.NoError(t, err)
+	require.Contains(t, string(offerJSON), `"chainID":42161`)
+
+	res, err := UnmarshalOffer(offerJSON)
+	require.NoError(t, err)
+	require.Equal(t, l2Offer.ID, res.ID)
+	require.Equal(t, uint64(42161), res.ChainID)
+
+	// changing the chain ID invalidates the offer
+	offerJSON = bytes.Replace(offerJSON, []byte(`"chainID":42161`), []byte(`"chainID":10`), 1)
+	_, err = UnmarshalOffer(offerJSON)
+	require.ErrorContains(t, err, "hash of offer fields does not match offer ID")
+}
+
+func TestUpgradeOffer(t *testing.T) {
+	min := apd.New(100, 0)
+	max := apd.New(200, 0)
+	rate := coins.ToExchangeRate(apd.New(15, -1)) // 1.5
+
+	offer := NewOffer(coins.ProvidesXMR, min, max, rate, EthAssetETH)
+	offer.Version = *semver.MustParse("0.2.0")
+	offer.ID = Hash{}
+	offer.setID()
+
+	upgraded, err := UpgradeOffer(offer)
+	require.NoError(t, err)
+	require.Equal(t, CurOfferVersion.String(), upgraded.Version.String())
+	require.Equal(t, offer.Nonce, upgraded.Nonce)
+	require.NotEqual(t, offer.ID, upgraded.ID)
+	require.Equal(t, "0.2.0", offer.Version.String()) // the passed offer is unchanged
+
+	offerJSON, err := vjson.MarshalStruct(upgraded)
+	require.NoError(t, err)
+	res, err := UnmarshalOffer(offerJSON)
+	require.NoError(t, err)
+	require.Equal(t, upgraded.ID, res.ID)
+}
+
+func TestOffer_CheckMinAmount(t *testing.T) {
+	min := coins.StrToDecimal("0.01")
+	max := coins.StrToDecimal("10")
+	rate := coins.ToExchangeRate(coins.StrToDecimal("0.1"))
+	offer := NewOffer(coins.ProvidesXMR, min, max, rate, EthAssetETH)
+
+	// the min amount is 0.001 ETH when converted
+	err := offer.CheckMinAmount(coins.EtherToWei(coins.StrToDecimal("0.0009")))
+	require.NoError(t, err)
+
+	err = offer.CheckMinAmount(coins.EtherToWei(coins.StrToDecimal("0.001")))
+	require.ErrorIs(t, err, ErrMinAmountBelowRelayerFee)
+	require.ErrorContains(t, err, `min amount must be greater than 0.001 ETH when converted (0.01 XMR * 0.1 = 0.001 ETH)`)
+}
+
 func TestOffer_UnmarshalJSON_BadID(t *testing.T) {
 	offerJSON := []byte(`{
 		"version": "0.1.0",
@@ -176,11 +248,6 @@ func TestOffer_UnmarshalJSON_BadAmountsOrRate(t *testing.T) {
 			jsonData:    fmt.Sprintf(offerJSON, `"-1"`, `"1"`, `"0.1"`),
 			errContains: `"minAmount" cannot be negative`,
 		},
-		{
-			// 0.01 relayer fee is 0.1 XMR with exchange rate of 0.1
-			jsonData:    fmt.Sprintf(offerJSON, `"0.01"`, `"10"`, `"0.1"`),
-			errContains: `min amount must be greater than 0.01 ETH when converted (10 XMR * 0.1 = 0.001 ETH)`,
-		},
 		// Max Amount checks
 		{
 			jsonData:    fmt.Sprintf(offerJSON, `"1"`, `null`, `"0.1"`),
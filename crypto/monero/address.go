@@ -105,6 +105,21 @@ func (a *Address) Type() AddressType {
 	}
 }
 
+// PublicKeyPair returns the public spend and view keys encoded in the address.
+func (a *Address) PublicKeyPair() (*PublicKeyPair, error) {
+	sk, err := NewPublicKeyFromBytes(a.decoded[1:33])
+	if err != nil {
+		return nil, fmt.Errorf("invalid public spend key in address: %w", err)
+	}
+
+	vk, err := NewPublicKeyFromBytes(a.decoded[33:65])
+	if err != nil {
+		return nil, fmt.Errorf("invalid public view key in address: %w", err)
+	}
+
+	return NewPublicKeyPair(sk, vk), nil
+}
+
 // validateDecoded ensures that the checksum and network prefix of the address
 // are valid. The Network() and Type() methods are not safe to use until
 // this base level validation is performed.
@@ -0,0 +1,140 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mcrypto
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/athanorlabs/atomic-swap/crypto"
+
+	ed25519 "filippo.io/edwards25519"
+)
+
+const (
+	// EncryptedAmountSize is the size of the ECDH encoded amount of a RingCT
+	// output (bulletproof transaction types and later).
+	EncryptedAmountSize = 8
+
+	amountKeySalt         = "amount"
+	viewTagSalt           = "view_tag"
+	commitmentMaskSalt    = "commitment_mask"
+	amountCommitmentBasis = "8b655970153799af2aeadc9ff1add0ea6c7251d54154cfa92c173a0dd39c1f94"
+)
+
+var (
+	errViewKeyNotInitialized = errors.New("private view key is not initialized")
+
+	// pointH is the generator H of the Pedersen commitments to RingCT output
+	// amounts, see rctTypes.h in the monero repo
+	pointH = mustDecodePoint(amountCommitmentBasis)
+)
+
+// KeyDerivation is the shared secret 8*r*A = 8*a*R between the sender of a
+// transaction (using the transaction private key r) and the holder of the
+// private view key a. It is used to recognise outputs belonging to an address
+// and to decrypt their amounts.
+type KeyDerivation struct {
+	key *ed25519.Point
+}
+
+// NewKeyDerivation computes the key derivation 8*a*R of the private view key a
+// and the transaction public key R.
+func NewKeyDerivation(vk *PrivateViewKey, txPubKey *PublicKey) (*KeyDerivation, error) {
+	if vk == nil || vk.key == nil {
+		return nil, errViewKeyNotInitialized
+	}
+
+	d := ed25519.NewIdentityPoint().ScalarMult(vk.key, txPubKey.key)
+	d.MultByCofactor(d)
+	return &KeyDerivation{key: d}, nil
+}
+
+// Bytes returns the canonical 32-byte encoding of the derivation.
+func (d *KeyDerivation) Bytes() []byte {
+	return d.key.Bytes()
+}
+
+// scalar returns the derivation scalar Hs(D || varint(outputIndex)).
+func (d *KeyDerivation) scalar(outputIndex uint64) *ed25519.Scalar {
+	h := crypto.Keccak256(d.key.Bytes(), encodeVarint(outputIndex))
+	reduced := scReduce32(h)
+	s, err := ed25519.NewScalar().SetCanonicalBytes(reduced[:])
+	if err != nil {
+		panic(err) // scReduce32 always returns a canonical scalar
+	}
+	return s
+}
+
+// OutputPublicKey returns the one-time public key Hs(D || varint(outputIndex))*G + B
+// that the output at outputIndex would have if it was sent to the address with
+// the public spend key B.
+func (d *KeyDerivation) OutputPublicKey(outputIndex uint64, spendKey *PublicKey) *PublicKey {
+	p := ed25519.NewIdentityPoint().ScalarBaseMult(d.scalar(outputIndex))
+	p.Add(p, spendKey.key)
+	return &PublicKey{key: p}
+}
+
+// ViewTag returns the 1-byte view tag of the output at outputIndex. Outputs of
+// transactions created after the view tag hard fork carry this value, allowing
+// non-matching outputs to be skipped without computing the full output key.
+func (d *KeyDerivation) ViewTag(outputIndex uint64) byte {
+	h := crypto.Keccak256([]byte(viewTagSalt), d.key.Bytes(), encodeVarint(outputIndex))
+	return h[0]
+}
+
+// DecryptAmount decrypts the ECDH encoded amount of the RingCT output at
+// outputIndex. The same operation encrypts a plaintext amount.
+func (d *KeyDerivation) DecryptAmount(outputIndex uint64, encAmount [EncryptedAmountSize]byte) uint64 {
+	mask := crypto.Keccak256([]byte(amountKeySalt), d.scalar(outputIndex).Bytes())
+	var amount [EncryptedAmountSize]byte
+	for i := range amount {
+		amount[i] = encAmount[i] ^ mask[i]
+	}
+	return binary.LittleEndian.Uint64(amount[:])
+}
+
+// AmountCommitment returns the Pedersen commitment mask*G + amount*H to the
+// amount of the RingCT output at outputIndex, where the mask is
+// Hs("commitment_mask" || Hs(D || varint(outputIndex))). A decrypted amount is
+// only genuine if this commitment matches the output's commitment in the
+// transaction, as the encrypted amount itself is not authenticated.
+func (d *KeyDerivation) AmountCommitment(outputIndex uint64, amount uint64) []byte {
+	h := crypto.Keccak256([]byte(commitmentMaskSalt), d.scalar(outputIndex).Bytes())
+	reduced := scReduce32(h)
+	mask, err := ed25519.NewScalar().SetCanonicalBytes(reduced[:])
+	if err != nil {
+		panic(err) // scReduce32 always returns a canonical scalar
+	}
+
+	var amountBytes [32]byte
+	binary.LittleEndian.PutUint64(amountBytes[:], amount)
+	amountScalar, err := ed25519.NewScalar().SetCanonicalBytes(amountBytes[:])
+	if err != nil {
+		panic(err) // any uint64 is less than the group order
+	}
+
+	c := ed25519.NewIdentityPoint().VarTimeDoubleScalarBaseMult(amountScalar, pointH, mask)
+	return c.Bytes()
+}
+
+func mustDecodePoint(pointHex string) *ed25519.Point {
+	b, err := hex.DecodeString(pointHex)
+	if err != nil {
+		panic(err)
+	}
+	p, err := ed25519.NewIdentityPoint().SetBytes(b)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// encodeVarint returns the varint encoding used by Monero, which is the same as
+// the unsigned LEB128 encoding used by encoding/binary.
+func encodeVarint(n uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, n)]
+}
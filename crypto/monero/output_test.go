@@ -0,0 +1,148 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mcrypto
+
+import (
+	"encoding/binary"
+	"testing"
+
+	ed25519 "filippo.io/edwards25519"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common"
+)
+
+// newTxKey returns a random transaction private key r and its public key R
+func newTxKey(t *testing.T) (*PrivateViewKey, *PublicKey) {
+	kp, err := GenerateKeys()
+	require.NoError(t, err)
+	return kp.ViewKey(), kp.ViewKey().Public()
+}
+
+func TestKeyDerivation_senderAndReceiverMatch(t *testing.T) {
+	recipient, err := GenerateKeys()
+	require.NoError(t, err)
+	txPrivKey, txPubKey := newTxKey(t)
+
+	// the sender computes 8*r*A, the recipient computes 8*a*R
+	senderDerivation, err := NewKeyDerivation(txPrivKey, recipient.ViewKey().Public())
+	require.NoError(t, err)
+	recipientDerivation, err := NewKeyDerivation(recipient.ViewKey(), txPubKey)
+	require.NoError(t, err)
+	require.Equal(t, senderDerivation.Bytes(), recipientDerivation.Bytes())
+}
+
+func TestKeyDerivation_OutputPublicKey(t *testing.T) {
+	recipient, err := GenerateKeys()
+	require.NoError(t, err)
+	_, txPubKey := newTxKey(t)
+
+	d, err := NewKeyDerivation(recipient.ViewKey(), txPubKey)
+	require.NoError(t, err)
+
+	for i := uint64(0); i < 3; i++ {
+		// The one-time private key of the output is Hs(D || i) + b, so its
+		// public key must match the output public key computed with B.
+		oneTimeKey := &PrivateSpendKey{key: d.scalar(i)}
+		oneTimeKey = SumPrivateSpendKeys(oneTimeKey, recipient.SpendKey())
+		expected := oneTimeKey.Public()
+
+		outputKey := d.OutputPublicKey(i, recipient.SpendKey().Public())
+		require.Equal(t, expected.Bytes(), outputKey.Bytes())
+	}
+
+	// different output indexes must result in different keys
+	require.NotEqual(t,
+		d.OutputPublicKey(0, recipient.SpendKey().Public()).Bytes(),
+		d.OutputPublicKey(1, recipient.SpendKey().Public()).Bytes(),
+	)
+}
+
+func TestKeyDerivation_DecryptAmount(t *testing.T) {
+	recipient, err := GenerateKeys()
+	require.NoError(t, err)
+	txPrivKey, txPubKey := newTxKey(t)
+
+	senderDerivation, err := NewKeyDerivation(txPrivKey, recipient.ViewKey().Public())
+	require.NoError(t, err)
+	recipientDerivation, err := NewKeyDerivation(recipient.ViewKey(), txPubKey)
+	require.NoError(t, err)
+
+	const amount = uint64(1_234_567_890_123)
+	var plain [EncryptedAmountSize]byte
+	binary.LittleEndian.PutUint64(plain[:], amount)
+
+	// ECDH amount encoding is a XOR with a mask, so "decrypting" with the
+	// sender's derivation encrypts the amount
+	var enc [EncryptedAmountSize]byte
+	binary.LittleEndian.PutUint64(enc[:], senderDerivation.DecryptAmount(1, plain))
+	require.NotEqual(t, plain, enc)
+
+	require.Equal(t, amount, recipientDerivation.DecryptAmount(1, enc))
+	require.NotEqual(t, amount, recipientDerivation.DecryptAmount(0, enc))
+}
+
+func TestKeyDerivation_AmountCommitment(t *testing.T) {
+	recipient, err := GenerateKeys()
+	require.NoError(t, err)
+	txPrivKey, txPubKey := newTxKey(t)
+
+	senderDerivation, err := NewKeyDerivation(txPrivKey, recipient.ViewKey().Public())
+	require.NoError(t, err)
+	recipientDerivation, err := NewKeyDerivation(recipient.ViewKey(), txPubKey)
+	require.NoError(t, err)
+
+	const amount = uint64(1_234_567_890_123)
+	commitment := senderDerivation.AmountCommitment(2, amount)
+	require.Equal(t, commitment, recipientDerivation.AmountCommitment(2, amount))
+	require.NotEqual(t, commitment, recipientDerivation.AmountCommitment(1, amount))
+
+	// commitments with the same mask differ by the difference of the amounts
+	// times H
+	c1, err := ed25519.NewIdentityPoint().SetBytes(commitment)
+	require.NoError(t, err)
+	c2, err := ed25519.NewIdentityPoint().SetBytes(recipientDerivation.AmountCommitment(2, amount+3))
+	require.NoError(t, err)
+	three, err := ed25519.NewScalar().SetCanonicalBytes(append([]byte{3}, make([]byte, 31)...))
+	require.NoError(t, err)
+	diff := ed25519.NewIdentityPoint().Subtract(c2, c1)
+	require.Equal(t, 1, diff.Equal(ed25519.NewIdentityPoint().ScalarMult(three, pointH)))
+}
+
+func TestKeyDerivation_ViewTag(t *testing.T) {
+	recipient, err := GenerateKeys()
+	require.NoError(t, err)
+	txPrivKey, txPubKey := newTxKey(t)
+
+	senderDerivation, err := NewKeyDerivation(txPrivKey, recipient.ViewKey().Public())
+	require.NoError(t, err)
+	recipientDerivation, err := NewKeyDerivation(recipient.ViewKey(), txPubKey)
+	require.NoError(t, err)
+	require.Equal(t, senderDerivation.ViewTag(5), recipientDerivation.ViewTag(5))
+}
+
+func TestNewKeyDerivation_nilViewKey(t *testing.T) {
+	_, txPubKey := newTxKey(t)
+	_, err := NewKeyDerivation(nil, txPubKey)
+	require.ErrorIs(t, err, errViewKeyNotInitialized)
+}
+
+func TestEncodeVarint(t *testing.T) {
+	require.Equal(t, []byte{0x00}, encodeVarint(0))
+	require.Equal(t, []byte{0x7f}, encodeVarint(127))
+	require.Equal(t, []byte{0x80, 0x01}, encodeVarint(128))
+	require.Equal(t, []byte{0xac, 0x02}, encodeVarint(300))
+}
+
+func TestAddress_PublicKeyPair(t *testing.T) {
+	kp, err := GenerateKeys()
+	require.NoError(t, err)
+	pubKeys := kp.PublicKeyPair()
+
+	addr := pubKeys.Address(common.Mainnet)
+	addrKeys, err := addr.PublicKeyPair()
+	require.NoError(t, err)
+	require.Equal(t, pubKeys.SpendKey().Bytes(), addrKeys.SpendKey().Bytes())
+	require.Equal(t, pubKeys.ViewKey().Bytes(), addrKeys.ViewKey().Bytes())
+}
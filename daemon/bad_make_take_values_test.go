@@ -33,7 +33,7 @@ func TestBadMakeTakeValues(t *testing.T) {
 	exRate := coins.StrToExchangeRate("13.3")
 	mockTether := getMockTetherAsset(t, aliceConf.EthereumClient)
 	expectedErr := `"net_makeOffer" failed: 14.979329 XMR * 13.3 exceeds token's 6 decimal precision`
-	_, err := bc.MakeOffer(minMaxXMRAmt, minMaxXMRAmt, exRate, mockTether, false)
+	_, err := bc.MakeOffer(minMaxXMRAmt, minMaxXMRAmt, exRate, mockTether, nil)
 	require.ErrorContains(t, err, expectedErr)
 	t.Log(err)
 
@@ -41,7 +41,7 @@ func TestBadMakeTakeValues(t *testing.T) {
 	minXMRAmt := coins.StrToDecimal("1")
 	maxXMRAmt := coins.StrToDecimal("10")
 	providesAmt := coins.StrToDecimal("5.1234567") // 7 digits, max is 6
-	makeResp, err := bc.MakeOffer(minXMRAmt, maxXMRAmt, exRate, mockTether, false)
+	makeResp, err := bc.MakeOffer(minXMRAmt, maxXMRAmt, exRate, mockTether, nil)
 	require.NoError(t, err)
 
 	// Fail because providesAmount has too much precision in the token's standard units
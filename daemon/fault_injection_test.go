@@ -0,0 +1,289 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/net/message"
+	"github.com/athanorlabs/atomic-swap/protocol/backend"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+	"github.com/athanorlabs/atomic-swap/rpcclient"
+)
+
+// The test in this file runs swaps on a SimNetwork while injecting network
+// faults into the swap messages of one of the parties, or closing its swap
+// stream, at every status at which it happens to send a message or pass
+// through. Whatever the fault, the funds of both parties must end up claimed
+// or refunded.
+
+const (
+	// faultStallTime is how long a swap may go without a status update
+	// before the chain's time is advanced to expire the swap's timeouts
+	faultStallTime = 5 * time.Second
+	// faultMessageDelay is the delay of faultDelay faults
+	faultMessageDelay = 3 * time.Second
+)
+
+const (
+	alice = "Alice"
+	bob   = "Bob"
+)
+
+// faultScenario injects a fault into the swap messages of a party, or closes
+// the party's swap stream when it reaches a status.
+type faultScenario struct {
+	party   string
+	fault   *netFault
+	closeAt types.Status
+}
+
+func (sc *faultScenario) String() string {
+	switch {
+	case sc.fault != nil:
+		return fmt.Sprintf("%s_%s_at_%s", sc.party, sc.fault.Kind, sc.fault.Status)
+	case sc.closeAt != types.UnknownStatus:
+		return fmt.Sprintf("%s_closes_stream_at_%s", sc.party, sc.closeAt)
+	default:
+		return "no_faults"
+	}
+}
+
+// faultRun is what happened in a scenario
+type faultRun struct {
+	sent     map[string][]*sentMessage
+	statuses map[string][]types.Status
+	exits    map[string]types.Status
+}
+
+func TestSwap_FaultInjection(t *testing.T) {
+	// The run without faults finds the failure points of each party: the
+	// messages it sends, and the statuses it passes through. It runs as a
+	// subtest, so its daemons are stopped before the scenarios start.
+	var clean *faultRun
+	noFaults := &faultScenario{}
+	t.Run(noFaults.String(), func(t *testing.T) {
+		clean = runFaultScenario(t, noFaults)
+		require.Equal(t, types.CompletedSuccess, clean.exits[alice])
+		require.Equal(t, types.CompletedSuccess, clean.exits[bob])
+	})
+	if t.Failed() {
+		return
+	}
+
+	var scenarios []*faultScenario
+	for _, party := range []string{bob, alice} {
+		require.NotEmpty(t, clean.sent[party], "%s sent no swap messages", party)
+		for _, sent := range clean.sent[party] {
+			for _, kind := range messageFaults {
+				scenarios = append(scenarios, &faultScenario{
+					party: party,
+					fault: &netFault{Status: sent.Status, Kind: kind, Delay: faultMessageDelay},
+				})
+			}
+		}
+
+		for _, status := range clean.statuses[party] {
+			if status.IsOngoing() {
+				scenarios = append(scenarios, &faultScenario{party: party, closeAt: status})
+			}
+		}
+	}
+
+	for _, sc := range scenarios {
+		sc := sc
+		t.Run(sc.String(), func(t *testing.T) {
+			t.Parallel()
+			run := runFaultScenario(t, sc)
+			t.Logf("%s exited with %s, %s exited with %s", alice, run.exits[alice], bob, run.exits[bob])
+		})
+	}
+}
+
+// runFaultScenario runs a swap between Alice and Bob with the scenario's fault
+// until both parties exit, advancing the chain's time whenever the swap
+// stalls. It then checks that the funds of both parties were either swapped or
+// returned.
+func runFaultScenario(t *testing.T, sc *faultScenario) *faultRun {
+	sim := CreateSimNetwork(t)
+	bobConf := sim.CreateTestConf(t, simBobETH, simBobXMR)
+	aliceConf := sim.CreateTestConf(t, simAliceETH, simNoFunding)
+
+	// the senders are created by the daemons' goroutines
+	var sendersMu sync.Mutex
+	senders := make(map[string]*faultyNetSender)
+	getSender := func(party string) *faultyNetSender {
+		sendersMu.Lock()
+		defer sendersMu.Unlock()
+		return senders[party]
+	}
+
+	wrapNet := make(map[*SwapdConfig]netSenderWrapper)
+	for party, conf := range map[string]*SwapdConfig{alice: aliceConf, bob: bobConf} {
+		party := party
+		wrapNet[conf] = func(net backend.NetSender, sm swap.Manager) backend.NetSender {
+			sender := newFaultyNetSender(net, sm)
+			if sc.party == party && sc.fault != nil {
+				sender.InjectFault(sc.fault)
+			}
+
+			sendersMu.Lock()
+			defer sendersMu.Unlock()
+			senders[party] = sender
+			return sender
+		}
+	}
+
+	ctx, _ := launchDaemons(t, 4*time.Minute, wrapNet, bobConf, aliceConf)
+	bc := rpcclient.NewClient(ctx, bobConf.RPCPort)
+	ac := rpcclient.NewClient(ctx, aliceConf.RPCPort)
+
+	start, err := getFaultBalances(ctx, aliceConf, bobConf)
+	require.NoError(t, err)
+	offerID, bobStatusCh, aliceStatusCh := startSimSwap(t, bc, ac)
+
+	run := &faultRun{
+		sent:     make(map[string][]*sentMessage),
+		statuses: make(map[string][]types.Status),
+		exits:    make(map[string]types.Status),
+	}
+	statusChs := map[string]<-chan types.Status{alice: aliceStatusCh, bob: bobStatusCh}
+
+	onStatus := func(party string, status types.Status, ok bool) {
+		if !ok {
+			t.Errorf("%s's status subscription ended before the swap completed", party)
+			run.exits[party] = types.UnknownStatus
+			statusChs[party] = nil
+			return
+		}
+
+		t.Logf("> %s got status: %s", party, status)
+		run.statuses[party] = append(run.statuses[party], status)
+		if sc.party == party && sc.closeAt == status {
+			t.Logf("closing %s's swap stream", party)
+			getSender(party).CloseProtocolStream(offerID)
+		}
+
+		if !status.IsOngoing() {
+			run.exits[party] = status
+			statusChs[party] = nil
+		}
+	}
+
+	swapTimeout := common.SwapTimeoutFromEnv(common.Development)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	lastProgress := time.Now()
+
+	for len(run.exits) < 2 {
+		select {
+		case status, ok := <-statusChs[alice]:
+			onStatus(alice, status, ok)
+			lastProgress = time.Now()
+		case status, ok := <-statusChs[bob]:
+			onStatus(bob, status, ok)
+			lastProgress = time.Now()
+		case <-ticker.C:
+			if time.Since(lastProgress) < faultStallTime {
+				continue
+			}
+			t.Logf("swap stalled, advancing chain time by %s", swapTimeout/10)
+			require.NoError(t, sim.Chain.AdvanceTime(swapTimeout/10))
+			lastProgress = time.Now()
+		case <-ctx.Done():
+			t.Fatalf("swap did not complete, %s exited with %s, %s exited with %s",
+				alice, run.exits[alice], bob, run.exits[bob])
+		}
+	}
+
+	for _, party := range []string{alice, bob} {
+		run.sent[party] = getSender(party).Sent()
+		for _, sent := range run.sent[party] {
+			t.Logf("%s sent %s at %s with fault %s",
+				party, message.TypeToString(sent.Type), sent.Status, sent.Fault)
+		}
+	}
+
+	checkFaultFundsSettled(ctx, t, sim, aliceConf, bobConf, start)
+	return run
+}
+
+type faultBalances struct {
+	aliceETH *coins.WeiAmount
+	bobETH   *coins.WeiAmount
+	aliceXMR uint64
+	bobXMR   uint64
+}
+
+func getFaultBalances(ctx context.Context, aliceConf, bobConf *SwapdConfig) (*faultBalances, error) {
+	aliceETH, err := aliceConf.EthereumClient.Balance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bobETH, err := bobConf.EthereumClient.Balance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aliceXMR, err := aliceConf.MoneroClient.GetBalance(0)
+	if err != nil {
+		return nil, err
+	}
+	bobXMR, err := bobConf.MoneroClient.GetBalance(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &faultBalances{
+		aliceETH: aliceETH,
+		bobETH:   bobETH,
+		aliceXMR: aliceXMR.Balance,
+		bobXMR:   bobXMR.Balance,
+	}, nil
+}
+
+// checkFaultFundsSettled checks that no ETH is left in the swap contract, and
+// that either both parties received the funds of the other party, or both got
+// their own funds back, minus fees.
+func checkFaultFundsSettled(
+	ctx context.Context,
+	t *testing.T,
+	sim *SimNetwork,
+	aliceConf, bobConf *SwapdConfig,
+	start *faultBalances,
+) {
+	contractBal, err := sim.Chain.Client().BalanceAt(ctx, sim.SwapCreatorAddr, nil)
+	require.NoError(t, err)
+	require.Zero(t, contractBal.Sign(), "ETH left in the swap contract")
+
+	maxFees := coins.StrToDecimal("0.01")
+	maxXMRFees := uint64(1e10) // 0.01 XMR
+
+	// the XMR of the swap wallet is swept after the ETH was claimed or refunded
+	require.Eventually(t, func() bool {
+		end, err := getFaultBalances(ctx, aliceConf, bobConf) //nolint:govet
+		if err != nil {
+			return false
+		}
+
+		bobGotETH := end.bobETH.Cmp(start.bobETH) > 0
+		if bobGotETH {
+			aliceLost := start.aliceETH.Sub(end.aliceETH)
+			return aliceLost.AsEther().Cmp(maxFees) > 0 && end.aliceXMR > start.aliceXMR
+		}
+
+		aliceLost := start.aliceETH.Sub(end.aliceETH)
+		return aliceLost.AsEther().Cmp(maxFees) < 0 &&
+			end.aliceXMR == start.aliceXMR &&
+			end.bobXMR+maxXMRFees > start.bobXMR
+	}, time.Minute, 500*time.Millisecond, "funds were neither swapped nor returned")
+}
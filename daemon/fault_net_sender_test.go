@@ -0,0 +1,249 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package daemon
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/net/message"
+	"github.com/athanorlabs/atomic-swap/protocol/backend"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+)
+
+// faultKind is a network failure that a faultyNetSender injects into a swap
+// message.
+type faultKind byte
+
+const (
+	// noFault sends the message unchanged
+	noFault faultKind = iota
+	// faultDrop does not send the message
+	faultDrop
+	// faultDelay sends the message after the fault's delay
+	faultDelay
+	// faultDuplicate sends the message twice
+	faultDuplicate
+	// faultReorder sends the message after the swap's next message, or right
+	// before the swap's stream is closed if no message follows
+	faultReorder
+	// faultCorrupt sends the message with corrupted encoded bytes
+	faultCorrupt
+	// faultCloseStream closes the swap's stream instead of sending the message
+	faultCloseStream
+)
+
+// messageFaults are all the kinds of faults that can be injected into a message
+var messageFaults = []faultKind{
+	faultDrop,
+	faultDelay,
+	faultDuplicate,
+	faultReorder,
+	faultCorrupt,
+	faultCloseStream,
+}
+
+func (k faultKind) String() string {
+	switch k {
+	case noFault:
+		return "none"
+	case faultDrop:
+		return "drop"
+	case faultDelay:
+		return "delay"
+	case faultDuplicate:
+		return "duplicate"
+	case faultReorder:
+		return "reorder"
+	case faultCorrupt:
+		return "corrupt"
+	case faultCloseStream:
+		return "close-stream"
+	default:
+		return fmt.Sprintf("faultKind(%d)", byte(k))
+	}
+}
+
+// netFault is injected into the first swap message that is sent while the swap
+// has the fault's status.
+type netFault struct {
+	Status types.Status
+	Kind   faultKind
+	Delay  time.Duration // only used by faultDelay
+}
+
+// sentMessage records a swap message that was passed to a faultyNetSender
+type sentMessage struct {
+	OfferID types.Hash
+	Status  types.Status // status of the swap when the message was sent
+	Type    byte
+	Fault   faultKind
+}
+
+// faultyNetSender is a backend.NetSender that injects faults into the swap
+// messages of the wrapped NetSender, so tests can check how the swap state
+// machines react to messages that are lost, late, repeated, reordered or
+// corrupted, and to streams that close early.
+type faultyNetSender struct {
+	backend.NetSender
+	swapManager swap.Manager
+
+	mu     sync.Mutex
+	faults []*netFault
+	sent   []*sentMessage
+	held   map[types.Hash][]common.Message
+}
+
+var _ backend.NetSender = (*faultyNetSender)(nil)
+
+// newFaultyNetSender returns a faultyNetSender wrapping net. The swap manager
+// provides the status of the swaps that messages are sent for.
+func newFaultyNetSender(net backend.NetSender, sm swap.Manager) *faultyNetSender {
+	return &faultyNetSender{
+		NetSender:   net,
+		swapManager: sm,
+		held:        make(map[types.Hash][]common.Message),
+	}
+}
+
+// InjectFault adds a fault that is injected into the next message sent while
+// a swap has the fault's status.
+func (s *faultyNetSender) InjectFault(fault *netFault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, fault)
+}
+
+// Sent returns the swap messages that were passed to the sender so far
+func (s *faultyNetSender) Sent() []*sentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*sentMessage{}, s.sent...)
+}
+
+// SendSwapMessage sends the message with the wrapped NetSender, applying the
+// first pending fault for the swap's current status.
+func (s *faultyNetSender) SendSwapMessage(msg common.Message, id types.Hash) error {
+	status := s.swapStatus(id)
+
+	s.mu.Lock()
+	fault := s.takeFault(status)
+	sent := &sentMessage{OfferID: id, Status: status, Type: msg.Type()}
+	if fault != nil {
+		sent.Fault = fault.Kind
+	}
+	s.sent = append(s.sent, sent)
+	held := s.held[id]
+	delete(s.held, id)
+	s.mu.Unlock()
+
+	if fault != nil {
+		log.Warnf("injecting %s fault into %s message of swap %s with status %s",
+			fault.Kind, message.TypeToString(msg.Type()), id, status)
+	}
+
+	err := s.send(msg, id, fault)
+	for _, heldMsg := range held {
+		if heldErr := s.NetSender.SendSwapMessage(heldMsg, id); heldErr != nil {
+			log.Warnf("failed to send reordered message: %s", heldErr)
+		}
+	}
+
+	return err
+}
+
+func (s *faultyNetSender) send(msg common.Message, id types.Hash, fault *netFault) error {
+	if fault == nil {
+		return s.NetSender.SendSwapMessage(msg, id)
+	}
+
+	switch fault.Kind {
+	case faultDrop:
+		return nil
+	case faultDelay:
+		go func() {
+			time.Sleep(fault.Delay)
+			if err := s.NetSender.SendSwapMessage(msg, id); err != nil {
+				log.Warnf("failed to send delayed message: %s", err)
+			}
+		}()
+		return nil
+	case faultDuplicate:
+		if err := s.NetSender.SendSwapMessage(msg, id); err != nil {
+			return err
+		}
+		return s.NetSender.SendSwapMessage(msg, id)
+	case faultReorder:
+		s.mu.Lock()
+		s.held[id] = append(s.held[id], msg)
+		s.mu.Unlock()
+		return nil
+	case faultCorrupt:
+		return s.NetSender.SendSwapMessage(&corruptMessage{Message: msg}, id)
+	case faultCloseStream:
+		s.NetSender.CloseProtocolStream(id)
+		return nil
+	default:
+		return s.NetSender.SendSwapMessage(msg, id)
+	}
+}
+
+// CloseProtocolStream sends the swap's reordered messages that are still held
+// back and closes the swap's stream.
+func (s *faultyNetSender) CloseProtocolStream(id types.Hash) {
+	s.mu.Lock()
+	held := s.held[id]
+	delete(s.held, id)
+	s.mu.Unlock()
+
+	for _, msg := range held {
+		if err := s.NetSender.SendSwapMessage(msg, id); err != nil {
+			log.Warnf("failed to send reordered message: %s", err)
+		}
+	}
+
+	s.NetSender.CloseProtocolStream(id)
+}
+
+// takeFault removes and returns the first pending fault for the status. The
+// lock must be held by the caller.
+func (s *faultyNetSender) takeFault(status types.Status) *netFault {
+	for i, fault := range s.faults {
+		if fault.Status == status {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			return fault
+		}
+	}
+	return nil
+}
+
+func (s *faultyNetSender) swapStatus(id types.Hash) types.Status {
+	info, err := s.swapManager.GetOngoingSwapSnapshot(id)
+	if err != nil {
+		return types.UnknownStatus
+	}
+	return info.Status
+}
+
+// corruptMessage is a message whose encoding has its payload bytes inverted.
+// The message type is kept, so the receiver tries to decode the payload.
+type corruptMessage struct {
+	common.Message
+}
+
+func (m *corruptMessage) Encode() ([]byte, error) {
+	b, err := m.Message.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(b); i++ {
+		b[i] = ^b[i]
+	}
+
+	return b, nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/rpcclient"
+)
+
+// The tests in this file run complete swaps between two in-process swapd
+// instances on a SimNetwork, so they need no ganache, monerod or
+// monero-wallet-rpc.
+
+var (
+	simMinXMR    = coins.StrToDecimal("1")
+	simExRate    = coins.StrToExchangeRate("0.1")
+	simBobETH    = coins.StrToDecimal("1")
+	simBobXMR    = coins.StrToDecimal("5")
+	simAliceETH  = coins.StrToDecimal("10")
+	simNoFunding = coins.StrToDecimal("0")
+)
+
+// waitForExitStatus reads the swap's status updates until the swap is no
+// longer ongoing, and returns the exit status. The test fails if the context
+// ends first.
+func waitForExitStatus(
+	ctx context.Context,
+	t *testing.T,
+	name string,
+	statusCh <-chan types.Status,
+	onStatus func(types.Status),
+) types.Status {
+	for {
+		select {
+		case status := <-statusCh:
+			t.Logf("> %s got status: %s", name, status)
+			if onStatus != nil {
+				onStatus(status)
+			}
+			if !status.IsOngoing() {
+				return status
+			}
+		case <-ctx.Done():
+			t.Errorf("%s's context cancelled before the swap completed", name)
+			return types.UnknownStatus
+		}
+	}
+}
+
+// startSimSwap has Bob make an offer and Alice take it, returning the offer ID
+// and both parties' status channels.
+func startSimSwap(
+	t *testing.T,
+	bc *rpcclient.Client,
+	ac *rpcclient.Client,
+) (types.Hash, <-chan types.Status, <-chan types.Status) {
+	providesAmt, err := simExRate.ToETH(simMinXMR)
+	require.NoError(t, err)
+
+	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(simMinXMR, simMinXMR, simExRate, types.EthAssetETH, nil)
+	require.NoError(t, err)
+
+	aliceStatusCh, err := ac.TakeOfferAndSubscribe(makeResp.PeerID, makeResp.OfferID, providesAmt)
+	require.NoError(t, err)
+
+	return makeResp.OfferID, bobStatusCh, aliceStatusCh
+}
+
+func TestSimSwap_Success(t *testing.T) {
+	sim := CreateSimNetwork(t)
+	bobConf := sim.CreateTestConf(t, simBobETH, simBobXMR)
+	aliceConf := sim.CreateTestConf(t, simAliceETH, simNoFunding)
+
+	ctx, _ := LaunchDaemons(t, 3*time.Minute, bobConf, aliceConf)
+	bc := rpcclient.NewClient(ctx, bobConf.RPCPort)
+	ac := rpcclient.NewClient(ctx, aliceConf.RPCPort)
+
+	_, bobStatusCh, aliceStatusCh := startSimSwap(t, bc, ac)
+
+	bobExitCh := make(chan types.Status, 1)
+	go func() {
+		bobExitCh <- waitForExitStatus(ctx, t, "Bob", bobStatusCh, nil)
+	}()
+	require.Equal(t, types.CompletedSuccess, waitForExitStatus(ctx, t, "Alice", aliceStatusCh, nil))
+	require.Equal(t, types.CompletedSuccess, <-bobExitCh)
+
+	// Alice swept the claimed XMR, minus the fee, to her primary wallet
+	aliceXMR, err := aliceConf.MoneroClient.GetBalance(0)
+	require.NoError(t, err)
+	require.NotZero(t, aliceXMR.Balance)
+	require.Less(t, aliceXMR.Balance, uint64(1e12))
+
+	bobETH, err := bobConf.EthereumClient.Balance(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, bobETH.AsEther().Cmp(simBobETH))
+}
+
+// Alice cancels the swap before any funds are locked, so both parties abort.
+func TestSimSwap_AliceAborts(t *testing.T) {
+	sim := CreateSimNetwork(t)
+	bobConf := sim.CreateTestConf(t, simBobETH, simBobXMR)
+	aliceConf := sim.CreateTestConf(t, simAliceETH, simNoFunding)
+
+	ctx, _ := LaunchDaemons(t, 3*time.Minute, bobConf, aliceConf)
+	bc := rpcclient.NewClient(ctx, bobConf.RPCPort)
+	ac := rpcclient.NewClient(ctx, aliceConf.RPCPort)
+
+	offerID, bobStatusCh, aliceStatusCh := startSimSwap(t, bc, ac)
+
+	bobExitCh := make(chan types.Status, 1)
+	go func() {
+		bobExitCh <- waitForExitStatus(ctx, t, "Bob", bobStatusCh, nil)
+	}()
+
+	for status := range aliceStatusCh {
+		t.Log("> Alice got status:", status)
+		if status == types.ExpectingKeys {
+			break
+		}
+	}
+
+	exitStatus, err := ac.Cancel(offerID)
+	require.NoError(t, err)
+	require.Equal(t, types.CompletedAbort, exitStatus)
+	require.Equal(t, types.CompletedAbort, <-bobExitCh)
+
+	// Bob's offer is available again after the aborted swap
+	require.Eventually(t, func() bool {
+		offers, err := bc.GetOffers() //nolint:govet
+		return err == nil && len(offers.Offers) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+// Bob goes offline after Alice locks her ETH, so Alice refunds the swap. If Bob
+// did not lock his XMR, she refunds before T1, otherwise after T2. The chain's
+// time is advanced in steps, so the swap timeouts expire without waiting.
+func TestSimSwap_AliceRefundsWhenBobGoesOffline(t *testing.T) {
+	sim := CreateSimNetwork(t)
+	bobConf := sim.CreateTestConf(t, simBobETH, simBobXMR)
+	aliceConf := sim.CreateTestConf(t, simAliceETH, simNoFunding)
+
+	// Each daemon gets its own context, so Bob can be stopped alone
+	aliceCtx, _ := LaunchDaemons(t, 3*time.Minute, aliceConf)
+	bobConf.EnvConf.Bootnodes = aliceConf.EnvConf.Bootnodes
+	bobCtx, bobCancel := LaunchDaemons(t, 3*time.Minute, bobConf)
+
+	bc := rpcclient.NewClient(bobCtx, bobConf.RPCPort)
+	ac := rpcclient.NewClient(aliceCtx, aliceConf.RPCPort)
+
+	aliceStartBal, err := aliceConf.EthereumClient.Balance(aliceCtx)
+	require.NoError(t, err)
+
+	_, _, aliceStatusCh := startSimSwap(t, bc, ac)
+
+	// steps are shorter than the window before T1 in which Alice refunds
+	swapTimeout := common.SwapTimeoutFromEnv(common.Development)
+	advanceCtx, advanceCancel := context.WithCancel(aliceCtx)
+	advanceDone := make(chan struct{})
+	advanceTime := func() {
+		defer close(advanceDone)
+		for advanceCtx.Err() == nil {
+			if err := sim.Chain.AdvanceTime(swapTimeout / 10); err != nil { //nolint:govet
+				t.Errorf("failed to advance chain time: %s", err)
+				return
+			}
+			_ = common.SleepWithContext(advanceCtx, 2*time.Second)
+		}
+	}
+
+	exitStatus := waitForExitStatus(aliceCtx, t, "Alice", aliceStatusCh, func(status types.Status) {
+		if status == types.ETHLocked {
+			bobCancel()
+			go advanceTime()
+		}
+	})
+	advanceCancel()
+	<-advanceDone
+	require.Equal(t, types.CompletedRefund, exitStatus)
+
+	// Alice only lost the gas fees
+	aliceEndBal, err := aliceConf.EthereumClient.Balance(aliceCtx)
+	require.NoError(t, err)
+	lost := aliceStartBal.Sub(aliceEndBal)
+	require.Less(t, lost.AsEther().Cmp(coins.StrToDecimal("0.01")), 0)
+}
+
+// Both daemons are stopped after Bob locks his XMR. After they restart, the
+// swap completes successfully.
+func TestSimSwap_RestartAfterXMRLock(t *testing.T) {
+	sim := CreateSimNetwork(t)
+	bobConf := sim.CreateTestConf(t, simBobETH, simBobXMR)
+	aliceConf := sim.CreateTestConf(t, simAliceETH, simNoFunding)
+
+	ctx, cancel := LaunchDaemons(t, 3*time.Minute, bobConf, aliceConf)
+
+	// clients use a separate context and will work across server restarts
+	clientCtx := context.Background()
+	bc := rpcclient.NewClient(clientCtx, bobConf.RPCPort)
+	ac := rpcclient.NewClient(clientCtx, aliceConf.RPCPort)
+
+	offerID, bobStatusCh, _ := startSimSwap(t, bc, ac)
+
+	for ctx.Err() == nil {
+		select {
+		case status := <-bobStatusCh:
+			t.Log("> Bob got status:", status)
+			require.True(t, status.IsOngoing(), "Bob's swap completed before the restart")
+			if status == types.XMRLocked {
+				cancel() // stop both Alice's and Bob's daemons
+			}
+		case <-ctx.Done():
+		}
+	}
+
+	t.Log("daemons stopped, now re-launching them")
+	time.Sleep(time.Second) // let both servers fully shut down
+	_, _ = LaunchDaemons(t, 3*time.Minute, bobConf, aliceConf)
+
+	for _, c := range []*rpcclient.Client{ac, bc} {
+		require.Eventually(t, func() bool {
+			resp, err := c.GetPastSwap(&offerID)
+			return err == nil && len(resp.Swaps) == 1 && resp.Swaps[0].Status == types.CompletedSuccess
+		}, 2*time.Minute, 500*time.Millisecond)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/db"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
 	"github.com/athanorlabs/atomic-swap/monero"
@@ -26,6 +27,7 @@ import (
 	"github.com/athanorlabs/atomic-swap/protocol/swap"
 	"github.com/athanorlabs/atomic-swap/protocol/xmrmaker"
 	"github.com/athanorlabs/atomic-swap/protocol/xmrtaker"
+	"github.com/athanorlabs/atomic-swap/relayer"
 	"github.com/athanorlabs/atomic-swap/rpc"
 )
 
@@ -33,6 +35,10 @@ const (
 	// databaseDirName is the name of the folder, located in the swapd's
 	// data-dir, for the current and past swap information.
 	databaseDirName = "swap-db"
+
+	// sqliteDatabaseFileName is the name of the file, located in the swapd's
+	// data-dir, holding the database when using the SQLite backend.
+	sqliteDatabaseFileName = "swap-db.sqlite"
 )
 
 var log = logging.Logger("daemon")
@@ -42,17 +48,51 @@ type SwapdConfig struct {
 	EnvConf        *common.Config
 	MoneroClient   monero.WalletClient
 	EthereumClient extethclient.EthClient
+	ExtraChains    []*backend.ChainConfig // EVM chains served in addition to EnvConf.EthChain
 	Libp2pPort     uint16
 	Libp2pKeyfile  string
 	RPCPort        uint16
 	IsRelayer      bool
 	NoTransferBack bool
+	DBBackend      db.Backend                // defaults to db.BackendBadger
+	TokenPolicy    *extethclient.TokenPolicy // nil allows all ERC20 tokens
+	RelayerLimits  *relayer.Limits           // nil relays claims without limits
+	PayoutAddress  ethcommon.Address         // zero pays claims and refunds to EthereumClient's address
+	PayoutFallback bool                      // pay EthereumClient's address if PayoutAddress can't be paid
+	Clock          clock.Clock               // nil measures swap timeouts with the local clock
+
+	// RelayerHTTPAddr is the "IP:port" address that relayers accept claim
+	// requests on over HTTP. If empty, claims are only relayed over libp2p.
+	RelayerHTTPAddr string
+
+	// RelayerHTTPAllowedOrigins are the origins that browsers can submit
+	// claims to RelayerHTTPAddr from. If empty, cross-origin requests are not
+	// allowed.
+	RelayerHTTPAllowedOrigins []string
+
+	// DBPassphrase returns the passphrase of the encrypted recovery database.
+	// newPassphrase is true when the passphrase will be used to encrypt the
+	// database for the first time. Only called if the database is already
+	// encrypted or EncryptDB is set.
+	DBPassphrase func(newPassphrase bool) (string, error)
+	EncryptDB    bool // encrypt the recovery database if it is not encrypted yet
 }
 
+// netSenderWrapper wraps the network interface of the swap state machines.
+// Tests use it to inject network faults.
+type netSenderWrapper func(backend.NetSender, swap.Manager) backend.NetSender
+
 // RunSwapDaemon assembles and runs a swapd instance blocking until swapd is
 // shut down. Typically, shutdown happens because a signal handler cancels the
 // passed in context, or when the shutdown RPC method is called.
-func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
+func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) error {
+	return runSwapDaemon(ctx, conf, nil)
+}
+
+// runSwapDaemon runs a swapd instance like RunSwapDaemon. If wrapNet is not
+// nil, the swap state machines send their messages through the NetSender that
+// it returns.
+func runSwapDaemon(ctx context.Context, conf *SwapdConfig, wrapNet netSenderWrapper) (err error) {
 	// Note: err can be modified in defer blocks, so it needs to be a named return
 	//       value above.
 	if conf.Libp2pKeyfile == "" {
@@ -65,9 +105,7 @@ func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
 
 	// Initialize the database first, so the defer statement that closes it
 	// will get executed last.
-	sdb, err := db.NewDatabase(&chaindb.Config{
-		DataDir: path.Join(conf.EnvConf.DataDir, databaseDirName),
-	})
+	sdb, err := OpenDatabase(conf.EnvConf.DataDir, conf.DBBackend)
 	if err != nil {
 		return err
 	}
@@ -77,7 +115,15 @@ func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
 		}
 	}()
 
-	sm, err := swap.NewManager(sdb)
+	if err = unlockRecoveryDB(sdb, conf); err != nil {
+		return err
+	}
+
+	if conf.Clock == nil {
+		conf.Clock = clock.Local()
+	}
+
+	sm, err := swap.NewManager(sdb, conf.Clock)
 	if err != nil {
 		return err
 	}
@@ -90,6 +136,7 @@ func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
 	host, err := net.NewHost(&net.Config{
 		Ctx:       ctx,
 		Env:       conf.EnvConf.Env,
+		ChainName: conf.EnvConf.EthChain.Name,
 		DataDir:   conf.EnvConf.DataDir,
 		Port:      conf.Libp2pPort,
 		KeyFile:   conf.Libp2pKeyfile,
@@ -106,15 +153,26 @@ func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
 		}
 	}()
 
+	var netSender backend.NetSender = host
+	if wrapNet != nil {
+		netSender = wrapNet(host, sm)
+	}
+
 	swapBackend, err := backend.NewBackend(&backend.Config{
 		Ctx:             ctx,
 		MoneroClient:    conf.MoneroClient,
 		EthereumClient:  conf.EthereumClient,
 		Environment:     conf.EnvConf.Env,
+		Clock:           conf.Clock,
 		SwapCreatorAddr: conf.EnvConf.SwapCreatorAddr,
+		ExtraChains:     conf.ExtraChains,
+		TokenPolicy:     conf.TokenPolicy,
+		RelayerLimits:   conf.RelayerLimits,
+		PayoutAddress:   conf.PayoutAddress,
+		PayoutFallback:  conf.PayoutFallback,
 		SwapManager:     sm,
 		RecoveryDB:      sdb.RecoveryDB(),
-		Net:             host,
+		Net:             netSender,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to make backend: %w", err)
@@ -124,6 +182,15 @@ func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
 		swapBackend.XMRClient().Endpoint(),
 		conf.EthereumClient.Endpoint(),
 	)
+	for _, chain := range conf.ExtraChains {
+		log.Infof("serving offers on additional ethereum chain ID %d with endpoint %s",
+			chain.EthereumClient.ChainID(),
+			chain.EthereumClient.Endpoint(),
+		)
+	}
+	if conf.PayoutAddress != (ethcommon.Address{}) {
+		log.Infof("claimed and refunded swap funds are sent to %s", conf.PayoutAddress)
+	}
 
 	xmrTaker, err := xmrtaker.NewInstance(&xmrtaker.Config{
 		Backend:        swapBackend,
@@ -150,15 +217,37 @@ func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
 		return err
 	}
 
+	var claimRelayer rpc.Relayer
+	if conf.IsRelayer {
+		claimRelayer = swapBackend.Relayer()
+
+		if conf.RelayerHTTPAddr != "" {
+			var relayerHTTPServer *relayer.HTTPServer
+			relayerHTTPServer, err = relayer.NewHTTPServer(
+				ctx,
+				conf.RelayerHTTPAddr,
+				swapBackend.Relayer(),
+				conf.RelayerHTTPAllowedOrigins,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to start relayer HTTP server: %w", err)
+			}
+			go serveRelayerHTTP(relayerHTTPServer)
+		}
+	}
+
 	rpcServer, err := rpc.NewServer(&rpc.Config{
 		Ctx:             ctx,
 		Env:             conf.EnvConf.Env,
+		EthChain:        conf.EnvConf.EthChain,
 		Address:         fmt.Sprintf("127.0.0.1:%d", conf.RPCPort),
 		Net:             host,
 		XMRTaker:        xmrTaker,
 		XMRMaker:        xmrMaker,
 		ProtocolBackend: swapBackend,
+		Relayer:         claimRelayer,
 		RecoveryDB:      sdb.RecoveryDB(),
+		Database:        sdb,
 		Namespaces:      rpc.AllNamespaces(),
 	})
 	if err != nil {
@@ -178,3 +267,108 @@ func RunSwapDaemon(ctx context.Context, conf *SwapdConfig) (err error) {
 	// return statement below (not nil)
 	return err
 }
+
+// unlockRecoveryDB unlocks the recovery database if it is encrypted, or
+// encrypts it if this was requested by the config.
+func unlockRecoveryDB(sdb *db.Database, conf *SwapdConfig) error {
+	isEncrypted := sdb.RecoveryDB().IsEncrypted()
+	if !isEncrypted && !conf.EncryptDB {
+		return nil
+	}
+
+	if conf.DBPassphrase == nil {
+		return errors.New("recovery database is encrypted, but no passphrase source was configured")
+	}
+
+	passphrase, err := conf.DBPassphrase(!isEncrypted)
+	if err != nil {
+		return err
+	}
+
+	if err = sdb.UnlockRecoveryDB(passphrase); err != nil {
+		return fmt.Errorf("failed to unlock recovery database: %w", err)
+	}
+
+	log.Info("Recovery database unlocked")
+	return nil
+}
+
+// OpenDatabase opens the database of the data directory with the given
+// backend. The empty backend is the same as db.BackendBadger.
+func OpenDatabase(dataDir string, backend db.Backend) (*db.Database, error) {
+	switch backend {
+	case db.BackendBadger, "":
+		return db.NewDatabase(&chaindb.Config{
+			DataDir: path.Join(dataDir, databaseDirName),
+		})
+	case db.BackendSQLite:
+		return db.NewSQLiteDatabase(path.Join(dataDir, sqliteDatabaseFileName))
+	default:
+		return nil, fmt.Errorf("unknown database backend %q", backend)
+	}
+}
+
+// ConvertDatabase copies the database of the data directory from one backend
+// to the other. The destination database must be empty. swapd must not be
+// running with the same data directory.
+func ConvertDatabase(dataDir string, from db.Backend, to db.Backend) (_ *db.Archive, err error) {
+	if from == to {
+		return nil, fmt.Errorf("source and destination database backends are both %q", from)
+	}
+
+	src, err := OpenDatabase(dataDir, from)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if dbErr := src.Close(); dbErr != nil {
+			err = multierror.Append(err, fmt.Errorf("closing %s database: %s", from, dbErr))
+		}
+	}()
+
+	dst, err := OpenDatabase(dataDir, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if dbErr := dst.Close(); dbErr != nil {
+			err = multierror.Append(err, fmt.Errorf("syncing %s database: %s", to, dbErr))
+		}
+	}()
+
+	return src.CopyTo(dst)
+}
+
+// RestoreDatabase replaces the offers, swaps and recovery data in the database
+// of the data directory with the content of the backup archive. swapd must not
+// be running with the same data directory.
+func RestoreDatabase(dataDir string, backend db.Backend, archivePath string, force bool) (_ *db.Archive, err error) {
+	archive, err := db.ReadArchive(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sdb, err := OpenDatabase(dataDir, backend)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if dbErr := sdb.Close(); dbErr != nil {
+			err = multierror.Append(err, fmt.Errorf("syncing database: %s", dbErr))
+		}
+	}()
+
+	if err = sdb.Restore(archive, force); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// serveRelayerHTTP serves the relayer's HTTP endpoint until swapd is shut down
+func serveRelayerHTTP(server *relayer.HTTPServer) {
+	err := server.Serve()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Errorf("relayer HTTP server failed: %s", err)
+	}
+}
@@ -19,7 +19,6 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/cliutil"
 	"github.com/athanorlabs/atomic-swap/coins"
-	"github.com/athanorlabs/atomic-swap/common"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
@@ -58,6 +57,15 @@ func minimumFundAlice(t *testing.T, aliceAddr ethcommon.Address, providesAmt *ap
 	t.Logf("Alice's start balance is: %s ETH", bal.AsEtherString())
 }
 
+// getRelayerFee returns the relayer fee that the maker paid in the past swap
+func getRelayerFee(t *testing.T, bc *rpcclient.Client, offerID types.Hash) *apd.Decimal {
+	resp, err := bc.GetPastSwap(&offerID)
+	require.NoError(t, err)
+	require.Len(t, resp.Swaps, 1)
+	require.NotNil(t, resp.Swaps[0].RelayerFee)
+	return resp.Swaps[0].RelayerFee
+}
+
 // Tests the scenario, where Bob has no ETH, there are no advertised relayers in
 // the network, and Alice relays Bob's claim.
 func TestRunSwapDaemon_SwapBobHasNoEth_AliceRelaysClaim(t *testing.T) {
@@ -81,7 +89,7 @@ func TestRunSwapDaemon_SwapBobHasNoEth_AliceRelaysClaim(t *testing.T) {
 	ac := rpcclient.NewClient(ctx, aliceConf.RPCPort)
 
 	useRelayer := false // Bob will use the relayer regardless, because he has no ETH
-	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, useRelayer)
+	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, types.NewOfferExtra(useRelayer))
 	require.NoError(t, err)
 
 	aliceStatusCh, err := ac.TakeOfferAndSubscribe(makeResp.PeerID, makeResp.OfferID, providesAmt)
@@ -135,7 +143,7 @@ func TestRunSwapDaemon_SwapBobHasNoEth_AliceRelaysClaim(t *testing.T) {
 	// Bob's ending balance should be Alice's provided amount minus the relayer fee
 	//
 	expectedBal := new(apd.Decimal)
-	_, err = coins.DecimalCtx().Sub(expectedBal, providesAmt, coins.RelayerFeeETH)
+	_, err = coins.DecimalCtx().Sub(expectedBal, providesAmt, getRelayerFee(t, bc, makeResp.OfferID))
 	require.NoError(t, err)
 
 	bobBalance, err := bobConf.EthereumClient.Balance(ctx)
@@ -172,7 +180,7 @@ func TestRunSwapDaemon_NoRelayersAvailable_Refund(t *testing.T) {
 	ac := rpcclient.NewClient(ctx, aliceConf.RPCPort)
 
 	useRelayer := false // Bob will use unsuccessfully use the relayer regardless, because he has no ETH
-	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, useRelayer)
+	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, types.NewOfferExtra(useRelayer))
 	require.NoError(t, err)
 
 	aliceStatusCh, err := ac.TakeOfferAndSubscribe(makeResp.PeerID, makeResp.OfferID, providesAmt)
@@ -255,7 +263,7 @@ func TestRunSwapDaemon_CharlieRelays(t *testing.T) {
 	ac := rpcclient.NewClient(ctx, aliceConf.RPCPort)
 
 	useRelayer := false // Bob will use the relayer regardless, because he has no ETH
-	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, useRelayer)
+	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, types.NewOfferExtra(useRelayer))
 	require.NoError(t, err)
 
 	aliceStatusCh, err := ac.TakeOfferAndSubscribe(makeResp.PeerID, makeResp.OfferID, providesAmt)
@@ -309,7 +317,7 @@ func TestRunSwapDaemon_CharlieRelays(t *testing.T) {
 	// Bob's ending balance should be Alice's provided amount minus the relayer fee
 	//
 	bobExpectedBal := new(apd.Decimal)
-	_, err = coins.DecimalCtx().Sub(bobExpectedBal, providesAmt, coins.RelayerFeeETH)
+	_, err = coins.DecimalCtx().Sub(bobExpectedBal, providesAmt, getRelayerFee(t, bc, makeResp.OfferID))
 	require.NoError(t, err)
 	bobBalance, err := bobConf.EthereumClient.Balance(ctx)
 	require.NoError(t, err)
@@ -357,7 +365,7 @@ func TestRunSwapDaemon_CharlieIsBroke_AliceRelays(t *testing.T) {
 	bc := rpcclient.NewClient(ctx, bobConf.RPCPort)
 	ac := rpcclient.NewClient(ctx, aliceConf.RPCPort)
 	useRelayer := false // Bob will use the relayer regardless, because he has no ETH
-	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, useRelayer)
+	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, types.EthAssetETH, types.NewOfferExtra(useRelayer))
 	require.NoError(t, err)
 
 	aliceStatusCh, err := ac.TakeOfferAndSubscribe(makeResp.PeerID, makeResp.OfferID, providesAmt)
@@ -411,7 +419,7 @@ func TestRunSwapDaemon_CharlieIsBroke_AliceRelays(t *testing.T) {
 	// Bob's ending balance should be Alice's provided amount minus the relayer fee
 	//
 	bobExpectedBal := new(apd.Decimal)
-	_, err = coins.DecimalCtx().Sub(bobExpectedBal, providesAmt, coins.RelayerFeeETH)
+	_, err = coins.DecimalCtx().Sub(bobExpectedBal, providesAmt, getRelayerFee(t, bc, makeResp.OfferID))
 	require.NoError(t, err)
 	bobBalance, err := bobConf.EthereumClient.Balance(ctx)
 	require.NoError(t, err)
@@ -431,7 +439,7 @@ func TestRunSwapDaemon_RPC_Version(t *testing.T) {
 	require.Equal(t, conf.EnvConf.Env, versionResp.Env)
 	require.NotEmpty(t, versionResp.SwapdVersion)
 	require.Equal(t, conf.EnvConf.SwapCreatorAddr, *versionResp.SwapCreatorAddr)
-	require.Equal(t, net.ChainProtocolID(common.Development), versionResp.P2PVersion)
+	require.Equal(t, net.ChainProtocolID(conf.EnvConf.EthChain.Name), versionResp.P2PVersion)
 }
 
 // Tests the shutdown RPC method
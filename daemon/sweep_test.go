@@ -42,7 +42,7 @@ func TestAliceStoppedAndRestartedDuringXMRSweep(t *testing.T) {
 
 	tokenAsset := getMockTetherAsset(t, aliceConf.EthereumClient)
 
-	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, tokenAsset, false)
+	makeResp, bobStatusCh, err := bc.MakeOfferAndSubscribe(minXMR, maxXMR, exRate, tokenAsset, nil)
 	require.NoError(t, err)
 
 	aliceStatusCh, err := ac.TakeOfferAndSubscribe(makeResp.PeerID, makeResp.OfferID, providesAmt)
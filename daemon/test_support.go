@@ -16,13 +16,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/apd/v3"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
 	"github.com/athanorlabs/atomic-swap/bootnode"
+	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/block"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/ethereum/simbackend"
 	"github.com/athanorlabs/atomic-swap/monero"
 	"github.com/athanorlabs/atomic-swap/rpcclient"
 	"github.com/athanorlabs/atomic-swap/tests"
@@ -64,6 +70,76 @@ func CreateTestConf(t *testing.T, ethKey *ecdsa.PrivateKey) *SwapdConfig {
 	}
 }
 
+// simMoneroBlockTime is the block time of the fake Monero ledger of a
+// SimNetwork. Locked XMR gets its MinSpendConfirmations in about a second.
+const simMoneroBlockTime = 100 * time.Millisecond
+
+// SimNetwork is an in-process simulated Ethereum chain, with a deployed
+// SwapCreator contract, and a fake Monero ledger, shared by the swapd
+// instances of a test. Swaps between the instances need no ganache, monerod or
+// monero-wallet-rpc.
+type SimNetwork struct {
+	Chain           *simbackend.Chain
+	Ledger          *monero.FakeLedger
+	SwapCreatorAddr ethcommon.Address
+	funder          extethclient.EthClient
+}
+
+// CreateSimNetwork creates a simulated chain and fake Monero ledger, and
+// deploys the SwapCreator contract.
+func CreateSimNetwork(t *testing.T) *SimNetwork {
+	funderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	chain := simbackend.CreateTestChain(t, funderKey)
+	funder := chain.CreateTestClient(t, funderKey)
+	swapCreatorAddr, _, err := contracts.DeploySwapCreatorWithKey(context.Background(), funder.Raw(), funderKey)
+	require.NoError(t, err)
+
+	return &SimNetwork{
+		Chain:           chain,
+		Ledger:          monero.NewFakeLedger(common.Development, simMoneroBlockTime),
+		SwapCreatorAddr: swapCreatorAddr,
+		funder:          funder,
+	}
+}
+
+// CreateTestConf creates a localhost-only dev environment SwapdConfig with a
+// new ethereum key and Monero wallet of the simulated network, funded with the
+// passed amounts of ETH and XMR. Either amount can be zero. The swap timeouts
+// are measured with the simulated chain's clock, so tests can expire them with
+// Chain.AdvanceTime.
+func (n *SimNetwork) CreateTestConf(t *testing.T, ethFunding *apd.Decimal, xmrFunding *apd.Decimal) *SwapdConfig {
+	ctx := context.Background()
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ec := n.Chain.CreateTestClient(t, ethKey)
+	if !ethFunding.IsZero() {
+		_, err = n.funder.Transfer(ctx, ec.Address(), coins.EtherToWei(ethFunding), nil)
+		require.NoError(t, err)
+	}
+
+	rpcPort, err := common.GetFreeTCPPort()
+	require.NoError(t, err)
+
+	envConf := new(common.Config)
+	*envConf = *common.ConfigDefaultsForEnv(common.Development)
+	envConf.DataDir = t.TempDir()
+	envConf.SwapCreatorAddr = n.SwapCreatorAddr
+
+	return &SwapdConfig{
+		EnvConf:        envConf,
+		MoneroClient:   n.Ledger.CreateWalletClient(t, coins.MoneroToPiconero(xmrFunding)),
+		EthereumClient: ec,
+		Libp2pPort:     0,
+		Libp2pKeyfile:  "",
+		RPCPort:        uint16(rpcPort),
+		IsRelayer:      false,
+		NoTransferBack: false,
+		Clock:          block.NewChainClock(n.Chain.Client()),
+	}
+}
+
 // CreateTestBootnode creates a bootnode for unit tests that is automatically
 // cleaned up when the test completes. Returns the local RPC port and P2P
 // address for the node.
@@ -113,6 +189,17 @@ func CreateTestBootnode(t *testing.T) (uint16, string) {
 // passed config are modified to make the first daemon the bootnode for the
 // remaining daemons.
 func LaunchDaemons(t *testing.T, timeout time.Duration, configs ...*SwapdConfig) (context.Context, context.CancelFunc) {
+	return launchDaemons(t, timeout, nil, configs...)
+}
+
+// launchDaemons launches daemons like LaunchDaemons. The network interface of
+// the daemons whose config has an entry in wrapNet is wrapped with it.
+func launchDaemons(
+	t *testing.T,
+	timeout time.Duration,
+	wrapNet map[*SwapdConfig]netSenderWrapper,
+	configs ...*SwapdConfig,
+) (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	// We'll use the bootnode(s) in the first config for any config that does
@@ -140,7 +227,7 @@ func LaunchDaemons(t *testing.T, timeout time.Duration, configs ...*SwapdConfig)
 		wg.Add(1)
 		go func(confIndex int) {
 			defer wg.Done()
-			err := RunSwapDaemon(ctx, conf)
+			err := runSwapDaemon(ctx, conf, wrapNet[conf])
 			require.ErrorIs(t, err, context.Canceled)
 			t.Logf("swapd#%d exited", confIndex)
 		}(n)
@@ -0,0 +1,395 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/common/vjson"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+)
+
+// CurArchiveVersion is the version of the backup archive format written by
+// this version of swapd.
+const CurArchiveVersion uint64 = 1
+
+// archivePrefixes are the prefixes of all tables included in a backup archive
+var archivePrefixes = []string{
+	metaPrefix,
+	offerPrefix,
+	swapPrefix,
+	recoveryPrefix,
+}
+
+var (
+	errArchiveChecksum = errors.New("backup archive checksum mismatch, the archive is corrupted")
+	errDatabaseNewer   = errors.New("database contains swaps that are newer than the backup archive")
+)
+
+// ArchiveEntry is a single key-value pair of the database. The key includes
+// the table prefix.
+type ArchiveEntry struct {
+	Key   []byte `json:"key" validate:"required"`
+	Value []byte `json:"value"`
+}
+
+// Archive is a consistent snapshot of the offers, swaps and recovery data in
+// the database. Recovery entries are archived as stored, so the archive of an
+// encrypted recovery database needs the same passphrase after a restore.
+type Archive struct {
+	Version       uint64          `json:"version" validate:"required"`
+	SchemaVersion uint64          `json:"schemaVersion"`
+	CreatedAt     time.Time       `json:"createdAt" validate:"required"`
+	Entries       []*ArchiveEntry `json:"entries" validate:"dive,required"`
+	Checksum      types.Hash      `json:"checksum" validate:"required"`
+}
+
+// NumOffers returns the number of archived offers.
+func (a *Archive) NumOffers() int {
+	return a.numTableEntries(offerPrefix)
+}
+
+// NumSwaps returns the number of archived swaps.
+func (a *Archive) NumSwaps() int {
+	return a.numTableEntries(swapPrefix)
+}
+
+// numTableEntries returns the number of archived entries with the given table prefix.
+func (a *Archive) numTableEntries(prefix string) int {
+	n := 0
+	for _, e := range a.Entries {
+		if bytes.HasPrefix(e.Key, []byte(prefix)) {
+			n++
+		}
+	}
+	return n
+}
+
+// computeChecksum returns the SHA-256 hash of the archive entries and header
+// fields.
+func (a *Archive) computeChecksum() types.Hash {
+	h := sha256.New()
+	writeUint := func(v uint64) {
+		_, _ = h.Write(binary.BigEndian.AppendUint64(nil, v))
+	}
+
+	writeUint(a.Version)
+	writeUint(a.SchemaVersion)
+	writeUint(uint64(a.CreatedAt.UnixNano()))
+	for _, e := range a.Entries {
+		writeUint(uint64(len(e.Key)))
+		_, _ = h.Write(e.Key)
+		writeUint(uint64(len(e.Value)))
+		_, _ = h.Write(e.Value)
+	}
+
+	var sum types.Hash
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// validate checks that the archive can be restored by this version of swapd,
+// and that its offers and swaps can be decoded.
+func (a *Archive) validate() error {
+	if a.Version != CurArchiveVersion {
+		return fmt.Errorf("unsupported backup archive version %d", a.Version)
+	}
+
+	if a.SchemaVersion > CurSchemaVersion {
+		return fmt.Errorf("backup archive schema version %d is newer than the supported version %d",
+			a.SchemaVersion, CurSchemaVersion)
+	}
+
+	if a.computeChecksum() != a.Checksum {
+		return errArchiveChecksum
+	}
+
+	for _, e := range a.Entries {
+		if !hasArchivePrefix(e.Key) {
+			return fmt.Errorf("backup archive contains unknown key %X", e.Key)
+		}
+
+		id, isOffer := tableEntryID(e.Key, offerPrefix)
+		if isOffer {
+			if _, err := unmarshalStoredOffer(e.Value); err != nil {
+				return fmt.Errorf("invalid offer 0x%X in backup archive: %w", id, err)
+			}
+		}
+
+		id, isSwap := tableEntryID(e.Key, swapPrefix)
+		if isSwap {
+			if _, err := swap.UnmarshalInfo(e.Value); err != nil {
+				return fmt.Errorf("invalid swap 0x%X in backup archive: %w", id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasArchivePrefix returns true if the key belongs to one of the archived tables.
+func hasArchivePrefix(key []byte) bool {
+	for _, prefix := range archivePrefixes {
+		if bytes.HasPrefix(key, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// tableEntryID returns the ID of a key from the offer or swap table, which
+// consists of the table prefix followed by the 32-byte ID.
+func tableEntryID(key []byte, prefix string) ([]byte, bool) {
+	if len(key) != len(prefix)+idLength || !bytes.HasPrefix(key, []byte(prefix)) {
+		return nil, false
+	}
+	return key[len(prefix):], true
+}
+
+// errIterator is an iterator that reports the error that ended the iteration
+// early. chaindb's BadgerDB iterators don't, they log value read errors and
+// return nil values instead.
+type errIterator interface {
+	chaindb.Iterator
+	Err() error
+}
+
+// Snapshot returns an archive of the offers, swaps and recovery data. The
+// schema version and all entries are read with a single iterator, which reads
+// from one read-only transaction, so the archive is consistent even while
+// swapd is writing to the database. The snapshot fails if any entry can't be
+// read.
+func (db *Database) Snapshot() (*Archive, error) {
+	iter := db.db.NewIterator()
+	defer iter.Release()
+
+	archive := &Archive{
+		Version:   CurArchiveVersion,
+		CreatedAt: time.Now().UTC(),
+		Entries:   []*ArchiveEntry{},
+	}
+
+	versionKey := append([]byte(metaPrefix), schemaVersionKey...)
+	for iter.Next() {
+		key := iter.Key()
+		if !hasArchivePrefix(key) {
+			continue
+		}
+
+		// no entry of the archived tables has an empty value
+		value := iter.Value()
+		if len(value) == 0 {
+			return nil, fmt.Errorf("failed to read database entry %X", key)
+		}
+
+		if bytes.Equal(key, versionKey) {
+			var err error
+			if archive.SchemaVersion, err = decodeSchemaVersion(value); err != nil {
+				return nil, err
+			}
+		}
+
+		archive.Entries = append(archive.Entries, &ArchiveEntry{
+			Key:   bytes.Clone(key),
+			Value: value,
+		})
+	}
+
+	if ei, ok := iter.(errIterator); ok && ei.Err() != nil {
+		return nil, fmt.Errorf("failed to read database: %w", ei.Err())
+	}
+
+	archive.Checksum = archive.computeChecksum()
+	return archive, nil
+}
+
+// Backup writes a snapshot of the database to a new file at the given path.
+// An existing file is never overwritten.
+func (db *Database) Backup(archivePath string) (*Archive, error) {
+	archive, err := db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = WriteArchive(archivePath, archive); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Backed up %d database entries to %s", len(archive.Entries), archivePath)
+	return archive, nil
+}
+
+// CopyTo copies the offers, swaps and recovery data to an empty database,
+// which is used to convert a database to a different backend.
+func (db *Database) CopyTo(dst *Database) (*Archive, error) {
+	if !dst.isEmpty() {
+		return nil, errors.New("destination database is not empty")
+	}
+
+	archive, err := db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = dst.Restore(archive, false); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// WriteArchive writes the archive as JSON to a new file at the given path. The
+// file is only readable by the current user, as it contains the swap secrets.
+func WriteArchive(archivePath string, archive *Archive) error {
+	data, err := vjson.MarshalIndentStruct(archive, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Clean(archivePath), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(archivePath)
+		return err
+	}
+
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(archivePath)
+		return err
+	}
+
+	return f.Close()
+}
+
+// ReadArchive reads and validates a backup archive written by WriteArchive.
+func ReadArchive(archivePath string) (*Archive, error) {
+	data, err := os.ReadFile(filepath.Clean(archivePath))
+	if err != nil {
+		return nil, err
+	}
+
+	archive := new(Archive)
+	if err = vjson.UnmarshalStruct(data, archive); err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+
+	if err = archive.validate(); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// Restore replaces the offers, swaps and recovery data in the database with
+// the content of the archive. It refuses to replace a database containing
+// swaps that were updated after the archive was created or are missing from
+// it, unless force is set. Archives with an older schema version are migrated
+// to the current version after restoring them. The database should not be used
+// by a running swapd instance.
+func (db *Database) Restore(archive *Archive, force bool) error {
+	err := archive.validate()
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		if err = db.checkNotNewerThan(archive); err != nil {
+			return err
+		}
+	}
+
+	batch := db.db.NewBatch()
+
+	// delete everything first, the batch gives precedence to puts of the same key
+	iter := db.db.NewIterator()
+	for iter.Next() {
+		key := iter.Key()
+		if !hasArchivePrefix(key) {
+			continue
+		}
+
+		if err = batch.Del(bytes.Clone(key)); err != nil {
+			iter.Release()
+			return err
+		}
+	}
+	iter.Release()
+
+	for _, e := range archive.Entries {
+		if err = batch.Put(e.Key, e.Value); err != nil {
+			return err
+		}
+	}
+
+	if err = batch.Flush(); err != nil {
+		return err
+	}
+
+	// the restored recovery table may be encrypted with a different key
+	if db.recoveryDB, err = newRecoveryDB(db.recoveryDB.db); err != nil {
+		return err
+	}
+
+	// the archive is the backup of the restored state
+	if err = db.migrate(""); err != nil {
+		return err
+	}
+
+	log.Infof("Restored %d database entries from backup archive created at %s",
+		len(archive.Entries), archive.CreatedAt)
+	return nil
+}
+
+// checkNotNewerThan returns an error if the database has swaps that are not in
+// the archive or that were updated after the archive was created.
+func (db *Database) checkNotNewerThan(archive *Archive) error {
+	archived := make(map[types.Hash]struct{})
+	for _, e := range archive.Entries {
+		id, isSwap := tableEntryID(e.Key, swapPrefix)
+		if isSwap {
+			var offerID types.Hash
+			copy(offerID[:], id)
+			archived[offerID] = struct{}{}
+		}
+	}
+
+	swaps, err := db.GetAllSwaps()
+	if err != nil {
+		return err
+	}
+
+	var errs *multierror.Error
+	for _, s := range swaps {
+		if _, ok := archived[s.OfferID]; !ok {
+			errs = multierror.Append(errs, fmt.Errorf("swap %s is not in the archive", s.OfferID))
+			continue
+		}
+
+		if s.LastStatusUpdateTime.After(archive.CreatedAt) {
+			errs = multierror.Append(errs, fmt.Errorf("swap %s was updated at %s", s.OfferID, s.LastStatusUpdateTime))
+		}
+	}
+
+	if errs != nil {
+		return fmt.Errorf("%w: %s", errDatabaseNewer, errs.Error())
+	}
+
+	return nil
+}
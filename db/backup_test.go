@@ -0,0 +1,226 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/Masterminds/semver/v3"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+)
+
+func newTestSwapInfo(offer *types.Offer, lastUpdate time.Time) *swap.Info {
+	timeout := time.Now().Add(30 * time.Minute)
+	return &swap.Info{
+		Version:              swap.CurInfoVersion,
+		PeerID:               testPeerID,
+		OfferID:              offer.ID,
+		Provides:             offer.Provides,
+		ProvidedAmount:       offer.MinAmount,
+		ExpectedAmount:       offer.MinAmount,
+		ExchangeRate:         offer.ExchangeRate,
+		EthAsset:             offer.EthAsset,
+		Status:               types.XMRLocked,
+		LastStatusUpdateTime: lastUpdate,
+		MoneroStartHeight:    12345,
+		StartTime:            time.Now(),
+		Timeout1:             &timeout,
+		Timeout2:             &timeout,
+	}
+}
+
+func newTestOffer() *types.Offer {
+	one := coins.StrToDecimal("1")
+	ethAsset := types.EthAsset(ethcommon.HexToAddress("0xa1E32d14AC4B6d8c1791CAe8E9baD46a1E15B7a8"))
+	return types.NewOffer(coins.ProvidesXMR, one, one, coins.ToExchangeRate(one), ethAsset)
+}
+
+func newTestDatabase(t *testing.T) *Database {
+	db, err := NewDatabase(&chaindb.Config{DataDir: t.TempDir(), InMemory: true})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+func TestDatabase_BackupRestore(t *testing.T) {
+	db := newTestDatabase(t)
+
+	offerA := newTestOffer()
+	offerB := newTestOffer()
+	require.NoError(t, db.PutOffer(offerB))
+	infoA := newTestSwapInfo(offerA, time.Now().Add(-time.Minute))
+	require.NoError(t, db.PutSwap(infoA))
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	require.NoError(t, db.RecoveryDB().PutSwapPrivateKey(offerA.ID, kp.SpendKey()))
+
+	archivePath := path.Join(t.TempDir(), "backup.json")
+	archive, err := db.Backup(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, CurSchemaVersion, archive.SchemaVersion)
+	require.Equal(t, 1, archive.NumOffers())
+	require.Equal(t, 1, archive.NumSwaps())
+	require.Equal(t, 1, archive.numTableEntries(recoveryPrefix))
+	require.Equal(t, 1, archive.numTableEntries(metaPrefix))
+
+	info, err := os.Stat(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// an existing archive is never overwritten
+	_, err = db.Backup(archivePath)
+	require.ErrorIs(t, err, os.ErrExist)
+
+	readArchive, err := ReadArchive(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, archive.Checksum, readArchive.Checksum)
+
+	// restore into a new database
+	db2 := newTestDatabase(t)
+	require.NoError(t, db2.PutOffer(newTestOffer())) // removed by the restore
+	require.NoError(t, db2.Restore(readArchive, false))
+
+	offers, err := db2.GetAllOffers()
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, offerB.ID, offers[0].ID)
+
+	resInfo, err := db2.GetSwap(offerA.ID)
+	require.NoError(t, err)
+	require.Equal(t, infoAsJSON(t, infoA), infoAsJSON(t, resInfo))
+
+	sk, err := db2.RecoveryDB().GetSwapPrivateKey(offerA.ID)
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().String(), sk.String())
+}
+
+func TestDatabase_Restore_newerDatabase(t *testing.T) {
+	db := newTestDatabase(t)
+	archive, err := db.Snapshot()
+	require.NoError(t, err)
+
+	// a swap that was started after the snapshot
+	offer := newTestOffer()
+	require.NoError(t, db.PutSwap(newTestSwapInfo(offer, time.Now())))
+	require.ErrorIs(t, db.Restore(archive, false), errDatabaseNewer)
+
+	// forcing the restore removes the swap
+	require.NoError(t, db.Restore(archive, true))
+	swaps, err := db.GetAllSwaps()
+	require.NoError(t, err)
+	require.Empty(t, swaps)
+}
+
+func TestDatabase_Restore_olderSchemaVersion(t *testing.T) {
+	db := newTestDatabase(t)
+	offer := newTestOffer()
+	info := newTestSwapInfo(offer, time.Now().Add(-time.Minute))
+	info.Version = semver.MustParse("0.2.0")
+	require.NoError(t, db.PutSwap(info))
+
+	// turn the snapshot into one of a database without a schema version
+	archive, err := db.Snapshot()
+	require.NoError(t, err)
+	var entries []*ArchiveEntry
+	for _, e := range archive.Entries {
+		if !bytes.HasPrefix(e.Key, []byte(metaPrefix)) {
+			entries = append(entries, e)
+		}
+	}
+	archive.Entries = entries
+	archive.SchemaVersion = 0
+	archive.Checksum = archive.computeChecksum()
+
+	db2 := newTestDatabase(t)
+	require.NoError(t, db2.Restore(archive, false))
+
+	version, err := db2.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, CurSchemaVersion, version)
+
+	resInfo, err := db2.GetSwap(offer.ID)
+	require.NoError(t, err)
+	require.True(t, resInfo.Version.Equal(swap.CurInfoVersion))
+}
+
+// unreadableValueDB is a database whose iterators return a nil value for one
+// key, like a BadgerDB iterator that fails to read the value.
+type unreadableValueDB struct {
+	chaindb.Database
+	key []byte
+}
+
+func (d *unreadableValueDB) NewIterator() chaindb.Iterator {
+	return &unreadableValueIterator{Iterator: d.Database.NewIterator(), key: d.key}
+}
+
+type unreadableValueIterator struct {
+	chaindb.Iterator
+	key []byte
+}
+
+func (i *unreadableValueIterator) Value() []byte {
+	if bytes.Equal(i.Key(), i.key) {
+		return nil
+	}
+	return i.Iterator.Value()
+}
+
+func TestDatabase_Snapshot_unreadableValue(t *testing.T) {
+	db := newTestDatabase(t)
+	unreadable := &unreadableValueDB{Database: db.db}
+	db.db = unreadable
+
+	offer := newTestOffer()
+	require.NoError(t, db.PutOffer(offer))
+	_, err := db.Snapshot()
+	require.NoError(t, err)
+
+	unreadable.key = append([]byte(offerPrefix), offer.ID[:]...)
+	_, err = db.Snapshot()
+	require.ErrorContains(t, err, "failed to read database entry")
+}
+
+func TestReadArchive_invalid(t *testing.T) {
+	db := newTestDatabase(t)
+	require.NoError(t, db.PutOffer(newTestOffer()))
+
+	archive, err := db.Snapshot()
+	require.NoError(t, err)
+	archive.Entries[0].Value = append(archive.Entries[0].Value, '!')
+
+	archivePath := path.Join(t.TempDir(), "backup.json")
+	require.NoError(t, WriteArchive(archivePath, archive))
+	_, err = ReadArchive(archivePath)
+	require.ErrorIs(t, err, errArchiveChecksum)
+
+	archive.SchemaVersion = CurSchemaVersion + 1
+	archive.Checksum = archive.computeChecksum()
+	require.ErrorContains(t, archive.validate(), "is newer than the supported version")
+}
+
+func TestNewDatabase_newerSchemaVersion(t *testing.T) {
+	cfg := &chaindb.Config{DataDir: t.TempDir()}
+	db, err := NewDatabase(cfg)
+	require.NoError(t, err)
+	require.NoError(t, db.putSchemaVersion(CurSchemaVersion+1))
+	require.NoError(t, db.Close())
+
+	_, err = NewDatabase(cfg)
+	require.ErrorContains(t, err, "is newer than the supported version")
+}
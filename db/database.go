@@ -5,7 +5,9 @@
 package db
 
 import (
-	"errors"
+	"bytes"
+	"fmt"
+	"path/filepath"
 
 	"github.com/ChainSafe/chaindb"
 	logging "github.com/ipfs/go-log/v2"
@@ -18,6 +20,7 @@ import (
 const (
 	offerPrefix = "offer"
 	swapPrefix  = "swap"
+	metaPrefix  = "meta"
 	idLength    = len(types.Hash{})
 )
 
@@ -27,6 +30,14 @@ var (
 
 // Database is the persistent datastore used by swapd.
 type Database struct {
+	// db is the underlying database containing all tables.
+	db chaindb.Database
+
+	// metaTable is a key-value store where all the keys are prefixed by
+	// metaPrefix in the underlying database. It contains information about
+	// the database itself, like the schema version.
+	metaTable chaindb.Database
+
 	// offerTable is a key-value store where all the keys are prefixed by offerPrefix
 	// in the underlying database.
 	// the key is the 32-byte offer ID and the value is a JSON-marshalled *types.Offer.
@@ -48,20 +59,46 @@ type Database struct {
 	recoveryDB *RecoveryDB
 }
 
-// NewDatabase returns a new *Database.
+// NewDatabase returns a new *Database stored in a BadgerDB.
 func NewDatabase(cfg *chaindb.Config) (*Database, error) {
 	db, err := chaindb.NewBadgerDB(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	recoveryDB := newRecoveryDB(chaindb.NewTable(db, recoveryPrefix))
+	// In-memory databases have nothing to back up before a migration
+	backupDir := ""
+	if !cfg.InMemory {
+		backupDir = filepath.Dir(filepath.Clean(cfg.DataDir))
+	}
+
+	return newDatabase(db, backupDir)
+}
+
+// newDatabase returns a new *Database using the passed underlying database,
+// which is closed if an error is returned. Migration backups are written to
+// backupDir, unless it is empty.
+func newDatabase(db chaindb.Database, backupDir string) (*Database, error) {
+	recoveryDB, err := newRecoveryDB(newTable(db, recoveryPrefix))
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
 
-	return &Database{
-		offerTable: chaindb.NewTable(db, offerPrefix),
-		swapTable:  chaindb.NewTable(db, swapPrefix),
+	sdb := &Database{
+		db:         db,
+		metaTable:  newTable(db, metaPrefix),
+		offerTable: newTable(db, offerPrefix),
+		swapTable:  newTable(db, swapPrefix),
 		recoveryDB: recoveryDB,
-	}, nil
+	}
+
+	if err = sdb.migrate(backupDir); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return sdb, nil
 }
 
 // Close flushes and closes the database.
@@ -84,6 +121,44 @@ func (db *Database) RecoveryDB() *RecoveryDB {
 	return db.recoveryDB
 }
 
+// UnlockRecoveryDB derives the encryption key of the recovery table from the
+// passphrase. If the recovery table is not encrypted yet, all of its existing
+// entries are encrypted with the new key. Once unlocked, the RecoveryDB is used
+// the same way as an unencrypted one.
+//
+// Encrypting existing entries overwrites them, but BadgerDB only discards the
+// old plaintext values when it compacts its files, which can take a long time.
+// docs/default-file-locations.md describes how to rebuild the database so no
+// plaintext copy is left on disk.
+func (db *Database) UnlockRecoveryDB(passphrase string) error {
+	if db.recoveryDB.IsEncrypted() {
+		return db.recoveryDB.unlock(passphrase)
+	}
+
+	// Every entry under the recovery prefix is encrypted, including entries
+	// of swaps that are no longer in the swap or offer tables.
+	prefix := []byte(recoveryPrefix)
+	var keys [][]byte
+	iter := db.db.NewIterator()
+	for iter.Next() {
+		key := iter.Key()
+		if bytes.HasPrefix(key, prefix) {
+			keys = append(keys, bytes.Clone(key[len(prefix):]))
+		}
+	}
+	iter.Release()
+
+	if err := db.recoveryDB.encrypt(passphrase, keys); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		log.Warnf("Encrypted %d existing recovery database entries, their plaintext values may "+
+			"remain in the database files until they are compacted", len(keys))
+	}
+	return nil
+}
+
 // PutOffer puts an offer in the database.
 func (db *Database) PutOffer(offer *types.Offer) error {
 	val, err := vjson.MarshalStruct(offer)
@@ -116,30 +191,9 @@ func (db *Database) GetOffer(id types.Hash) (*types.Offer, error) {
 	return types.UnmarshalOffer(val)
 }
 
-// purgeInvalidOffer purges an offer after its JSON entry failed to decode when GetAllOffers
-// is called on start. We also purge any swap entry with the same offer ID.
-func (db *Database) purgeInvalidOffer(id []byte, encodedOffer string, reasonErr error) error {
-	log.Warnf("removing invalid offer with ID=0x%X from database: %s", id, reasonErr)
-	log.Warnf("invalid offer JSON was: %s", encodedOffer)
-	if err := db.offerTable.Del(id[:]); err != nil {
-		return err
-	}
-	swapEncoded, err := db.swapTable.Get(id[:])
-	if err != nil {
-		if errors.Is(chaindb.ErrKeyNotFound, err) {
-			return nil // no swap entry to remove, we are done
-		}
-		return err
-	}
-	log.Warnf("removing invalid offer's swap entry: %s", swapEncoded)
-	if err := db.swapTable.Del(id[:]); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// GetAllOffers returns all offers in the database.
+// GetAllOffers returns all offers in the database. Offers that fail to decode
+// are not removed, the error is returned instead, as the schema migrations run
+// by NewDatabase ensure that all stored offers are valid.
 func (db *Database) GetAllOffers() ([]*types.Offer, error) {
 	iter := db.offerTable.NewIterator()
 	defer iter.Release()
@@ -148,22 +202,17 @@ func (db *Database) GetAllOffers() ([]*types.Offer, error) {
 	for iter.Valid() {
 		id := iter.Key()
 
-		// if the key/offerID becomes longer than 32, we're not iterating over offers
-		if len(id) > idLength {
+		// if the key/offerID is not 32 bytes, we're not iterating over offers
+		if len(id) != idLength {
 			break
 		}
 
-		encodedOffer := iter.Value()
-		offer, err := types.UnmarshalOffer(encodedOffer)
+		offer, err := types.UnmarshalOffer(iter.Value())
 		if err != nil {
-			// Assuming logging and purging succeeds, don't propagate the error up,
-			// so swapd can continue running.
-			if err = db.purgeInvalidOffer(id, string(encodedOffer), err); err != nil {
-				return nil, err
-			}
-		} else {
-			offers = append(offers, offer)
+			return nil, fmt.Errorf("invalid offer with ID=0x%X in database: %w", id, err)
 		}
+
+		offers = append(offers, offer)
 		iter.Next()
 	}
 
@@ -226,7 +275,8 @@ func (db *Database) GetSwap(id types.Hash) (*swap.Info, error) {
 	return &s, nil
 }
 
-// GetAllSwaps returns all swaps in the database.
+// GetAllSwaps returns all swaps in the database. Like GetAllOffers, it returns
+// an error instead of removing swaps that fail to decode.
 func (db *Database) GetAllSwaps() ([]*swap.Info, error) {
 	iter := db.swapTable.NewIterator()
 	defer iter.Release()
@@ -235,24 +285,18 @@ func (db *Database) GetAllSwaps() ([]*swap.Info, error) {
 	for iter.Valid() {
 		id := iter.Key()
 
-		// if the key becomes longer than 32, we're not iterating over swaps
-		if len(id) > idLength {
+		// if the key is not 32 bytes, we're not iterating over swaps
+		if len(id) != idLength {
 			break
 		}
 
 		// value is the encoded swap
-		encodedSwap := iter.Value()
-		s, err := swap.UnmarshalInfo(encodedSwap)
+		s, err := swap.UnmarshalInfo(iter.Value())
 		if err != nil {
-			log.Warnf("removing invalid swap info with offerID=0x%X: %s", id, err)
-			log.Warnf("invalid swap info JSON was: %s", string(encodedSwap))
-			if err = db.swapTable.Del(id[:]); err != nil {
-				return nil, err
-			}
-		} else {
-			swaps = append(swaps, s)
+			return nil, fmt.Errorf("invalid swap info with offerID=0x%X in database: %w", id, err)
 		}
 
+		swaps = append(swaps, s)
 		iter.Next()
 	}
 
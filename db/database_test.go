@@ -144,24 +144,22 @@ func TestDatabase_GetAllOffers_InvalidEntry(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, exists)
 
-	// Only the good offer should be returned by GetAllOffers
-	offers, err := db.GetAllOffers()
-	require.NoError(t, err)
-	require.Equal(t, 1, len(offers))
-	require.EqualValues(t, goodOffer.ID[:], offers[0].ID[:])
+	// GetAllOffers fails on the bad offer
+	_, err = db.GetAllOffers()
+	require.ErrorContains(t, err, "invalid offer with ID=0x010203")
 
-	// GetAllOffers should have pruned the bad offer, but left the good offer
+	// No entries were removed
 	exists, err = db.offerTable.Has(goodOffer.ID[:])
 	require.NoError(t, err)
 	require.True(t, exists)
 
 	exists, err = db.offerTable.Has(badOfferID[:])
 	require.NoError(t, err)
-	require.False(t, exists) // offer entry was pruned
+	require.True(t, exists)
 
 	exists, err = db.swapTable.Has(badOfferID[:])
 	require.NoError(t, err)
-	require.False(t, exists) // swap info tied to removed offer pruned
+	require.True(t, exists)
 }
 
 func TestDatabase_SwapTable(t *testing.T) {
@@ -279,20 +277,18 @@ func TestDatabase_GetAllSwaps_InvalidEntry(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, exists)
 
-	// Only the good offer should be returned by GetAllSwaps
-	swaps, err := db.GetAllSwaps()
-	require.NoError(t, err)
-	require.Equal(t, 1, len(swaps))
-	require.EqualValues(t, goodInfo.OfferID[:], swaps[0].OfferID[:])
+	// GetAllSwaps fails on the bad entry
+	_, err = db.GetAllSwaps()
+	require.ErrorContains(t, err, "invalid swap info with offerID=0x040506")
 
-	// GetAllSwaps should have pruned the bad swap info entry, but left the good entry
+	// No entries were removed
 	exists, err = db.swapTable.Has(goodInfo.OfferID[:])
 	require.NoError(t, err)
-	require.True(t, exists) // entry still exists
+	require.True(t, exists)
 
 	exists, err = db.swapTable.Has(badInfoID[:])
 	require.NoError(t, err)
-	require.False(t, exists) // entry was pruned
+	require.True(t, exists)
 }
 
 func TestDatabase_SwapTable_Update(t *testing.T) {
@@ -0,0 +1,365 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/Masterminds/semver/v3"
+	"github.com/cockroachdb/apd/v3"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/common/vjson"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+)
+
+// schemaVersionKey is the key in the meta table holding the schema version.
+// Databases created before schema versions were introduced don't have it and
+// are at schema version 0.
+const schemaVersionKey = "schemaversion"
+
+// migration upgrades the database by a single schema version.
+type migration struct {
+	description string
+
+	// migrate adds the changes of the migration to the batch, which is only
+	// flushed if it succeeds. Recovery table values are passed as stored, so
+	// they are encrypted if the recovery DB is encrypted.
+	migrate func(db *Database, batch chaindb.Batch) error
+}
+
+// migrations are applied in order on startup, migrations[i] upgrades the
+// database from schema version i to i+1. Migrations must never be removed or
+// reordered, new ones are appended.
+var migrations = []*migration{
+	{
+		description: "upgrade offers, swap infos and recovery records to the current layouts",
+		migrate:     migrateRecordLayouts,
+	},
+}
+
+// CurSchemaVersion is the version of the database layout written by this
+// version of swapd.
+var CurSchemaVersion = uint64(len(migrations))
+
+// SchemaVersion returns the schema version of the database.
+func (db *Database) SchemaVersion() (uint64, error) {
+	val, err := db.metaTable.Get([]byte(schemaVersionKey))
+	if err != nil {
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return decodeSchemaVersion(val)
+}
+
+func decodeSchemaVersion(val []byte) (uint64, error) {
+	if len(val) != 8 {
+		return 0, fmt.Errorf("invalid database schema version %X", val)
+	}
+
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func (db *Database) putSchemaVersion(version uint64) error {
+	if err := db.metaTable.Put([]byte(schemaVersionKey), encodeSchemaVersion(version)); err != nil {
+		return err
+	}
+
+	return db.metaTable.Flush()
+}
+
+func encodeSchemaVersion(version uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, version)
+}
+
+// isEmpty returns true if the database has no offers, swaps or recovery data.
+func (db *Database) isEmpty() bool {
+	iter := db.db.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		key := iter.Key()
+		if hasArchivePrefix(key) && !bytes.HasPrefix(key, []byte(metaPrefix)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// migrate upgrades the database to the current schema version. Before the
+// first migration, a backup archive of the database is written to backupDir,
+// unless backupDir is empty. If a migration fails, the database is left at the
+// last successfully applied schema version and the error is returned, so
+// swapd refuses to start instead of running with data it can't read.
+func (db *Database) migrate(backupDir string) error {
+	has, err := db.metaTable.Has([]byte(schemaVersionKey))
+	if err != nil {
+		return err
+	}
+
+	if !has && db.isEmpty() {
+		// new database, nothing to migrate
+		return db.putSchemaVersion(CurSchemaVersion)
+	}
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if version > CurSchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than the supported version %d",
+			version, CurSchemaVersion)
+	}
+
+	if version == CurSchemaVersion {
+		return nil
+	}
+
+	if backupDir != "" {
+		backupPath := filepath.Join(
+			backupDir,
+			fmt.Sprintf("swap-db-v%d-%s.backup.json", version, time.Now().Format("20060102-150405")),
+		)
+		if _, err = db.Backup(backupPath); err != nil {
+			return fmt.Errorf("failed to back up database before migration: %w", err)
+		}
+	}
+
+	for ; version < CurSchemaVersion; version++ {
+		m := migrations[version]
+		log.Infof("Migrating database to schema version %d: %s", version+1, m.description)
+
+		batch := db.db.NewBatch()
+		if err = m.migrate(db, batch); err != nil {
+			return fmt.Errorf("database migration to schema version %d failed: %w", version+1, err)
+		}
+
+		err = batch.Put(append([]byte(metaPrefix), schemaVersionKey...), encodeSchemaVersion(version+1))
+		if err != nil {
+			return err
+		}
+
+		if err = batch.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachTableEntry calls fn with the ID and value of every entry of the offer
+// or swap table.
+func (db *Database) forEachTableEntry(prefix string, fn func(id []byte, value []byte) error) error {
+	iter := db.db.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, []byte(prefix)) {
+			continue
+		}
+
+		id, ok := tableEntryID(key, prefix)
+		if !ok {
+			return fmt.Errorf("invalid key %X in %s table", key, prefix)
+		}
+
+		if err := fn(bytes.Clone(id), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateRecordLayouts upgrades all offers, swap infos and unencrypted
+// recovery records to the current layouts. The version is part of the offer
+// ID, so upgraded offers are stored under their new ID. Swaps and their
+// recovery records keep the offer ID the swap was started with.
+func migrateRecordLayouts(db *Database, batch chaindb.Batch) error {
+	err := db.forEachTableEntry(offerPrefix, func(id []byte, value []byte) error {
+		offer, err := unmarshalStoredOffer(value)
+		if err != nil {
+			return fmt.Errorf("invalid offer with ID=0x%X: %w", id, err)
+		}
+
+		if bytes.Equal(offer.ID[:], id) {
+			return nil
+		}
+
+		newValue, err := vjson.MarshalStruct(offer)
+		if err != nil {
+			return err
+		}
+
+		if err = batch.Del(append([]byte(offerPrefix), id...)); err != nil {
+			return err
+		}
+		return batch.Put(append([]byte(offerPrefix), offer.ID[:]...), newValue)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.forEachTableEntry(swapPrefix, func(id []byte, value []byte) error {
+		info, err := swap.UnmarshalInfo(value)
+		if err != nil {
+			return fmt.Errorf("invalid swap info with offerID=0x%X: %w", id, err)
+		}
+
+		if info.Version.Equal(swap.CurInfoVersion) {
+			return nil
+		}
+
+		info.Version = swap.CurInfoVersion
+		newValue, err := vjson.MarshalStruct(info)
+		if err != nil {
+			return err
+		}
+
+		return batch.Put(append([]byte(swapPrefix), id...), newValue)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Encrypted values can't be read before the recovery DB is unlocked.
+	// EthereumSwapInfo also decodes the old layout, so they don't have to be
+	// rewritten.
+	if db.recoveryDB.encrypted {
+		return nil
+	}
+
+	return db.forEachContractSwapInfo(func(key []byte, value []byte) error {
+		var info EthereumSwapInfo
+		if err := vjson.UnmarshalStruct(value, &info); err != nil {
+			return fmt.Errorf("invalid contract swap info with offerID=0x%X: %w", key[:idLength], err)
+		}
+
+		newValue, err := vjson.MarshalStruct(&info)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(value, newValue) {
+			return nil
+		}
+
+		return batch.Put(append([]byte(recoveryPrefix), key...), newValue)
+	})
+}
+
+// forEachContractSwapInfo calls fn with the key, without the recovery table
+// prefix, and the value of every EthereumSwapInfo in the recovery table.
+func (db *Database) forEachContractSwapInfo(fn func(key []byte, value []byte) error) error {
+	iter := db.db.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, []byte(recoveryPrefix)) {
+			continue
+		}
+
+		key = key[len(recoveryPrefix):]
+		if len(key) != idLength+len(contractSwapInfoPrefix) ||
+			!bytes.HasSuffix(key, []byte(contractSwapInfoPrefix)) {
+			continue
+		}
+
+		if err := fn(bytes.Clone(key), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// legacyOffer is the layout of offers older than offerAmountsRenamedVersion,
+// which stored the amounts and exchange rate as JSON numbers.
+type legacyOffer struct {
+	Version       semver.Version     `json:"version"`
+	ID            types.Hash         `json:"offerID" validate:"required"`
+	Provides      coins.ProvidesCoin `json:"provides" validate:"required"`
+	MinimumAmount json.Number        `json:"minimumAmount" validate:"required"`
+	MaximumAmount json.Number        `json:"maximumAmount" validate:"required"`
+	ExchangeRate  json.Number        `json:"exchangeRate" validate:"required"`
+	EthAsset      types.EthAsset     `json:"ethAsset"`
+	Nonce         uint64             `json:"nonce" validate:"required"`
+}
+
+// offerAmountsRenamedVersion is the offer version that renamed minimumAmount
+// and maximumAmount to minAmount and maxAmount, and stores them and the
+// exchange rate as decimal strings.
+var offerAmountsRenamedVersion = semver.MustParse("1.0.0")
+
+// unmarshalStoredOffer decodes an offer of the offer table. Offers older than
+// offerAmountsRenamedVersion are upgraded to the current offer version, which
+// changes their ID.
+func unmarshalStoredOffer(value []byte) (*types.Offer, error) {
+	ov := struct {
+		Version *semver.Version `json:"version"`
+	}{}
+	if err := json.Unmarshal(value, &ov); err != nil {
+		return nil, err
+	}
+
+	if ov.Version == nil || !ov.Version.LessThan(offerAmountsRenamedVersion) {
+		return types.UnmarshalOffer(value)
+	}
+
+	var lo legacyOffer
+	if err := vjson.UnmarshalStruct(value, &lo); err != nil {
+		return nil, err
+	}
+
+	minAmount, err := legacyOfferDecimal("minimumAmount", lo.MinimumAmount)
+	if err != nil {
+		return nil, err
+	}
+	maxAmount, err := legacyOfferDecimal("maximumAmount", lo.MaximumAmount)
+	if err != nil {
+		return nil, err
+	}
+	exRate, err := legacyOfferDecimal("exchangeRate", lo.ExchangeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.UpgradeOffer(&types.Offer{
+		Version:      lo.Version,
+		ID:           lo.ID,
+		Provides:     lo.Provides,
+		MinAmount:    minAmount,
+		MaxAmount:    maxAmount,
+		ExchangeRate: coins.ToExchangeRate(exRate),
+		EthAsset:     lo.EthAsset,
+		Nonce:        lo.Nonce,
+	})
+}
+
+// legacyOfferDecimal converts a JSON number of a legacy offer to a reduced
+// decimal, the form that offer IDs are computed with.
+func legacyOfferDecimal(field string, num json.Number) (*apd.Decimal, error) {
+	d, _, err := apd.NewFromString(num.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q: %w", field, err)
+	}
+
+	_, _ = d.Reduce(d)
+	return d, nil
+}
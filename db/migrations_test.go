@@ -0,0 +1,211 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/Masterminds/semver/v3"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+)
+
+// newLegacyDatabase returns the config of a closed database without a schema
+// version, containing the passed offer, swap info and raw entries, which are
+// keyed by their full key including the table prefix.
+func newLegacyDatabase(t *testing.T, offer *types.Offer, info *swap.Info, rawEntries map[string]string) *chaindb.Config {
+	cfg := &chaindb.Config{DataDir: path.Join(t.TempDir(), "swap-db")}
+	db, err := NewDatabase(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, db.PutOffer(offer))
+	require.NoError(t, db.PutSwap(info))
+	for key, value := range rawEntries {
+		require.NoError(t, db.db.Put([]byte(key), []byte(value)))
+	}
+	require.NoError(t, db.metaTable.Del([]byte(schemaVersionKey)))
+	require.NoError(t, db.Close())
+
+	return cfg
+}
+
+func TestNewDatabase_migrateLegacy(t *testing.T) {
+	offer := newTestOffer()
+	info := newTestSwapInfo(offer, time.Now())
+	info.Version = semver.MustParse("0.2.0")
+	cfg := newLegacyDatabase(t, offer, info, nil)
+
+	db, err := NewDatabase(cfg)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	version, err := db.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, CurSchemaVersion, version)
+
+	resInfo, err := db.GetSwap(offer.ID)
+	require.NoError(t, err)
+	require.True(t, resInfo.Version.Equal(swap.CurInfoVersion))
+
+	// the state before the migration was backed up next to the database
+	backups, err := filepath.Glob(path.Join(path.Dir(cfg.DataDir), "swap-db-v0-*.backup.json"))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	archive, err := ReadArchive(backups[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), archive.SchemaVersion)
+	require.Equal(t, 1, archive.NumOffers())
+	require.Equal(t, 1, archive.NumSwaps())
+}
+
+func TestNewDatabase_migrateLegacyLayouts(t *testing.T) {
+	offer := newTestOffer()
+	legacyOfferID := types.Hash{0x1}
+	legacySwapID := types.Hash{0x2}
+
+	// offers before version 1.0.0 stored the amounts as JSON numbers
+	legacyOffer := `{
+		"version": "0.1.0",
+		"offerID": "0x0100000000000000000000000000000000000000000000000000000000000000",
+		"provides": "XMR",
+		"minimumAmount": 0.20,
+		"maximumAmount": 1.5,
+		"exchangeRate": 0.0625,
+		"ethAsset": "ETH",
+		"nonce": 1234
+	}`
+
+	// swap infos before version 0.3.0 used timeout0 and timeout1
+	legacySwap := `{
+		"version": "0.2.0",
+		"peerID": "12D3KooWQQRJuKTZ35eiHGNPGDpQqjpJSdaxEMJRxi6NWFrrvQVi",
+		"offerID": "0x0200000000000000000000000000000000000000000000000000000000000000",
+		"provides": "XMR",
+		"providedAmount": "1.25",
+		"expectedAmount": "1",
+		"exchangeRate": "0.8",
+		"ethAsset": "ETH",
+		"moneroStartHeight": 200,
+		"status": "XMRLocked",
+		"lastStatusUpdateTime": "2023-02-20T17:29:43.471020297-05:00",
+		"startTime": "2023-02-20T17:29:43.471020297-05:00",
+		"timeout0": "2023-02-20T18:29:43-05:00",
+		"timeout1": "2023-02-20T19:29:43-05:00"
+	}`
+
+	// contract swap infos before the contract was renamed to SwapCreator used
+	// contractAddress and the old field names of the Swap struct
+	legacyEthInfo := `{
+		"startNumber": 100,
+		"swapID": "0x0300000000000000000000000000000000000000000000000000000000000000",
+		"swap": {
+			"owner": "0x00000000000000000000000000000000000000a1",
+			"claimer": "0x00000000000000000000000000000000000000b2",
+			"pubKeyClaim": "0x0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c",
+			"pubKeyRefund": "0x0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d0d",
+			"timeout0": 1000,
+			"timeout1": 2000,
+			"asset": "0x0000000000000000000000000000000000000000",
+			"value": 5000000000000000000,
+			"nonce": 7
+		},
+		"contractAddress": "0x00000000000000000000000000000000000000c3"
+	}`
+
+	rawEntries := map[string]string{
+		offerPrefix + string(legacyOfferID[:]):                            legacyOffer,
+		swapPrefix + string(legacySwapID[:]):                              legacySwap,
+		recoveryPrefix + string(legacySwapID[:]) + contractSwapInfoPrefix: legacyEthInfo,
+	}
+	cfg := newLegacyDatabase(t, offer, newTestSwapInfo(offer, time.Now()), rawEntries)
+
+	db, err := NewDatabase(cfg)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	// the legacy offer was upgraded and moved to its new ID
+	_, err = db.GetOffer(legacyOfferID)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+	offers, err := db.GetAllOffers()
+	require.NoError(t, err)
+	require.Len(t, offers, 2)
+	for _, o := range offers {
+		require.True(t, o.Version.Equal(types.CurOfferVersion))
+		if o.ID == offer.ID {
+			continue
+		}
+		require.NotEqual(t, legacyOfferID, o.ID)
+		require.Equal(t, "0.2", o.MinAmount.String())
+		require.Equal(t, "1.5", o.MaxAmount.String())
+		require.Equal(t, "0.0625", o.ExchangeRate.String())
+		require.Equal(t, types.EthAssetETH, o.EthAsset)
+		require.Equal(t, uint64(1234), o.Nonce)
+
+		resOffer, err := db.GetOffer(o.ID) //nolint:govet
+		require.NoError(t, err)
+		require.Equal(t, o, resOffer)
+	}
+
+	// the legacy swap kept its ID and got the renamed timeouts
+	info, err := db.GetSwap(legacySwapID)
+	require.NoError(t, err)
+	require.True(t, info.Version.Equal(swap.CurInfoVersion))
+	require.Equal(t, "2023-02-20T18:29:43-05:00", info.Timeout1.Format(time.RFC3339))
+	require.Equal(t, "2023-02-20T19:29:43-05:00", info.Timeout2.Format(time.RFC3339))
+
+	// the contract swap info was rewritten with the current layout
+	ethInfo, err := db.RecoveryDB().GetContractSwapInfo(legacySwapID)
+	require.NoError(t, err)
+	require.Equal(t, ethcommon.HexToAddress("0xc3"), ethInfo.SwapCreatorAddr)
+	require.Equal(t, ethcommon.HexToAddress("0xa1"), ethInfo.Swap.Owner)
+	require.Equal(t, byte(0xc), ethInfo.Swap.ClaimCommitment[31])
+	require.Equal(t, byte(0xd), ethInfo.Swap.RefundCommitment[31])
+	require.Equal(t, int64(1000), ethInfo.Swap.Timeout1.Int64())
+	require.Equal(t, int64(2000), ethInfo.Swap.Timeout2.Int64())
+	require.Equal(t, "5000000000000000000", ethInfo.Swap.Value.String())
+
+	value, err := db.db.Get([]byte(recoveryPrefix + string(legacySwapID[:]) + contractSwapInfoPrefix))
+	require.NoError(t, err)
+	require.Contains(t, string(value), `"swapCreatorAddr"`)
+	require.NotContains(t, string(value), `"contractAddress"`)
+}
+
+func TestNewDatabase_migrationFails(t *testing.T) {
+	offer := newTestOffer()
+	badOfferID := types.Hash{0x1, 0x2, 0x3}
+	rawEntries := map[string]string{offerPrefix + string(badOfferID[:]): `{"key":"value"}`}
+	cfg := newLegacyDatabase(t, offer, newTestSwapInfo(offer, time.Now()), rawEntries)
+
+	_, err := NewDatabase(cfg)
+	require.ErrorContains(t, err, "database migration to schema version 1 failed")
+	require.ErrorContains(t, err, "invalid offer with ID=0x010203")
+
+	// the invalid offer was not purged and the schema version is unchanged
+	bdb, err := chaindb.NewBadgerDB(cfg)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, bdb.Close()) }()
+
+	has, err := bdb.Has(append([]byte(offerPrefix), badOfferID[:]...))
+	require.NoError(t, err)
+	require.True(t, has)
+
+	has, err = bdb.Has([]byte(metaPrefix + schemaVersionKey))
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
+func TestNewDatabase_newSchemaVersion(t *testing.T) {
+	db := newTestDatabase(t)
+	version, err := db.SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, CurSchemaVersion, version)
+}
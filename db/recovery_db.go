@@ -4,6 +4,8 @@
 package db
 
 import (
+	"crypto/cipher"
+
 	"github.com/athanorlabs/atomic-swap/common/types"
 	"github.com/athanorlabs/atomic-swap/common/vjson"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
@@ -17,20 +19,42 @@ const (
 	swapPrivateKeyPrefix             = "privkey"
 	counterpartySwapPrivateKeyPrefix = "cspriv"
 	relayerInfoPrefix                = "relayer"
+	xmrPriorityPrefix                = "xmrprio"
 	counterpartySwapKeysPrefix       = "cskeys"
 	newSwapTxHashPrefix              = "newswap"
 )
 
+// recoveryKeySuffixes are the suffixes of all per-swap keys in the recovery table
+var recoveryKeySuffixes = []string{
+	relayerInfoPrefix,
+	xmrPriorityPrefix,
+	contractSwapInfoPrefix,
+	swapPrivateKeyPrefix,
+	counterpartySwapPrivateKeyPrefix,
+	counterpartySwapKeysPrefix,
+	newSwapTxHashPrefix,
+}
+
 // RecoveryDB contains information about ongoing swaps required for recovery
-// in case of shutdown.
+// in case of shutdown. The values can optionally be encrypted with a key
+// derived from a passphrase, in which case the RecoveryDB has to be unlocked
+// before use.
 type RecoveryDB struct {
-	db chaindb.Database
+	db        chaindb.Database
+	encrypted bool        // whether the values in the table are encrypted
+	aead      cipher.AEAD // nil until an encrypted table is unlocked
 }
 
-func newRecoveryDB(db chaindb.Database) *RecoveryDB {
-	return &RecoveryDB{
-		db: db,
+func newRecoveryDB(db chaindb.Database) (*RecoveryDB, error) {
+	encrypted, err := db.Has([]byte(encryptionSaltKey))
+	if err != nil {
+		return nil, err
 	}
+
+	return &RecoveryDB{
+		db:        db,
+		encrypted: encrypted,
+	}, nil
 }
 
 func getRecoveryDBKey(id types.Hash, additional string) []byte {
@@ -49,18 +73,13 @@ func (db *RecoveryDB) PutSwapRelayerInfo(id types.Hash, info *types.OfferExtra)
 	}
 
 	key := getRecoveryDBKey(id, relayerInfoPrefix)
-	err = db.db.Put(key, val)
-	if err != nil {
-		return err
-	}
-
-	return db.db.Flush()
+	return db.put(key, val)
 }
 
 // GetSwapRelayerInfo ...
 func (db *RecoveryDB) GetSwapRelayerInfo(id types.Hash) (*types.OfferExtra, error) {
 	key := getRecoveryDBKey(id, relayerInfoPrefix)
-	value, err := db.db.Get(key)
+	value, err := db.get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +93,35 @@ func (db *RecoveryDB) GetSwapRelayerInfo(id types.Hash) (*types.OfferExtra, erro
 	return &s, nil
 }
 
+// PutSwapXMRPriority stores the fee priority of the Monero transactions sent
+// for the given swap ID.
+func (db *RecoveryDB) PutSwapXMRPriority(id types.Hash, priority types.MoneroTxPriority) error {
+	val, err := priority.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	key := getRecoveryDBKey(id, xmrPriorityPrefix)
+	return db.put(key, val)
+}
+
+// GetSwapXMRPriority returns the fee priority of the Monero transactions sent
+// for the given swap ID.
+func (db *RecoveryDB) GetSwapXMRPriority(id types.Hash) (types.MoneroTxPriority, error) {
+	key := getRecoveryDBKey(id, xmrPriorityPrefix)
+	value, err := db.get(key)
+	if err != nil {
+		return types.PriorityDefault, err
+	}
+
+	var priority types.MoneroTxPriority
+	if err = priority.UnmarshalText(value); err != nil {
+		return types.PriorityDefault, err
+	}
+
+	return priority, nil
+}
+
 // PutContractSwapInfo stores the given contract swap ID (which is not the same as the daemon
 // swap ID, but is instead a hash of the `SwapCreatorSwap` structure)
 // and contract swap structure for the given swap ID.
@@ -84,19 +132,14 @@ func (db *RecoveryDB) PutContractSwapInfo(id types.Hash, info *EthereumSwapInfo)
 	}
 
 	key := getRecoveryDBKey(id, contractSwapInfoPrefix)
-	err = db.db.Put(key, val)
-	if err != nil {
-		return err
-	}
-
-	return db.db.Flush()
+	return db.put(key, val)
 }
 
 // GetContractSwapInfo returns the contract swap ID (a hash of the `SwapCreatorSwap` structure) and
 // and contract swap structure for the given swap ID.
 func (db *RecoveryDB) GetContractSwapInfo(id types.Hash) (*EthereumSwapInfo, error) {
 	key := getRecoveryDBKey(id, contractSwapInfoPrefix)
-	value, err := db.db.Get(key)
+	value, err := db.get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -118,18 +161,13 @@ func (db *RecoveryDB) PutSwapPrivateKey(id types.Hash, sk *mcrypto.PrivateSpendK
 	}
 
 	key := getRecoveryDBKey(id, swapPrivateKeyPrefix)
-	err = db.db.Put(key, val)
-	if err != nil {
-		return err
-	}
-
-	return db.db.Flush()
+	return db.put(key, val)
 }
 
 // GetSwapPrivateKey returns the swap private key share, if it exists.
 func (db *RecoveryDB) GetSwapPrivateKey(id types.Hash) (*mcrypto.PrivateSpendKey, error) {
 	key := getRecoveryDBKey(id, swapPrivateKeyPrefix)
-	value, err := db.db.Get(key[:])
+	value, err := db.get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -151,18 +189,13 @@ func (db *RecoveryDB) PutCounterpartySwapPrivateKey(id types.Hash, kp *mcrypto.P
 	}
 
 	key := getRecoveryDBKey(id, counterpartySwapPrivateKeyPrefix)
-	err = db.db.Put(key, val)
-	if err != nil {
-		return err
-	}
-
-	return db.db.Flush()
+	return db.put(key, val)
 }
 
 // GetCounterpartySwapPrivateKey returns the counterparty's swap private key, if it exists.
 func (db *RecoveryDB) GetCounterpartySwapPrivateKey(id types.Hash) (*mcrypto.PrivateSpendKey, error) {
 	key := getRecoveryDBKey(id, counterpartySwapPrivateKeyPrefix)
-	value, err := db.db.Get(key[:])
+	value, err := db.get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -193,19 +226,13 @@ func (db *RecoveryDB) PutCounterpartySwapKeys(id types.Hash, sk *mcrypto.PublicK
 
 	key := getRecoveryDBKey(id, counterpartySwapKeysPrefix)
 	log.Debugf("PutCounterpartySwapKeys %s", key)
-	err = db.db.Put(key, val)
-	if err != nil {
-		return err
-	}
-
-	log.Debugf("flushing db")
-	return db.db.Flush()
+	return db.put(key, val)
 }
 
 // GetCounterpartySwapKeys is called during recovery to retrieve the counterparty's swap keys.
 func (db *RecoveryDB) GetCounterpartySwapKeys(id types.Hash) (*mcrypto.PublicKey, *mcrypto.PrivateViewKey, error) {
 	key := getRecoveryDBKey(id, counterpartySwapKeysPrefix)
-	value, err := db.db.Get(key)
+	value, err := db.get(key)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -222,18 +249,13 @@ func (db *RecoveryDB) GetCounterpartySwapKeys(id types.Hash) (*mcrypto.PublicKey
 // PutNewSwapTxHash stores the newSwap transaction hash for the given swap ID.
 func (db *RecoveryDB) PutNewSwapTxHash(id types.Hash, txHash types.Hash) error {
 	key := getRecoveryDBKey(id, newSwapTxHashPrefix)
-	err := db.db.Put(key, txHash[:])
-	if err != nil {
-		return err
-	}
-
-	return db.db.Flush()
+	return db.put(key, txHash[:])
 }
 
 // GetNewSwapTxHash returns the newSwap transaction hash for the given swap ID.
 func (db *RecoveryDB) GetNewSwapTxHash(id types.Hash) (types.Hash, error) {
 	key := getRecoveryDBKey(id, newSwapTxHashPrefix)
-	value, err := db.db.Get(key)
+	value, err := db.get(key)
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -252,17 +274,8 @@ func (db *RecoveryDB) DeleteSwap(id types.Hash) error {
 
 // deleteSwap is currently unused.
 func (db *RecoveryDB) deleteSwap(id types.Hash) error {
-	keys := [][]byte{
-		getRecoveryDBKey(id, relayerInfoPrefix),
-		getRecoveryDBKey(id, contractSwapInfoPrefix),
-		getRecoveryDBKey(id, swapPrivateKeyPrefix),
-		getRecoveryDBKey(id, counterpartySwapPrivateKeyPrefix),
-		getRecoveryDBKey(id, counterpartySwapKeysPrefix),
-		getRecoveryDBKey(id, newSwapTxHashPrefix),
-	}
-
-	for _, key := range keys {
-		err := db.db.Del(key)
+	for _, suffix := range recoveryKeySuffixes {
+		err := db.db.Del(getRecoveryDBKey(id, suffix))
 		if err != nil {
 			return err
 		}
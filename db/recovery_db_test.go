@@ -93,6 +93,21 @@ func TestRecoveryDB_SwapRelayerInfo(t *testing.T) {
 	require.Equal(t, extra, res)
 }
 
+func TestRecoveryDB_SwapXMRPriority(t *testing.T) {
+	rdb := newTestRecoveryDB(t)
+	offerID := types.Hash{5, 6, 7, 8}
+
+	_, err := rdb.GetSwapXMRPriority(offerID)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	err = rdb.PutSwapXMRPriority(offerID, types.PriorityElevated)
+	require.NoError(t, err)
+
+	res, err := rdb.GetSwapXMRPriority(offerID)
+	require.NoError(t, err)
+	require.Equal(t, types.PriorityElevated, res)
+}
+
 func TestRecoveryDB_SwapPrivateKey(t *testing.T) {
 	rdb := newTestRecoveryDB(t)
 	offerID := types.Hash{5, 6, 7, 8}
@@ -169,6 +184,8 @@ func TestRecoveryDB_DeleteSwap(t *testing.T) {
 	require.NoError(t, err)
 	err = rdb.PutSwapRelayerInfo(offerID, extra)
 	require.NoError(t, err)
+	err = rdb.PutSwapXMRPriority(offerID, types.PriorityHighest)
+	require.NoError(t, err)
 	err = rdb.PutSwapPrivateKey(offerID, kp.SpendKey())
 	require.NoError(t, err)
 	err = rdb.PutCounterpartySwapPrivateKey(offerID, kp.SpendKey())
@@ -182,6 +199,8 @@ func TestRecoveryDB_DeleteSwap(t *testing.T) {
 	require.EqualError(t, chaindb.ErrKeyNotFound, err.Error())
 	_, err = rdb.GetSwapRelayerInfo(offerID)
 	require.EqualError(t, chaindb.ErrKeyNotFound, err.Error())
+	_, err = rdb.GetSwapXMRPriority(offerID)
+	require.EqualError(t, chaindb.ErrKeyNotFound, err.Error())
 	_, err = rdb.GetSwapPrivateKey(offerID)
 	require.EqualError(t, chaindb.ErrKeyNotFound, err.Error())
 	_, err = rdb.GetCounterpartySwapPrivateKey(offerID)
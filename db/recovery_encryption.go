@@ -0,0 +1,223 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Keys of the encryption metadata in the recovery table. They are shorter
+	// than a swap ID, so they can't collide with the per-swap keys.
+	encryptionSaltKey  = "encsalt"
+	encryptionCheckKey = "enccheck"
+
+	encryptionSaltLen = 32
+	encryptionKeyLen  = 32 // AES-256
+)
+
+var (
+	// scrypt cost parameters for deriving the recovery table key from the
+	// passphrase, lowered by unit tests
+	scryptN = 1 << 16
+	scryptR = 8
+	scryptP = 1
+
+	// encryptionCheckValue is encrypted with the derived key and stored, so a
+	// wrong passphrase is detected when unlocking instead of on first use.
+	encryptionCheckValue = []byte("atomic-swap recovery db")
+
+	errRecoveryDBLocked     = errors.New("recovery database is encrypted and has not been unlocked")
+	errWrongDBPassphrase    = errors.New("wrong recovery database passphrase")
+	errEmptyDBPassphrase    = errors.New("recovery database passphrase cannot be empty")
+	errInvalidEncryptedData = errors.New("invalid encrypted recovery database entry")
+)
+
+// IsEncrypted returns true if the recovery table is encrypted with a passphrase.
+func (db *RecoveryDB) IsEncrypted() bool {
+	return db.encrypted
+}
+
+// IsLocked returns true if the recovery table is encrypted and the passphrase
+// has not been provided yet.
+func (db *RecoveryDB) IsLocked() bool {
+	return db.encrypted && db.aead == nil
+}
+
+// unlock derives the encryption key of an encrypted recovery table from the
+// passphrase, enabling reads and writes.
+func (db *RecoveryDB) unlock(passphrase string) error {
+	if !db.encrypted {
+		panic("unlock called on an unencrypted recovery database")
+	}
+
+	salt, err := db.db.Get([]byte(encryptionSaltKey))
+	if err != nil {
+		return fmt.Errorf("failed to read recovery database salt: %w", err)
+	}
+
+	aead, err := newRecoveryAEAD(passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	sealedCheck, err := db.db.Get([]byte(encryptionCheckKey))
+	if err != nil {
+		return fmt.Errorf("failed to read recovery database check value: %w", err)
+	}
+
+	check, err := openValue(aead, []byte(encryptionCheckKey), sealedCheck)
+	if err != nil || !bytes.Equal(check, encryptionCheckValue) {
+		return errWrongDBPassphrase
+	}
+
+	db.aead = aead
+	return nil
+}
+
+// encrypt encrypts the values of the passed keys, which must be all the
+// existing keys of the recovery table, with a key derived from the passphrase.
+// All entries and the encryption metadata are written in a single batch.
+func (db *RecoveryDB) encrypt(passphrase string, keys [][]byte) error {
+	if db.encrypted {
+		panic("recovery database is already encrypted")
+	}
+
+	salt := make([]byte, encryptionSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	aead, err := newRecoveryAEAD(passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	batch := db.db.NewBatch()
+	for _, key := range keys {
+		value, err := db.db.Get(key)
+		if err != nil {
+			return fmt.Errorf("failed to read recovery database entry: %w", err)
+		}
+
+		sealed, err := sealValue(aead, key, value)
+		if err != nil {
+			return err
+		}
+
+		if err = batch.Put(key, sealed); err != nil {
+			return err
+		}
+	}
+
+	sealedCheck, err := sealValue(aead, []byte(encryptionCheckKey), encryptionCheckValue)
+	if err != nil {
+		return err
+	}
+	if err = batch.Put([]byte(encryptionCheckKey), sealedCheck); err != nil {
+		return err
+	}
+	if err = batch.Put([]byte(encryptionSaltKey), salt); err != nil {
+		return err
+	}
+
+	if err = batch.Flush(); err != nil {
+		return err
+	}
+
+	db.encrypted = true
+	db.aead = aead
+	return nil
+}
+
+// put stores the value, encrypting it if the recovery table is encrypted.
+func (db *RecoveryDB) put(key []byte, value []byte) error {
+	if db.IsLocked() {
+		return errRecoveryDBLocked
+	}
+
+	if db.encrypted {
+		var err error
+		value, err = sealValue(db.aead, key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := db.db.Put(key, value); err != nil {
+		return err
+	}
+
+	return db.db.Flush()
+}
+
+// get retrieves the value, decrypting it if the recovery table is encrypted.
+func (db *RecoveryDB) get(key []byte) ([]byte, error) {
+	if db.IsLocked() {
+		return nil, errRecoveryDBLocked
+	}
+
+	value, err := db.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if db.encrypted {
+		return openValue(db.aead, key, value)
+	}
+
+	return value, nil
+}
+
+func newRecoveryAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errEmptyDBPassphrase
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, encryptionKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// sealValue encrypts the value, returning the random nonce followed by the
+// ciphertext. The database key is authenticated as additional data, so an
+// encrypted value can't be moved to a different key or swap.
+func sealValue(aead cipher.AEAD, key []byte, value []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, value, key), nil
+}
+
+// openValue decrypts a value encrypted by sealValue.
+func openValue(aead cipher.AEAD, key []byte, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errInvalidEncryptedData
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, ciphertext, key)
+	if err != nil {
+		return nil, errInvalidEncryptedData
+	}
+
+	return value, nil
+}
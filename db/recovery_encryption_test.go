@@ -0,0 +1,156 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/common/vjson"
+	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+)
+
+func init() {
+	// keep key derivation fast in unit tests
+	scryptN = 1 << 10
+}
+
+func TestDatabase_UnlockRecoveryDB(t *testing.T) {
+	cfg := &chaindb.Config{DataDir: t.TempDir()}
+	db, err := NewDatabase(cfg)
+	require.NoError(t, err)
+	require.False(t, db.RecoveryDB().IsEncrypted())
+
+	one := coins.StrToDecimal("1")
+	ethAsset := types.EthAsset(ethcommon.HexToAddress("0xa1E32d14AC4B6d8c1791CAe8E9baD46a1E15B7a8"))
+
+	// offerA was taken and has a swap, offerB only has relayer info
+	offerA := types.NewOffer(coins.ProvidesXMR, one, one, coins.ToExchangeRate(one), ethAsset)
+	offerB := types.NewOffer(coins.ProvidesXMR, one, one, coins.ToExchangeRate(one), ethAsset)
+	require.NoError(t, db.PutOffer(offerB))
+
+	timeout := time.Now().Add(30 * time.Minute)
+	err = db.PutSwap(&swap.Info{
+		Version:              swap.CurInfoVersion,
+		PeerID:               testPeerID,
+		OfferID:              offerA.ID,
+		Provides:             offerA.Provides,
+		ProvidedAmount:       offerA.MinAmount,
+		ExpectedAmount:       offerA.MinAmount,
+		ExchangeRate:         offerA.ExchangeRate,
+		EthAsset:             offerA.EthAsset,
+		Status:               types.XMRLocked,
+		LastStatusUpdateTime: time.Now(),
+		MoneroStartHeight:    12345,
+		StartTime:            time.Now(),
+		Timeout1:             &timeout,
+		Timeout2:             &timeout,
+	})
+	require.NoError(t, err)
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	rdb := db.RecoveryDB()
+	require.NoError(t, rdb.PutSwapPrivateKey(offerA.ID, kp.SpendKey()))
+	require.NoError(t, rdb.PutNewSwapTxHash(offerA.ID, types.Hash{0x1}))
+	extra := types.NewOfferExtra(true)
+	require.NoError(t, rdb.PutSwapRelayerInfo(offerB.ID, extra))
+
+	// an orphaned entry of a swap that is in neither the swap nor offer table
+	orphanID := types.Hash{0xd}
+	require.NoError(t, rdb.PutCounterpartySwapPrivateKey(orphanID, kp.SpendKey()))
+
+	// encrypt the existing entries
+	require.NoError(t, db.UnlockRecoveryDB("secret"))
+	require.True(t, rdb.IsEncrypted())
+	require.False(t, rdb.IsLocked())
+
+	// the raw value in the table is no longer the plaintext JSON
+	plainKey, err := vjson.MarshalStruct(kp.SpendKey())
+	require.NoError(t, err)
+	rawValue, err := rdb.db.Get(getRecoveryDBKey(offerA.ID, swapPrivateKeyPrefix))
+	require.NoError(t, err)
+	require.NotContains(t, string(rawValue), string(plainKey))
+	rawValue, err = rdb.db.Get(getRecoveryDBKey(orphanID, counterpartySwapPrivateKeyPrefix))
+	require.NoError(t, err)
+	require.NotContains(t, string(rawValue), string(plainKey))
+
+	sk, err := rdb.GetSwapPrivateKey(offerA.ID)
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().String(), sk.String())
+
+	require.NoError(t, db.Close())
+
+	// after reopening, the recovery DB is locked until the passphrase is provided
+	db, err = NewDatabase(cfg)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+	rdb = db.RecoveryDB()
+	require.True(t, rdb.IsLocked())
+
+	_, err = rdb.GetSwapPrivateKey(offerA.ID)
+	require.ErrorIs(t, err, errRecoveryDBLocked)
+	err = rdb.PutSwapPrivateKey(offerA.ID, kp.SpendKey())
+	require.ErrorIs(t, err, errRecoveryDBLocked)
+
+	require.ErrorIs(t, db.UnlockRecoveryDB("wrong"), errWrongDBPassphrase)
+	require.True(t, rdb.IsLocked())
+
+	require.NoError(t, db.UnlockRecoveryDB("secret"))
+	sk, err = rdb.GetSwapPrivateKey(offerA.ID)
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().String(), sk.String())
+
+	txHash, err := rdb.GetNewSwapTxHash(offerA.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.Hash{0x1}, txHash)
+
+	resExtra, err := rdb.GetSwapRelayerInfo(offerB.ID)
+	require.NoError(t, err)
+	require.Equal(t, extra, resExtra)
+
+	sk, err = rdb.GetCounterpartySwapPrivateKey(orphanID)
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().String(), sk.String())
+
+	// new entries are encrypted too
+	offerC := types.Hash{0xc}
+	require.NoError(t, rdb.PutCounterpartySwapPrivateKey(offerC, kp.SpendKey()))
+	sk, err = rdb.GetCounterpartySwapPrivateKey(offerC)
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().String(), sk.String())
+}
+
+func TestRecoveryDB_encryptedValueBoundToKey(t *testing.T) {
+	rdb := newTestRecoveryDB(t)
+	require.NoError(t, rdb.encrypt("secret", nil))
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	idA := types.Hash{0xa}
+	idB := types.Hash{0xb}
+	require.NoError(t, rdb.PutSwapPrivateKey(idA, kp.SpendKey()))
+
+	// copying the encrypted value of one swap to another must fail to decrypt
+	rawValue, err := rdb.db.Get(getRecoveryDBKey(idA, swapPrivateKeyPrefix))
+	require.NoError(t, err)
+	require.NoError(t, rdb.db.Put(getRecoveryDBKey(idB, swapPrivateKeyPrefix), rawValue))
+
+	_, err = rdb.GetSwapPrivateKey(idB)
+	require.ErrorIs(t, err, errInvalidEncryptedData)
+}
+
+func TestDatabase_UnlockRecoveryDB_emptyPassphrase(t *testing.T) {
+	db, err := NewDatabase(&chaindb.Config{DataDir: t.TempDir(), InMemory: true})
+	require.NoError(t, err)
+	require.ErrorIs(t, db.UnlockRecoveryDB(""), errEmptyDBPassphrase)
+	require.False(t, db.RecoveryDB().IsEncrypted())
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/dgraph-io/badger/v4/pb"
+	_ "modernc.org/sqlite" // registers the pure Go sqlite database/sql driver
+
+	"github.com/athanorlabs/atomic-swap/common"
+)
+
+// Backend is the storage engine used by the database.
+type Backend string
+
+// Supported database backends
+const (
+	// BackendBadger stores the database in a BadgerDB directory
+	BackendBadger Backend = "badger"
+	// BackendSQLite stores the database in a single SQLite file, which can be
+	// queried with SQL for reporting.
+	BackendSQLite Backend = "sqlite"
+)
+
+// NewBackend converts a string to a database Backend with validation.
+func NewBackend(name string) (Backend, error) {
+	switch b := Backend(name); b {
+	case BackendBadger, BackendSQLite:
+		return b, nil
+	default:
+		return "", fmt.Errorf("unknown database backend %q", name)
+	}
+}
+
+// sqliteSchema creates the key-value table holding all database entries, and
+// read-only views over the offer and swap tables for ad-hoc queries.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS kv (
+	key   BLOB PRIMARY KEY,
+	value BLOB NOT NULL
+) WITHOUT ROWID;
+
+CREATE VIEW IF NOT EXISTS offers AS
+SELECT
+	json_extract(CAST(value AS TEXT), '$.offerID')      AS offer_id,
+	json_extract(CAST(value AS TEXT), '$.provides')     AS provides,
+	json_extract(CAST(value AS TEXT), '$.minAmount')    AS min_amount,
+	json_extract(CAST(value AS TEXT), '$.maxAmount')    AS max_amount,
+	json_extract(CAST(value AS TEXT), '$.exchangeRate') AS exchange_rate,
+	json_extract(CAST(value AS TEXT), '$.ethAsset')     AS eth_asset,
+	CAST(value AS TEXT)                                 AS json
+FROM kv WHERE substr(key, 1, 5) = CAST('offer' AS BLOB) AND length(key) = 37;
+
+CREATE VIEW IF NOT EXISTS swaps AS
+SELECT
+	json_extract(CAST(value AS TEXT), '$.offerID')              AS offer_id,
+	json_extract(CAST(value AS TEXT), '$.peerID')               AS peer_id,
+	json_extract(CAST(value AS TEXT), '$.status')               AS status,
+	json_extract(CAST(value AS TEXT), '$.provides')             AS provides,
+	json_extract(CAST(value AS TEXT), '$.providedAmount')       AS provided_amount,
+	json_extract(CAST(value AS TEXT), '$.expectedAmount')       AS expected_amount,
+	json_extract(CAST(value AS TEXT), '$.exchangeRate')         AS exchange_rate,
+	json_extract(CAST(value AS TEXT), '$.ethAsset')             AS eth_asset,
+	json_extract(CAST(value AS TEXT), '$.relayerFee')           AS relayer_fee,
+	json_extract(CAST(value AS TEXT), '$.startTime')            AS start_time,
+	json_extract(CAST(value AS TEXT), '$.endTime')              AS end_time,
+	json_extract(CAST(value AS TEXT), '$.lastStatusUpdateTime') AS last_status_update_time,
+	CAST(value AS TEXT)                                         AS json
+FROM kv WHERE substr(key, 1, 4) = CAST('swap' AS BLOB) AND length(key) = 36;
+`
+
+// sqliteDB implements chaindb.Database on top of a SQLite file, so the
+// Database tables work the same as with BadgerDB.
+type sqliteDB struct {
+	db        *sql.DB
+	path      string
+	closeOnce sync.Once
+	closeErr  error
+}
+
+var _ chaindb.Database = (*sqliteDB)(nil)
+
+// NewSQLiteDatabase returns a new *Database stored in the SQLite file at the
+// given path, which is created if it does not exist.
+func NewSQLiteDatabase(dbPath string) (*Database, error) {
+	db, err := openSQLiteDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDatabase(db, filepath.Dir(filepath.Clean(dbPath)))
+}
+
+func openSQLiteDB(dbPath string) (*sqliteDB, error) {
+	if err := common.MakeDir(filepath.Dir(dbPath)); err != nil {
+		return nil, err
+	}
+
+	// WAL mode allows reporting queries from other processes while swapd is
+	// writing to the database.
+	dsn := fmt.Sprintf(
+		"file:%s?_pragma=journal_mode(WAL)&_pragma=synchronous(FULL)&_pragma=busy_timeout(5000)",
+		dbPath,
+	)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single connection serializes all access, iterators read their entries
+	// before returning, so they never hold the connection.
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize SQLite database %s: %w", dbPath, err)
+	}
+
+	return &sqliteDB{
+		db:   db,
+		path: dbPath,
+	}, nil
+}
+
+// Get returns the value of the key, or chaindb.ErrKeyNotFound.
+func (db *sqliteDB) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := db.db.QueryRow(`SELECT value FROM kv WHERE key = ?`, key).Scan(&value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, chaindb.ErrKeyNotFound
+		}
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Has returns whether the key exists.
+func (db *sqliteDB) Has(key []byte) (bool, error) {
+	var exists bool
+	err := db.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM kv WHERE key = ?)`, key).Scan(&exists)
+	return exists, err
+}
+
+// Put sets the value of the key.
+func (db *sqliteDB) Put(key []byte, value []byte) error {
+	_, err := db.db.Exec(`INSERT OR REPLACE INTO kv (key, value) VALUES (?, ?)`, key, nonNil(value))
+	return err
+}
+
+// Del removes the key, if it exists.
+func (db *sqliteDB) Del(key []byte) error {
+	_, err := db.db.Exec(`DELETE FROM kv WHERE key = ?`, key)
+	return err
+}
+
+// Flush is a no-op, as SQLite commits every write to disk.
+func (db *sqliteDB) Flush() error {
+	return nil
+}
+
+// Close closes the database. The Database tables share the underlying
+// database, so it's safe to call it multiple times.
+func (db *sqliteDB) Close() error {
+	db.closeOnce.Do(func() {
+		db.closeErr = db.db.Close()
+	})
+	return db.closeErr
+}
+
+// NewBatch returns a batch whose writes are committed in a single transaction.
+func (db *sqliteDB) NewBatch() chaindb.Batch {
+	return &sqliteBatch{
+		db:      db,
+		updates: make(map[string][]byte),
+	}
+}
+
+// Path returns the path of the SQLite file.
+func (db *sqliteDB) Path() string {
+	return db.path
+}
+
+// NewIterator returns an iterator over a consistent snapshot of all entries
+// in key order. Like chaindb.BadgerIterator, Next has to be called before
+// reading the first entry. If the entries can't be read, the iterator is
+// empty and Err returns the error.
+func (db *sqliteDB) NewIterator() chaindb.Iterator {
+	iter := &sqliteIterator{pos: -1}
+
+	rows, err := db.db.Query(`SELECT key, value FROM kv ORDER BY key`)
+	if err != nil {
+		iter.err = fmt.Errorf("failed to query SQLite database: %w", err)
+		return iter
+	}
+	defer rows.Close() //nolint:errcheck
+
+	for rows.Next() {
+		var e sqliteEntry
+		if err = rows.Scan(&e.key, &e.value); err != nil {
+			return &sqliteIterator{pos: -1, err: fmt.Errorf("failed to read SQLite database entry: %w", err)}
+		}
+		iter.entries = append(iter.entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return &sqliteIterator{pos: -1, err: fmt.Errorf("failed to read SQLite database entries: %w", err)}
+	}
+
+	return iter
+}
+
+// Subscribe is not supported by the SQLite backend.
+func (db *sqliteDB) Subscribe(_ context.Context, _ func(kv *chaindb.KVList) error, _ []pb.Match) error {
+	return errors.New("subscriptions are not supported by the SQLite database backend")
+}
+
+// ClearAll deletes all entries.
+func (db *sqliteDB) ClearAll() error {
+	_, err := db.db.Exec(`DELETE FROM kv`)
+	return err
+}
+
+// nonNil returns an empty slice instead of nil, as the value column can't be NULL.
+func nonNil(value []byte) []byte {
+	if value == nil {
+		return []byte{}
+	}
+	return value
+}
+
+type sqliteEntry struct {
+	key   []byte
+	value []byte
+}
+
+type sqliteIterator struct {
+	entries []sqliteEntry
+	pos     int
+	err     error
+}
+
+func (i *sqliteIterator) Valid() bool {
+	return i.pos >= 0 && i.pos < len(i.entries)
+}
+
+func (i *sqliteIterator) Next() bool {
+	if i.pos < len(i.entries) {
+		i.pos++
+	}
+	return i.Valid()
+}
+
+func (i *sqliteIterator) Key() []byte {
+	return i.entries[i.pos].key
+}
+
+func (i *sqliteIterator) Value() []byte {
+	return i.entries[i.pos].value
+}
+
+// Err returns the error that prevented the entries from being read.
+func (i *sqliteIterator) Err() error {
+	return i.err
+}
+
+func (i *sqliteIterator) Release() {
+	i.entries = nil
+}
+
+// sqliteBatch collects writes that are committed in one transaction on Flush.
+// A nil value in updates marks a deletion. As with the BadgerDB batch, the
+// last write to a key wins.
+type sqliteBatch struct {
+	db      *sqliteDB
+	updates map[string][]byte
+	size    int
+	mu      sync.Mutex
+}
+
+func (b *sqliteBatch) Put(key, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.updates[string(key)] = nonNil(value)
+	b.size += len(value)
+	return nil
+}
+
+func (b *sqliteBatch) Del(key []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.updates[string(key)] = nil
+	return nil
+}
+
+func (b *sqliteBatch) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tx, err := b.db.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range b.updates {
+		if value == nil {
+			_, err = tx.Exec(`DELETE FROM kv WHERE key = ?`, []byte(key))
+		} else {
+			_, err = tx.Exec(`INSERT OR REPLACE INTO kv (key, value) VALUES (?, ?)`, []byte(key), value)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *sqliteBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *sqliteBatch) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.updates = make(map[string][]byte)
+	b.size = 0
+}
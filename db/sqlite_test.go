@@ -0,0 +1,144 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"database/sql"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+)
+
+func newTestSQLiteDatabase(t *testing.T) (*Database, string) {
+	dbPath := path.Join(t.TempDir(), "swap-db.sqlite")
+	db, err := NewSQLiteDatabase(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db, dbPath
+}
+
+func TestNewBackend(t *testing.T) {
+	b, err := NewBackend("sqlite")
+	require.NoError(t, err)
+	require.Equal(t, BackendSQLite, b)
+
+	_, err = NewBackend("mysql")
+	require.ErrorContains(t, err, `unknown database backend "mysql"`)
+}
+
+func TestSQLiteDatabase(t *testing.T) {
+	db, dbPath := newTestSQLiteDatabase(t)
+
+	offerA := newTestOffer()
+	offerB := newTestOffer()
+	require.NoError(t, db.PutOffer(offerA))
+	require.NoError(t, db.PutOffer(offerB))
+	info := newTestSwapInfo(offerA, time.Now())
+	require.NoError(t, db.PutSwap(info))
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	require.NoError(t, db.RecoveryDB().PutSwapPrivateKey(offerA.ID, kp.SpendKey()))
+	require.NoError(t, db.RecoveryDB().PutNewSwapTxHash(offerB.ID, types.Hash{0x1}))
+
+	offers, err := db.GetAllOffers()
+	require.NoError(t, err)
+	require.Len(t, offers, 2)
+
+	swaps, err := db.GetAllSwaps()
+	require.NoError(t, err)
+	require.Len(t, swaps, 1)
+	require.Equal(t, infoAsJSON(t, info), infoAsJSON(t, swaps[0]))
+
+	_, err = db.GetSwap(offerB.ID)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	sk, err := db.RecoveryDB().GetSwapPrivateKey(offerA.ID)
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().String(), sk.String())
+
+	require.NoError(t, db.DeleteOffer(offerB.ID))
+	require.NoError(t, db.ClearAllOffers())
+	offers, err = db.GetAllOffers()
+	require.NoError(t, err)
+	require.Empty(t, offers)
+
+	// the views can be queried by other processes while swapd is running
+	sqlDB, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sqlDB.Close()) }()
+
+	var journalMode string
+	require.NoError(t, sqlDB.QueryRow(`PRAGMA journal_mode`).Scan(&journalMode))
+	require.Equal(t, "wal", journalMode)
+
+	var offerID, status string
+	err = sqlDB.QueryRow(`SELECT offer_id, status FROM swaps`).Scan(&offerID, &status)
+	require.NoError(t, err)
+	require.Equal(t, offerA.ID.Hex(), offerID)
+	require.Equal(t, types.XMRLocked.String(), status)
+}
+
+func TestSQLiteDatabase_encryptedRecoveryDB(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "swap-db.sqlite")
+	db, err := NewSQLiteDatabase(dbPath)
+	require.NoError(t, err)
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	offer := newTestOffer()
+	id := offer.ID
+	require.NoError(t, db.RecoveryDB().PutSwapPrivateKey(id, kp.SpendKey()))
+	require.NoError(t, db.PutOffer(offer))
+	require.NoError(t, db.UnlockRecoveryDB("secret"))
+	require.NoError(t, db.Close())
+
+	db, err = NewSQLiteDatabase(dbPath)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+	require.True(t, db.RecoveryDB().IsLocked())
+	require.NoError(t, db.UnlockRecoveryDB("secret"))
+
+	sk, err := db.RecoveryDB().GetSwapPrivateKey(id)
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().String(), sk.String())
+}
+
+func TestDatabase_CopyTo(t *testing.T) {
+	src := newTestDatabase(t)
+	offer := newTestOffer()
+	require.NoError(t, src.PutOffer(offer))
+	info := newTestSwapInfo(offer, time.Now())
+	require.NoError(t, src.PutSwap(info))
+	require.NoError(t, src.RecoveryDB().PutNewSwapTxHash(offer.ID, types.Hash{0x1}))
+
+	dst, _ := newTestSQLiteDatabase(t)
+	archive, err := src.CopyTo(dst)
+	require.NoError(t, err)
+	require.Equal(t, 1, archive.NumSwaps())
+
+	resOffer, err := dst.GetOffer(offer.ID)
+	require.NoError(t, err)
+	require.Equal(t, offer.ID, resOffer.ID)
+
+	resInfo, err := dst.GetSwap(offer.ID)
+	require.NoError(t, err)
+	require.Equal(t, infoAsJSON(t, info), infoAsJSON(t, resInfo))
+
+	txHash, err := dst.RecoveryDB().GetNewSwapTxHash(offer.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.Hash{0x1}, txHash)
+
+	// the destination has to be empty
+	_, err = src.CopyTo(dst)
+	require.ErrorContains(t, err, "destination database is not empty")
+}
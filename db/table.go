@@ -0,0 +1,74 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"bytes"
+
+	"github.com/ChainSafe/chaindb"
+)
+
+// table is a chaindb table whose keys are prefixed in the underlying database.
+// Unlike the tables returned by chaindb.NewTable, which can only iterate over
+// a BadgerDB, it supports iteration over any chaindb.Database.
+type table struct {
+	chaindb.Database
+	root   chaindb.Database
+	prefix []byte
+}
+
+func newTable(db chaindb.Database, prefix string) chaindb.Database {
+	return &table{
+		Database: chaindb.NewTable(db, prefix),
+		root:     db,
+		prefix:   []byte(prefix),
+	}
+}
+
+// NewIterator returns an iterator positioned at the first entry of the table.
+// Keys are returned without the table prefix.
+func (t *table) NewIterator() chaindb.Iterator {
+	if _, ok := t.root.(*chaindb.BadgerDB); ok {
+		return t.Database.NewIterator()
+	}
+
+	iter := &prefixIterator{
+		Iterator: t.root.NewIterator(),
+		prefix:   t.prefix,
+	}
+	iter.Next()
+	return iter
+}
+
+// prefixIterator iterates over the entries of the underlying iterator whose
+// keys have the prefix. Like the chaindb table iterators, it is positioned at
+// the first entry when created, so it's used with a loop over Valid().
+type prefixIterator struct {
+	chaindb.Iterator
+	prefix []byte
+	valid  bool
+}
+
+// Valid returns whether the current iterator position has an item.
+func (i *prefixIterator) Valid() bool {
+	return i.valid
+}
+
+// Next advances the iterator to the next entry with the prefix.
+func (i *prefixIterator) Next() bool {
+	for i.Iterator.Next() {
+		if bytes.HasPrefix(i.Iterator.Key(), i.prefix) {
+			i.valid = true
+			return true
+		}
+	}
+
+	i.valid = false
+	return false
+}
+
+// Key returns the key of the current entry without the prefix.
+func (i *prefixIterator) Key() []byte {
+	return i.Iterator.Key()[len(i.prefix):]
+}
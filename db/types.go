@@ -4,6 +4,7 @@
 package db
 
 import (
+	"encoding/json"
 	"math/big"
 
 	"github.com/athanorlabs/atomic-swap/common/types"
@@ -29,3 +30,64 @@ type EthereumSwapInfo struct {
 	// SwapCreatorAddr is the address of the contract on which the swap was created.
 	SwapCreatorAddr ethcommon.Address `json:"swapCreatorAddr" validate:"required"`
 }
+
+// legacySwapFieldNames maps the field names of the `Swap` structure written
+// before the contract was renamed to SwapCreator to the current names.
+var legacySwapFieldNames = map[string]string{
+	"pubKeyClaim":  "claimCommitment",
+	"pubKeyRefund": "refundCommitment",
+	"timeout0":     "timeout1",
+	"timeout1":     "timeout2",
+}
+
+// UnmarshalJSON decodes the current layout of EthereumSwapInfo, as well as the
+// layout written before the contract was renamed to SwapCreator, which stored
+// the contract address as contractAddress and used the old field names of the
+// `Swap` structure.
+func (i *EthereumSwapInfo) UnmarshalJSON(data []byte) error {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if contractAddr, isLegacy := fields["contractAddress"]; isLegacy {
+		delete(fields, "contractAddress")
+		fields["swapCreatorAddr"] = contractAddr
+
+		if swapData, ok := fields["swap"]; ok {
+			upgraded, err := renameJSONFields(swapData, legacySwapFieldNames)
+			if err != nil {
+				return err
+			}
+			fields["swap"] = upgraded
+		}
+
+		var err error
+		if data, err = json.Marshal(fields); err != nil {
+			return err
+		}
+	}
+
+	// Unmarshal without recursion
+	type _EthereumSwapInfo EthereumSwapInfo
+	return json.Unmarshal(data, (*_EthereumSwapInfo)(i))
+}
+
+// renameJSONFields renames the fields of a JSON object. All renames are
+// applied at once, so a field can take the name of another renamed field.
+func renameJSONFields(data []byte, names map[string]string) ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	renamed := make(map[string]json.RawMessage, len(fields))
+	for name, value := range fields {
+		if newName, ok := names[name]; ok {
+			name = newName
+		}
+		renamed[name] = value
+	}
+
+	return json.Marshal(renamed)
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package block
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/athanorlabs/atomic-swap/common/clock"
+)
+
+const (
+	chainClockPollInterval   = time.Second
+	chainClockRequestTimeout = 10 * time.Second
+	// chainClockCacheTime is how long a fetched block timestamp is reused
+	// before the latest block is requested again
+	chainClockCacheTime = chainClockPollInterval
+)
+
+// ChainClock is a clock.Clock whose time is the timestamp of the latest block
+// of an ethereum chain. The swap contract compares the timeouts of a swap
+// against block timestamps, so timers of the chain clock fire when the chain,
+// and not the local system, has reached their deadline.
+//
+// The latest block is requested at most once per chainClockCacheTime, no
+// matter how many timers are running or how often Now is called.
+type ChainClock struct {
+	ec *ethclient.Client
+
+	mu        sync.Mutex
+	blockTime time.Time // timestamp of the latest block at the last fetch
+	fetchedAt time.Time // local time of the last successful fetch
+	err       error     // error of the last fetch, nil if it succeeded
+}
+
+var _ clock.Clock = (*ChainClock)(nil)
+
+// NewChainClock returns a clock that follows the block timestamps of the chain
+// of the passed client.
+func NewChainClock(ec *ethclient.Client) *ChainClock {
+	return &ChainClock{ec: ec}
+}
+
+// Now returns the timestamp of the latest block. If the timestamp can not be
+// retrieved, the last fetched timestamp plus the local time elapsed since it
+// was fetched is returned, or the local time if no timestamp was fetched yet.
+// Err returns the error of the failed request.
+func (c *ChainClock) Now() time.Time {
+	ctx, cancel := context.WithTimeout(context.Background(), chainClockRequestTimeout)
+	defer cancel()
+
+	now, err := c.now(ctx)
+	if err == nil {
+		return now
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fetchedAt.IsZero() {
+		return time.Now()
+	}
+	return c.blockTime.Add(time.Since(c.fetchedAt))
+}
+
+// Err returns the error of the last request for the latest block, or nil if
+// it succeeded. While it returns an error, Now is estimated from the local
+// clock.
+func (c *ChainClock) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// now returns the timestamp of the latest block, which is only requested if
+// the cached timestamp is older than chainClockCacheTime.
+func (c *ChainClock) now(ctx context.Context) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err == nil && !c.fetchedAt.IsZero() && time.Since(c.fetchedAt) < chainClockCacheTime {
+		return c.blockTime, nil
+	}
+
+	header, err := c.ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		// a stopped timer cancels its request, which says nothing about the chain
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return time.Time{}, err
+		}
+		if c.err == nil {
+			log.Warnf("failed to get latest block timestamp, estimating chain time with the local clock: %s", err)
+		}
+		c.err = err
+		return time.Time{}, err
+	}
+
+	if c.err != nil {
+		log.Infof("retrieved latest block timestamp again, following chain time")
+	}
+	c.err = nil
+	c.blockTime = time.Unix(int64(header.Time), 0)
+	c.fetchedAt = time.Now()
+	return c.blockTime, nil
+}
+
+// NewTimer returns a timer that fires once the chain has a block whose
+// timestamp is at least d after the current chain time.
+func (c *ChainClock) NewTimer(d time.Duration) clock.Timer {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &chainTimer{
+		ch:     make(chan time.Time, 1),
+		cancel: cancel,
+	}
+
+	deadline := c.Now().Add(d)
+	go c.runTimer(ctx, t, deadline)
+	return t
+}
+
+// runTimer polls the latest block until its timestamp reaches the deadline
+// and fires the timer, unless it is stopped first.
+func (c *ChainClock) runTimer(ctx context.Context, t *chainTimer, deadline time.Time) {
+	ticker := time.NewTicker(chainClockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		reqCtx, cancel := context.WithTimeout(ctx, chainClockRequestTimeout)
+		now, err := c.now(reqCtx)
+		cancel()
+		if err == nil && !now.Before(deadline) {
+			t.fire(now)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type chainTimer struct {
+	mu      sync.Mutex
+	ch      chan time.Time
+	cancel  context.CancelFunc
+	stopped bool
+}
+
+func (t *chainTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// fire sends the time on the timer's channel, unless the timer was stopped
+func (t *chainTimer) fire(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped {
+		return
+	}
+	t.stopped = true
+	t.ch <- now
+}
+
+func (t *chainTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cancel()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
+}
@@ -45,6 +45,26 @@ func TestCheckSwapCreatorContractCode(t *testing.T) {
 	contractAddr, _ := DevDeploySwapCreator(t, ec, pk)
 	err := CheckSwapCreatorContractCode(context.Background(), ec, contractAddr)
 	require.NoError(t, err)
+
+	version, err := GetSwapCreatorVersion(context.Background(), ec, contractAddr)
+	require.NoError(t, err)
+	require.Equal(t, SwapCreatorV2, version)
+}
+
+// Ensure that the first release of the contract, which is deployed at the
+// default mainnet and sepolia addresses, is still accepted
+func TestGetSwapCreatorVersion_v1(t *testing.T) {
+	ctx := context.Background()
+	ec, _ := tests.NewEthClient(t)
+
+	contractAddr := ethcommon.Address{0x5c}
+	err := ec.Client().CallContext(ctx, nil, "evm_setAccountCode", contractAddr, "0x"+swapCreatorV1BytecodeHex)
+	require.NoError(t, err)
+
+	version, err := GetSwapCreatorVersion(ctx, ec, contractAddr)
+	require.NoError(t, err)
+	require.Equal(t, SwapCreatorV1, version)
+	require.NoError(t, CheckSwapCreatorContractCode(ctx, ec, contractAddr))
 }
 
 // Tests that we fail when the wrong contract byte code is found
@@ -81,6 +101,7 @@ func TestMainnetContract(t *testing.T) {
 	ctx := context.Background()
 	ec := tests.NewEthMainnetClient(t)
 	mainnetConf := common.MainnetConfig()
-	err := CheckSwapCreatorContractCode(ctx, ec, mainnetConf.SwapCreatorAddr)
+	version, err := GetSwapCreatorVersion(ctx, ec, mainnetConf.SwapCreatorAddr)
 	require.NoError(t, err)
+	require.Equal(t, SwapCreatorV1, version)
 }
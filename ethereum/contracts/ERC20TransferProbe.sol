@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: LGPLv3
+pragma solidity ^0.8.19;
+
+import {IERC20} from "@openzeppelin/contracts/token/ERC20/IERC20.sol";
+
+// ERC20TransferProbe is never deployed. Its code is placed at the address of a
+// token holder with an eth_call state override, so that a transfer of the
+// holder's tokens can be simulated together with the balance changes it causes.
+contract ERC20TransferProbe {
+    // Transfers the amount of the token from this address to `to` and returns
+    // the token balances of both addresses before and after the transfer. Some
+    // tokens, like USDT on mainnet, do not return a value from transfer, so only
+    // a returned false is treated as a failed transfer.
+    function probeTransfer(
+        IERC20 token,
+        address to,
+        uint256 amount
+    )
+        external
+        returns (uint256 fromBefore, uint256 fromAfter, uint256 toBefore, uint256 toAfter)
+    {
+        fromBefore = token.balanceOf(address(this));
+        toBefore = token.balanceOf(to);
+
+        // solhint-disable-next-line avoid-low-level-calls
+        (bool ok, bytes memory ret) = address(token).call(
+            abi.encodeWithSelector(IERC20.transfer.selector, to, amount)
+        );
+        require(ok && (ret.length == 0 || abi.decode(ret, (bool))), "transfer failed");
+
+        fromAfter = token.balanceOf(address(this));
+        toAfter = token.balanceOf(to);
+    }
+}
@@ -2,6 +2,7 @@
 pragma solidity ^0.8.19;
 
 import {IERC20} from "@openzeppelin/contracts/token/ERC20/IERC20.sol";
+import {IERC20Permit} from "@openzeppelin/contracts/token/ERC20/extensions/IERC20Permit.sol";
 import {SafeERC20} from "@openzeppelin/contracts/token/ERC20/utils/SafeERC20.sol";
 import {Secp256k1} from "./Secp256k1.sol";
 
@@ -28,6 +29,16 @@ contract SwapCreator is Secp256k1 {
     // swaps maps from a swap ID to the swap's current Stage
     mapping(bytes32 => Stage) public swaps;
 
+    // entered is true while a function that transfers swap value is executing.
+    // Swap values are sent with `call`, which forwards all gas, so owners and
+    // claimers can be contract wallets.
+    bool private entered;
+
+    // REFUND_RELAY_TYPEHASH prefixes the RelaySwap that Alice signs for
+    // `refundRelayer`, so that the signature of a refund can never be used to
+    // claim a swap, and the signature of a claim can never refund one.
+    bytes32 private constant REFUND_RELAY_TYPEHASH = keccak256("SwapCreator.refundRelayer");
+
     // Swap stores the swap parameters, the hash of which forms the swap ID.
     struct Swap {
         // owner is the address of Alice, who initiates the swap by calling
@@ -61,8 +72,9 @@ contract SwapCreator is Secp256k1 {
     }
 
     // RelaySwap contains additional information required for relayed claim
-    // transactions. This entire structure is encoded and signed by the swap
-    // claimer, and the signature is passed to `claimRelayer`.
+    // and refund transactions. This entire structure is encoded and signed by
+    // the swap claimer, and the signature is passed to `claimRelayer`, or by
+    // the swap owner, and the signature is passed to `refundRelayer`.
     struct RelaySwap {
         // swap specifies which swap is being claimed
         Swap swap;
@@ -74,6 +86,16 @@ contract SwapCreator is Secp256k1 {
         address swapCreator;
     }
 
+    // Permit is an EIP-2612 permit signature of Alice, allowing this contract
+    // to transfer the swap value of her tokens.
+    struct Permit {
+        // deadline is the block timestamp after which the signature is invalid
+        uint256 deadline;
+        uint8 v;
+        bytes32 r;
+        bytes32 s;
+    }
+
     event New(
         bytes32 swapID,
         bytes32 claimKey,
@@ -113,8 +135,8 @@ contract SwapCreator is Secp256k1 {
     // thrown when the caller of `setReady` or `refund` is not the swap owner
     error OnlySwapOwner();
 
-    // thrown when the signer of the relayed transaction is not the swap's
-    // claimer
+    // thrown when the caller of `claim`, or the signer of a relayed claim, is
+    // not the swap's claimer
     error OnlySwapClaimer();
 
     // thrown when trying to call `claim` or `refund` on an invalid swap
@@ -151,6 +173,30 @@ contract SwapCreator is Secp256k1 {
     // `claimRelayer` does not match the relayer hash in `RelaySwap`
     error InvalidRelayerAddress();
 
+    // thrown when the swaps and secrets passed to `claimBatch` or
+    // `refundBatch` are empty or have different lengths
+    error InvalidBatch();
+
+    // thrown when the payout address passed to `claimTo`, `refundTo` or a batch
+    // function is the zero address
+    error InvalidPayout();
+
+    // thrown when transferring the EVM native currency to a payout address
+    // fails, eg. when the payout address is a contract that does not accept it
+    error TransferFailed();
+
+    // thrown when a function that transfers swap value is re-entered
+    error ReentrantCall();
+
+    // `nonReentrant` prevents a payout address from calling back into this
+    // contract while swap value is being transferred to it
+    modifier nonReentrant() {
+        if (entered) revert ReentrantCall();
+        entered = true;
+        _;
+        entered = false;
+    }
+
     // `newSwap` creates a new Swap instance using the passed parameters and
     // locks Alice's native EVM currency or token asset in the contract. On
     // success, the swap ID is returned.
@@ -183,6 +229,80 @@ contract SwapCreator is Secp256k1 {
             IERC20(_asset).safeTransferFrom(msg.sender, address(this), _value);
         }
 
+        return
+            _newSwap(
+                _claimCommitment,
+                _refundCommitment,
+                _claimer,
+                _timeoutDuration1,
+                _timeoutDuration2,
+                _asset,
+                _value,
+                _nonce
+            );
+    }
+
+    // `newSwapWithPermit` is the same as `newSwap` for tokens that implement
+    // EIP-2612 `permit`. Instead of approving this contract to transfer the
+    // swap value in a prior transaction, Alice passes her permit signature,
+    // so the swap is created and funded in a single transaction.
+    function newSwapWithPermit(
+        bytes32 _claimCommitment,
+        bytes32 _refundCommitment,
+        address payable _claimer,
+        uint256 _timeoutDuration1,
+        uint256 _timeoutDuration2,
+        address _asset,
+        uint256 _value,
+        uint256 _nonce,
+        Permit memory _permit
+    ) public returns (bytes32) {
+        if (_value == 0) revert ZeroValue();
+        if (_asset == address(0)) revert InvalidValue();
+
+        // Anyone can submit the permit signature once it is public, so a
+        // front-runner can make the permit call revert. The allowance is set
+        // either way, so we only need the transfer below to succeed.
+        try
+            IERC20Permit(_asset).permit(
+                msg.sender,
+                address(this),
+                _value,
+                _permit.deadline,
+                _permit.v,
+                _permit.r,
+                _permit.s
+            )
+        {} catch {} // solhint-disable-line no-empty-blocks
+
+        // WARN: fee-on-transfer tokens are not supported
+        IERC20(_asset).safeTransferFrom(msg.sender, address(this), _value);
+
+        return
+            _newSwap(
+                _claimCommitment,
+                _refundCommitment,
+                _claimer,
+                _timeoutDuration1,
+                _timeoutDuration2,
+                _asset,
+                _value,
+                _nonce
+            );
+    }
+
+    // `_newSwap` creates the swap after the swap value was transferred to this
+    // contract.
+    function _newSwap(
+        bytes32 _claimCommitment,
+        bytes32 _refundCommitment,
+        address payable _claimer,
+        uint256 _timeoutDuration1,
+        uint256 _timeoutDuration2,
+        address _asset,
+        uint256 _value,
+        uint256 _nonce
+    ) private returns (bytes32) {
         if (_claimCommitment == 0 || _refundCommitment == 0) revert InvalidSwapKey();
         if (_claimer == address(0)) revert InvalidClaimer();
         if (_timeoutDuration1 == 0 || _timeoutDuration2 == 0) revert InvalidTimeout();
@@ -230,21 +350,30 @@ contract SwapCreator is Secp256k1 {
     // Bob can call `claim` if either of these hold true:
     // (1) Alice has set the swap to `ready` and it's before timeout1
     // (2) It is between timeout1 and timeout2
-    function claim(Swap memory _swap, bytes32 _secret) public {
+    function claim(Swap memory _swap, bytes32 _secret) public nonReentrant {
+        _claimTo(_swap, _secret, _swap.claimer);
+    }
+
+    // `claimTo` is the same as `claim`, but the swap value is transferred to
+    // _payout instead of the claimer. This lets Bob keep the funds that he
+    // receives away from the key that he claims with.
+    function claimTo(
+        Swap memory _swap,
+        bytes32 _secret,
+        address payable _payout
+    ) public nonReentrant {
+        _claimTo(_swap, _secret, _payout);
+    }
+
+    function _claimTo(Swap memory _swap, bytes32 _secret, address payable _payout) internal {
         if (msg.sender != _swap.claimer) revert OnlySwapClaimer();
+        if (_payout == address(0)) revert InvalidPayout();
         _claim(_swap, _secret);
 
-        if (_swap.asset == address(0)) {
-            // Transfer the swap value as the EVM's native currency
-            _swap.claimer.transfer(_swap.value);
-        } else {
-            // Transfer the swap value as a token amount.
-            // WARNING: this will FAIL for fee-on-transfer or rebasing tokens if
-            // the token transfer reverts (i.e. if this contract does not
-            // contain _swap.value tokens), exposing Bob's secret while giving
-            // him nothing.
-            IERC20(_swap.asset).safeTransfer(_swap.claimer, _swap.value);
-        }
+        // WARNING: this will FAIL for fee-on-transfer or rebasing tokens if the
+        // token transfer reverts (i.e. if this contract does not contain
+        // _swap.value tokens), exposing Bob's secret while giving him nothing.
+        _send(_swap.asset, _payout, _swap.value);
     }
 
     // Anyone can call `claimRelayer` if they receive a signed _relaySwap object
@@ -263,7 +392,7 @@ contract SwapCreator is Secp256k1 {
         uint8 v,
         bytes32 r,
         bytes32 s
-    ) public {
+    ) public nonReentrant {
         address signer = ecrecover(keccak256(abi.encode(_relaySwap)), v, r, s);
         if (signer != _relaySwap.swap.claimer) revert InvalidSignature();
         if (address(this) != _relaySwap.swapCreator) revert InvalidContractAddress();
@@ -272,19 +401,29 @@ contract SwapCreator is Secp256k1 {
 
         _claim(_relaySwap.swap, _secret);
 
-        // send ether to swap claimer, subtracting the relayer fee
-        if (_relaySwap.swap.asset == address(0)) {
-            _relaySwap.swap.claimer.transfer(_relaySwap.swap.value - _relaySwap.fee);
-            payable(_relayer).transfer(_relaySwap.fee);
-        } else {
-            // WARN: this will FAIL for fee-on-transfer or rebasing tokens if the token
-            // transfer reverts (i.e. if this contract does not contain _swap.value tokens),
-            // exposing Bob's secret while giving him nothing.
-            IERC20(_relaySwap.swap.asset).safeTransfer(
-                _relaySwap.swap.claimer,
-                _relaySwap.swap.value - _relaySwap.fee
-            );
-            IERC20(_relaySwap.swap.asset).safeTransfer(_relayer, _relaySwap.fee);
+        // send the swap value to the claimer, subtracting the relayer fee
+        // WARN: this will FAIL for fee-on-transfer or rebasing tokens if the token
+        // transfer reverts (i.e. if this contract does not contain _swap.value tokens),
+        // exposing Bob's secret while giving him nothing.
+        _send(
+            _relaySwap.swap.asset,
+            _relaySwap.swap.claimer,
+            _relaySwap.swap.value - _relaySwap.fee
+        );
+        _send(_relaySwap.swap.asset, _relayer, _relaySwap.fee);
+    }
+
+    // `claimBatch` lets Bob claim many swaps in a single transaction, passing
+    // the secret of each swap at the same index. The rules of `claimTo` apply
+    // to every swap, and the whole batch reverts if any of the claims fail.
+    function claimBatch(
+        Swap[] memory _swaps,
+        bytes32[] memory _secrets,
+        address payable _payout
+    ) public nonReentrant {
+        if (_swaps.length == 0 || _swaps.length != _secrets.length) revert InvalidBatch();
+        for (uint256 i = 0; i < _swaps.length; i++) {
+            _claimTo(_swaps[i], _secrets[i], _payout);
         }
     }
 
@@ -304,12 +443,71 @@ contract SwapCreator is Secp256k1 {
     // Alice can `refund` her swap funds:
     // - Until timeout1, unless she called `setReady`
     // - After timeout2, independent of whether she called `setReady`
-    function refund(Swap memory _swap, bytes32 _secret) public {
+    function refund(Swap memory _swap, bytes32 _secret) public nonReentrant {
+        _refundTo(_swap, _secret, _swap.owner);
+    }
+
+    // `refundTo` is the same as `refund`, but the swap value is transferred to
+    // _payout instead of the owner.
+    function refundTo(
+        Swap memory _swap,
+        bytes32 _secret,
+        address payable _payout
+    ) public nonReentrant {
+        _refundTo(_swap, _secret, _payout);
+    }
+
+    function _refundTo(Swap memory _swap, bytes32 _secret, address payable _payout) internal {
+        if (_payout == address(0)) revert InvalidPayout();
+        if (_swap.owner != msg.sender) revert OnlySwapOwner();
+        _refund(_swap, _secret);
+
+        // send asset back to the swap owner's payout address
+        _send(_swap.asset, _payout, _swap.value);
+    }
+
+    // Anyone can call `refundRelayer` if they receive a signed _relaySwap
+    // object from Alice, so she can refund without holding the EVM native
+    // currency for gas. The same rules for when Alice can call `refund` apply.
+    // Like `claimRelayer`, the relayer is paid _relaySwap.fee and Alice only
+    // signs a salted hash of the relayer's payout address. Alice signs the
+    // _relaySwap prefixed by REFUND_RELAY_TYPEHASH.
+    function refundRelayer(
+        RelaySwap memory _relaySwap,
+        bytes32 _secret,
+        address payable _relayer,
+        uint32 _salt,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) public nonReentrant {
+        address signer = ecrecover(
+            keccak256(abi.encode(REFUND_RELAY_TYPEHASH, _relaySwap)),
+            v,
+            r,
+            s
+        );
+        if (signer != _relaySwap.swap.owner) revert InvalidSignature();
+        if (address(this) != _relaySwap.swapCreator) revert InvalidContractAddress();
+        if (keccak256(abi.encodePacked(_relayer, _salt)) != _relaySwap.relayerHash)
+            revert InvalidRelayerAddress();
+
+        _refund(_relaySwap.swap, _secret);
+
+        // send the swap value back to the owner, subtracting the relayer fee
+        _send(
+            _relaySwap.swap.asset,
+            _relaySwap.swap.owner,
+            _relaySwap.swap.value - _relaySwap.fee
+        );
+        _send(_relaySwap.swap.asset, _relayer, _relaySwap.fee);
+    }
+
+    function _refund(Swap memory _swap, bytes32 _secret) internal {
         bytes32 swapID = keccak256(abi.encode(_swap));
         Stage swapStage = swaps[swapID];
         if (swapStage == Stage.INVALID) revert InvalidSwap();
         if (swapStage == Stage.COMPLETED) revert SwapCompleted();
-        if (_swap.owner != msg.sender) revert OnlySwapOwner();
         if (
             block.timestamp < _swap.timeout2 &&
             (block.timestamp > _swap.timeout1 || swapStage == Stage.READY)
@@ -317,13 +515,35 @@ contract SwapCreator is Secp256k1 {
 
         verifySecret(_secret, _swap.refundCommitment);
         emit Refunded(swapID, _secret);
-
-        // send asset back to swap owner
         swaps[swapID] = Stage.COMPLETED;
-        if (_swap.asset == address(0)) {
-            _swap.owner.transfer(_swap.value);
+    }
+
+    // `refundBatch` lets Alice refund many swaps in a single transaction,
+    // passing the secret of each swap at the same index. The rules of
+    // `refundTo` apply to every swap, and the whole batch reverts if any of the
+    // refunds fail.
+    function refundBatch(
+        Swap[] memory _swaps,
+        bytes32[] memory _secrets,
+        address payable _payout
+    ) public nonReentrant {
+        if (_swaps.length == 0 || _swaps.length != _secrets.length) revert InvalidBatch();
+        for (uint256 i = 0; i < _swaps.length; i++) {
+            _refundTo(_swaps[i], _secrets[i], _payout);
+        }
+    }
+
+    // `_send` transfers _value of _asset to _to. The EVM native currency is
+    // sent with `call` instead of `transfer`, as the 2300 gas stipend of
+    // `transfer` is not enough for contract wallets to receive it. Callers
+    // must be `nonReentrant` and update the swap stage before sending.
+    function _send(address _asset, address _to, uint256 _value) private {
+        if (_asset == address(0)) {
+            // solhint-disable-next-line avoid-low-level-calls
+            (bool success, ) = payable(_to).call{value: _value}("");
+            if (!success) revert TransferFailed();
         } else {
-            IERC20(_swap.asset).safeTransfer(_swap.owner, _swap.value);
+            IERC20(_asset).safeTransfer(_to, _value);
         }
     }
 
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+import {TestERC20} from "./TestERC20.sol";
+
+// ERC20 token that burns 1% of every transfer, for testing the rejection of
+// fee-on-transfer tokens
+contract TestERC20FeeOnTransfer is TestERC20 {
+    constructor(
+        string memory name,
+        string memory symbol,
+        uint8 numDecimals,
+        address initialAccount,
+        uint256 initialBalance
+    ) payable TestERC20(name, symbol, numDecimals, initialAccount, initialBalance) {}
+
+    function _transfer(address from, address to, uint256 amount) internal virtual override {
+        uint256 fee = amount / 100;
+        _burn(from, fee);
+        super._transfer(from, to, amount - fee);
+    }
+}
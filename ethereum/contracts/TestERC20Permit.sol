@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+import {TestERC20} from "./TestERC20.sol";
+
+// ERC20 token implementing EIP-2612 `permit` for testing purposes
+contract TestERC20Permit is TestERC20 {
+    bytes32 private constant PERMIT_TYPEHASH =
+        keccak256(
+            "Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"
+        );
+
+    // solhint-disable-next-line var-name-mixedcase
+    bytes32 public immutable DOMAIN_SEPARATOR;
+
+    mapping(address => uint256) public nonces;
+
+    constructor(
+        string memory name,
+        string memory symbol,
+        uint8 numDecimals,
+        address initialAccount,
+        uint256 initialBalance
+    ) payable TestERC20(name, symbol, numDecimals, initialAccount, initialBalance) {
+        DOMAIN_SEPARATOR = keccak256(
+            abi.encode(
+                keccak256(
+                    "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"
+                ),
+                keccak256(bytes(name)),
+                keccak256(bytes("1")),
+                block.chainid,
+                address(this)
+            )
+        );
+    }
+
+    function permit(
+        address owner,
+        address spender,
+        uint256 value,
+        uint256 deadline,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) public {
+        // solhint-disable-next-line not-rely-on-time
+        require(block.timestamp <= deadline, "permit deadline expired");
+
+        bytes32 structHash = keccak256(
+            abi.encode(PERMIT_TYPEHASH, owner, spender, value, nonces[owner]++, deadline)
+        );
+        bytes32 digest = keccak256(abi.encodePacked("\x19\x01", DOMAIN_SEPARATOR, structHash));
+        address signer = ecrecover(digest, v, r, s);
+        require(signer != address(0) && signer == owner, "invalid permit signature");
+
+        _approve(owner, spender, value);
+    }
+}
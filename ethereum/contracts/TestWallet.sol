@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+// Contract wallet for testing purposes. Like a Safe multisig, it executes
+// calls for its owner, and receiving the EVM native currency uses more than
+// the 2300 gas stipend of `transfer`.
+contract TestWallet {
+    address public immutable owner;
+    uint256 public received;
+
+    constructor(address _owner) payable {
+        owner = _owner;
+    }
+
+    receive() external payable {
+        received += msg.value;
+    }
+
+    function execute(address to, uint256 value, bytes calldata data) external returns (bytes memory) {
+        require(msg.sender == owner, "only owner");
+        // solhint-disable-next-line avoid-low-level-calls
+        (bool success, bytes memory result) = to.call{value: value}(data);
+        if (!success) {
+            // solhint-disable-next-line no-inline-assembly
+            assembly {
+                revert(add(result, 32), mload(result))
+            }
+        }
+        return result;
+    }
+}
@@ -0,0 +1,907 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TestERC20FeeOnTransferMetaData contains all meta data concerning the TestERC20FeeOnTransfer contract.
+var TestERC20FeeOnTransferMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"},{\"internalType\":\"uint8\",\"name\":\"numDecimals\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"initialAccount\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"initialBalance\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"approveInternal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"subtractedValue\",\"type\":\"uint256\"}],\"name\":\"decreaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"addedValue\",\"type\":\"uint256\"}],\"name\":\"increaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transferInternal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Bin: "0x60a060405260405162001244380380620012448339810160408190526200002691620001f7565b8484848484848460036200003b83826200032a565b5060046200004a82826200032a565b50505060ff831660805262000060828262000070565b5050505050505050505062000418565b6001600160a01b038216620000cb5760405162461bcd60e51b815260206004820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f206164647265737300604482015260640160405180910390fd5b8060025f828254620000de9190620003f2565b90915550506001600160a01b0382165f81815260208181526040808320805486019055518481527fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35050565b505050565b634e487b7160e01b5f52604160045260245ffd5b5f82601f8301126200015d575f80fd5b81516001600160401b03808211156200017a576200017a62000139565b604051601f8301601f19908116603f01168101908282118183101715620001a557620001a562000139565b81604052838152602092508683858801011115620001c1575f80fd5b5f91505b83821015620001e45785820183015181830184015290820190620001c5565b5f93810190920192909252949350505050565b5f805f805f60a086880312156200020c575f80fd5b85516001600160401b038082111562000223575f80fd5b6200023189838a016200014d565b9650602088015191508082111562000247575f80fd5b5062000256888289016200014d565b945050604086015160ff811681146200026d575f80fd5b60608701519093506001600160a01b03811681146200028a575f80fd5b80925050608086015190509295509295909350565b600181811c90821680620002b457607f821691505b602082108103620002d357634e487b7160e01b5f52602260045260245ffd5b50919050565b601f82111562000134575f81815260208120601f850160051c81016020861015620003015750805b601f850160051c820191505b8181101562000322578281556001016200030d565b505050505050565b81516001600160401b0381111562000346576200034662000139565b6200035e816200035784546200029f565b84620002d9565b602080601f83116001811462000394575f84156200037c5750858301515b5f19600386901b1c1916600185901b17855562000322565b5f85815260208120601f198616915b82811015620003c457888601518255948401946001909101908401620003a3565b5085821015620003e257878501515f19600388901b60f8161c191681555b5050505050600190811b01905550565b808201808211156200041257634e487b7160e01b5f52601160045260245ffd5b92915050565b608051610e0d620004375f395f818160f7015261022d0152610e0d5ff3fe6080604052600436106100e7575f3560e01c806340c10f19116100875780639dc29fac116100575780639dc29fac146102f7578063a457c2d714610316578063a9059cbb14610335578063dd62ed3e14610354575f80fd5b806340c10f191461027657806356189cb41461029057806370a08231146102af57806395d89b41146102e3575f80fd5b8063222f5be0116100c2578063222f5be0146101dc57806323b872dd146101fb578063313ce5671461021a5780633950935114610257575f80fd5b806306fdde0314610165578063095ea7b31461018f57806318160ddd146101be575f80fd5b366101615761012e3361011e60ff7f000000000000000000000000000000000000000000000000000000000000000016600a610c20565b610129906064610c32565b610373565b341561015f5760405133903480156108fc02915f818181858888f1935050505015801561015d573d5f803e3d5ffd5b505b005b5f80fd5b348015610170575f80fd5b50610179610381565b6040516101869190610c49565b60405180910390f35b34801561019a575f80fd5b506101ae6101a9366004610caf565b610411565b6040519015158152602001610186565b3480156101c9575f80fd5b506002545b604051908152602001610186565b3480156101e7575f80fd5b5061015f6101f6366004610cd7565b6104ad565b348015610206575f80fd5b506101ae610215366004610cd7565b6104bd565b348015610225575f80fd5b5060405160ff7f0000000000000000000000000000000000000000000000000000000000000000168152602001610186565b348015610262575f80fd5b506101ae610271366004610caf565b6104e0565b348015610281575f80fd5b5061015f610129366004610caf565b34801561029b575f80fd5b5061015f6102aa366004610cd7565b610501565b3480156102ba575f80fd5b506101ce6102c9366004610d10565b6001600160a01b03165f9081526020819052604090205490565b3480156102ee575f80fd5b5061017961050c565b348015610302575f80fd5b5061015f610311366004610caf565b61051b565b348015610321575f80fd5b506101ae610330366004610caf565b610525565b348015610340575f80fd5b506101ae61034f366004610caf565b61059f565b34801561035f575f80fd5b506101ce61036e366004610d29565b6105ac565b61037d82826105d6565b5050565b60606003805461039090610d5a565b80601f01602080910402602001604051908101604052809291908181526020018280546103bc90610d5a565b80156104075780601f106103de57610100808354040283529160200191610407565b820191905f5260205f20905b8154815290600101906020018083116103ea57829003601f168201915b5050505050905090565b5f33821580610427575061042581856105ac565b155b6104965760405162461bcd60e51b815260206004820152603560248201527f617070726f766520616c6c6f77616e6365206d7573742062652073657420746f604482015274207a65726f206265666f7265207570646174696e6760581b60648201526084015b60405180910390fd5b6104a1818585610693565b60019150505b92915050565b6104b88383836107b6565b505050565b5f336104ca8582856107e8565b6104d58585856107b6565b506001949350505050565b5f336104a18185856104f283836105ac565b6104fc9190610d92565b610693565b6104b8838383610693565b60606004805461039090610d5a565b61037d828261085a565b5f338161053282866105ac565b9050838110156105925760405162461bcd60e51b815260206004820152602560248201527f45524332303a2064656372656173656420616c6c6f77616e63652062656c6f77604482015264207a65726f60d81b606482015260840161048d565b6104d58286868403610693565b5f336104a18185856107b6565b6001600160a01b039182165f90815260016020908152604080832093909416825291909152205490565b6001600160a01b03821661062c5760405162461bcd60e51b815260206004820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f206164647265737300604482015260640161048d565b8060025f82825461063d9190610d92565b90915550506001600160a01b0382165f81815260208181526040808320805486019055518481527fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35050565b6001600160a01b0383166106f55760405162461bcd60e51b8152602060048201526024808201527f45524332303a20617070726f76652066726f6d20746865207a65726f206164646044820152637265737360e01b606482015260840161048d565b6001600160a01b0382166107565760405162461bcd60e51b815260206004820152602260248201527f45524332303a20617070726f766520746f20746865207a65726f206164647265604482015261737360f01b606482015260840161048d565b6001600160a01b038381165f8181526001602090815260408083209487168084529482529182902085905590518481527f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a3505050565b5f6107c2606483610da5565b90506107ce848261085a565b6107e284846107dd8486610dc4565b61098a565b50505050565b5f6107f384846105ac565b90505f1981146107e2578181101561084d5760405162461bcd60e51b815260206004820152601d60248201527f45524332303a20696e73756666696369656e7420616c6c6f77616e6365000000604482015260640161048d565b6107e28484848403610693565b6001600160a01b0382166108ba5760405162461bcd60e51b815260206004820152602160248201527f45524332303a206275726e2066726f6d20746865207a65726f206164647265736044820152607360f81b606482015260840161048d565b6001600160a01b0382165f908152602081905260409020548181101561092d5760405162461bcd60e51b815260206004820152602260248201527f45524332303a206275726e20616d6f756e7420657863656564732062616c616e604482015261636560f01b606482015260840161048d565b6001600160a01b0383165f818152602081815260408083208686039055600280548790039055518581529192917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a3505050565b6001600160a01b0383166109ee5760405162461bcd60e51b815260206004820152602560248201527f45524332303a207472616e736665722066726f6d20746865207a65726f206164604482015264647265737360d81b606482015260840161048d565b6001600160a01b038216610a505760405162461bcd60e51b815260206004820152602360248201527f45524332303a207472616e7366657220746f20746865207a65726f206164647260448201526265737360e81b606482015260840161048d565b6001600160a01b0383165f9081526020819052604090205481811015610ac75760405162461bcd60e51b815260206004820152602660248201527f45524332303a207472616e7366657220616d6f756e7420657863656564732062604482015265616c616e636560d01b606482015260840161048d565b6001600160a01b038481165f81815260208181526040808320878703905593871680835291849020805487019055925185815290927fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a36107e2565b634e487b7160e01b5f52601160045260245ffd5b600181815b80851115610b7a57815f1904821115610b6057610b60610b2c565b80851615610b6d57918102915b93841c9390800290610b45565b509250929050565b5f82610b90575060016104a7565b81610b9c57505f6104a7565b8160018114610bb25760028114610bbc57610bd8565b60019150506104a7565b60ff841115610bcd57610bcd610b2c565b50506001821b6104a7565b5060208310610133831016604e8410600b8410161715610bfb575081810a6104a7565b610c058383610b40565b805f1904821115610c1857610c18610b2c565b029392505050565b5f610c2b8383610b82565b9392505050565b80820281158282048414176104a7576104a7610b2c565b5f6020808352835180828501525f5b81811015610c7457858101830151858201604001528201610c58565b505f604082860101526040601f19601f8301168501019250505092915050565b80356001600160a01b0381168114610caa575f80fd5b919050565b5f8060408385031215610cc0575f80fd5b610cc983610c94565b946020939093013593505050565b5f805f60608486031215610ce9575f80fd5b610cf284610c94565b9250610d0060208501610c94565b9150604084013590509250925092565b5f60208284031215610d20575f80fd5b610c2b82610c94565b5f8060408385031215610d3a575f80fd5b610d4383610c94565b9150610d5160208401610c94565b90509250929050565b600181811c90821680610d6e57607f821691505b602082108103610d8c57634e487b7160e01b5f52602260045260245ffd5b50919050565b808201808211156104a7576104a7610b2c565b5f82610dbf57634e487b7160e01b5f52601260045260245ffd5b500490565b818103818111156104a7576104a7610b2c56fea26469706673582212208084cdd8ef145c63077d837b90d131464d383608e671d3d3fbd9e7ca6a69a12d64736f6c63430008150033",
+}
+
+// TestERC20FeeOnTransferABI is the input ABI used to generate the binding from.
+// Deprecated: Use TestERC20FeeOnTransferMetaData.ABI instead.
+var TestERC20FeeOnTransferABI = TestERC20FeeOnTransferMetaData.ABI
+
+// TestERC20FeeOnTransferBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use TestERC20FeeOnTransferMetaData.Bin instead.
+var TestERC20FeeOnTransferBin = TestERC20FeeOnTransferMetaData.Bin
+
+// DeployTestERC20FeeOnTransfer deploys a new Ethereum contract, binding an instance of TestERC20FeeOnTransfer to it.
+func DeployTestERC20FeeOnTransfer(auth *bind.TransactOpts, backend bind.ContractBackend, name string, symbol string, numDecimals uint8, initialAccount common.Address, initialBalance *big.Int) (common.Address, *types.Transaction, *TestERC20FeeOnTransfer, error) {
+	parsed, err := TestERC20FeeOnTransferMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(TestERC20FeeOnTransferBin), backend, name, symbol, numDecimals, initialAccount, initialBalance)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &TestERC20FeeOnTransfer{TestERC20FeeOnTransferCaller: TestERC20FeeOnTransferCaller{contract: contract}, TestERC20FeeOnTransferTransactor: TestERC20FeeOnTransferTransactor{contract: contract}, TestERC20FeeOnTransferFilterer: TestERC20FeeOnTransferFilterer{contract: contract}}, nil
+}
+
+// TestERC20FeeOnTransfer is an auto generated Go binding around an Ethereum contract.
+type TestERC20FeeOnTransfer struct {
+	TestERC20FeeOnTransferCaller     // Read-only binding to the contract
+	TestERC20FeeOnTransferTransactor // Write-only binding to the contract
+	TestERC20FeeOnTransferFilterer   // Log filterer for contract events
+}
+
+// TestERC20FeeOnTransferCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TestERC20FeeOnTransferCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestERC20FeeOnTransferTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TestERC20FeeOnTransferTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestERC20FeeOnTransferFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TestERC20FeeOnTransferFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestERC20FeeOnTransferSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TestERC20FeeOnTransferSession struct {
+	Contract     *TestERC20FeeOnTransfer // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts           // Call options to use throughout this session
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// TestERC20FeeOnTransferCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TestERC20FeeOnTransferCallerSession struct {
+	Contract *TestERC20FeeOnTransferCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                 // Call options to use throughout this session
+}
+
+// TestERC20FeeOnTransferTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TestERC20FeeOnTransferTransactorSession struct {
+	Contract     *TestERC20FeeOnTransferTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                 // Transaction auth options to use throughout this session
+}
+
+// TestERC20FeeOnTransferRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TestERC20FeeOnTransferRaw struct {
+	Contract *TestERC20FeeOnTransfer // Generic contract binding to access the raw methods on
+}
+
+// TestERC20FeeOnTransferCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TestERC20FeeOnTransferCallerRaw struct {
+	Contract *TestERC20FeeOnTransferCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TestERC20FeeOnTransferTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TestERC20FeeOnTransferTransactorRaw struct {
+	Contract *TestERC20FeeOnTransferTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTestERC20FeeOnTransfer creates a new instance of TestERC20FeeOnTransfer, bound to a specific deployed contract.
+func NewTestERC20FeeOnTransfer(address common.Address, backend bind.ContractBackend) (*TestERC20FeeOnTransfer, error) {
+	contract, err := bindTestERC20FeeOnTransfer(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20FeeOnTransfer{TestERC20FeeOnTransferCaller: TestERC20FeeOnTransferCaller{contract: contract}, TestERC20FeeOnTransferTransactor: TestERC20FeeOnTransferTransactor{contract: contract}, TestERC20FeeOnTransferFilterer: TestERC20FeeOnTransferFilterer{contract: contract}}, nil
+}
+
+// NewTestERC20FeeOnTransferCaller creates a new read-only instance of TestERC20FeeOnTransfer, bound to a specific deployed contract.
+func NewTestERC20FeeOnTransferCaller(address common.Address, caller bind.ContractCaller) (*TestERC20FeeOnTransferCaller, error) {
+	contract, err := bindTestERC20FeeOnTransfer(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20FeeOnTransferCaller{contract: contract}, nil
+}
+
+// NewTestERC20FeeOnTransferTransactor creates a new write-only instance of TestERC20FeeOnTransfer, bound to a specific deployed contract.
+func NewTestERC20FeeOnTransferTransactor(address common.Address, transactor bind.ContractTransactor) (*TestERC20FeeOnTransferTransactor, error) {
+	contract, err := bindTestERC20FeeOnTransfer(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20FeeOnTransferTransactor{contract: contract}, nil
+}
+
+// NewTestERC20FeeOnTransferFilterer creates a new log filterer instance of TestERC20FeeOnTransfer, bound to a specific deployed contract.
+func NewTestERC20FeeOnTransferFilterer(address common.Address, filterer bind.ContractFilterer) (*TestERC20FeeOnTransferFilterer, error) {
+	contract, err := bindTestERC20FeeOnTransfer(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20FeeOnTransferFilterer{contract: contract}, nil
+}
+
+// bindTestERC20FeeOnTransfer binds a generic wrapper to an already deployed contract.
+func bindTestERC20FeeOnTransfer(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TestERC20FeeOnTransferMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TestERC20FeeOnTransfer.Contract.TestERC20FeeOnTransferCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.TestERC20FeeOnTransferTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.TestERC20FeeOnTransferTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TestERC20FeeOnTransfer.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCaller) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TestERC20FeeOnTransfer.contract.Call(opts, &out, "allowance", owner, spender)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _TestERC20FeeOnTransfer.Contract.Allowance(&_TestERC20FeeOnTransfer.CallOpts, owner, spender)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCallerSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _TestERC20FeeOnTransfer.Contract.Allowance(&_TestERC20FeeOnTransfer.CallOpts, owner, spender)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCaller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TestERC20FeeOnTransfer.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _TestERC20FeeOnTransfer.Contract.BalanceOf(&_TestERC20FeeOnTransfer.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _TestERC20FeeOnTransfer.Contract.BalanceOf(&_TestERC20FeeOnTransfer.CallOpts, account)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _TestERC20FeeOnTransfer.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Decimals() (uint8, error) {
+	return _TestERC20FeeOnTransfer.Contract.Decimals(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCallerSession) Decimals() (uint8, error) {
+	return _TestERC20FeeOnTransfer.Contract.Decimals(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _TestERC20FeeOnTransfer.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Name() (string, error) {
+	return _TestERC20FeeOnTransfer.Contract.Name(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCallerSession) Name() (string, error) {
+	return _TestERC20FeeOnTransfer.Contract.Name(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _TestERC20FeeOnTransfer.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Symbol() (string, error) {
+	return _TestERC20FeeOnTransfer.Contract.Symbol(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCallerSession) Symbol() (string, error) {
+	return _TestERC20FeeOnTransfer.Contract.Symbol(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TestERC20FeeOnTransfer.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) TotalSupply() (*big.Int, error) {
+	return _TestERC20FeeOnTransfer.Contract.TotalSupply(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferCallerSession) TotalSupply() (*big.Int, error) {
+	return _TestERC20FeeOnTransfer.Contract.TotalSupply(&_TestERC20FeeOnTransfer.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Approve(&_TestERC20FeeOnTransfer.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Approve(&_TestERC20FeeOnTransfer.TransactOpts, spender, amount)
+}
+
+// ApproveInternal is a paid mutator transaction binding the contract method 0x56189cb4.
+//
+// Solidity: function approveInternal(address owner, address spender, uint256 value) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) ApproveInternal(opts *bind.TransactOpts, owner common.Address, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "approveInternal", owner, spender, value)
+}
+
+// ApproveInternal is a paid mutator transaction binding the contract method 0x56189cb4.
+//
+// Solidity: function approveInternal(address owner, address spender, uint256 value) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) ApproveInternal(owner common.Address, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.ApproveInternal(&_TestERC20FeeOnTransfer.TransactOpts, owner, spender, value)
+}
+
+// ApproveInternal is a paid mutator transaction binding the contract method 0x56189cb4.
+//
+// Solidity: function approveInternal(address owner, address spender, uint256 value) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) ApproveInternal(owner common.Address, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.ApproveInternal(&_TestERC20FeeOnTransfer.TransactOpts, owner, spender, value)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address account, uint256 amount) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) Burn(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "burn", account, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address account, uint256 amount) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Burn(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Burn(&_TestERC20FeeOnTransfer.TransactOpts, account, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address account, uint256 amount) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) Burn(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Burn(&_TestERC20FeeOnTransfer.TransactOpts, account, amount)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) DecreaseAllowance(opts *bind.TransactOpts, spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "decreaseAllowance", spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.DecreaseAllowance(&_TestERC20FeeOnTransfer.TransactOpts, spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.DecreaseAllowance(&_TestERC20FeeOnTransfer.TransactOpts, spender, subtractedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "increaseAllowance", spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.IncreaseAllowance(&_TestERC20FeeOnTransfer.TransactOpts, spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.IncreaseAllowance(&_TestERC20FeeOnTransfer.TransactOpts, spender, addedValue)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address account, uint256 amount) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) Mint(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "mint", account, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address account, uint256 amount) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Mint(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Mint(&_TestERC20FeeOnTransfer.TransactOpts, account, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address account, uint256 amount) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) Mint(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Mint(&_TestERC20FeeOnTransfer.TransactOpts, account, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "transfer", to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Transfer(&_TestERC20FeeOnTransfer.TransactOpts, to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Transfer(&_TestERC20FeeOnTransfer.TransactOpts, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "transferFrom", from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.TransferFrom(&_TestERC20FeeOnTransfer.TransactOpts, from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.TransferFrom(&_TestERC20FeeOnTransfer.TransactOpts, from, to, amount)
+}
+
+// TransferInternal is a paid mutator transaction binding the contract method 0x222f5be0.
+//
+// Solidity: function transferInternal(address from, address to, uint256 value) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) TransferInternal(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.Transact(opts, "transferInternal", from, to, value)
+}
+
+// TransferInternal is a paid mutator transaction binding the contract method 0x222f5be0.
+//
+// Solidity: function transferInternal(address from, address to, uint256 value) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) TransferInternal(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.TransferInternal(&_TestERC20FeeOnTransfer.TransactOpts, from, to, value)
+}
+
+// TransferInternal is a paid mutator transaction binding the contract method 0x222f5be0.
+//
+// Solidity: function transferInternal(address from, address to, uint256 value) returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) TransferInternal(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.TransferInternal(&_TestERC20FeeOnTransfer.TransactOpts, from, to, value)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferSession) Receive() (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Receive(&_TestERC20FeeOnTransfer.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferTransactorSession) Receive() (*types.Transaction, error) {
+	return _TestERC20FeeOnTransfer.Contract.Receive(&_TestERC20FeeOnTransfer.TransactOpts)
+}
+
+// TestERC20FeeOnTransferApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the TestERC20FeeOnTransfer contract.
+type TestERC20FeeOnTransferApprovalIterator struct {
+	Event *TestERC20FeeOnTransferApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TestERC20FeeOnTransferApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TestERC20FeeOnTransferApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TestERC20FeeOnTransferApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TestERC20FeeOnTransferApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TestERC20FeeOnTransferApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TestERC20FeeOnTransferApproval represents a Approval event raised by the TestERC20FeeOnTransfer contract.
+type TestERC20FeeOnTransferApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*TestERC20FeeOnTransferApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _TestERC20FeeOnTransfer.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20FeeOnTransferApprovalIterator{contract: _TestERC20FeeOnTransfer.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *TestERC20FeeOnTransferApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _TestERC20FeeOnTransfer.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TestERC20FeeOnTransferApproval)
+				if err := _TestERC20FeeOnTransfer.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferFilterer) ParseApproval(log types.Log) (*TestERC20FeeOnTransferApproval, error) {
+	event := new(TestERC20FeeOnTransferApproval)
+	if err := _TestERC20FeeOnTransfer.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TestERC20FeeOnTransferTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the TestERC20FeeOnTransfer contract.
+type TestERC20FeeOnTransferTransferIterator struct {
+	Event *TestERC20FeeOnTransferTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TestERC20FeeOnTransferTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TestERC20FeeOnTransferTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TestERC20FeeOnTransferTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TestERC20FeeOnTransferTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TestERC20FeeOnTransferTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TestERC20FeeOnTransferTransfer represents a Transfer event raised by the TestERC20FeeOnTransfer contract.
+type TestERC20FeeOnTransferTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*TestERC20FeeOnTransferTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _TestERC20FeeOnTransfer.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20FeeOnTransferTransferIterator{contract: _TestERC20FeeOnTransfer.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *TestERC20FeeOnTransferTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _TestERC20FeeOnTransfer.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TestERC20FeeOnTransferTransfer)
+				if err := _TestERC20FeeOnTransfer.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TestERC20FeeOnTransfer *TestERC20FeeOnTransferFilterer) ParseTransfer(log types.Log) (*TestERC20FeeOnTransferTransfer, error) {
+	event := new(TestERC20FeeOnTransferTransfer)
+	if err := _TestERC20FeeOnTransfer.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
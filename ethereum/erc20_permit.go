@@ -0,0 +1,104 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrPermitNotSupported is returned when a token does not implement EIP-2612
+var ErrPermitNotSupported = errors.New("token does not support EIP-2612 permit")
+
+// erc20PermitABI has the view methods of EIP-2612 needed to sign a permit
+const erc20PermitABI = `[
+	{"inputs":[],"name":"DOMAIN_SEPARATOR","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"owner","type":"address"}],"name":"nonces","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+var permitTypeHash = crypto.Keccak256Hash(
+	[]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"),
+)
+
+// SignERC20Permit returns the EIP-2612 permit signature of the owner of the
+// private key, allowing the spender to transfer the value of the token until
+// the deadline. ErrPermitNotSupported is returned if the token does not have
+// the DOMAIN_SEPARATOR and nonces methods of EIP-2612.
+func SignERC20Permit(
+	ctx context.Context,
+	ec *ethclient.Client,
+	token ethcommon.Address,
+	ownerKey *ecdsa.PrivateKey,
+	spender ethcommon.Address,
+	value *big.Int,
+	deadline *big.Int,
+) (*SwapCreatorPermit, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(erc20PermitABI))
+	if err != nil {
+		return nil, err
+	}
+
+	owner := crypto.PubkeyToAddress(ownerKey.PublicKey)
+	tokenContract := bind.NewBoundContract(token, parsedABI, ec, nil, nil)
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	var out []any
+	if err = tokenContract.Call(callOpts, &out, "DOMAIN_SEPARATOR"); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPermitNotSupported, err)
+	}
+	domainSeparator := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	out = nil
+	if err = tokenContract.Call(callOpts, &out, "nonces", owner); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPermitNotSupported, err)
+	}
+	nonce := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	digest := erc20PermitDigest(domainSeparator, owner, spender, value, nonce, deadline)
+	sig, err := crypto.Sign(digest[:], ownerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	permit := &SwapCreatorPermit{
+		Deadline: deadline,
+		V:        sig[64] + 27, // Ethereum wants 27/28 for v
+	}
+	copy(permit.R[:], sig[:32])
+	copy(permit.S[:], sig[32:64])
+
+	return permit, nil
+}
+
+// erc20PermitDigest returns the EIP-712 digest of an EIP-2612 permit
+func erc20PermitDigest(
+	domainSeparator [32]byte,
+	owner ethcommon.Address,
+	spender ethcommon.Address,
+	value *big.Int,
+	nonce *big.Int,
+	deadline *big.Int,
+) [32]byte {
+	structHash := crypto.Keccak256(
+		permitTypeHash[:],
+		ethcommon.LeftPadBytes(owner[:], 32),
+		ethcommon.LeftPadBytes(spender[:], 32),
+		math.U256Bytes(new(big.Int).Set(value)),
+		math.U256Bytes(new(big.Int).Set(nonce)),
+		math.U256Bytes(new(big.Int).Set(deadline)),
+	)
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator[:], structHash)
+}
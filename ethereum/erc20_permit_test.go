@@ -0,0 +1,64 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/tests"
+)
+
+func TestERC20PermitDigest(t *testing.T) {
+	domainSeparator := crypto.Keccak256Hash([]byte("test domain"))
+	owner := ethcommon.Address{0x1}
+	spender := ethcommon.Address{0x2}
+	value := big.NewInt(1000)
+	nonce := big.NewInt(3)
+	deadline := big.NewInt(1700000000)
+
+	// compute the expected digest with ABI encoding of the permit struct
+	bytes32Ty, _ := abi.NewType("bytes32", "", nil)
+	addressTy, _ := abi.NewType("address", "", nil)
+	uint256Ty, _ := abi.NewType("uint256", "", nil)
+	args := abi.Arguments{
+		{Type: bytes32Ty},
+		{Type: addressTy},
+		{Type: addressTy},
+		{Type: uint256Ty},
+		{Type: uint256Ty},
+		{Type: uint256Ty},
+	}
+	encoded, err := args.Pack(permitTypeHash, owner, spender, value, nonce, deadline)
+	require.NoError(t, err)
+	expected := crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator[:], crypto.Keccak256(encoded))
+
+	digest := erc20PermitDigest(domainSeparator, owner, spender, value, nonce, deadline)
+	require.Equal(t, expected, ethcommon.Hash(digest))
+}
+
+func TestSignERC20Permit_notSupported(t *testing.T) {
+	pk := tests.GetMakerTestKey(t)
+	ec, _ := tests.NewEthClient(t)
+
+	// the test tokens do not implement EIP-2612
+	token := GetMockTether(t, ec, pk)
+	_, err := SignERC20Permit(
+		context.Background(),
+		ec,
+		token.Address,
+		pk,
+		ethcommon.Address{0x2},
+		big.NewInt(1),
+		big.NewInt(1700000000),
+	)
+	require.True(t, errors.Is(err, ErrPermitNotSupported))
+}
@@ -0,0 +1,990 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TestERC20PermitMetaData contains all meta data concerning the TestERC20Permit contract.
+var TestERC20PermitMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"},{\"internalType\":\"uint8\",\"name\":\"numDecimals\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"initialAccount\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"initialBalance\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"DOMAIN_SEPARATOR\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"approveInternal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"subtractedValue\",\"type\":\"uint256\"}],\"name\":\"decreaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"addedValue\",\"type\":\"uint256\"}],\"name\":\"increaseAllowance\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"nonces\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"permit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transferInternal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Bin: "0x60c0604052604051620015f0380380620015f083398101604081905262000026916200029e565b8484848484848460036200003b8382620003d1565b5060046200004a8282620003d1565b50505060ff831660805262000060828262000117565b505087516020808a019190912060408051808201825260018152603160f81b9084015280517f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f938101939093528201527fc89efdaa54c0f20c7adf612882df0950f5a951637e0307cdcb4c672f298b8bc660608201524660808201523060a082015260c0019250620000f0915050565b60408051601f19818403018152919052805160209091012060a05250620004bf9350505050565b6001600160a01b038216620001725760405162461bcd60e51b815260206004820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f206164647265737300604482015260640160405180910390fd5b8060025f82825462000185919062000499565b90915550506001600160a01b0382165f81815260208181526040808320805486019055518481527fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35050565b505050565b634e487b7160e01b5f52604160045260245ffd5b5f82601f83011262000204575f80fd5b81516001600160401b0380821115620002215762000221620001e0565b604051601f8301601f19908116603f011681019082821181831017156200024c576200024c620001e0565b8160405283815260209250868385880101111562000268575f80fd5b5f91505b838210156200028b57858201830151818301840152908201906200026c565b5f93810190920192909252949350505050565b5f805f805f60a08688031215620002b3575f80fd5b85516001600160401b0380821115620002ca575f80fd5b620002d889838a01620001f4565b96506020880151915080821115620002ee575f80fd5b50620002fd88828901620001f4565b945050604086015160ff8116811462000314575f80fd5b60608701519093506001600160a01b038116811462000331575f80fd5b80925050608086015190509295509295909350565b600181811c908216806200035b57607f821691505b6020821081036200037a57634e487b7160e01b5f52602260045260245ffd5b50919050565b601f821115620001db575f81815260208120601f850160051c81016020861015620003a85750805b601f850160051c820191505b81811015620003c957828155600101620003b4565b505050505050565b81516001600160401b03811115620003ed57620003ed620001e0565b6200040581620003fe845462000346565b8462000380565b602080601f8311600181146200043b575f8415620004235750858301515b5f19600386901b1c1916600185901b178555620003c9565b5f85815260208120601f198616915b828110156200046b578886015182559484019460019091019084016200044a565b50858210156200048957878501515f19600388901b60f8161c191681555b5050505050600190811b01905550565b80820180821115620004b957634e487b7160e01b5f52601160045260245ffd5b92915050565b60805160a051611101620004ef5f395f8181610289015261074a01525f8181610118015261024e01526111015ff3fe608060405260043610610108575f3560e01c806356189cb4116100925780639dc29fac116100625780639dc29fac14610376578063a457c2d714610395578063a9059cbb146103b4578063d505accf146103d3578063dd62ed3e146103f2575f80fd5b806356189cb4146102e457806370a08231146103035780637ecebe001461033757806395d89b4114610362575f80fd5b806323b872dd116100d857806323b872dd1461021c578063313ce5671461023b5780633644e5151461027857806339509351146102ab57806340c10f19146102ca575f80fd5b806306fdde0314610186578063095ea7b3146101b057806318160ddd146101df578063222f5be0146101fd575f80fd5b366101825761014f3361013f60ff7f000000000000000000000000000000000000000000000000000000000000000016600a610ec1565b61014a906064610ed3565b610411565b34156101805760405133903480156108fc02915f818181858888f1935050505015801561017e573d5f803e3d5ffd5b505b005b5f80fd5b348015610191575f80fd5b5061019a61041f565b6040516101a79190610eea565b60405180910390f35b3480156101bb575f80fd5b506101cf6101ca366004610f50565b6104af565b60405190151581526020016101a7565b3480156101ea575f80fd5b506002545b6040519081526020016101a7565b348015610208575f80fd5b50610180610217366004610f78565b61054b565b348015610227575f80fd5b506101cf610236366004610f78565b61055b565b348015610246575f80fd5b5060405160ff7f00000000000000000000000000000000000000000000000000000000000000001681526020016101a7565b348015610283575f80fd5b506101ef7f000000000000000000000000000000000000000000000000000000000000000081565b3480156102b6575f80fd5b506101cf6102c5366004610f50565b61057e565b3480156102d5575f80fd5b5061018061014a366004610f50565b3480156102ef575f80fd5b506101806102fe366004610f78565b61059f565b34801561030e575f80fd5b506101ef61031d366004610fb1565b6001600160a01b03165f9081526020819052604090205490565b348015610342575f80fd5b506101ef610351366004610fb1565b60056020525f908152604090205481565b34801561036d575f80fd5b5061019a6105aa565b348015610381575f80fd5b50610180610390366004610f50565b6105b9565b3480156103a0575f80fd5b506101cf6103af366004610f50565b6105c3565b3480156103bf575f80fd5b506101cf6103ce366004610f50565b61063d565b3480156103de575f80fd5b506101806103ed366004610fca565b61064a565b3480156103fd575f80fd5b506101ef61040c366004611037565b61087d565b61041b82826108a7565b5050565b60606003805461042e90611068565b80601f016020809104026020016040519081016040528092919081815260200182805461045a90611068565b80156104a55780601f1061047c576101008083540402835291602001916104a5565b820191905f5260205f20905b81548152906001019060200180831161048857829003601f168201915b5050505050905090565b5f338215806104c557506104c3818561087d565b155b6105345760405162461bcd60e51b815260206004820152603560248201527f617070726f766520616c6c6f77616e6365206d7573742062652073657420746f604482015274207a65726f206265666f7265207570646174696e6760581b60648201526084015b60405180910390fd5b61053f818585610964565b60019150505b92915050565b610556838383610a87565b505050565b5f33610568858285610c2b565b610573858585610a87565b506001949350505050565b5f3361053f818585610590838361087d565b61059a91906110a0565b610964565b610556838383610964565b60606004805461042e90611068565b61041b8282610c9d565b5f33816105d0828661087d565b9050838110156106305760405162461bcd60e51b815260206004820152602560248201527f45524332303a2064656372656173656420616c6c6f77616e63652062656c6f77604482015264207a65726f60d81b606482015260840161052b565b6105738286868403610964565b5f3361053f818585610a87565b8342111561069a5760405162461bcd60e51b815260206004820152601760248201527f7065726d697420646561646c696e652065787069726564000000000000000000604482015260640161052b565b6001600160a01b0387165f90815260056020526040812080547f6e71edae12b1b97f4d1f60370fef10105fa2faae0126114a169c64845d6126c9918a918a918a9190866106e6836110b3565b909155506040805160208101969096526001600160a01b0394851690860152929091166060840152608083015260a082015260c0810186905260e00160408051601f1981840301815290829052805160209182012061190160f01b918301919091527f000000000000000000000000000000000000000000000000000000000000000060228301526042820181905291505f9060620160408051601f1981840301815282825280516020918201205f80855291840180845281905260ff89169284019290925260608301879052608083018690529092509060019060a0016020604051602081039080840390855afa1580156107e4573d5f803e3d5ffd5b5050604051601f1901519150506001600160a01b0381161580159061081a5750896001600160a01b0316816001600160a01b0316145b6108665760405162461bcd60e51b815260206004820152601860248201527f696e76616c6964207065726d6974207369676e61747572650000000000000000604482015260640161052b565b6108718a8a8a610964565b50505050505050505050565b6001600160a01b039182165f90815260016020908152604080832093909416825291909152205490565b6001600160a01b0382166108fd5760405162461bcd60e51b815260206004820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f206164647265737300604482015260640161052b565b8060025f82825461090e91906110a0565b90915550506001600160a01b0382165f81815260208181526040808320805486019055518481527fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35050565b6001600160a01b0383166109c65760405162461bcd60e51b8152602060048201526024808201527f45524332303a20617070726f76652066726f6d20746865207a65726f206164646044820152637265737360e01b606482015260840161052b565b6001600160a01b038216610a275760405162461bcd60e51b815260206004820152602260248201527f45524332303a20617070726f766520746f20746865207a65726f206164647265604482015261737360f01b606482015260840161052b565b6001600160a01b038381165f8181526001602090815260408083209487168084529482529182902085905590518481527f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a3505050565b6001600160a01b038316610aeb5760405162461bcd60e51b815260206004820152602560248201527f45524332303a207472616e736665722066726f6d20746865207a65726f206164604482015264647265737360d81b606482015260840161052b565b6001600160a01b038216610b4d5760405162461bcd60e51b815260206004820152602360248201527f45524332303a207472616e7366657220746f20746865207a65726f206164647260448201526265737360e81b606482015260840161052b565b6001600160a01b0383165f9081526020819052604090205481811015610bc45760405162461bcd60e51b815260206004820152602660248201527f45524332303a207472616e7366657220616d6f756e7420657863656564732062604482015265616c616e636560d01b606482015260840161052b565b6001600160a01b038481165f81815260208181526040808320878703905593871680835291849020805487019055925185815290927fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35b50505050565b5f610c36848461087d565b90505f198114610c255781811015610c905760405162461bcd60e51b815260206004820152601d60248201527f45524332303a20696e73756666696369656e7420616c6c6f77616e6365000000604482015260640161052b565b610c258484848403610964565b6001600160a01b038216610cfd5760405162461bcd60e51b815260206004820152602160248201527f45524332303a206275726e2066726f6d20746865207a65726f206164647265736044820152607360f81b606482015260840161052b565b6001600160a01b0382165f9081526020819052604090205481811015610d705760405162461bcd60e51b815260206004820152602260248201527f45524332303a206275726e20616d6f756e7420657863656564732062616c616e604482015261636560f01b606482015260840161052b565b6001600160a01b0383165f818152602081815260408083208686039055600280548790039055518581529192917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a3505050565b634e487b7160e01b5f52601160045260245ffd5b600181815b80851115610e1b57815f1904821115610e0157610e01610dcd565b80851615610e0e57918102915b93841c9390800290610de6565b509250929050565b5f82610e3157506001610545565b81610e3d57505f610545565b8160018114610e535760028114610e5d57610e79565b6001915050610545565b60ff841115610e6e57610e6e610dcd565b50506001821b610545565b5060208310610133831016604e8410600b8410161715610e9c575081810a610545565b610ea68383610de1565b805f1904821115610eb957610eb9610dcd565b029392505050565b5f610ecc8383610e23565b9392505050565b808202811582820484141761054557610545610dcd565b5f6020808352835180828501525f5b81811015610f1557858101830151858201604001528201610ef9565b505f604082860101526040601f19601f8301168501019250505092915050565b80356001600160a01b0381168114610f4b575f80fd5b919050565b5f8060408385031215610f61575f80fd5b610f6a83610f35565b946020939093013593505050565b5f805f60608486031215610f8a575f80fd5b610f9384610f35565b9250610fa160208501610f35565b9150604084013590509250925092565b5f60208284031215610fc1575f80fd5b610ecc82610f35565b5f805f805f805f60e0888a031215610fe0575f80fd5b610fe988610f35565b9650610ff760208901610f35565b95506040880135945060608801359350608088013560ff8116811461101a575f80fd5b9699959850939692959460a0840135945060c09093013592915050565b5f8060408385031215611048575f80fd5b61105183610f35565b915061105f60208401610f35565b90509250929050565b600181811c9082168061107c57607f821691505b60208210810361109a57634e487b7160e01b5f52602260045260245ffd5b50919050565b8082018082111561054557610545610dcd565b5f600182016110c4576110c4610dcd565b506001019056fea2646970667358221220cff73310674cb3f233a2683971279a8655a330e6869fe2a0d3d25cfc45e632b264736f6c63430008150033",
+}
+
+// TestERC20PermitABI is the input ABI used to generate the binding from.
+// Deprecated: Use TestERC20PermitMetaData.ABI instead.
+var TestERC20PermitABI = TestERC20PermitMetaData.ABI
+
+// TestERC20PermitBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use TestERC20PermitMetaData.Bin instead.
+var TestERC20PermitBin = TestERC20PermitMetaData.Bin
+
+// DeployTestERC20Permit deploys a new Ethereum contract, binding an instance of TestERC20Permit to it.
+func DeployTestERC20Permit(auth *bind.TransactOpts, backend bind.ContractBackend, name string, symbol string, numDecimals uint8, initialAccount common.Address, initialBalance *big.Int) (common.Address, *types.Transaction, *TestERC20Permit, error) {
+	parsed, err := TestERC20PermitMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(TestERC20PermitBin), backend, name, symbol, numDecimals, initialAccount, initialBalance)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &TestERC20Permit{TestERC20PermitCaller: TestERC20PermitCaller{contract: contract}, TestERC20PermitTransactor: TestERC20PermitTransactor{contract: contract}, TestERC20PermitFilterer: TestERC20PermitFilterer{contract: contract}}, nil
+}
+
+// TestERC20Permit is an auto generated Go binding around an Ethereum contract.
+type TestERC20Permit struct {
+	TestERC20PermitCaller     // Read-only binding to the contract
+	TestERC20PermitTransactor // Write-only binding to the contract
+	TestERC20PermitFilterer   // Log filterer for contract events
+}
+
+// TestERC20PermitCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TestERC20PermitCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestERC20PermitTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TestERC20PermitTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestERC20PermitFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TestERC20PermitFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestERC20PermitSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TestERC20PermitSession struct {
+	Contract     *TestERC20Permit  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// TestERC20PermitCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TestERC20PermitCallerSession struct {
+	Contract *TestERC20PermitCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// TestERC20PermitTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TestERC20PermitTransactorSession struct {
+	Contract     *TestERC20PermitTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// TestERC20PermitRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TestERC20PermitRaw struct {
+	Contract *TestERC20Permit // Generic contract binding to access the raw methods on
+}
+
+// TestERC20PermitCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TestERC20PermitCallerRaw struct {
+	Contract *TestERC20PermitCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TestERC20PermitTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TestERC20PermitTransactorRaw struct {
+	Contract *TestERC20PermitTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTestERC20Permit creates a new instance of TestERC20Permit, bound to a specific deployed contract.
+func NewTestERC20Permit(address common.Address, backend bind.ContractBackend) (*TestERC20Permit, error) {
+	contract, err := bindTestERC20Permit(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20Permit{TestERC20PermitCaller: TestERC20PermitCaller{contract: contract}, TestERC20PermitTransactor: TestERC20PermitTransactor{contract: contract}, TestERC20PermitFilterer: TestERC20PermitFilterer{contract: contract}}, nil
+}
+
+// NewTestERC20PermitCaller creates a new read-only instance of TestERC20Permit, bound to a specific deployed contract.
+func NewTestERC20PermitCaller(address common.Address, caller bind.ContractCaller) (*TestERC20PermitCaller, error) {
+	contract, err := bindTestERC20Permit(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20PermitCaller{contract: contract}, nil
+}
+
+// NewTestERC20PermitTransactor creates a new write-only instance of TestERC20Permit, bound to a specific deployed contract.
+func NewTestERC20PermitTransactor(address common.Address, transactor bind.ContractTransactor) (*TestERC20PermitTransactor, error) {
+	contract, err := bindTestERC20Permit(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20PermitTransactor{contract: contract}, nil
+}
+
+// NewTestERC20PermitFilterer creates a new log filterer instance of TestERC20Permit, bound to a specific deployed contract.
+func NewTestERC20PermitFilterer(address common.Address, filterer bind.ContractFilterer) (*TestERC20PermitFilterer, error) {
+	contract, err := bindTestERC20Permit(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20PermitFilterer{contract: contract}, nil
+}
+
+// bindTestERC20Permit binds a generic wrapper to an already deployed contract.
+func bindTestERC20Permit(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TestERC20PermitMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TestERC20Permit *TestERC20PermitRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TestERC20Permit.Contract.TestERC20PermitCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TestERC20Permit *TestERC20PermitRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.TestERC20PermitTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TestERC20Permit *TestERC20PermitRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.TestERC20PermitTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TestERC20Permit *TestERC20PermitCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TestERC20Permit.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TestERC20Permit *TestERC20PermitTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TestERC20Permit *TestERC20PermitTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.contract.Transact(opts, method, params...)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_TestERC20Permit *TestERC20PermitCaller) DOMAINSEPARATOR(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "DOMAIN_SEPARATOR")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_TestERC20Permit *TestERC20PermitSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _TestERC20Permit.Contract.DOMAINSEPARATOR(&_TestERC20Permit.CallOpts)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_TestERC20Permit *TestERC20PermitCallerSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _TestERC20Permit.Contract.DOMAINSEPARATOR(&_TestERC20Permit.CallOpts)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCaller) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "allowance", owner, spender)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _TestERC20Permit.Contract.Allowance(&_TestERC20Permit.CallOpts, owner, spender)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCallerSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _TestERC20Permit.Contract.Allowance(&_TestERC20Permit.CallOpts, owner, spender)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCaller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _TestERC20Permit.Contract.BalanceOf(&_TestERC20Permit.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _TestERC20Permit.Contract.BalanceOf(&_TestERC20Permit.CallOpts, account)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TestERC20Permit *TestERC20PermitCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TestERC20Permit *TestERC20PermitSession) Decimals() (uint8, error) {
+	return _TestERC20Permit.Contract.Decimals(&_TestERC20Permit.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TestERC20Permit *TestERC20PermitCallerSession) Decimals() (uint8, error) {
+	return _TestERC20Permit.Contract.Decimals(&_TestERC20Permit.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TestERC20Permit *TestERC20PermitCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TestERC20Permit *TestERC20PermitSession) Name() (string, error) {
+	return _TestERC20Permit.Contract.Name(&_TestERC20Permit.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TestERC20Permit *TestERC20PermitCallerSession) Name() (string, error) {
+	return _TestERC20Permit.Contract.Name(&_TestERC20Permit.CallOpts)
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address ) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCaller) Nonces(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "nonces", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address ) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitSession) Nonces(arg0 common.Address) (*big.Int, error) {
+	return _TestERC20Permit.Contract.Nonces(&_TestERC20Permit.CallOpts, arg0)
+}
+
+// Nonces is a free data retrieval call binding the contract method 0x7ecebe00.
+//
+// Solidity: function nonces(address ) view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCallerSession) Nonces(arg0 common.Address) (*big.Int, error) {
+	return _TestERC20Permit.Contract.Nonces(&_TestERC20Permit.CallOpts, arg0)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TestERC20Permit *TestERC20PermitCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TestERC20Permit *TestERC20PermitSession) Symbol() (string, error) {
+	return _TestERC20Permit.Contract.Symbol(&_TestERC20Permit.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TestERC20Permit *TestERC20PermitCallerSession) Symbol() (string, error) {
+	return _TestERC20Permit.Contract.Symbol(&_TestERC20Permit.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TestERC20Permit.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitSession) TotalSupply() (*big.Int, error) {
+	return _TestERC20Permit.Contract.TotalSupply(&_TestERC20Permit.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TestERC20Permit *TestERC20PermitCallerSession) TotalSupply() (*big.Int, error) {
+	return _TestERC20Permit.Contract.TotalSupply(&_TestERC20Permit.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Approve(&_TestERC20Permit.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Approve(&_TestERC20Permit.TransactOpts, spender, amount)
+}
+
+// ApproveInternal is a paid mutator transaction binding the contract method 0x56189cb4.
+//
+// Solidity: function approveInternal(address owner, address spender, uint256 value) returns()
+func (_TestERC20Permit *TestERC20PermitTransactor) ApproveInternal(opts *bind.TransactOpts, owner common.Address, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "approveInternal", owner, spender, value)
+}
+
+// ApproveInternal is a paid mutator transaction binding the contract method 0x56189cb4.
+//
+// Solidity: function approveInternal(address owner, address spender, uint256 value) returns()
+func (_TestERC20Permit *TestERC20PermitSession) ApproveInternal(owner common.Address, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.ApproveInternal(&_TestERC20Permit.TransactOpts, owner, spender, value)
+}
+
+// ApproveInternal is a paid mutator transaction binding the contract method 0x56189cb4.
+//
+// Solidity: function approveInternal(address owner, address spender, uint256 value) returns()
+func (_TestERC20Permit *TestERC20PermitTransactorSession) ApproveInternal(owner common.Address, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.ApproveInternal(&_TestERC20Permit.TransactOpts, owner, spender, value)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address account, uint256 amount) returns()
+func (_TestERC20Permit *TestERC20PermitTransactor) Burn(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "burn", account, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address account, uint256 amount) returns()
+func (_TestERC20Permit *TestERC20PermitSession) Burn(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Burn(&_TestERC20Permit.TransactOpts, account, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address account, uint256 amount) returns()
+func (_TestERC20Permit *TestERC20PermitTransactorSession) Burn(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Burn(&_TestERC20Permit.TransactOpts, account, amount)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactor) DecreaseAllowance(opts *bind.TransactOpts, spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "decreaseAllowance", spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_TestERC20Permit *TestERC20PermitSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.DecreaseAllowance(&_TestERC20Permit.TransactOpts, spender, subtractedValue)
+}
+
+// DecreaseAllowance is a paid mutator transaction binding the contract method 0xa457c2d7.
+//
+// Solidity: function decreaseAllowance(address spender, uint256 subtractedValue) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactorSession) DecreaseAllowance(spender common.Address, subtractedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.DecreaseAllowance(&_TestERC20Permit.TransactOpts, spender, subtractedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactor) IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "increaseAllowance", spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_TestERC20Permit *TestERC20PermitSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.IncreaseAllowance(&_TestERC20Permit.TransactOpts, spender, addedValue)
+}
+
+// IncreaseAllowance is a paid mutator transaction binding the contract method 0x39509351.
+//
+// Solidity: function increaseAllowance(address spender, uint256 addedValue) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactorSession) IncreaseAllowance(spender common.Address, addedValue *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.IncreaseAllowance(&_TestERC20Permit.TransactOpts, spender, addedValue)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address account, uint256 amount) returns()
+func (_TestERC20Permit *TestERC20PermitTransactor) Mint(opts *bind.TransactOpts, account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "mint", account, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address account, uint256 amount) returns()
+func (_TestERC20Permit *TestERC20PermitSession) Mint(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Mint(&_TestERC20Permit.TransactOpts, account, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address account, uint256 amount) returns()
+func (_TestERC20Permit *TestERC20PermitTransactorSession) Mint(account common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Mint(&_TestERC20Permit.TransactOpts, account, amount)
+}
+
+// Permit is a paid mutator transaction binding the contract method 0xd505accf.
+//
+// Solidity: function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_TestERC20Permit *TestERC20PermitTransactor) Permit(opts *bind.TransactOpts, owner common.Address, spender common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "permit", owner, spender, value, deadline, v, r, s)
+}
+
+// Permit is a paid mutator transaction binding the contract method 0xd505accf.
+//
+// Solidity: function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_TestERC20Permit *TestERC20PermitSession) Permit(owner common.Address, spender common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Permit(&_TestERC20Permit.TransactOpts, owner, spender, value, deadline, v, r, s)
+}
+
+// Permit is a paid mutator transaction binding the contract method 0xd505accf.
+//
+// Solidity: function permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) returns()
+func (_TestERC20Permit *TestERC20PermitTransactorSession) Permit(owner common.Address, spender common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Permit(&_TestERC20Permit.TransactOpts, owner, spender, value, deadline, v, r, s)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactor) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "transfer", to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Transfer(&_TestERC20Permit.TransactOpts, to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactorSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Transfer(&_TestERC20Permit.TransactOpts, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "transferFrom", from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.TransferFrom(&_TestERC20Permit.TransactOpts, from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_TestERC20Permit *TestERC20PermitTransactorSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.TransferFrom(&_TestERC20Permit.TransactOpts, from, to, amount)
+}
+
+// TransferInternal is a paid mutator transaction binding the contract method 0x222f5be0.
+//
+// Solidity: function transferInternal(address from, address to, uint256 value) returns()
+func (_TestERC20Permit *TestERC20PermitTransactor) TransferInternal(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.Transact(opts, "transferInternal", from, to, value)
+}
+
+// TransferInternal is a paid mutator transaction binding the contract method 0x222f5be0.
+//
+// Solidity: function transferInternal(address from, address to, uint256 value) returns()
+func (_TestERC20Permit *TestERC20PermitSession) TransferInternal(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.TransferInternal(&_TestERC20Permit.TransactOpts, from, to, value)
+}
+
+// TransferInternal is a paid mutator transaction binding the contract method 0x222f5be0.
+//
+// Solidity: function transferInternal(address from, address to, uint256 value) returns()
+func (_TestERC20Permit *TestERC20PermitTransactorSession) TransferInternal(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.TransferInternal(&_TestERC20Permit.TransactOpts, from, to, value)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestERC20Permit *TestERC20PermitTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestERC20Permit.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestERC20Permit *TestERC20PermitSession) Receive() (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Receive(&_TestERC20Permit.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestERC20Permit *TestERC20PermitTransactorSession) Receive() (*types.Transaction, error) {
+	return _TestERC20Permit.Contract.Receive(&_TestERC20Permit.TransactOpts)
+}
+
+// TestERC20PermitApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the TestERC20Permit contract.
+type TestERC20PermitApprovalIterator struct {
+	Event *TestERC20PermitApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TestERC20PermitApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TestERC20PermitApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TestERC20PermitApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TestERC20PermitApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TestERC20PermitApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TestERC20PermitApproval represents a Approval event raised by the TestERC20Permit contract.
+type TestERC20PermitApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TestERC20Permit *TestERC20PermitFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*TestERC20PermitApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _TestERC20Permit.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20PermitApprovalIterator{contract: _TestERC20Permit.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TestERC20Permit *TestERC20PermitFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *TestERC20PermitApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _TestERC20Permit.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TestERC20PermitApproval)
+				if err := _TestERC20Permit.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TestERC20Permit *TestERC20PermitFilterer) ParseApproval(log types.Log) (*TestERC20PermitApproval, error) {
+	event := new(TestERC20PermitApproval)
+	if err := _TestERC20Permit.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TestERC20PermitTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the TestERC20Permit contract.
+type TestERC20PermitTransferIterator struct {
+	Event *TestERC20PermitTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TestERC20PermitTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TestERC20PermitTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TestERC20PermitTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TestERC20PermitTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TestERC20PermitTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TestERC20PermitTransfer represents a Transfer event raised by the TestERC20Permit contract.
+type TestERC20PermitTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TestERC20Permit *TestERC20PermitFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*TestERC20PermitTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _TestERC20Permit.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TestERC20PermitTransferIterator{contract: _TestERC20Permit.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TestERC20Permit *TestERC20PermitFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *TestERC20PermitTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _TestERC20Permit.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TestERC20PermitTransfer)
+				if err := _TestERC20Permit.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TestERC20Permit *TestERC20PermitFilterer) ParseTransfer(log types.Log) (*TestERC20PermitTransfer, error) {
+	event := new(TestERC20PermitTransfer)
+	if err := _TestERC20Permit.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,224 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ERC20TransferProbeMetaData contains all meta data concerning the ERC20TransferProbe contract.
+var ERC20TransferProbeMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"contractIERC20\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"probeTransfer\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"fromBefore\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"fromAfter\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"toBefore\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"toAfter\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561000f575f80fd5b506104178061001d5f395ff3fe608060405234801561000f575f80fd5b5060043610610029575f3560e01c8063d48dba971461002d575b5f80fd5b61004061003b36600461033a565b610064565b60408051948552602085019390935291830152606082015260800160405180910390f35b6040516370a0823160e01b81523060048201525f908190819081906001600160a01b038816906370a0823190602401602060405180830381865afa1580156100ae573d5f803e3d5ffd5b505050506040513d601f19601f820116820180604052508101906100d29190610378565b6040516370a0823160e01b81526001600160a01b038881166004830152919550908816906370a0823190602401602060405180830381865afa15801561011a573d5f803e3d5ffd5b505050506040513d601f19601f8201168201806040525081019061013e9190610378565b604080516001600160a01b03898116602483015260448083018a905283518084039091018152606490920183526020820180516001600160e01b031663a9059cbb60e01b17905291519294505f9283928b169161019a9161038f565b5f604051808303815f865af19150503d805f81146101d3576040519150601f19603f3d011682016040523d82523d5f602084013e6101d8565b606091505b509150915081801561020257508051158061020257508080602001905181019061020291906103bb565b6102445760405162461bcd60e51b815260206004820152600f60248201526e1d1c985b9cd9995c8819985a5b1959608a1b604482015260640160405180910390fd5b6040516370a0823160e01b81523060048201526001600160a01b038a16906370a0823190602401602060405180830381865afa158015610286573d5f803e3d5ffd5b505050506040513d601f19601f820116820180604052508101906102aa9190610378565b6040516370a0823160e01b81526001600160a01b038a81166004830152919650908a16906370a0823190602401602060405180830381865afa1580156102f2573d5f803e3d5ffd5b505050506040513d601f19601f820116820180604052508101906103169190610378565b9250505093509350935093565b6001600160a01b0381168114610337575f80fd5b50565b5f805f6060848603121561034c575f80fd5b833561035781610323565b9250602084013561036781610323565b929592945050506040919091013590565b5f60208284031215610388575f80fd5b5051919050565b5f82515f5b818110156103ae5760208186018101518583015201610394565b505f920191825250919050565b5f602082840312156103cb575f80fd5b815180151581146103da575f80fd5b939250505056fea2646970667358221220e0830f21ef111d451a7e70391473691796bcb88bb3d381b85b2f5142688c407064736f6c63430008150033",
+}
+
+// ERC20TransferProbeABI is the input ABI used to generate the binding from.
+// Deprecated: Use ERC20TransferProbeMetaData.ABI instead.
+var ERC20TransferProbeABI = ERC20TransferProbeMetaData.ABI
+
+// ERC20TransferProbeBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use ERC20TransferProbeMetaData.Bin instead.
+var ERC20TransferProbeBin = ERC20TransferProbeMetaData.Bin
+
+// DeployERC20TransferProbe deploys a new Ethereum contract, binding an instance of ERC20TransferProbe to it.
+func DeployERC20TransferProbe(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *ERC20TransferProbe, error) {
+	parsed, err := ERC20TransferProbeMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(ERC20TransferProbeBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &ERC20TransferProbe{ERC20TransferProbeCaller: ERC20TransferProbeCaller{contract: contract}, ERC20TransferProbeTransactor: ERC20TransferProbeTransactor{contract: contract}, ERC20TransferProbeFilterer: ERC20TransferProbeFilterer{contract: contract}}, nil
+}
+
+// ERC20TransferProbe is an auto generated Go binding around an Ethereum contract.
+type ERC20TransferProbe struct {
+	ERC20TransferProbeCaller     // Read-only binding to the contract
+	ERC20TransferProbeTransactor // Write-only binding to the contract
+	ERC20TransferProbeFilterer   // Log filterer for contract events
+}
+
+// ERC20TransferProbeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ERC20TransferProbeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC20TransferProbeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ERC20TransferProbeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC20TransferProbeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ERC20TransferProbeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC20TransferProbeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ERC20TransferProbeSession struct {
+	Contract     *ERC20TransferProbe // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts       // Call options to use throughout this session
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// ERC20TransferProbeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ERC20TransferProbeCallerSession struct {
+	Contract *ERC20TransferProbeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts             // Call options to use throughout this session
+}
+
+// ERC20TransferProbeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ERC20TransferProbeTransactorSession struct {
+	Contract     *ERC20TransferProbeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts             // Transaction auth options to use throughout this session
+}
+
+// ERC20TransferProbeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ERC20TransferProbeRaw struct {
+	Contract *ERC20TransferProbe // Generic contract binding to access the raw methods on
+}
+
+// ERC20TransferProbeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ERC20TransferProbeCallerRaw struct {
+	Contract *ERC20TransferProbeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ERC20TransferProbeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ERC20TransferProbeTransactorRaw struct {
+	Contract *ERC20TransferProbeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewERC20TransferProbe creates a new instance of ERC20TransferProbe, bound to a specific deployed contract.
+func NewERC20TransferProbe(address common.Address, backend bind.ContractBackend) (*ERC20TransferProbe, error) {
+	contract, err := bindERC20TransferProbe(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20TransferProbe{ERC20TransferProbeCaller: ERC20TransferProbeCaller{contract: contract}, ERC20TransferProbeTransactor: ERC20TransferProbeTransactor{contract: contract}, ERC20TransferProbeFilterer: ERC20TransferProbeFilterer{contract: contract}}, nil
+}
+
+// NewERC20TransferProbeCaller creates a new read-only instance of ERC20TransferProbe, bound to a specific deployed contract.
+func NewERC20TransferProbeCaller(address common.Address, caller bind.ContractCaller) (*ERC20TransferProbeCaller, error) {
+	contract, err := bindERC20TransferProbe(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20TransferProbeCaller{contract: contract}, nil
+}
+
+// NewERC20TransferProbeTransactor creates a new write-only instance of ERC20TransferProbe, bound to a specific deployed contract.
+func NewERC20TransferProbeTransactor(address common.Address, transactor bind.ContractTransactor) (*ERC20TransferProbeTransactor, error) {
+	contract, err := bindERC20TransferProbe(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20TransferProbeTransactor{contract: contract}, nil
+}
+
+// NewERC20TransferProbeFilterer creates a new log filterer instance of ERC20TransferProbe, bound to a specific deployed contract.
+func NewERC20TransferProbeFilterer(address common.Address, filterer bind.ContractFilterer) (*ERC20TransferProbeFilterer, error) {
+	contract, err := bindERC20TransferProbe(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20TransferProbeFilterer{contract: contract}, nil
+}
+
+// bindERC20TransferProbe binds a generic wrapper to an already deployed contract.
+func bindERC20TransferProbe(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ERC20TransferProbeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC20TransferProbe *ERC20TransferProbeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC20TransferProbe.Contract.ERC20TransferProbeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC20TransferProbe *ERC20TransferProbeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC20TransferProbe.Contract.ERC20TransferProbeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC20TransferProbe *ERC20TransferProbeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC20TransferProbe.Contract.ERC20TransferProbeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC20TransferProbe *ERC20TransferProbeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC20TransferProbe.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC20TransferProbe *ERC20TransferProbeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC20TransferProbe.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC20TransferProbe *ERC20TransferProbeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC20TransferProbe.Contract.contract.Transact(opts, method, params...)
+}
+
+// ProbeTransfer is a paid mutator transaction binding the contract method 0xd48dba97.
+//
+// Solidity: function probeTransfer(address token, address to, uint256 amount) returns(uint256 fromBefore, uint256 fromAfter, uint256 toBefore, uint256 toAfter)
+func (_ERC20TransferProbe *ERC20TransferProbeTransactor) ProbeTransfer(opts *bind.TransactOpts, token common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20TransferProbe.contract.Transact(opts, "probeTransfer", token, to, amount)
+}
+
+// ProbeTransfer is a paid mutator transaction binding the contract method 0xd48dba97.
+//
+// Solidity: function probeTransfer(address token, address to, uint256 amount) returns(uint256 fromBefore, uint256 fromAfter, uint256 toBefore, uint256 toAfter)
+func (_ERC20TransferProbe *ERC20TransferProbeSession) ProbeTransfer(token common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20TransferProbe.Contract.ProbeTransfer(&_ERC20TransferProbe.TransactOpts, token, to, amount)
+}
+
+// ProbeTransfer is a paid mutator transaction binding the contract method 0xd48dba97.
+//
+// Solidity: function probeTransfer(address token, address to, uint256 amount) returns(uint256 fromBefore, uint256 fromAfter, uint256 toBefore, uint256 toAfter)
+func (_ERC20TransferProbe *ERC20TransferProbeTransactorSession) ProbeTransfer(token common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20TransferProbe.Contract.ProbeTransfer(&_ERC20TransferProbe.TransactOpts, token, to, amount)
+}
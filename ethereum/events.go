@@ -0,0 +1,230 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+)
+
+// Names of the SwapCreator events of a swap
+const (
+	EventNew      = "New"
+	EventReady    = "Ready"
+	EventClaimed  = "Claimed"
+	EventRefunded = "Refunded"
+)
+
+// eventsBlockWindow is the number of blocks that GetSwapLifecycles requests the
+// events of at a time. It is a variable so that tests can shrink it.
+var eventsBlockWindow uint64 = 5000
+
+// SwapEvent is a decoded SwapCreator event of a swap.
+type SwapEvent struct {
+	Name        string
+	BlockNumber uint64
+	TxHash      ethcommon.Hash
+	LogIndex    uint
+	Secret      *types.Hash // only set for Claimed and Refunded events
+}
+
+// SwapLifecycle is the lifecycle of a swap, joined by swap ID from the
+// SwapCreator events that were found in a block range.
+type SwapLifecycle struct {
+	SwapID types.Hash
+	// New is the swap's New event, which is nil if the swap was created before
+	// the scanned block range.
+	New    *SwapCreatorNew
+	Events []*SwapEvent // ordered by block and log index
+	Stage  byte         // the current stage of the swap in the contract
+	// Expired is set when the swap is still pending or ready after its
+	// timeout2, so only its owner can do anything with it, by refunding it.
+	Expired bool
+}
+
+// GetSwapLifecycles scans the SwapCreator contract at the passed address for
+// the events of its swaps between fromBlock and toBlock inclusive, and joins
+// them by swap ID. The range is requested in windows of eventsBlockWindow
+// blocks, as endpoints limit the block range or the number of logs of a single
+// eth_getLogs request. The swaps are ordered by their first event in the range.
+// The stage of each swap is queried from the contract, at the latest block, so
+// a stage can be later than the swap's last event in the range.
+func GetSwapLifecycles(
+	ctx context.Context,
+	ec *ethclient.Client,
+	swapCreatorAddr ethcommon.Address,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]*SwapLifecycle, error) {
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("from block %d is after to block %d", fromBlock, toBlock)
+	}
+
+	swapCreator, err := NewSwapCreator(swapCreatorAddr, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycles := make(map[types.Hash]*SwapLifecycle)
+	addEvent := func(swapID [32]byte, name string, log *ethtypes.Log, secret *types.Hash) *SwapLifecycle {
+		lc, ok := lifecycles[swapID]
+		if !ok {
+			lc = &SwapLifecycle{SwapID: swapID}
+			lifecycles[swapID] = lc
+		}
+		lc.Events = append(lc.Events, &SwapEvent{
+			Name:        name,
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+			LogIndex:    log.Index,
+			Secret:      secret,
+		})
+		return lc
+	}
+
+	start := fromBlock
+	for {
+		end := start + eventsBlockWindow - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+
+		opts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+		if err = filterSwapEvents(swapCreator, opts, addEvent); err != nil {
+			return nil, fmt.Errorf("blocks %d-%d: %w", start, end, err)
+		}
+
+		if end == toBlock {
+			break
+		}
+		start = end + 1
+	}
+
+	latest, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Unix(int64(latest.Time), 0)
+	callOpts := &bind.CallOpts{Context: ctx, BlockNumber: latest.Number}
+
+	result := make([]*SwapLifecycle, 0, len(lifecycles))
+	for _, lc := range lifecycles {
+		lc.Stage, err = swapCreator.Swaps(callOpts, lc.SwapID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stage of swap %s: %w", lc.SwapID, err)
+		}
+		lc.Expired = lc.isExpired(now)
+		sortSwapEvents(lc.Events)
+		result = append(result, lc)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return swapEventLess(result[i].Events[0], result[j].Events[0])
+	})
+
+	return result, nil
+}
+
+// filterSwapEvents passes the New, Ready, Claimed and Refunded events of the
+// SwapCreator contract in the block range of opts to addEvent.
+func filterSwapEvents(
+	swapCreator *SwapCreator,
+	opts *bind.FilterOpts,
+	addEvent func(swapID [32]byte, name string, log *ethtypes.Log, secret *types.Hash) *SwapLifecycle,
+) error {
+	newIter, err := swapCreator.FilterNew(opts)
+	if err != nil {
+		return err
+	}
+	for newIter.Next() {
+		ev := newIter.Event
+		addEvent(ev.SwapID, EventNew, &ev.Raw, nil).New = ev
+	}
+	if err = closeIterator(newIter.Error(), newIter.Close()); err != nil {
+		return fmt.Errorf("failed to filter %s events: %w", EventNew, err)
+	}
+
+	readyIter, err := swapCreator.FilterReady(opts, nil)
+	if err != nil {
+		return err
+	}
+	for readyIter.Next() {
+		ev := readyIter.Event
+		addEvent(ev.SwapID, EventReady, &ev.Raw, nil)
+	}
+	if err = closeIterator(readyIter.Error(), readyIter.Close()); err != nil {
+		return fmt.Errorf("failed to filter %s events: %w", EventReady, err)
+	}
+
+	claimedIter, err := swapCreator.FilterClaimed(opts, nil, nil)
+	if err != nil {
+		return err
+	}
+	for claimedIter.Next() {
+		ev := claimedIter.Event
+		secret := types.Hash(ev.S)
+		addEvent(ev.SwapID, EventClaimed, &ev.Raw, &secret)
+	}
+	if err = closeIterator(claimedIter.Error(), claimedIter.Close()); err != nil {
+		return fmt.Errorf("failed to filter %s events: %w", EventClaimed, err)
+	}
+
+	refundedIter, err := swapCreator.FilterRefunded(opts, nil, nil)
+	if err != nil {
+		return err
+	}
+	for refundedIter.Next() {
+		ev := refundedIter.Event
+		secret := types.Hash(ev.S)
+		addEvent(ev.SwapID, EventRefunded, &ev.Raw, &secret)
+	}
+	if err = closeIterator(refundedIter.Error(), refundedIter.Close()); err != nil {
+		return fmt.Errorf("failed to filter %s events: %w", EventRefunded, err)
+	}
+
+	return nil
+}
+
+// isExpired returns true if the swap is still pending or ready at the passed
+// block time, which is after its timeout2. It is false if the swap's timeout2
+// is unknown, because its New event was not found.
+func (lc *SwapLifecycle) isExpired(blockTime time.Time) bool {
+	if lc.New == nil || (lc.Stage != StagePending && lc.Stage != StageReady) {
+		return false
+	}
+
+	return big.NewInt(blockTime.Unix()).Cmp(lc.New.Timeout2) >= 0
+}
+
+func sortSwapEvents(events []*SwapEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return swapEventLess(events[i], events[j])
+	})
+}
+
+func swapEventLess(a, b *SwapEvent) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber < b.BlockNumber
+	}
+	return a.LogIndex < b.LogIndex
+}
+
+// closeIterator returns the error of an event iterator or, if there was none,
+// the error from closing it.
+func closeIterator(iterErr error, closeErr error) error {
+	if iterErr != nil {
+		return iterErr
+	}
+	return closeErr
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/dleq"
+	"github.com/athanorlabs/atomic-swap/tests"
+)
+
+func TestGetSwapLifecycles(t *testing.T) {
+	ctx := context.Background()
+	pk := tests.GetTakerTestKey(t)
+	ec, _ := tests.NewEthClient(t)
+	addr := crypto.PubkeyToAddress(pk.PublicKey)
+	swapCreatorAddr, swapCreator := DevDeploySwapCreator(t, ec, pk)
+
+	startBlock, err := ec.BlockNumber(ctx)
+	require.NoError(t, err)
+
+	// create a swap that we set ready and one that we refund
+	newTestSwap := func(refundCommitment [32]byte) (types.Hash, *SwapCreatorSwap) {
+		txOpts := getAuth(t, pk)
+		txOpts.Value = defaultSwapValue
+		nonce := GenerateNewSwapNonce()
+		tx, err := swapCreator.NewSwap(txOpts, dummySwapKey, refundCommitment, addr, //nolint:govet
+			defaultTimeoutDuration, defaultTimeoutDuration, types.EthAssetETH.Address(), defaultSwapValue, nonce)
+		require.NoError(t, err)
+		receipt := getReceipt(t, ec, tx)
+
+		id, err := GetIDFromLog(receipt.Logs[0])
+		require.NoError(t, err)
+		t1, t2, err := GetTimeoutsFromLog(receipt.Logs[0])
+		require.NoError(t, err)
+
+		return id, &SwapCreatorSwap{
+			Owner:            addr,
+			Claimer:          addr,
+			ClaimCommitment:  dummySwapKey,
+			RefundCommitment: refundCommitment,
+			Timeout1:         t1,
+			Timeout2:         t2,
+			Asset:            types.EthAssetETH.Address(),
+			Value:            defaultSwapValue,
+			Nonce:            nonce,
+		}
+	}
+
+	readyID, readySwap := newTestSwap(dummySwapKey)
+	tx, err := swapCreator.SetReady(getAuth(t, pk), *readySwap)
+	require.NoError(t, err)
+	getReceipt(t, ec, tx)
+
+	proof, err := (&dleq.DefaultDLEq{}).Prove()
+	require.NoError(t, err)
+	res, err := (&dleq.DefaultDLEq{}).Verify(proof)
+	require.NoError(t, err)
+	refundedID, refundedSwap := newTestSwap(res.Secp256k1PublicKey().Keccak256())
+	tx, err = swapCreator.Refund(getAuth(t, pk), *refundedSwap, proof.Secret())
+	require.NoError(t, err)
+	getReceipt(t, ec, tx)
+
+	endBlock, err := ec.BlockNumber(ctx)
+	require.NoError(t, err)
+
+	lifecycles, err := GetSwapLifecycles(ctx, ec, swapCreatorAddr, startBlock, endBlock)
+	require.NoError(t, err)
+	require.Len(t, lifecycles, 2)
+
+	require.Equal(t, readyID, lifecycles[0].SwapID)
+	require.NotNil(t, lifecycles[0].New)
+	require.Equal(t, readySwap.Timeout2, lifecycles[0].New.Timeout2)
+	require.Len(t, lifecycles[0].Events, 2)
+	require.Equal(t, EventNew, lifecycles[0].Events[0].Name)
+	require.Equal(t, EventReady, lifecycles[0].Events[1].Name)
+	require.Equal(t, StageReady, lifecycles[0].Stage)
+	require.False(t, lifecycles[0].Expired)
+
+	require.Equal(t, refundedID, lifecycles[1].SwapID)
+	require.Len(t, lifecycles[1].Events, 2)
+	require.Equal(t, EventRefunded, lifecycles[1].Events[1].Name)
+	require.Equal(t, types.Hash(proof.Secret()), *lifecycles[1].Events[1].Secret)
+	require.Equal(t, StageCompleted, lifecycles[1].Stage)
+
+	// scanning the range a block at a time finds the same swaps
+	defer func(window uint64) { eventsBlockWindow = window }(eventsBlockWindow)
+	eventsBlockWindow = 1
+	paged, err := GetSwapLifecycles(ctx, ec, swapCreatorAddr, startBlock, endBlock)
+	require.NoError(t, err)
+	require.Equal(t, lifecycles, paged)
+
+	// the range excludes the New event of the refunded swap
+	lifecycles, err = GetSwapLifecycles(ctx, ec, swapCreatorAddr, endBlock, endBlock)
+	require.NoError(t, err)
+	require.Len(t, lifecycles, 1)
+	require.Nil(t, lifecycles[0].New)
+	require.Equal(t, EventRefunded, lifecycles[0].Events[0].Name)
+}
+
+func TestSwapLifecycle_isExpired(t *testing.T) {
+	now := time.Now()
+	lc := &SwapLifecycle{
+		New:   &SwapCreatorNew{Timeout2: big.NewInt(now.Unix())},
+		Stage: StageReady,
+	}
+	require.True(t, lc.isExpired(now))
+	require.False(t, lc.isExpired(now.Add(-time.Second)))
+
+	lc.Stage = StageCompleted
+	require.False(t, lc.isExpired(now))
+
+	// timeout2 is unknown without the New event
+	lc.Stage = StagePending
+	lc.New = nil
+	require.False(t, lc.isExpired(now))
+}
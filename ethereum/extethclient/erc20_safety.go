@@ -0,0 +1,325 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+)
+
+// Errors returned by the ERC20 token safety checks
+var (
+	ErrTokenDenied           = errors.New("token is on the deny list")
+	ErrTokenNotAllowed       = errors.New("token is not on the allow list")
+	ErrTokenTransferMismatch = errors.New("token transfer amount does not match the balance change")
+)
+
+// TokenPolicy is the configurable allow and deny list of ERC20 tokens that can
+// be swapped. When the allow list is not empty, only the tokens on it can be
+// swapped. A nil policy allows all tokens.
+type TokenPolicy struct {
+	Allowed []ethcommon.Address
+	Denied  []ethcommon.Address
+}
+
+// Check returns an error if the asset is a token that the policy does not allow.
+// ETH is always allowed.
+func (p *TokenPolicy) Check(asset types.EthAsset) error {
+	if p == nil || asset.IsETH() {
+		return nil
+	}
+
+	token := asset.Address()
+	for _, addr := range p.Denied {
+		if addr == token {
+			return fmt.Errorf("%w: %s", ErrTokenDenied, token)
+		}
+	}
+
+	if len(p.Allowed) == 0 {
+		return nil
+	}
+
+	for _, addr := range p.Allowed {
+		if addr == token {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrTokenNotAllowed, token)
+}
+
+// SimulateERC20Transfer simulates, with a local eth_call, a transfer of the
+// amount of the token from our address to the given address. It returns an
+// error if the transfer would revert or return false, or if the token balances
+// of the two addresses would not change by exactly the amount, which is the
+// case for fee-on-transfer tokens.
+//
+// The balances are compared by an ERC20TransferProbe contract, whose code is
+// placed at our address with an eth_call state override. If the node does not
+// support state overrides, only the transfer itself is simulated.
+func (c *ethClient) SimulateERC20Transfer(
+	ctx context.Context,
+	token ethcommon.Address,
+	to ethcommon.Address,
+	amount *big.Int,
+) error {
+	balances, err := c.probeERC20Transfer(ctx, token, to, amount)
+	if err != nil {
+		log.Warnf("failed to simulate transfer of token %s with balance checks, simulating the transfer only: %s",
+			token, err)
+		return c.simulateERC20TransferOnly(ctx, token, to, amount)
+	}
+
+	sent := new(big.Int).Sub(balances.FromBefore, balances.FromAfter)
+	received := new(big.Int).Sub(balances.ToAfter, balances.ToBefore)
+	if sent.Cmp(amount) != 0 || received.Cmp(amount) != 0 {
+		return fmt.Errorf("%w: simulated transfer of %s of token %s sent %s and %s received %s",
+			ErrTokenTransferMismatch, amount, token, sent, to, received)
+	}
+
+	return nil
+}
+
+// probeTransferBalances are the token balances returned by
+// ERC20TransferProbe.probeTransfer
+type probeTransferBalances struct {
+	FromBefore *big.Int
+	FromAfter  *big.Int
+	ToBefore   *big.Int
+	ToAfter    *big.Int
+}
+
+// probeERC20Transfer simulates the transfer with the code of the
+// ERC20TransferProbe contract at our address, returning the token balances of
+// our address and the given address before and after the transfer.
+func (c *ethClient) probeERC20Transfer(
+	ctx context.Context,
+	token ethcommon.Address,
+	to ethcommon.Address,
+	amount *big.Int,
+) (*probeTransferBalances, error) {
+	probeABI, err := contracts.ERC20TransferProbeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	// Simulating the deployment of the contract returns its runtime code
+	probeCode, err := c.ec.CallContract(ctx, ethereum.CallMsg{
+		From: c.Address(),
+		Data: ethcommon.FromHex(contracts.ERC20TransferProbeMetaData.Bin),
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the code of the transfer probe: %w", err)
+	}
+
+	data, err := probeABI.Pack("probeTransfer", token, to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ourAddr := c.Address()
+	msg := ethereum.CallMsg{
+		From: ourAddr,
+		To:   &ourAddr,
+		Data: data,
+	}
+	overrides := map[ethcommon.Address]gethclient.OverrideAccount{
+		ourAddr: {Code: probeCode},
+	}
+
+	out, err := gethclient.New(c.ec.Client()).CallContract(ctx, msg, nil, &overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nodes that ignore the state override execute a call to an address
+	// without code, which returns nothing
+	if len(out) == 0 {
+		return nil, errors.New("state overrides are not supported")
+	}
+
+	balances := new(probeTransferBalances)
+	if err = probeABI.UnpackIntoInterface(balances, "probeTransfer", out); err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+// simulateERC20TransferOnly simulates the transfer without checking the
+// balance changes
+func (c *ethClient) simulateERC20TransferOnly(
+	ctx context.Context,
+	token ethcommon.Address,
+	to ethcommon.Address,
+	amount *big.Int,
+) error {
+	erc20ABI, err := contracts.IERC20MetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+
+	data, err := erc20ABI.Pack("transfer", to, amount)
+	if err != nil {
+		return err
+	}
+
+	msg := ethereum.CallMsg{
+		From: c.Address(),
+		To:   &token,
+		Data: data,
+	}
+
+	out, err := c.ec.CallContract(ctx, msg, nil)
+	if err != nil {
+		return fmt.Errorf("simulated transfer of token %s failed: %w", token, err)
+	}
+
+	// Some tokens, like USDT on mainnet, do not return a value from transfer
+	if len(out) == 0 {
+		return nil
+	}
+
+	results, err := erc20ABI.Unpack("transfer", out)
+	if err != nil {
+		return fmt.Errorf("simulated transfer of token %s returned invalid data: %w", token, err)
+	}
+
+	if ok, _ := results[0].(bool); !ok {
+		return fmt.Errorf("simulated transfer of token %s returned false", token)
+	}
+
+	return nil
+}
+
+// CheckERC20Transfer checks that the transaction of the receipt transferred
+// exactly the amount of the token to the given address, and that the token
+// balance of the address changed in the receipt's block by exactly the net
+// amount of the block's Transfer events. Fee-on-transfer tokens fail the first
+// check and rebasing tokens can fail the second. The second check needs the
+// state of the block before the receipt's block, which nodes that are not
+// archive nodes only keep for recent blocks.
+func (c *ethClient) CheckERC20Transfer(
+	ctx context.Context,
+	token ethcommon.Address,
+	receipt *ethtypes.Receipt,
+	to ethcommon.Address,
+	amount *big.Int,
+) error {
+	tokenContract, err := contracts.NewIERC20(token, c.ec)
+	if err != nil {
+		return err
+	}
+
+	transferred := new(big.Int)
+	for _, log := range receipt.Logs {
+		if log.Address != token {
+			continue
+		}
+
+		transfer, err := tokenContract.ParseTransfer(*log) //nolint:govet
+		if err != nil {
+			continue // not a Transfer event
+		}
+
+		transferred.Add(transferred, netTransfer(transfer, to))
+	}
+
+	if transferred.Cmp(amount) != 0 {
+		return fmt.Errorf("%w: token %s transferred %s to %s in tx %s, expected %s",
+			ErrTokenTransferMismatch, token, transferred, to, receipt.TxHash, amount)
+	}
+
+	blockNum := receipt.BlockNumber.Uint64()
+	logged, err := loggedTransfersInBlock(ctx, tokenContract, blockNum, to)
+	if err != nil {
+		return err
+	}
+
+	balanceBefore, err := tokenContract.BalanceOf(
+		&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))},
+		to,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get token balance before block %d: %w", blockNum, err)
+	}
+
+	balanceAfter, err := tokenContract.BalanceOf(&bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber}, to)
+	if err != nil {
+		return fmt.Errorf("failed to get token balance at block %d: %w", blockNum, err)
+	}
+
+	balanceChange := new(big.Int).Sub(balanceAfter, balanceBefore)
+	if balanceChange.Cmp(logged) != 0 {
+		return fmt.Errorf("%w: balance of token %s changed by %s for %s in block %d, but transfers logged %s",
+			ErrTokenTransferMismatch, token, balanceChange, to, blockNum, logged)
+	}
+
+	return nil
+}
+
+// loggedTransfersInBlock returns the net amount of the token transferred to the
+// address in the block, according to the token's Transfer events.
+func loggedTransfersInBlock(
+	ctx context.Context,
+	tokenContract *contracts.IERC20,
+	blockNum uint64,
+	addr ethcommon.Address,
+) (*big.Int, error) {
+	logged := new(big.Int)
+	filterOpts := &bind.FilterOpts{Start: blockNum, End: &blockNum, Context: ctx}
+
+	// Transfers to and from the address are separate filters, as indexed
+	// topics of an event filter are combined with AND.
+	filters := []struct{ from, to []ethcommon.Address }{
+		{to: []ethcommon.Address{addr}},
+		{from: []ethcommon.Address{addr}},
+	}
+
+	for _, f := range filters {
+		iter, err := tokenContract.FilterTransfer(filterOpts, f.from, f.to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token transfers in block %d: %w", blockNum, err)
+		}
+
+		for iter.Next() {
+			// a transfer from the address to itself matches both filters, but
+			// its net amount is zero
+			logged.Add(logged, netTransfer(iter.Event, addr))
+		}
+
+		err = iter.Error()
+		_ = iter.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return logged, nil
+}
+
+// netTransfer returns the amount that the transfer added to the balance of the
+// address, which is negative for transfers from the address.
+func netTransfer(transfer *contracts.IERC20Transfer, addr ethcommon.Address) *big.Int {
+	net := new(big.Int)
+	if transfer.To == addr {
+		net.Add(net, transfer.Value)
+	}
+	if transfer.From == addr {
+		net.Sub(net, transfer.Value)
+	}
+	return net
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extethclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/tests"
+)
+
+func TestTokenPolicy_Check(t *testing.T) {
+	tokenA := types.EthAsset(ethcommon.Address{0xa})
+	tokenB := types.EthAsset(ethcommon.Address{0xb})
+
+	var nilPolicy *TokenPolicy
+	require.NoError(t, nilPolicy.Check(tokenA))
+
+	policy := &TokenPolicy{Denied: []ethcommon.Address{tokenA.Address()}}
+	require.ErrorIs(t, policy.Check(tokenA), ErrTokenDenied)
+	require.NoError(t, policy.Check(tokenB))
+	require.NoError(t, policy.Check(types.EthAssetETH))
+
+	policy = &TokenPolicy{Allowed: []ethcommon.Address{tokenB.Address()}}
+	require.ErrorIs(t, policy.Check(tokenA), ErrTokenNotAllowed)
+	require.NoError(t, policy.Check(tokenB))
+	require.NoError(t, policy.Check(types.EthAssetETH))
+
+	// the deny list wins over the allow list
+	policy.Denied = []ethcommon.Address{tokenB.Address()}
+	require.ErrorIs(t, policy.Check(tokenB), ErrTokenDenied)
+}
+
+func Test_ethClient_SimulateERC20Transfer(t *testing.T) {
+	ctx := context.Background()
+	pk := tests.GetTestKeyByIndex(t, 0)
+	ec := CreateTestClient(t, pk)
+	token := contracts.GetMockTether(t, ec.Raw(), pk)
+
+	to := ethcommon.Address{0x1}
+	err := ec.SimulateERC20Transfer(ctx, token.Address, to, big.NewInt(1))
+	require.NoError(t, err)
+
+	// a client without tokens can't transfer any
+	emptyKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	emptyEC := CreateTestClient(t, emptyKey)
+	err = emptyEC.SimulateERC20Transfer(ctx, token.Address, to, big.NewInt(1))
+	require.ErrorContains(t, err, "simulated transfer of token")
+}
+
+func Test_ethClient_CheckERC20Transfer(t *testing.T) {
+	ctx := context.Background()
+	pk := tests.GetTestKeyByIndex(t, 0)
+	ec := CreateTestClient(t, pk)
+	token := contracts.GetMockTether(t, ec.Raw(), pk)
+
+	tokenContract, err := contracts.NewIERC20(token.Address, ec.Raw())
+	require.NoError(t, err)
+
+	to := ethcommon.Address{0x2}
+	amount := big.NewInt(1000)
+
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := tokenContract.Transfer(txOpts, to, amount)
+	require.NoError(t, err)
+	receipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	require.NoError(t, ec.CheckERC20Transfer(ctx, token.Address, receipt, to, amount))
+
+	err = ec.CheckERC20Transfer(ctx, token.Address, receipt, to, big.NewInt(1001))
+	require.ErrorIs(t, err, ErrTokenTransferMismatch)
+}
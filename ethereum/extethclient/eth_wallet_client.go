@@ -44,6 +44,23 @@ type EthClient interface {
 
 	ERC20Info(ctx context.Context, tokenAddr ethcommon.Address) (*coins.ERC20TokenInfo, error)
 
+	// SimulateERC20Transfer simulates a transfer of the token from our address
+	// to the given address with eth_call, failing if the transfer would revert
+	// or would not change the balances of both addresses by exactly the amount.
+	SimulateERC20Transfer(ctx context.Context, token ethcommon.Address, to ethcommon.Address, amount *big.Int) error
+
+	// CheckERC20Transfer checks that the receipt's transaction transferred
+	// exactly the amount of the token to the given address, and that the
+	// address's balance changed accordingly. Fee-on-transfer and rebasing
+	// tokens fail the check.
+	CheckERC20Transfer(
+		ctx context.Context,
+		token ethcommon.Address,
+		receipt *ethtypes.Receipt,
+		to ethcommon.Address,
+		amount *big.Int,
+	) error
+
 	SetGasPrice(uint64)
 	SetGasLimit(uint64)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
@@ -106,6 +123,19 @@ func NewEthClient(
 		return nil, err
 	}
 
+	return NewEthClientFromRaw(ctx, env, endpoint, ec, privKey)
+}
+
+// NewEthClientFromRaw creates and returns our extended ethereum client/wallet
+// layered on an existing go-ethereum client, like the in-process client of a
+// simulated chain. The endpoint is only reported by the Endpoint method.
+func NewEthClientFromRaw(
+	ctx context.Context,
+	env common.Environment,
+	endpoint string,
+	ec *ethclient.Client,
+	privKey *ecdsa.PrivateKey,
+) (EthClient, error) {
 	chainID, err := ec.ChainID(ctx)
 	if err != nil {
 		return nil, err
@@ -457,22 +487,20 @@ func (c *ethClient) isContractAddress(ctx context.Context, addr ethcommon.Addres
 	return isContract, nil
 }
 
+// validateChainID checks that the chain ID is of a registered chain that is
+// used by the environment.
 func validateChainID(env common.Environment, chainID *big.Int) error {
-	switch env {
-	case common.Mainnet:
-		if chainID.Cmp(big.NewInt(common.MainnetChainID)) != 0 {
-			return fmt.Errorf("expected Mainnet chain ID (%d), but found %s", common.MainnetChainID, chainID)
-		}
-	case common.Stagenet:
-		if chainID.Cmp(big.NewInt(common.SepoliaChainID)) != 0 {
-			return fmt.Errorf("expected Sepolia chain ID (%d), but found %s", common.SepoliaChainID, chainID)
-		}
-	case common.Development:
-		if chainID.Cmp(big.NewInt(common.GanacheChainID)) != 0 {
-			return fmt.Errorf("expected Ganache chain ID (%d), but found %s", common.GanacheChainID, chainID)
-		}
-	default:
-		panic("unhandled environment type")
+	if !chainID.IsUint64() {
+		return fmt.Errorf("invalid chain ID %s", chainID)
+	}
+
+	chain, err := common.EthChainByID(chainID.Uint64())
+	if err != nil {
+		return err
+	}
+
+	if chain.Env != env {
+		return fmt.Errorf("ethereum chain %s is not a %s chain", chain, env)
 	}
 
 	return nil
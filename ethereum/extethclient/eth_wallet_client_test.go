@@ -128,9 +128,22 @@ func Test_validateChainID_devSuccess(t *testing.T) {
 func Test_validateChainID_mismatchedEnv(t *testing.T) {
 	err := validateChainID(common.Mainnet, big.NewInt(common.GanacheChainID))
 	require.Error(t, err)
-	assert.ErrorContains(t, err, "expected Mainnet chain ID (1), but found 1337")
+	assert.ErrorContains(t, err, "ethereum chain ganache (chain ID 1337) is not a mainnet chain")
 
 	err = validateChainID(common.Stagenet, big.NewInt(common.GanacheChainID))
 	require.Error(t, err)
-	assert.ErrorContains(t, err, "expected Sepolia chain ID (11155111), but found 1337")
+	assert.ErrorContains(t, err, "ethereum chain ganache (chain ID 1337) is not a stagenet chain")
+}
+
+func Test_validateChainID_L2(t *testing.T) {
+	err := validateChainID(common.Mainnet, big.NewInt(common.ArbitrumChainID))
+	require.NoError(t, err)
+
+	err = validateChainID(common.Stagenet, big.NewInt(common.BaseSepoliaChainID))
+	require.NoError(t, err)
+}
+
+func Test_validateChainID_unknownChain(t *testing.T) {
+	err := validateChainID(common.Mainnet, big.NewInt(999999))
+	require.ErrorContains(t, err, "unsupported ethereum chain ID 999999")
 }
@@ -0,0 +1,134 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrPayoutFailed is returned when a claim or refund can't transfer the swap
+// value to the payout address and falling back to the sender's address is not
+// allowed.
+var ErrPayoutFailed = errors.New("swap contract can not pay the payout address")
+
+// ClaimWithPayout claims the swap, transferring the swap value to the payout
+// address with `claimTo`. The swap is claimed with `claim`, transferring the
+// value to the claimer, if the payout address is zero. If a simulated
+// `claimTo` call fails without a SwapCreator error, as contracts deployed
+// before payout support do not have it, or because the contract can't pay the
+// payout address, an error is returned unless allowFallback is set, in which
+// case the swap is claimed with `claim`. The claim is simulated before it is
+// sent, so a *RevertError is returned instead of sending a transaction that
+// would revert.
+func ClaimWithPayout(
+	ctx context.Context,
+	ec *ethclient.Client,
+	swapCreatorAddr ethcommon.Address,
+	opts *bind.TransactOpts,
+	swap *SwapCreatorSwap,
+	secret [32]byte,
+	payout ethcommon.Address,
+	allowFallback bool,
+) (*ethtypes.Transaction, error) {
+	swapCreator, err := NewSwapCreator(swapCreatorAddr, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	usePayoutMethod, err := usePayout(ctx, ec, swapCreatorAddr, opts.From, payout, allowFallback,
+		"claimTo", *swap, secret, payout)
+	if err != nil {
+		return nil, err
+	}
+	if usePayoutMethod {
+		return swapCreator.ClaimTo(opts, *swap, secret, payout)
+	}
+
+	if err = SimulateSwapCreatorCall(ctx, ec, swapCreatorAddr, opts.From, nil, "claim", *swap, secret); err != nil {
+		return nil, err
+	}
+
+	return swapCreator.Claim(opts, *swap, secret)
+}
+
+// RefundWithPayout refunds the swap, transferring the swap value to the payout
+// address with `refundTo`. Like ClaimWithPayout, it only falls back to
+// `refund`, transferring the value to the owner, if allowFallback is set, and
+// simulates the refund before sending it.
+func RefundWithPayout(
+	ctx context.Context,
+	ec *ethclient.Client,
+	swapCreatorAddr ethcommon.Address,
+	opts *bind.TransactOpts,
+	swap *SwapCreatorSwap,
+	secret [32]byte,
+	payout ethcommon.Address,
+	allowFallback bool,
+) (*ethtypes.Transaction, error) {
+	swapCreator, err := NewSwapCreator(swapCreatorAddr, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	usePayoutMethod, err := usePayout(ctx, ec, swapCreatorAddr, opts.From, payout, allowFallback,
+		"refundTo", *swap, secret, payout)
+	if err != nil {
+		return nil, err
+	}
+	if usePayoutMethod {
+		return swapCreator.RefundTo(opts, *swap, secret, payout)
+	}
+
+	if err = SimulateSwapCreatorCall(ctx, ec, swapCreatorAddr, opts.From, nil, "refund", *swap, secret); err != nil {
+		return nil, err
+	}
+
+	return swapCreator.Refund(opts, *swap, secret)
+}
+
+// usePayout returns whether the payout address is set and a call of the passed
+// payout method succeeds when simulated. A *RevertError is returned if the
+// simulated call reverts with a SwapCreator error, as the call without the
+// payout address would fail in the same way. If the call fails because the
+// contract can't pay the payout address, false is only returned if
+// allowFallback is set, so that the value is never sent to the caller's address
+// without the user opting in.
+func usePayout(
+	ctx context.Context,
+	ec *ethclient.Client,
+	swapCreatorAddr ethcommon.Address,
+	from ethcommon.Address,
+	payout ethcommon.Address,
+	allowFallback bool,
+	method string,
+	args ...any,
+) (bool, error) {
+	if payout == (ethcommon.Address{}) {
+		return false, nil
+	}
+
+	err := SimulateSwapCreatorCall(ctx, ec, swapCreatorAddr, from, nil, method, args...)
+	if err != nil {
+		var revertErr *RevertError
+		if errors.As(err, &revertErr) && revertErr.Name != "InvalidPayout" && revertErr.Name != "TransferFailed" {
+			return false, err
+		}
+
+		if !allowFallback {
+			return false, fmt.Errorf("%w %s: %s", ErrPayoutFailed, payout, err)
+		}
+
+		log.Warnf("not paying out to %s: %s", payout, err)
+		return false, nil
+	}
+
+	return true, nil
+}
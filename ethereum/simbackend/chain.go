@@ -0,0 +1,249 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package simbackend provides an in-process simulated Ethereum chain, served
+// over go-ethereum's JSON-RPC API, so that the code that uses an
+// *ethclient.Client can be tested without an external node like ganache.
+package simbackend
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/athanorlabs/atomic-swap/common"
+)
+
+// blockGasLimit is the gas limit of the simulated blocks
+const blockGasLimit = 30_000_000
+
+var (
+	log = logging.Logger("ethereum/simbackend")
+
+	// gasTipCap is the suggested priority fee per gas
+	gasTipCap = big.NewInt(params.GWei)
+
+	errBlockNotFound = errors.New("block not found")
+)
+
+// Chain is a simulated proof-of-stake Ethereum chain, with all forks up to
+// Shanghai active, and the chain ID of ganache, so it passes the checks of the
+// development environment. Unlike a real node, the chain has no mempool: each
+// transaction is mined in its own block as soon as it is sent, or rejected
+// with the error that a real node returns. Block timestamps follow the local
+// clock. When the chain head is read and the clock has passed the head's
+// timestamp, a new empty block is mined, so code waiting for a block timestamp
+// makes progress.
+type Chain struct {
+	config *params.ChainConfig
+	db     ethdb.Database
+	engine consensus.Engine
+	bc     *core.BlockChain
+	server *rpc.Server
+
+	mu     sync.Mutex    // serialises mining
+	offset time.Duration // added to the local clock by AdvanceTime
+}
+
+// NewChain creates a simulated chain whose genesis block allocates the passed
+// balances. The returned chain must be closed with Close.
+func NewChain(alloc core.GenesisAlloc) (*Chain, error) {
+	config := *params.AllEthashProtocolChanges
+	config.ChainID = big.NewInt(common.GanacheChainID)
+	config.TerminalTotalDifficulty = big.NewInt(0)
+	config.TerminalTotalDifficultyPassed = true
+	shanghaiTime := uint64(0)
+	config.ShanghaiTime = &shanghaiTime
+
+	genesis := &core.Genesis{
+		Config:     &config,
+		Timestamp:  uint64(time.Now().Unix()),
+		GasLimit:   blockGasLimit,
+		Difficulty: big.NewInt(0),
+		Alloc:      alloc,
+	}
+
+	db := rawdb.NewMemoryDatabase()
+	engine := beacon.New(ethash.NewFaker())
+	bc, err := core.NewBlockChain(db, nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create simulated chain: %w", err)
+	}
+
+	c := &Chain{
+		config: &config,
+		db:     db,
+		engine: engine,
+		bc:     bc,
+		server: rpc.NewServer(),
+	}
+
+	if err = c.server.RegisterName("eth", &ethAPI{chain: c}); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Client returns a new in-process JSON-RPC client of the chain. The client
+// should be closed when no longer needed.
+func (c *Chain) Client() *ethclient.Client {
+	return ethclient.NewClient(rpc.DialInProc(c.server))
+}
+
+// AdvanceTime moves the chain's clock forward by the passed duration and mines
+// an empty block with the new time, so the contracts see the time as passed.
+func (c *Chain) AdvanceTime(d time.Duration) error {
+	c.mu.Lock()
+	c.offset += d
+	c.mu.Unlock()
+
+	return c.mineToNow()
+}
+
+// Close stops the chain's RPC server and the blockchain.
+func (c *Chain) Close() {
+	c.server.Stop()
+	c.bc.Stop()
+	if err := c.db.Close(); err != nil {
+		log.Warnf("failed to close simulated chain database: %s", err)
+	}
+}
+
+// now returns the time of the chain's clock in seconds
+func (c *Chain) now() uint64 {
+	return uint64(time.Now().Add(c.offset).Unix())
+}
+
+// head returns the header of the latest block
+func (c *Chain) head() *ethtypes.Header {
+	return c.bc.CurrentBlock()
+}
+
+// nextHeader returns the header of the block that is mined next, without the
+// fields that depend on the block's transactions.
+func (c *Chain) nextHeader() *ethtypes.Header {
+	parent := c.head()
+	timestamp := c.now()
+	if timestamp <= parent.Time {
+		timestamp = parent.Time + 1
+	}
+
+	return &ethtypes.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		GasLimit:   parent.GasLimit,
+		Time:       timestamp,
+		Difficulty: big.NewInt(0),
+		BaseFee:    misc.CalcBaseFee(c.config, parent),
+	}
+}
+
+// mineToNow mines an empty block if the clock has passed the timestamp of the
+// chain head.
+func (c *Chain) mineToNow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.now() <= c.head().Time {
+		return nil
+	}
+
+	return c.mineBlock()
+}
+
+// sendTransaction mines the transaction in its own block. The transaction is
+// rejected with the error of a real node if it is not valid for the state of
+// the chain head.
+func (c *Chain) sendTransaction(tx *ethtypes.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tx.ChainId().Cmp(c.config.ChainID) != 0 {
+		return fmt.Errorf("invalid chain id %s, expected %s", tx.ChainId(), c.config.ChainID)
+	}
+
+	return c.mineBlock(tx)
+}
+
+// mineBlock mines a block with the passed transactions on the chain head.
+// Must be called with the mutex held.
+func (c *Chain) mineBlock(txs ...*ethtypes.Transaction) error {
+	header := c.nextHeader()
+	stateDB, err := c.bc.StateAt(c.head().Root)
+	if err != nil {
+		return err
+	}
+
+	gasPool := new(core.GasPool).AddGas(header.GasLimit)
+	receipts := make([]*ethtypes.Receipt, len(txs))
+	for i, tx := range txs {
+		stateDB.SetTxContext(tx.Hash(), i)
+		receipts[i], err = core.ApplyTransaction(c.config, c.bc, &header.Coinbase, gasPool, stateDB, header, tx,
+			&header.GasUsed, vm.Config{})
+		if err != nil {
+			return err
+		}
+	}
+
+	block, err := c.engine.FinalizeAndAssemble(c.bc, header, stateDB, txs, nil, receipts, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.bc.InsertChain(ethtypes.Blocks{block}); err != nil {
+		return fmt.Errorf("failed to insert simulated block: %w", err)
+	}
+
+	return nil
+}
+
+// headerByNumber returns the header of the block with the passed number, or
+// the chain head if the number is nil.
+func (c *Chain) headerByNumber(number *big.Int) (*ethtypes.Header, error) {
+	if number == nil {
+		return c.head(), nil
+	}
+
+	header := c.bc.GetHeaderByNumber(number.Uint64())
+	if header == nil {
+		return nil, errBlockNotFound
+	}
+	return header, nil
+}
+
+// stateAt returns the state after the block with the passed number, or after
+// the chain head if the number is nil.
+func (c *Chain) stateAt(number *big.Int) (*state.StateDB, error) {
+	header, err := c.headerByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return c.bc.StateAt(header.Root)
+}
+
+// receipt returns the receipt of the mined transaction, or nil if there is no
+// mined transaction with the hash.
+func (c *Chain) receipt(txHash ethcommon.Hash) *ethtypes.Receipt {
+	receipt, _, _, _ := rawdb.ReadReceipt(c.db, txHash, c.config)
+	return receipt
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package simbackend
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/dleq"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/block"
+)
+
+func TestChain_transfer(t *testing.T) {
+	ctx := context.Background()
+	senderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	receiverKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	chain := CreateTestChain(t, senderKey)
+	sender := chain.CreateTestClient(t, senderKey)
+	receiver := chain.CreateTestClient(t, receiverKey)
+
+	amount := coins.EtherToWei(coins.StrToDecimal("1.5"))
+	receipt, err := sender.Transfer(ctx, receiver.Address(), amount, nil)
+	require.NoError(t, err)
+
+	head, err := sender.Raw().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, head.Number.Uint64(), receipt.BlockNumber.Uint64())
+
+	bal, err := receiver.Balance(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "1.5", bal.AsEtherString())
+
+	tx, isPending, err := receiver.Raw().TransactionByHash(ctx, receipt.TxHash)
+	require.NoError(t, err)
+	require.False(t, isPending)
+	require.Equal(t, amount.BigInt(), tx.Value())
+
+	// the receiver can't pay for the gas of sending all of its balance
+	_, err = receiver.Transfer(ctx, sender.Address(), bal, nil)
+	require.ErrorContains(t, err, "insufficient funds for gas * price + value")
+}
+
+func TestChain_swap(t *testing.T) {
+	ctx := context.Background()
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	chain := CreateTestChain(t, ownerKey)
+	ec := chain.CreateTestClient(t, ownerKey)
+	swapCreatorAddr, swapCreator, err := contracts.DeploySwapCreatorWithKey(ctx, ec.Raw(), ownerKey)
+	require.NoError(t, err)
+
+	proof, err := (&dleq.DefaultDLEq{}).Prove()
+	require.NoError(t, err)
+	res, err := (&dleq.DefaultDLEq{}).Verify(proof)
+	require.NoError(t, err)
+
+	timeout := big.NewInt(60)
+	value := big.NewInt(100)
+	swap := &contracts.SwapCreatorSwap{
+		Owner:            ec.Address(),
+		Claimer:          ec.Address(),
+		ClaimCommitment:  [32]byte{1},
+		RefundCommitment: res.Secp256k1PublicKey().Keccak256(),
+		Asset:            types.EthAssetETH.Address(),
+		Value:            value,
+		Nonce:            contracts.GenerateNewSwapNonce(),
+	}
+
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	txOpts.Value = value
+	tx, err := swapCreator.NewSwap(txOpts, swap.ClaimCommitment, swap.RefundCommitment, swap.Claimer,
+		timeout, timeout, swap.Asset, swap.Value, swap.Nonce)
+	require.NoError(t, err)
+	receipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	swap.Timeout1, swap.Timeout2, err = contracts.GetTimeoutsFromLog(receipt.Logs[0])
+	require.NoError(t, err)
+
+	// block timestamps follow the local clock
+	blockTime, err := ec.LatestBlockTimestamp(ctx)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), blockTime, 2*time.Second)
+
+	txOpts, err = ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err = swapCreator.SetReady(txOpts, *swap)
+	require.NoError(t, err)
+	readyReceipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	// calls at past blocks see the state of the past block
+	pastOpts := &bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber}
+	stage, err := swapCreator.Swaps(pastOpts, swap.SwapID())
+	require.NoError(t, err)
+	require.Equal(t, contracts.StagePending, stage)
+	stage, err = swapCreator.Swaps(ec.CallOpts(ctx), swap.SwapID())
+	require.NoError(t, err)
+	require.Equal(t, contracts.StageReady, stage)
+
+	// revert errors of simulated calls can be decoded
+	err = contracts.SimulateSwapCreatorCall(ctx, ec.Raw(), swapCreatorAddr, ec.Address(), nil,
+		"refund", *swap, proof.Secret())
+	require.True(t, contracts.IsRevertError(err, "NotTimeToRefund"), err)
+
+	require.NoError(t, chain.AdvanceTime(2*time.Duration(timeout.Int64())*time.Second))
+	txOpts, err = ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err = swapCreator.Refund(txOpts, *swap, proof.Secret())
+	require.NoError(t, err)
+	refundReceipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	lifecycles, err := contracts.GetSwapLifecycles(ctx, ec.Raw(), swapCreatorAddr,
+		receipt.BlockNumber.Uint64(), refundReceipt.BlockNumber.Uint64())
+	require.NoError(t, err)
+	require.Len(t, lifecycles, 1)
+	require.Len(t, lifecycles[0].Events, 3)
+	require.Equal(t, readyReceipt.TxHash, lifecycles[0].Events[1].TxHash)
+	require.Equal(t, contracts.EventRefunded, lifecycles[0].Events[2].Name)
+	require.Equal(t, contracts.StageCompleted, lifecycles[0].Stage)
+}
+
+func TestChain_chainClock(t *testing.T) {
+	chain := CreateTestChain(t)
+	c := block.NewChainClock(chain.Client())
+
+	// the chain clock follows the chain's time, not the local time
+	require.NoError(t, chain.AdvanceTime(time.Hour))
+	require.WithinDuration(t, time.Now().Add(time.Hour), c.Now(), 2*time.Second)
+
+	timer := c.NewTimer(time.Hour)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		t.Fatal("chain clock timer fired before the chain reached its deadline")
+	case <-time.After(1500 * time.Millisecond):
+	}
+
+	require.NoError(t, chain.AdvanceTime(time.Hour))
+	select {
+	case now := <-timer.C():
+		require.WithinDuration(t, time.Now().Add(2*time.Hour), now, 2*time.Second)
+	case <-time.After(5 * time.Second):
+		t.Fatal("chain clock timer did not fire")
+	}
+	require.False(t, timer.Stop())
+
+	stopped := c.NewTimer(time.Minute)
+	require.True(t, stopped.Stop())
+	require.NoError(t, chain.AdvanceTime(time.Hour))
+	select {
+	case <-stopped.C():
+		t.Fatal("stopped chain clock timer fired")
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
+
+func TestChain_chainClockCache(t *testing.T) {
+	chain := CreateTestChain(t)
+	ec := chain.Client()
+	c := block.NewChainClock(ec)
+
+	// the fetched block timestamp is reused until it is older than a second
+	start := c.Now()
+	require.NoError(t, chain.AdvanceTime(time.Hour))
+	require.Equal(t, start, c.Now())
+	require.Eventually(t, func() bool {
+		return c.Now().Sub(start) >= time.Hour
+	}, 3*time.Second, 100*time.Millisecond)
+	require.NoError(t, c.Err())
+
+	// once the chain can't be reached, the chain time is estimated from the
+	// last block timestamp and the error is returned by Err
+	ec.Close()
+	time.Sleep(1100 * time.Millisecond)
+	require.WithinDuration(t, time.Now().Add(time.Hour), c.Now(), 2*time.Second)
+	require.Error(t, c.Err())
+}
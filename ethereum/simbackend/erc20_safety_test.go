@@ -0,0 +1,64 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package simbackend
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+)
+
+// The balance checks of SimulateERC20Transfer need eth_call state overrides,
+// which the simulated chain supports.
+
+func newTestERC20Client(t *testing.T) extethclient.EthClient {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return CreateTestChain(t, key).CreateTestClient(t, key)
+}
+
+func TestSimulateERC20Transfer(t *testing.T) {
+	ctx := context.Background()
+	ec := newTestERC20Client(t)
+	tokenAddr, _ := deployPermitToken(t, ec, 1000)
+
+	to := ethcommon.Address{0x1}
+	require.NoError(t, ec.SimulateERC20Transfer(ctx, tokenAddr, to, big.NewInt(1000)))
+
+	// the simulation does not change the balances
+	token, err := contracts.NewIERC20(tokenAddr, ec.Raw())
+	require.NoError(t, err)
+	balance, err := token.BalanceOf(ec.CallOpts(ctx), ec.Address())
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), balance.Int64())
+
+	err = ec.SimulateERC20Transfer(ctx, tokenAddr, to, big.NewInt(1001))
+	require.ErrorContains(t, err, "simulated transfer of token")
+}
+
+func TestSimulateERC20Transfer_feeOnTransfer(t *testing.T) {
+	ctx := context.Background()
+	ec := newTestERC20Client(t)
+
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tokenAddr, tx, _, err := contracts.DeployTestERC20FeeOnTransfer(txOpts, ec.Raw(), "Fee Token", "FEE", 18,
+		ec.Address(), big.NewInt(1000))
+	require.NoError(t, err)
+	_, err = ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	err = ec.SimulateERC20Transfer(ctx, tokenAddr, ethcommon.Address{0x1}, big.NewInt(100))
+	require.ErrorIs(t, err, extethclient.ErrTokenTransferMismatch)
+
+	// the fee is rounded down to zero for amounts below 100
+	require.NoError(t, ec.SimulateERC20Transfer(ctx, tokenAddr, ethcommon.Address{0x1}, big.NewInt(99)))
+}
@@ -0,0 +1,359 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package simbackend
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ethAPI is the subset of the "eth" JSON-RPC namespace that is used by
+// ethclient.Client in this repo, served from the simulated chain. Method
+// names map to RPC methods, eg. GetBlockByNumber is eth_getBlockByNumber.
+type ethAPI struct {
+	chain *Chain
+}
+
+// callArgs are the transaction call arguments of eth_call and eth_estimateGas
+type callArgs struct {
+	From                 *ethcommon.Address `json:"from"`
+	To                   *ethcommon.Address `json:"to"`
+	Gas                  *hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big       `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big       `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big       `json:"value"`
+	Data                 *hexutil.Bytes     `json:"data"`
+	Input                *hexutil.Bytes     `json:"input"`
+}
+
+// overrideAccount is the state of an account that is overridden for the
+// duration of an eth_call
+type overrideAccount struct {
+	Nonce     *hexutil.Uint64                    `json:"nonce"`
+	Code      *hexutil.Bytes                     `json:"code"`
+	Balance   *hexutil.Big                       `json:"balance"`
+	State     *map[ethcommon.Hash]ethcommon.Hash `json:"state"`
+	StateDiff *map[ethcommon.Hash]ethcommon.Hash `json:"stateDiff"`
+}
+
+// stateOverride is the optional state override parameter of eth_call
+type stateOverride map[ethcommon.Address]overrideAccount
+
+// apply overrides the accounts of the state
+func (o stateOverride) apply(stateDB *state.StateDB) {
+	for addr, account := range o {
+		if account.Nonce != nil {
+			stateDB.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			stateDB.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			stateDB.SetBalance(addr, (*big.Int)(account.Balance))
+		}
+		if account.State != nil {
+			stateDB.SetStorage(addr, *account.State)
+		}
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				stateDB.SetState(addr, key, value)
+			}
+		}
+	}
+}
+
+func (args *callArgs) toCallMsg() ethereum.CallMsg {
+	msg := ethereum.CallMsg{
+		To:        args.To,
+		GasPrice:  (*big.Int)(args.GasPrice),
+		GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+		GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+		Value:     (*big.Int)(args.Value),
+	}
+	if args.From != nil {
+		msg.From = *args.From
+	}
+	if args.Gas != nil {
+		msg.Gas = uint64(*args.Gas)
+	}
+	if args.Input != nil {
+		msg.Data = *args.Input
+	} else if args.Data != nil {
+		msg.Data = *args.Data
+	}
+	return msg
+}
+
+// ChainId returns the chain ID of the simulated chain
+func (api *ethAPI) ChainId() *hexutil.Big { //nolint:revive
+	return (*hexutil.Big)(api.chain.config.ChainID)
+}
+
+// BlockNumber returns the number of the chain head, after mining an empty
+// block if the clock passed the head's timestamp.
+func (api *ethAPI) BlockNumber() (hexutil.Uint64, error) {
+	if err := api.chain.mineToNow(); err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(api.chain.head().Number.Uint64()), nil
+}
+
+// GetBlockByNumber returns the block, or nil if there is no block with the
+// number. Reading the latest block mines an empty block if the clock passed
+// the head's timestamp.
+func (api *ethAPI) GetBlockByNumber(
+	ctx context.Context,
+	number rpc.BlockNumber,
+	fullTx bool,
+) (map[string]any, error) {
+	if number < 0 { // latest, pending, safe or finalized
+		if err := api.chain.mineToNow(); err != nil {
+			return nil, err
+		}
+		number = rpc.BlockNumber(api.chain.head().Number.Int64())
+	}
+
+	block := api.chain.bc.GetBlockByNumber(uint64(number))
+	if block == nil {
+		return nil, nil
+	}
+	return api.marshalBlock(block, fullTx)
+}
+
+// GetBlockByHash returns the block, or nil if there is no block with the hash.
+func (api *ethAPI) GetBlockByHash(ctx context.Context, hash ethcommon.Hash, fullTx bool) (map[string]any, error) {
+	block := api.chain.bc.GetBlockByHash(hash)
+	if block == nil {
+		return nil, nil
+	}
+	return api.marshalBlock(block, fullTx)
+}
+
+// GetBalance returns the balance of the address at the block
+func (api *ethAPI) GetBalance(
+	ctx context.Context,
+	addr ethcommon.Address,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (*hexutil.Big, error) {
+	number, err := api.blockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	stateDB, err := api.chain.stateAt(number)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(stateDB.GetBalance(addr)), nil
+}
+
+// GetCode returns the contract code of the address at the block
+func (api *ethAPI) GetCode(
+	ctx context.Context,
+	addr ethcommon.Address,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	number, err := api.blockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	stateDB, err := api.chain.stateAt(number)
+	if err != nil {
+		return nil, err
+	}
+	return stateDB.GetCode(addr), nil
+}
+
+// GetTransactionCount returns the nonce of the address at the block
+func (api *ethAPI) GetTransactionCount(
+	ctx context.Context,
+	addr ethcommon.Address,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (hexutil.Uint64, error) {
+	number, err := api.blockNumber(blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+
+	stateDB, err := api.chain.stateAt(number)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(stateDB.GetNonce(addr)), nil
+}
+
+// GasPrice returns the base fee of the pending block plus the suggested tip
+func (api *ethAPI) GasPrice() *hexutil.Big {
+	price := new(big.Int).Add(api.chain.nextHeader().BaseFee, gasTipCap)
+	return (*hexutil.Big)(price)
+}
+
+// MaxPriorityFeePerGas returns the suggested gas tip cap
+func (api *ethAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).Set(gasTipCap))
+}
+
+// EstimateGas estimates the gas of the call in the pending block
+func (api *ethAPI) EstimateGas(ctx context.Context, args callArgs) (hexutil.Uint64, error) {
+	gas, err := api.chain.estimateGas(args.toCallMsg())
+	return hexutil.Uint64(gas), err
+}
+
+// Call executes the call at the block, with the optional state overrides.
+// Reverts are returned as errors with the revert data, like a real node does.
+func (api *ethAPI) Call(
+	ctx context.Context,
+	args callArgs,
+	blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *stateOverride,
+) (hexutil.Bytes, error) {
+	var accounts stateOverride
+	if overrides != nil {
+		accounts = *overrides
+	}
+
+	if isPending(blockNrOrHash) {
+		return api.chain.pendingCall(args.toCallMsg(), accounts)
+	}
+
+	number, err := api.blockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.chain.callAt(args.toCallMsg(), number, accounts)
+}
+
+// SendRawTransaction mines the signed transaction in its own block
+func (api *ethAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (ethcommon.Hash, error) {
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	if err := api.chain.sendTransaction(tx); err != nil {
+		return ethcommon.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// GetTransactionReceipt returns the receipt, or nil if the transaction is not
+// mined.
+func (api *ethAPI) GetTransactionReceipt(ctx context.Context, hash ethcommon.Hash) (*ethtypes.Receipt, error) {
+	return api.chain.receipt(hash), nil
+}
+
+// GetTransactionByHash returns the mined transaction, or nil if there is no
+// transaction with the hash.
+func (api *ethAPI) GetTransactionByHash(ctx context.Context, hash ethcommon.Hash) (map[string]any, error) {
+	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(api.chain.db, hash)
+	if tx == nil {
+		return nil, nil
+	}
+	return marshalTransaction(tx, blockHash, blockNumber, index)
+}
+
+// GetLogs returns the logs matching the filter
+func (api *ethAPI) GetLogs(ctx context.Context, crit filters.FilterCriteria) ([]ethtypes.Log, error) {
+	return api.chain.filterLogs(ethereum.FilterQuery(crit))
+}
+
+// blockNumber returns the number of the block, or nil for the latest block
+func (api *ethAPI) blockNumber(blockNrOrHash rpc.BlockNumberOrHash) (*big.Int, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header := api.chain.bc.GetHeaderByHash(hash)
+		if header == nil {
+			return nil, errBlockNotFound
+		}
+		return header.Number, nil
+	}
+
+	number, ok := blockNrOrHash.Number()
+	if !ok || number < 0 {
+		return nil, nil
+	}
+	return big.NewInt(number.Int64()), nil
+}
+
+func isPending(blockNrOrHash rpc.BlockNumberOrHash) bool {
+	number, ok := blockNrOrHash.Number()
+	return ok && number == rpc.PendingBlockNumber
+}
+
+// marshalBlock returns the JSON fields of the block like a real node. The
+// transactions are full transaction objects if fullTx is set, otherwise they
+// are transaction hashes.
+func (api *ethAPI) marshalBlock(block *ethtypes.Block, fullTx bool) (map[string]any, error) {
+	fields, err := toJSONFields(block.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]any, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		if !fullTx {
+			txs[i] = tx.Hash()
+			continue
+		}
+		txs[i], err = marshalTransaction(tx, block.Hash(), block.NumberU64(), uint64(i))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields["transactions"] = txs
+	fields["uncles"] = []ethcommon.Hash{}
+	fields["size"] = hexutil.Uint64(block.Size())
+	return fields, nil
+}
+
+// marshalTransaction returns the JSON fields of a mined transaction like a
+// real node.
+func marshalTransaction(
+	tx *ethtypes.Transaction,
+	blockHash ethcommon.Hash,
+	blockNumber uint64,
+	index uint64,
+) (map[string]any, error) {
+	fields, err := toJSONFields(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields["blockHash"] = blockHash
+	fields["blockNumber"] = hexutil.Uint64(blockNumber)
+	fields["transactionIndex"] = hexutil.Uint64(index)
+	fields["from"] = from
+	return fields, nil
+}
+
+// toJSONFields returns the fields of the value's JSON object
+func toJSONFields(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]any)
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
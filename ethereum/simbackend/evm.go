@@ -0,0 +1,240 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package simbackend
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// call executes the call on the state with the passed root, in the context of
+// the block with the passed header, after applying the state overrides, which
+// can be nil. Like eth_call of a real node, the call pays no gas, but the
+// caller must have the balance for the call's value.
+func (c *Chain) call(
+	call ethereum.CallMsg,
+	header *ethtypes.Header,
+	root ethcommon.Hash,
+	overrides stateOverride,
+) (*core.ExecutionResult, error) {
+	stateDB, err := c.bc.StateAt(root)
+	if err != nil {
+		return nil, err
+	}
+	overrides.apply(stateDB)
+
+	if call.Gas == 0 {
+		call.Gas = header.GasLimit
+	}
+	if call.Value == nil {
+		call.Value = new(big.Int)
+	}
+
+	msg := &core.Message{
+		From:              call.From,
+		To:                call.To,
+		Value:             call.Value,
+		GasLimit:          call.Gas,
+		GasPrice:          new(big.Int),
+		GasFeeCap:         new(big.Int),
+		GasTipCap:         new(big.Int),
+		Data:              call.Data,
+		SkipAccountChecks: true,
+	}
+
+	blockCtx := core.NewEVMBlockContext(header, c.bc, nil)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), stateDB, c.config, vm.Config{NoBaseFee: true})
+	return core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+}
+
+// callAt executes the call at the block with the passed number, or at the
+// chain head if the number is nil. Reverts are returned as a *revertError.
+func (c *Chain) callAt(call ethereum.CallMsg, number *big.Int, overrides stateOverride) ([]byte, error) {
+	header, err := c.headerByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return resultOf(c.call(call, header, header.Root, overrides))
+}
+
+// pendingCall executes the call on the state of the chain head, in the context
+// of the block that is mined next.
+func (c *Chain) pendingCall(call ethereum.CallMsg, overrides stateOverride) ([]byte, error) {
+	root := c.head().Root
+	return resultOf(c.call(call, c.nextHeader(), root, overrides))
+}
+
+// estimateGas returns the lowest gas limit with which the call succeeds in the
+// block that is mined next, using a binary search like a real node.
+func (c *Chain) estimateGas(call ethereum.CallMsg) (uint64, error) {
+	root := c.head().Root
+	header := c.nextHeader()
+
+	hi := header.GasLimit
+	if call.Gas >= params.TxGas && call.Gas < hi {
+		hi = call.Gas
+	}
+
+	execute := func(gas uint64) (*core.ExecutionResult, error) {
+		call.Gas = gas
+		return c.call(call, header, root, nil)
+	}
+
+	res, err := execute(hi)
+	if err != nil {
+		return 0, err
+	}
+	if res.Failed() {
+		if len(res.Revert()) > 0 {
+			return 0, newRevertError(res)
+		}
+		if !errors.Is(res.Err, vm.ErrOutOfGas) {
+			return 0, res.Err
+		}
+		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+
+	lo := params.TxGas - 1
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		res, err = execute(mid)
+		if errors.Is(err, core.ErrIntrinsicGas) {
+			lo = mid
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if res.Failed() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hi, nil
+}
+
+// filterLogs returns the logs of the canonical chain that match the query
+func (c *Chain) filterLogs(query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	var from, to uint64
+	if query.BlockHash != nil {
+		header := c.bc.GetHeaderByHash(*query.BlockHash)
+		if header == nil {
+			return nil, errBlockNotFound
+		}
+		from, to = header.Number.Uint64(), header.Number.Uint64()
+	} else {
+		to = c.head().Number.Uint64()
+		if query.FromBlock != nil && query.FromBlock.Sign() >= 0 {
+			from = query.FromBlock.Uint64()
+		}
+		if query.ToBlock != nil && query.ToBlock.Sign() >= 0 && query.ToBlock.Uint64() < to {
+			to = query.ToBlock.Uint64()
+		}
+	}
+
+	logs := []ethtypes.Log{}
+	for number := from; number <= to; number++ {
+		header := c.bc.GetHeaderByNumber(number)
+		if header == nil {
+			break
+		}
+
+		for _, receipt := range c.bc.GetReceiptsByHash(header.Hash()) {
+			for _, l := range receipt.Logs {
+				if logMatches(l, query) {
+					logs = append(logs, *l)
+				}
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+// logMatches returns true if the log matches the addresses and topics of the
+// query. An empty list of addresses, or of topics at a position, matches any.
+func logMatches(l *ethtypes.Log, query ethereum.FilterQuery) bool {
+	if len(query.Addresses) > 0 && !containsAddress(query.Addresses, l.Address) {
+		return false
+	}
+
+	if len(query.Topics) > len(l.Topics) {
+		return false
+	}
+
+	for i, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+
+		matched := false
+		for _, topic := range topics {
+			if topic == l.Topics[i] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsAddress(addrs []ethcommon.Address, addr ethcommon.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// resultOf returns the return data of the executed call, or its error. Reverts
+// are returned as a *revertError.
+func resultOf(res *core.ExecutionResult, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Revert()) > 0 {
+		return nil, newRevertError(res)
+	}
+	return res.Return(), res.Err
+}
+
+// revertError is the JSON-RPC error of a reverted call, which carries the
+// revert data like the errors of a real node.
+type revertError struct {
+	data string // hex encoded revert data
+}
+
+func newRevertError(res *core.ExecutionResult) *revertError {
+	return &revertError{data: hexutil.Encode(res.Revert())}
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+// ErrorCode returns the JSON-RPC error code of reverted calls
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded revert data
+func (e *revertError) ErrorData() any {
+	return e.data
+}
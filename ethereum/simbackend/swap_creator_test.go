@@ -0,0 +1,407 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package simbackend
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/crypto/secp256k1"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+)
+
+// The tests in this file call the SwapCreator contract on a simulated chain,
+// as the contract's own tests need ganache.
+
+// newTestSwapCreator returns a client of a simulated chain with a deployed
+// SwapCreator contract. The key of the client is funded.
+func newTestSwapCreator(t *testing.T) (*Chain, extethclient.EthClient, ethcommon.Address, *contracts.SwapCreator) {
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	chain := CreateTestChain(t, ownerKey)
+	ec := chain.CreateTestClient(t, ownerKey)
+	swapCreatorAddr, swapCreator, err := contracts.DeploySwapCreatorWithKey(context.Background(), ec.Raw(), ownerKey)
+	require.NoError(t, err)
+
+	return chain, ec, swapCreatorAddr, swapCreator
+}
+
+// deployPermitToken deploys an EIP-2612 token, giving the supply to the owner
+// of the client's key.
+func deployPermitToken(t *testing.T, ec extethclient.EthClient, supply int64) (
+	ethcommon.Address,
+	*contracts.TestERC20Permit,
+) {
+	ctx := context.Background()
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+
+	tokenAddr, tx, token, err := contracts.DeployTestERC20Permit(txOpts, ec.Raw(), "Permit Token", "PERMIT", 18,
+		ec.Address(), big.NewInt(supply))
+	require.NoError(t, err)
+	_, err = ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	return tokenAddr, token
+}
+
+// newTestSwap returns a swap of the client's address to the claimer, without
+// the timeouts, which are set by the contract.
+func newTestSwap(
+	ec extethclient.EthClient,
+	claimer ethcommon.Address,
+	asset ethcommon.Address,
+	value int64,
+) *contracts.SwapCreatorSwap {
+	return &contracts.SwapCreatorSwap{
+		Owner:            ec.Address(),
+		Claimer:          claimer,
+		ClaimCommitment:  [32]byte{1},
+		RefundCommitment: [32]byte{2},
+		Asset:            asset,
+		Value:            big.NewInt(value),
+		Nonce:            contracts.GenerateNewSwapNonce(),
+	}
+}
+
+// setSwapTimeouts sets the timeouts of the swap from the New event of the
+// receipt of its newSwap transaction.
+func setSwapTimeouts(t *testing.T, swap *contracts.SwapCreatorSwap, receipt *ethtypes.Receipt) {
+	var err error
+	swap.Timeout1, swap.Timeout2, err = contracts.GetTimeoutsFromLog(receipt.Logs[len(receipt.Logs)-1])
+	require.NoError(t, err)
+}
+
+// newTestSecret returns a swap secret and its commitment
+func newTestSecret(t *testing.T) ([32]byte, [32]byte) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var secret, x, y [32]byte
+	key.D.FillBytes(secret[:])
+	key.X.FillBytes(x[:])
+	key.Y.FillBytes(y[:])
+	return secret, secp256k1.NewPublicKey(x, y).Keccak256()
+}
+
+// newFundedTestClient returns a client with a new key of the chain, funded by
+// the passed client.
+func newFundedTestClient(t *testing.T, chain *Chain, funder extethclient.EthClient) extethclient.EthClient {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ec := chain.CreateTestClient(t, key)
+	_, err = funder.Transfer(context.Background(), ec.Address(), coins.EtherToWei(coins.StrToDecimal("1")), nil)
+	require.NoError(t, err)
+	return ec
+}
+
+// createTestETHSwaps creates swaps of the client's ETH to the claimer, with
+// new claim and refund secrets, returning the swaps and their secrets.
+func createTestETHSwaps(
+	t *testing.T,
+	ec extethclient.EthClient,
+	swapCreator *contracts.SwapCreator,
+	claimer ethcommon.Address,
+	numSwaps int,
+) ([]contracts.SwapCreatorSwap, [][32]byte, [][32]byte) {
+	ctx := context.Background()
+	timeout := big.NewInt(60)
+
+	swaps := make([]contracts.SwapCreatorSwap, numSwaps)
+	claimSecrets := make([][32]byte, numSwaps)
+	refundSecrets := make([][32]byte, numSwaps)
+	for i := range swaps {
+		swap := newTestSwap(ec, claimer, ethcommon.Address{}, 1000)
+		claimSecrets[i], swap.ClaimCommitment = newTestSecret(t)
+		refundSecrets[i], swap.RefundCommitment = newTestSecret(t)
+
+		txOpts, err := ec.TxOpts(ctx)
+		require.NoError(t, err)
+		txOpts.Value = swap.Value
+		tx, err := swapCreator.NewSwap(txOpts, swap.ClaimCommitment, swap.RefundCommitment, swap.Claimer,
+			timeout, timeout, swap.Asset, swap.Value, swap.Nonce)
+		require.NoError(t, err)
+		receipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		setSwapTimeouts(t, swap, receipt)
+		swaps[i] = *swap
+	}
+
+	return swaps, claimSecrets, refundSecrets
+}
+
+// requireSwapsCompleted checks that the swaps are completed and that the payout
+// address received their value.
+func requireSwapsCompleted(
+	t *testing.T,
+	ec extethclient.EthClient,
+	swapCreator *contracts.SwapCreator,
+	swaps []contracts.SwapCreatorSwap,
+	payout ethcommon.Address,
+) {
+	ctx := context.Background()
+	for _, swap := range swaps {
+		stage, err := swapCreator.Swaps(ec.CallOpts(ctx), swap.SwapID())
+		require.NoError(t, err)
+		require.Equal(t, contracts.StageCompleted, stage)
+	}
+
+	bal, err := ec.Raw().BalanceAt(ctx, payout, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000*len(swaps)), bal.Int64())
+}
+
+func signTestPermit(
+	t *testing.T,
+	ec extethclient.EthClient,
+	key *ecdsa.PrivateKey,
+	tokenAddr ethcommon.Address,
+	spender ethcommon.Address,
+	value *big.Int,
+) *contracts.SwapCreatorPermit {
+	deadline := big.NewInt(time.Now().Add(time.Hour).Unix())
+	permit, err := contracts.SignERC20Permit(context.Background(), ec.Raw(), tokenAddr, key, spender, value, deadline)
+	require.NoError(t, err)
+	return permit
+}
+
+func TestSwapCreator_code(t *testing.T) {
+	_, ec, swapCreatorAddr, _ := newTestSwapCreator(t)
+	err := contracts.CheckSwapCreatorContractCode(context.Background(), ec.Raw(), swapCreatorAddr)
+	require.NoError(t, err)
+}
+
+func TestSwapCreator_newSwapWithPermit(t *testing.T) {
+	ctx := context.Background()
+	_, ec, swapCreatorAddr, swapCreator := newTestSwapCreator(t)
+	tokenAddr, token := deployPermitToken(t, ec, 1000)
+
+	timeout := big.NewInt(60)
+	swap := newTestSwap(ec, ethcommon.Address{0x1}, tokenAddr, 100)
+	permit := signTestPermit(t, ec, ec.PrivateKey(), tokenAddr, swapCreatorAddr, swap.Value)
+
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := swapCreator.NewSwapWithPermit(txOpts, swap.ClaimCommitment, swap.RefundCommitment, swap.Claimer,
+		timeout, timeout, swap.Asset, swap.Value, swap.Nonce, *permit)
+	require.NoError(t, err)
+	receipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	setSwapTimeouts(t, swap, receipt)
+
+	stage, err := swapCreator.Swaps(ec.CallOpts(ctx), swap.SwapID())
+	require.NoError(t, err)
+	require.Equal(t, contracts.StagePending, stage)
+
+	// the permit was used and the swap value was transferred in the same transaction
+	callOpts := ec.CallOpts(ctx)
+	bal, err := token.BalanceOf(callOpts, swapCreatorAddr)
+	require.NoError(t, err)
+	require.Equal(t, swap.Value, bal)
+	bal, err = token.BalanceOf(callOpts, ec.Address())
+	require.NoError(t, err)
+	require.Equal(t, int64(900), bal.Int64())
+	allowance, err := token.Allowance(callOpts, ec.Address(), swapCreatorAddr)
+	require.NoError(t, err)
+	require.Zero(t, allowance.Sign())
+	permitNonce, err := token.Nonces(callOpts, ec.Address())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), permitNonce.Int64())
+
+	// the permit can't fund a second swap
+	err = contracts.SimulateSwapCreatorCall(ctx, ec.Raw(), swapCreatorAddr, ec.Address(), nil,
+		"newSwapWithPermit", swap.ClaimCommitment, swap.RefundCommitment, swap.Claimer, timeout, timeout,
+		swap.Asset, swap.Value, contracts.GenerateNewSwapNonce(), *permit)
+	require.ErrorContains(t, err, "execution reverted")
+}
+
+// The permit signature is public once the newSwapWithPermit transaction is
+// broadcast, so anyone can submit it to the token before the swap is created.
+func TestSwapCreator_newSwapWithPermit_frontRun(t *testing.T) {
+	ctx := context.Background()
+	chain, ec, swapCreatorAddr, swapCreator := newTestSwapCreator(t)
+	tokenAddr, token := deployPermitToken(t, ec, 1000)
+
+	frontRunnerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	frontRunner := chain.CreateTestClient(t, frontRunnerKey)
+	_, err = ec.Transfer(ctx, frontRunner.Address(), coins.EtherToWei(coins.StrToDecimal("1")), nil)
+	require.NoError(t, err)
+
+	timeout := big.NewInt(60)
+	swap := newTestSwap(ec, ethcommon.Address{0x1}, tokenAddr, 100)
+	permit := signTestPermit(t, ec, ec.PrivateKey(), tokenAddr, swapCreatorAddr, swap.Value)
+
+	txOpts, err := frontRunner.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := token.Permit(txOpts, ec.Address(), swapCreatorAddr, swap.Value, permit.Deadline,
+		permit.V, permit.R, permit.S)
+	require.NoError(t, err)
+	_, err = frontRunner.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	// the permit call of the contract reverts, but the allowance is set
+	txOpts, err = ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err = swapCreator.NewSwapWithPermit(txOpts, swap.ClaimCommitment, swap.RefundCommitment, swap.Claimer,
+		timeout, timeout, swap.Asset, swap.Value, swap.Nonce, *permit)
+	require.NoError(t, err)
+	receipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	setSwapTimeouts(t, swap, receipt)
+
+	stage, err := swapCreator.Swaps(ec.CallOpts(ctx), swap.SwapID())
+	require.NoError(t, err)
+	require.Equal(t, contracts.StagePending, stage)
+	bal, err := token.BalanceOf(ec.CallOpts(ctx), swapCreatorAddr)
+	require.NoError(t, err)
+	require.Equal(t, swap.Value, bal)
+}
+
+func TestSwapCreator_claimBatch(t *testing.T) {
+	ctx := context.Background()
+	chain, ec, swapCreatorAddr, swapCreator := newTestSwapCreator(t)
+	claimer := newFundedTestClient(t, chain, ec)
+	swaps, secrets, _ := createTestETHSwaps(t, ec, swapCreator, claimer.Address(), 3)
+
+	// the swaps can be claimed between timeout1 and timeout2 without being set
+	// to ready
+	require.NoError(t, chain.AdvanceTime(90*time.Second))
+
+	// the swaps and secrets must be passed in pairs
+	payout := ethcommon.Address{0x9}
+	err := contracts.SimulateSwapCreatorCall(ctx, claimer.Raw(), swapCreatorAddr, claimer.Address(), nil,
+		"claimBatch", swaps, secrets[1:], payout)
+	require.ErrorContains(t, err, "InvalidBatch")
+
+	txOpts, err := claimer.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := swapCreator.ClaimBatch(txOpts, swaps, secrets, payout)
+	require.NoError(t, err)
+	_, err = claimer.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	requireSwapsCompleted(t, claimer, swapCreator, swaps, payout)
+
+	// a batch reverts as a whole if any of its swaps can't be claimed
+	moreSwaps, moreSecrets, _ := createTestETHSwaps(t, ec, swapCreator, claimer.Address(), 1)
+	require.NoError(t, chain.AdvanceTime(90*time.Second))
+	err = contracts.SimulateSwapCreatorCall(ctx, claimer.Raw(), swapCreatorAddr, claimer.Address(), nil,
+		"claimBatch", append(moreSwaps, swaps[0]), append(moreSecrets, secrets[0]), payout)
+	require.ErrorContains(t, err, "SwapCompleted")
+}
+
+func TestSwapCreator_refundBatch(t *testing.T) {
+	ctx := context.Background()
+	chain, ec, swapCreatorAddr, swapCreator := newTestSwapCreator(t)
+	swaps, claimSecrets, refundSecrets := createTestETHSwaps(t, ec, swapCreator, ethcommon.Address{0x1}, 3)
+
+	// the claim secrets can't refund
+	payout := ethcommon.Address{0x9}
+	err := contracts.SimulateSwapCreatorCall(ctx, ec.Raw(), swapCreatorAddr, ec.Address(), nil,
+		"refundBatch", swaps, claimSecrets, payout)
+	require.ErrorContains(t, err, "InvalidSecret")
+
+	// the swaps can be refunded before timeout1, as they are not ready
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := swapCreator.RefundBatch(txOpts, swaps, refundSecrets, payout)
+	require.NoError(t, err)
+	_, err = ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	requireSwapsCompleted(t, ec, swapCreator, swaps, payout)
+
+	// only the owner can refund
+	moreSwaps, _, moreSecrets := createTestETHSwaps(t, ec, swapCreator, ethcommon.Address{0x1}, 1)
+	other := newFundedTestClient(t, chain, ec)
+	err = contracts.SimulateSwapCreatorCall(ctx, other.Raw(), swapCreatorAddr, other.Address(), nil,
+		"refundBatch", moreSwaps, moreSecrets, payout)
+	require.ErrorContains(t, err, "OnlySwapOwner")
+}
+
+func TestSwapCreator_claimTo(t *testing.T) {
+	ctx := context.Background()
+	chain, ec, swapCreatorAddr, swapCreator := newTestSwapCreator(t)
+	claimer := newFundedTestClient(t, chain, ec)
+	swaps, secrets, _ := createTestETHSwaps(t, ec, swapCreator, claimer.Address(), 1)
+	require.NoError(t, chain.AdvanceTime(90*time.Second))
+
+	err := contracts.SimulateSwapCreatorCall(ctx, claimer.Raw(), swapCreatorAddr, claimer.Address(), nil,
+		"claimTo", swaps[0], secrets[0], ethcommon.Address{})
+	require.ErrorContains(t, err, "InvalidPayout")
+
+	payout := ethcommon.Address{0x9}
+	txOpts, err := claimer.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := swapCreator.ClaimTo(txOpts, swaps[0], secrets[0], payout)
+	require.NoError(t, err)
+	_, err = claimer.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	requireSwapsCompleted(t, claimer, swapCreator, swaps, payout)
+}
+
+func TestSwapCreator_refundTo(t *testing.T) {
+	ctx := context.Background()
+	chain, ec, swapCreatorAddr, swapCreator := newTestSwapCreator(t)
+	swaps, _, secrets := createTestETHSwaps(t, ec, swapCreator, ethcommon.Address{0x1}, 1)
+
+	// only the owner can refund, even to the owner's payout address
+	other := newFundedTestClient(t, chain, ec)
+	payout := ethcommon.Address{0x9}
+	err := contracts.SimulateSwapCreatorCall(ctx, other.Raw(), swapCreatorAddr, other.Address(), nil,
+		"refundTo", swaps[0], secrets[0], payout)
+	require.ErrorContains(t, err, "OnlySwapOwner")
+
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := swapCreator.RefundTo(txOpts, swaps[0], secrets[0], payout)
+	require.NoError(t, err)
+	_, err = ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	requireSwapsCompleted(t, ec, swapCreator, swaps, payout)
+}
+
+// A payout address that can't receive ETH, like the swap contract itself,
+// fails the claim unless falling back to the claimer's address is allowed.
+func TestClaimWithPayout_fallback(t *testing.T) {
+	ctx := context.Background()
+	chain, ec, swapCreatorAddr, swapCreator := newTestSwapCreator(t)
+	claimer := newFundedTestClient(t, chain, ec)
+	swaps, secrets, _ := createTestETHSwaps(t, ec, swapCreator, claimer.Address(), 1)
+	require.NoError(t, chain.AdvanceTime(90*time.Second))
+
+	txOpts, err := claimer.TxOpts(ctx)
+	require.NoError(t, err)
+	_, err = contracts.ClaimWithPayout(ctx, claimer.Raw(), swapCreatorAddr, txOpts, &swaps[0], secrets[0],
+		swapCreatorAddr, false)
+	require.ErrorIs(t, err, contracts.ErrPayoutFailed)
+
+	startBal, err := claimer.Balance(ctx)
+	require.NoError(t, err)
+	txOpts, err = claimer.TxOpts(ctx)
+	require.NoError(t, err)
+	tx, err := contracts.ClaimWithPayout(ctx, claimer.Raw(), swapCreatorAddr, txOpts, &swaps[0], secrets[0],
+		swapCreatorAddr, true)
+	require.NoError(t, err)
+	receipt, err := claimer.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+
+	// the claimer received the swap value, minus the gas of the claim
+	gas := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	endBal, err := claimer.Balance(ctx)
+	require.NoError(t, err)
+	expected := new(big.Int).Sub(new(big.Int).Add(startBal.BigInt(), swaps[0].Value), gas)
+	require.Equal(t, expected, endBal.BigInt())
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build !prod
+
+package simbackend
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+)
+
+// This file is only for test support. Use the build tag "prod" to prevent
+// symbols in this file from consuming space in production binaries.
+
+// testFundingETH is the ETH balance of each funded test key
+const testFundingETH = "1000"
+
+// CreateTestChain creates a simulated chain where each of the passed keys has
+// a balance of 1000 ETH. The chain is closed when the test completes.
+func CreateTestChain(t *testing.T, fundedKeys ...*ecdsa.PrivateKey) *Chain {
+	funding := coins.EtherToWei(coins.StrToDecimal(testFundingETH)).BigInt()
+	alloc := make(core.GenesisAlloc)
+	for _, key := range fundedKeys {
+		alloc[common.EthereumPrivateKeyToAddress(key)] = core.GenesisAccount{Balance: new(big.Int).Set(funding)}
+	}
+
+	chain, err := NewChain(alloc)
+	require.NoError(t, err)
+	t.Cleanup(chain.Close)
+	return chain
+}
+
+// CreateTestClient creates an extended eth client of the chain using the
+// passed ethereum wallet key. The client is closed when the test completes.
+func (c *Chain) CreateTestClient(t *testing.T, ethKey *ecdsa.PrivateKey) extethclient.EthClient {
+	ec, err := extethclient.NewEthClientFromRaw(context.Background(), common.Development, "simulated", c.Client(), ethKey)
+	require.NoError(t, err)
+	t.Cleanup(ec.Close)
+	return ec
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// revertHints are actionable explanations of the SwapCreator custom errors
+var revertHints = map[string]string{
+	"ZeroValue":              "the swap value must not be zero",
+	"InvalidSwapKey":         "the claim and refund commitments must not be zero",
+	"InvalidClaimer":         "the claimer must not be the zero address",
+	"InvalidTimeout":         "the swap timeouts must not be zero",
+	"InvalidValue":           "the ETH sent does not match the swap value",
+	"SwapAlreadyExists":      "a swap with the same values and nonce already exists",
+	"SwapNotPending":         "the swap was already set ready or completed",
+	"OnlySwapOwner":          "only the swap's owner can set it ready or refund it",
+	"OnlySwapClaimer":        "only the swap's claimer can claim it",
+	"InvalidSwap":            "the swap does not exist in the contract, check the contract address and swap values",
+	"SwapCompleted":          "the swap was already claimed or refunded",
+	"TooEarlyToClaim":        "the swap is not ready and timeout1 has not passed yet",
+	"TooLateToClaim":         "timeout2 has passed, so only the owner can refund the swap",
+	"NotTimeToRefund":        "the swap can only be refunded before timeout1 if not ready, or after timeout2",
+	"InvalidSecret":          "the secret does not match the swap's commitment",
+	"InvalidSignature":       "the relayed swap was not signed by the swap party",
+	"InvalidContractAddress": "the relayed swap is for a different SwapCreator contract",
+	"InvalidRelayerAddress":  "the relayer address and salt do not match the relayer hash",
+	"InvalidBatch":           "the batch is empty or its swaps and secrets have different lengths",
+	"InvalidPayout":          "the payout address must not be the zero address",
+	"TransferFailed":         "the payout address did not accept the transfer",
+	"ReentrantCall":          "the transfer of the swap value re-entered the contract",
+}
+
+// RevertError is the error of a SwapCreator method call that reverts with one
+// of the contract's custom errors.
+type RevertError struct {
+	Method string // the called SwapCreator method
+	Name   string // the name of the custom error, eg. TooEarlyToClaim
+}
+
+func (e *RevertError) Error() string {
+	msg := fmt.Sprintf("%s reverts with %s", e.Method, e.Name)
+	if hint, ok := revertHints[e.Name]; ok {
+		msg = fmt.Sprintf("%s: %s", msg, hint)
+	}
+	return msg
+}
+
+// IsRevertError returns true if the error is a *RevertError of the SwapCreator
+// custom error with the passed name.
+func IsRevertError(err error, name string) bool {
+	var revertErr *RevertError
+	return errors.As(err, &revertErr) && revertErr.Name == name
+}
+
+// SimulateSwapCreatorCall simulates a transaction from the passed address that
+// calls the SwapCreator method, with eth_call at the pending block. It returns a
+// *RevertError if the transaction would revert with one of the contract's
+// custom errors, so that we don't pay gas for it.
+func SimulateSwapCreatorCall(
+	ctx context.Context,
+	ec *ethclient.Client,
+	swapCreatorAddr ethcommon.Address,
+	from ethcommon.Address,
+	value *big.Int,
+	method string,
+	args ...any,
+) error {
+	data, err := SwapCreatorParsedABI.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    &swapCreatorAddr,
+		Value: value,
+		Data:  data,
+	}
+	if _, err = ec.PendingCallContract(ctx, msg); err != nil {
+		return DecodeRevertError(method, err)
+	}
+
+	return nil
+}
+
+// DecodeRevertError returns a *RevertError if the passed error of a call of the
+// SwapCreator method carries the revert data of one of the contract's custom
+// errors. Otherwise, the error is returned wrapped with the method name.
+func DecodeRevertError(method string, err error) error {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if hexData, ok := dataErr.ErrorData().(string); ok {
+			if name, ok := revertErrorName(hexData); ok {
+				return &RevertError{Method: method, Name: name}
+			}
+		}
+	}
+
+	return fmt.Errorf("simulated %s failed: %w", method, err)
+}
+
+// revertErrorName returns the name of the SwapCreator custom error whose
+// selector starts the hex-encoded revert data
+func revertErrorName(hexData string) (string, bool) {
+	data, err := hexutil.Decode(hexData)
+	if err != nil || len(data) < 4 {
+		return "", false
+	}
+
+	for name, abiErr := range SwapCreatorParsedABI.Errors {
+		if bytes.Equal(abiErr.ID[:4], data[:4]) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package contracts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/tests"
+)
+
+// testDataError implements rpc.DataError like the errors of reverted calls
+type testDataError struct {
+	data any
+}
+
+func (e *testDataError) Error() string {
+	return "execution reverted"
+}
+
+func (e *testDataError) ErrorData() any {
+	return e.data
+}
+
+func TestDecodeRevertError(t *testing.T) {
+	id := SwapCreatorParsedABI.Errors["TooEarlyToClaim"].ID
+
+	err := DecodeRevertError("claim", &testDataError{data: hexutil.Encode(id[:4])})
+	require.True(t, IsRevertError(err, "TooEarlyToClaim"))
+	require.False(t, IsRevertError(err, "TooLateToClaim"))
+	require.EqualError(t, err, "claim reverts with TooEarlyToClaim: the swap is not ready and timeout1 has not passed yet")
+
+	// errors without the revert data of a SwapCreator error are wrapped
+	for _, origErr := range []error{
+		errors.New("connection refused"),
+		&testDataError{data: "0x01020304"},
+		&testDataError{data: "0x"},
+		&testDataError{data: nil},
+	} {
+		err = DecodeRevertError("claim", origErr)
+		var revertErr *RevertError
+		require.False(t, errors.As(err, &revertErr))
+		require.ErrorIs(t, err, origErr)
+	}
+}
+
+func TestSimulateSwapCreatorCall(t *testing.T) {
+	ctx := context.Background()
+	pk := tests.GetTakerTestKey(t)
+	ec, _ := tests.NewEthClient(t)
+	addr := crypto.PubkeyToAddress(pk.PublicKey)
+	swapCreatorAddr, swapCreator := DevDeploySwapCreator(t, ec, pk)
+
+	nonce := GenerateNewSwapNonce()
+	newSwapArgs := []any{dummySwapKey, dummySwapKey, addr, defaultTimeoutDuration, defaultTimeoutDuration,
+		types.EthAssetETH.Address(), defaultSwapValue, nonce}
+
+	// the ETH sent does not match the swap value
+	err := SimulateSwapCreatorCall(ctx, ec, swapCreatorAddr, addr, nil, "newSwap", newSwapArgs...)
+	require.True(t, IsRevertError(err, "InvalidValue"), err)
+
+	err = SimulateSwapCreatorCall(ctx, ec, swapCreatorAddr, addr, defaultSwapValue, "newSwap", newSwapArgs...)
+	require.NoError(t, err)
+
+	txOpts := getAuth(t, pk)
+	txOpts.Value = defaultSwapValue
+	tx, err := swapCreator.NewSwap(txOpts, dummySwapKey, dummySwapKey, addr, defaultTimeoutDuration,
+		defaultTimeoutDuration, types.EthAssetETH.Address(), defaultSwapValue, nonce)
+	require.NoError(t, err)
+	receipt := getReceipt(t, ec, tx)
+	t1, t2, err := GetTimeoutsFromLog(receipt.Logs[0])
+	require.NoError(t, err)
+
+	swap := SwapCreatorSwap{
+		Owner:            addr,
+		Claimer:          addr,
+		ClaimCommitment:  dummySwapKey,
+		RefundCommitment: dummySwapKey,
+		Timeout1:         t1,
+		Timeout2:         t2,
+		Asset:            types.EthAssetETH.Address(),
+		Value:            defaultSwapValue,
+		Nonce:            nonce,
+	}
+
+	// the swap is not ready and timeout1 has not passed yet
+	err = SimulateSwapCreatorCall(ctx, ec, swapCreatorAddr, addr, nil, "claim", swap, [32]byte{})
+	require.True(t, IsRevertError(err, "TooEarlyToClaim"), err)
+
+	// the swap already exists
+	err = SimulateSwapCreatorCall(ctx, ec, swapCreatorAddr, addr, defaultSwapValue, "newSwap", newSwapArgs...)
+	require.True(t, IsRevertError(err, "SwapAlreadyExists"), err)
+}
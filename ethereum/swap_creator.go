@@ -29,6 +29,14 @@ var (
 	_ = abi.ConvertType
 )
 
+// SwapCreatorPermit is an auto generated low-level Go binding around an user-defined struct.
+type SwapCreatorPermit struct {
+	Deadline *big.Int
+	V        uint8
+	R        [32]byte
+	S        [32]byte
+}
+
 // SwapCreatorRelaySwap is an auto generated low-level Go binding around an user-defined struct.
 type SwapCreatorRelaySwap struct {
 	Swap        SwapCreatorSwap
@@ -52,8 +60,8 @@ type SwapCreatorSwap struct {
 
 // SwapCreatorMetaData contains all meta data concerning the SwapCreator contract.
 var SwapCreatorMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"name\":\"InvalidClaimer\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidContractAddress\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidRelayerAddress\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSecret\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSignature\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSwap\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSwapKey\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidTimeout\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidValue\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotTimeToRefund\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"OnlySwapClaimer\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"OnlySwapOwner\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"SwapAlreadyExists\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"SwapCompleted\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"SwapNotPending\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"TooEarlyToClaim\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"TooLateToClaim\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ZeroValue\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Claimed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"claimKey\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"refundKey\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"New\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"}],\"name\":\"Ready\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Refunded\",\"type\":\"event\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"}],\"name\":\"claim\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"swap\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"relayerHash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"swapCreator\",\"type\":\"address\"}],\"internalType\":\"structSwapCreator.RelaySwap\",\"name\":\"_relaySwap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_relayer\",\"type\":\"address\"},{\"internalType\":\"uint32\",\"name\":\"_salt\",\"type\":\"uint32\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"claimRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"scalar\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"qKeccak\",\"type\":\"uint256\"}],\"name\":\"mulVerify\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_claimer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_nonce\",\"type\":\"uint256\"}],\"name\":\"newSwap\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"}],\"name\":\"refund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"}],\"name\":\"setReady\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"swaps\",\"outputs\":[{\"internalType\":\"enumSwapCreator.Stage\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
-	Bin: "0x608060405234801561000f575f80fd5b506113fc8061001d5f395ff3fe60806040526004361061006e575f3560e01c8063b32d1b4f1161004c578063b32d1b4f146100d1578063c41e46cf14610105578063eb84e7f214610126578063fcaf229c14610161575f80fd5b80631e6c5acc146100725780635cb969161461009357806387065c49146100b2575b5f80fd5b34801561007d575f80fd5b5061009161008c366004611040565b610180565b005b34801561009e575f80fd5b506100916100ad366004611040565b610362565b3480156100bd575f80fd5b506100916100cc36600461108e565b610425565b3480156100dc575f80fd5b506100f06100eb366004611146565b610688565b60405190151581526020015b60405180910390f35b610118610113366004611166565b610754565b6040519081526020016100fc565b348015610131575f80fd5b506101546101403660046111d2565b5f6020819052908152604090205460ff1681565b6040516100fc91906111fd565b34801561016c575f80fd5b5061009161017b366004611223565b6109cc565b5f8260405160200161019291906112ad565b60408051601f1981840301815291815281516020928301205f818152928390529082205490925060ff16908160038111156101cf576101cf6111e9565b036101ed57604051631115766760e01b815260040160405180910390fd5b6003816003811115610201576102016111e9565b0361021f5760405163066916a960e01b815260040160405180910390fd5b83516001600160a01b031633146102495760405163148ca24360e11b815260040160405180910390fd5b8360a001514210801561027a5750836080015142118061027a57506002816003811115610278576102786111e9565b145b15610298576040516332a1860f60e11b815260040160405180910390fd5b6102a6838560600151610aa7565b604051839083907e7c875846b687732a7579c19bb1dade66cd14e9f4f809565e2b2b5e76c72b4f905f90a35f828152602081905260409020805460ff1916600317905560c08401516001600160a01b031661033b57835160e08501516040516001600160a01b039092169181156108fc0291905f818181858888f19350505050158015610335573d5f803e3d5ffd5b5061035c565b835160e085015160c086015161035c926001600160a01b0390911691610ace565b50505050565b81602001516001600160a01b0316336001600160a01b03161461039857604051633471640960e11b815260040160405180910390fd5b6103a28282610b31565b60c08201516001600160a01b03166103f75781602001516001600160a01b03166108fc8360e0015190811502906040515f60405180830381858888f193505050501580156103f2573d5f803e3d5ffd5b505050565b61042182602001518360e001518460c001516001600160a01b0316610ace9092919063ffffffff16565b5050565b5f60018860405160200161043991906112bc565b60408051601f1981840301815282825280516020918201205f84529083018083525260ff871690820152606081018590526080810184905260a0016020604051602081039080840390855afa158015610494573d5f803e3d5ffd5b505050602060405103519050875f0151602001516001600160a01b0316816001600160a01b0316146104d957604051638baa579f60e01b815260040160405180910390fd5b87606001516001600160a01b0316306001600160a01b03161461050f5760405163a710429d60e01b815260040160405180910390fd5b60408089015190516bffffffffffffffffffffffff19606089901b1660208201526001600160e01b031960e088901b166034820152603801604051602081830303815290604052805190602001201461057b5760405163fe16c3c560e01b815260040160405180910390fd5b87516105879088610b31565b875160c001516001600160a01b031661062757875f0151602001516001600160a01b03166108fc89602001518a5f015160e001516105c59190611312565b6040518115909202915f818181858888f193505050501580156105ea573d5f803e3d5ffd5b5060208801516040516001600160a01b0388169180156108fc02915f818181858888f19350505050158015610621573d5f803e3d5ffd5b5061067e565b8751602080820151908a015160e09092015161065c9261064691611312565b8a5160c001516001600160a01b03169190610ace565b6020880151885160c0015161067e916001600160a01b03909116908890610ace565b5050505050505050565b5f80600181601b7f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f8179870014551231950b75fc4402da1732fc9bebe197f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f817988909604080515f8152602081018083529590955260ff909316928401929092526060830152608082015260a0016020604051602081039080840390855afa158015610731573d5f803e3d5ffd5b5050604051601f1901516001600160a01b03858116911614925050505b92915050565b5f825f0361077557604051637c946ed760e01b815260040160405180910390fd5b6001600160a01b0384166107a8573483146107a357604051632a9ffab760e21b815260040160405180910390fd5b6107bd565b6107bd6001600160a01b038516333086610c8e565b8815806107c8575087155b156107e657604051631bc61bed60e11b815260040160405180910390fd5b6001600160a01b03871661080c576040516208978560e71b815260040160405180910390fd5b851580610817575084155b1561083557604051631ffb86f160e21b815260040160405180910390fd5b5f604051806101200160405280336001600160a01b03168152602001896001600160a01b031681526020018b81526020018a815260200188426108789190611325565b8152602001876108888a42611325565b6108929190611325565b8152602001866001600160a01b031681526020018581526020018481525090505f816040516020016108c491906112ad565b60408051601f19818403018152919052805160209091012090505f808281526020819052604090205460ff166003811115610901576109016111e9565b1461091f576040516339a2986760e11b815260040160405180910390fd5b7f91446ce035ac29998b5473504609a5ef5e961005daba4630a1684b63be848f56818c8c85608001518660a001518760c001518860e0015160405161099e979695949392919096875260208701959095526040860193909352606085019190915260808401526001600160a01b031660a083015260c082015260e00190565b60405180910390a15f818152602081905260409020805460ff191660011790559a9950505050505050505050565b5f816040516020016109de91906112ad565b60408051601f198184030181529190528051602090910120905060015f8281526020819052604090205460ff166003811115610a1c57610a1c6111e9565b14610a3a57604051630fe0fb5160e11b815260040160405180910390fd5b81516001600160a01b03163314610a645760405163148ca24360e11b815260040160405180910390fd5b5f81815260208190526040808220805460ff191660021790555182917f5fc23b25552757626e08b316cc2387ad1bc70ee1594af7204db4ce0c39f5d15f91a25050565b610ab18282610688565b6104215760405163abab6bd760e01b815260040160405180910390fd5b6040516001600160a01b0383166024820152604481018290526103f290849063a9059cbb60e01b906064015b60408051601f198184030181529190526020810180516001600160e01b03166001600160e01b031990931692909217909152610cc6565b5f82604051602001610b4391906112ad565b60408051601f1981840301815291815281516020928301205f818152928390529082205490925060ff1690816003811115610b8057610b806111e9565b03610b9e57604051631115766760e01b815260040160405180910390fd5b6003816003811115610bb257610bb26111e9565b03610bd05760405163066916a960e01b815260040160405180910390fd5b836080015142108015610bf557506002816003811115610bf257610bf26111e9565b14155b15610c135760405163d71d60b560e01b815260040160405180910390fd5b8360a001514210610c375760405163497df9d160e01b815260040160405180910390fd5b610c45838560400151610aa7565b604051839083907f38d6042dbdae8e73a7f6afbabd3fbe0873f9f5ed3cd71294591c3908c2e65fee905f90a3505f908152602081905260409020805460ff191660031790555050565b6040516001600160a01b038085166024830152831660448201526064810182905261035c9085906323b872dd60e01b90608401610afa565b5f610d1a826040518060400160405280602081526020017f5361666545524332303a206c6f772d6c6576656c2063616c6c206661696c6564815250856001600160a01b0316610d9e9092919063ffffffff16565b905080515f1480610d3a575080806020019051810190610d3a9190611338565b6103f25760405162461bcd60e51b815260206004820152602a60248201527f5361666545524332303a204552433230206f7065726174696f6e20646964206e6044820152691bdd081cdd58d8d9595960b21b60648201526084015b60405180910390fd5b6060610dac84845f85610db4565b949350505050565b606082471015610e155760405162461bcd60e51b815260206004820152602660248201527f416464726573733a20696e73756666696369656e742062616c616e636520666f6044820152651c8818d85b1b60d21b6064820152608401610d95565b5f80866001600160a01b03168587604051610e309190611379565b5f6040518083038185875af1925050503d805f8114610e6a576040519150601f19603f3d011682016040523d82523d5f602084013e610e6f565b606091505b5091509150610e8087838387610e8b565b979650505050505050565b60608315610ef95782515f03610ef2576001600160a01b0385163b610ef25760405162461bcd60e51b815260206004820152601d60248201527f416464726573733a2063616c6c20746f206e6f6e2d636f6e74726163740000006044820152606401610d95565b5081610dac565b610dac8383815115610f0e5781518083602001fd5b8060405162461bcd60e51b8152600401610d959190611394565b604051610120810167ffffffffffffffff81118282101715610f5857634e487b7160e01b5f52604160045260245ffd5b60405290565b6040516080810167ffffffffffffffff81118282101715610f5857634e487b7160e01b5f52604160045260245ffd5b6001600160a01b0381168114610fa1575f80fd5b50565b8035610faf81610f8d565b919050565b5f6101208284031215610fc5575f80fd5b610fcd610f28565b9050610fd882610fa4565b8152610fe660208301610fa4565b602082015260408201356040820152606082013560608201526080820135608082015260a082013560a082015261101f60c08301610fa4565b60c082015260e082013560e082015261010080830135818301525092915050565b5f806101408385031215611052575f80fd5b61105c8484610fb4565b94610120939093013593505050565b803563ffffffff81168114610faf575f80fd5b803560ff81168114610faf575f80fd5b5f805f805f805f8789036102408112156110a6575f80fd5b610180808212156110b5575f80fd5b6110bd610f5e565b91506110c98b8b610fb4565b82526101208a013560208301526101408a013560408301526101608a01356110f081610f8d565b6060830152909750880135955061110a6101a08901610fa4565b94506111196101c0890161106b565b93506111286101e0890161107e565b92506102008801359150610220880135905092959891949750929550565b5f8060408385031215611157575f80fd5b50508035926020909101359150565b5f805f805f805f80610100898b03121561117e575f80fd5b8835975060208901359650604089013561119781610f8d565b9550606089013594506080890135935060a08901356111b581610f8d565b979a969950949793969295929450505060c08201359160e0013590565b5f602082840312156111e2575f80fd5b5035919050565b634e487b7160e01b5f52602160045260245ffd5b602081016004831061121d57634e487b7160e01b5f52602160045260245ffd5b91905290565b5f6101208284031215611234575f80fd5b61123e8383610fb4565b9392505050565b60018060a01b0380825116835280602083015116602084015260408201516040840152606082015160608401526080820151608084015260a082015160a08401528060c08301511660c08401525060e081015160e08301526101008082015181840152505050565b610120810161074e8284611245565b5f610180820190506112cf828451611245565b602083015161012083015260408301516101408301526060909201516001600160a01b03166101609091015290565b634e487b7160e01b5f52601160045260245ffd5b8181038181111561074e5761074e6112fe565b8082018082111561074e5761074e6112fe565b5f60208284031215611348575f80fd5b8151801515811461123e575f80fd5b5f5b83811015611371578181015183820152602001611359565b50505f910152565b5f825161138a818460208701611357565b9190910192915050565b602081525f82518060208401526113b2816040850160208701611357565b601f01601f1916919091016040019291505056fea264697066735822122058723d6f94b6ae0fd67bfece90eee43db933ef07d0eecef13276b9cf2f7a7b7e64736f6c63430008140033",
+	ABI: "[{\"inputs\":[],\"name\":\"InvalidBatch\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidClaimer\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidContractAddress\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidPayout\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidRelayerAddress\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSecret\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSignature\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSwap\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSwapKey\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidTimeout\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidValue\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotTimeToRefund\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"OnlySwapClaimer\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"OnlySwapOwner\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ReentrantCall\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"SwapAlreadyExists\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"SwapCompleted\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"SwapNotPending\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"TooEarlyToClaim\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"TooLateToClaim\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"TransferFailed\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ZeroValue\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Claimed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"claimKey\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"refundKey\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"New\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"}],\"name\":\"Ready\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Refunded\",\"type\":\"event\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"}],\"name\":\"claim\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap[]\",\"name\":\"_swaps\",\"type\":\"tuple[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_secrets\",\"type\":\"bytes32[]\"},{\"internalType\":\"addresspayable\",\"name\":\"_payout\",\"type\":\"address\"}],\"name\":\"claimBatch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"swap\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"relayerHash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"swapCreator\",\"type\":\"address\"}],\"internalType\":\"structSwapCreator.RelaySwap\",\"name\":\"_relaySwap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_relayer\",\"type\":\"address\"},{\"internalType\":\"uint32\",\"name\":\"_salt\",\"type\":\"uint32\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"claimRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_payout\",\"type\":\"address\"}],\"name\":\"claimTo\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"scalar\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"qKeccak\",\"type\":\"uint256\"}],\"name\":\"mulVerify\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_claimer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_nonce\",\"type\":\"uint256\"}],\"name\":\"newSwap\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_claimer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_nonce\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"internalType\":\"structSwapCreator.Permit\",\"name\":\"_permit\",\"type\":\"tuple\"}],\"name\":\"newSwapWithPermit\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"}],\"name\":\"refund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap[]\",\"name\":\"_swaps\",\"type\":\"tuple[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"_secrets\",\"type\":\"bytes32[]\"},{\"internalType\":\"addresspayable\",\"name\":\"_payout\",\"type\":\"address\"}],\"name\":\"refundBatch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"swap\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"relayerHash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"swapCreator\",\"type\":\"address\"}],\"internalType\":\"structSwapCreator.RelaySwap\",\"name\":\"_relaySwap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_relayer\",\"type\":\"address\"},{\"internalType\":\"uint32\",\"name\":\"_salt\",\"type\":\"uint32\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"refundRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"_secret\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_payout\",\"type\":\"address\"}],\"name\":\"refundTo\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"claimCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"refundCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout2\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"internalType\":\"structSwapCreator.Swap\",\"name\":\"_swap\",\"type\":\"tuple\"}],\"name\":\"setReady\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"swaps\",\"outputs\":[{\"internalType\":\"enumSwapCreator.Stage\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561000f575f80fd5b50611ce28061001d5f395ff3fe6080604052600436106100bf575f3560e01c8063a2ede5191161007c578063ca53df5711610057578063ca53df57146101f3578063cd69500614610212578063eb84e7f214610231578063fcaf229c1461026c575f80fd5b8063a2ede51914610192578063b32d1b4f146101b1578063c41e46cf146101e0575f80fd5b80631e6c5acc146100c35780633458bbf9146100e4578063475a0253146101035780635cb9691614610122578063687044ae1461014157806387065c4914610173575b5f80fd5b3480156100ce575f80fd5b506100e26100dd366004611665565b61028b565b005b3480156100ef575f80fd5b506100e26100fe366004611690565b6102d8565b34801561010e575f80fd5b506100e261011d3660046116f4565b610322565b34801561012d575f80fd5b506100e261013c366004611665565b6104fe565b34801561014c575f80fd5b5061016061015b3660046117ac565b610540565b6040519081526020015b60405180910390f35b34801561017e575f80fd5b506100e261018d3660046116f4565b610648565b34801561019d575f80fd5b506100e26101ac3660046118f3565b6107de565b3480156101bc575f80fd5b506101d06101cb3660046119bf565b6108a6565b604051901515815260200161016a565b6101606101ee3660046119df565b610972565b3480156101fe575f80fd5b506100e261020d366004611690565b6109f8565b34801561021d575f80fd5b506100e261022c3660046118f3565b610a33565b34801561023c575f80fd5b5061025f61024b366004611a4b565b5f6020819052908152604090205460ff1681565b60405161016a9190611a76565b348015610277575f80fd5b506100e2610286366004611a9c565b610aeb565b60015460ff16156102af576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff19168117905581516102ca9083908390610bc6565b50506001805460ff19169055565b60015460ff16156102fc576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff191681179055610313838383610bc6565b50506001805460ff1916905550565b60015460ff1615610346576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff1916811781556040515f9190610388907fcc8b3921acd6543e529d34779e916e73be3ec8d44ce5b61354c5f3a5e51875c6908b90602001611b56565b60408051601f1981840301815282825280516020918201205f84529083018083525260ff871690820152606081018590526080810184905260a0016020604051602081039080840390855afa1580156103e3573d5f803e3d5ffd5b5050604051601f1901518951519092506001600160a01b03808416911614905061042057604051638baa579f60e01b815260040160405180910390fd5b87606001516001600160a01b0316306001600160a01b0316146104565760405163a710429d60e01b815260040160405180910390fd5b8760400151868660405160200161046e929190611b6b565b60405160208183030381529060405280519060200120146104a25760405163fe16c3c560e01b815260040160405180910390fd5b87516104ae9088610c39565b875160c0810151815160208b015160e0909301516104d4936104cf91611bae565b610d86565b6104ea885f015160c00151878a60200151610d86565b50506001805460ff19169055505050505050565b60015460ff1615610522576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff19168117905560208201516102ca9083908390610e1e565b5f835f0361056157604051637c946ed760e01b815260040160405180910390fd5b6001600160a01b03851661058857604051632a9ffab760e21b815260040160405180910390fd5b815160208301516040808501516060860151915163d505accf60e01b815233600482015230602482015260448101899052606481019490945260ff909216608484015260a483019190915260c48201526001600160a01b0386169063d505accf9060e4015f604051808303815f87803b158015610603575f80fd5b505af1925050508015610614575060015b5061062a6001600160a01b038616333087610e85565b61063a8a8a8a8a8a8a8a8a610ef0565b9a9950505050505050505050565b60015460ff161561066c576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff1916811781556040515f919061068c908a90602001611bc1565b60408051601f1981840301815282825280516020918201205f84529083018083525260ff871690820152606081018590526080810184905260a0016020604051602081039080840390855afa1580156106e7573d5f803e3d5ffd5b505050602060405103519050875f0151602001516001600160a01b0316816001600160a01b03161461072c57604051638baa579f60e01b815260040160405180910390fd5b87606001516001600160a01b0316306001600160a01b0316146107625760405163a710429d60e01b815260040160405180910390fd5b8760400151868660405160200161077a929190611b6b565b60405160208183030381529060405280519060200120146107ae5760405163fe16c3c560e01b815260040160405180910390fd5b87516107ba9088611100565b875160c0810151602080830151908b015160e0909301516104d4936104cf91611bae565b60015460ff1615610802576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff1916811790558251158061081e57508151835114155b1561083c576040516333b094a160e01b815260040160405180910390fd5b5f5b83518110156108965761088484828151811061085c5761085c611bd0565b602002602001015184838151811061087657610876611bd0565b602002602001015184610e1e565b8061088e81611be4565b91505061083e565b50506001805460ff191690555050565b5f80600181601b7f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f8179870014551231950b75fc4402da1732fc9bebe197f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f817988909604080515f8152602081018083529590955260ff909316928401929092526060830152608082015260a0016020604051602081039080840390855afa15801561094f573d5f803e3d5ffd5b5050604051601f1901516001600160a01b03858116911614925050505b92915050565b5f825f0361099357604051637c946ed760e01b815260040160405180910390fd5b6001600160a01b0384166109c6573483146109c157604051632a9ffab760e21b815260040160405180910390fd5b6109db565b6109db6001600160a01b038516333086610e85565b6109eb8989898989898989610ef0565b9998505050505050505050565b60015460ff1615610a1c576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff191681179055610313838383610e1e565b60015460ff1615610a57576040516306fda65d60e31b815260040160405180910390fd5b6001805460ff19168117905582511580610a7357508151835114155b15610a91576040516333b094a160e01b815260040160405180910390fd5b5f5b835181101561089657610ad9848281518110610ab157610ab1611bd0565b6020026020010151848381518110610acb57610acb611bd0565b602002602001015184610bc6565b80610ae381611be4565b915050610a93565b5f81604051602001610afd9190611bfc565b60408051601f198184030181529190528051602090910120905060015f8281526020819052604090205460ff166003811115610b3b57610b3b611a62565b14610b5957604051630fe0fb5160e11b815260040160405180910390fd5b81516001600160a01b03163314610b835760405163148ca24360e11b815260040160405180910390fd5b5f81815260208190526040808220805460ff191660021790555182917f5fc23b25552757626e08b316cc2387ad1bc70ee1594af7204db4ce0c39f5d15f91a25050565b6001600160a01b038116610bed576040516338e04ef560e21b815260040160405180910390fd5b82516001600160a01b03163314610c175760405163148ca24360e11b815260040160405180910390fd5b610c218383610c39565b610c348360c00151828560e00151610d86565b505050565b5f82604051602001610c4b9190611bfc565b60408051601f1981840301815291815281516020928301205f818152928390529082205490925060ff1690816003811115610c8857610c88611a62565b03610ca657604051631115766760e01b815260040160405180910390fd5b6003816003811115610cba57610cba611a62565b03610cd85760405163066916a960e01b815260040160405180910390fd5b8360a0015142108015610d0957508360800151421180610d0957506002816003811115610d0757610d07611a62565b145b15610d27576040516332a1860f60e11b815260040160405180910390fd5b610d3583856060015161125f565b604051839083907e7c875846b687732a7579c19bb1dade66cd14e9f4f809565e2b2b5e76c72b4f905f90a35f82815260208190526040902080546003919060ff19166001835b021790555050505050565b6001600160a01b038316610e0a575f826001600160a01b0316826040515f6040518083038185875af1925050503d805f8114610ddd576040519150601f19603f3d011682016040523d82523d5f602084013e610de2565b606091505b5050905080610e04576040516312171d8360e31b815260040160405180910390fd5b50505050565b610c346001600160a01b038416838361128a565b82602001516001600160a01b0316336001600160a01b031614610e5457604051633471640960e11b815260040160405180910390fd5b6001600160a01b038116610e7b576040516338e04ef560e21b815260040160405180910390fd5b610c218383611100565b6040516001600160a01b0380851660248301528316604482015260648101829052610e049085906323b872dd60e01b906084015b60408051601f198184030181529190526020810180516001600160e01b03166001600160e01b0319909316929092179091526112ba565b5f881580610efc575087155b15610f1a57604051631bc61bed60e11b815260040160405180910390fd5b6001600160a01b038716610f40576040516208978560e71b815260040160405180910390fd5b851580610f4b575084155b15610f6957604051631ffb86f160e21b815260040160405180910390fd5b5f604051806101200160405280336001600160a01b03168152602001896001600160a01b031681526020018b81526020018a81526020018842610fac9190611c0b565b815260200187610fbc8a42611c0b565b610fc69190611c0b565b8152602001866001600160a01b031681526020018581526020018481525090505f81604051602001610ff89190611bfc565b60408051601f19818403018152919052805160209091012090505f808281526020819052604090205460ff16600381111561103557611035611a62565b14611053576040516339a2986760e11b815260040160405180910390fd5b7f91446ce035ac29998b5473504609a5ef5e961005daba4630a1684b63be848f56818c8c85608001518660a001518760c001518860e001516040516110d2979695949392919096875260208701959095526040860193909352606085019190915260808401526001600160a01b031660a083015260c082015260e00190565b60405180910390a15f818152602081905260409020805460ff191660011790559a9950505050505050505050565b5f826040516020016111129190611bfc565b60408051601f1981840301815291815281516020928301205f818152928390529082205490925060ff169081600381111561114f5761114f611a62565b0361116d57604051631115766760e01b815260040160405180910390fd5b600381600381111561118157611181611a62565b0361119f5760405163066916a960e01b815260040160405180910390fd5b8360800151421080156111c4575060028160038111156111c1576111c1611a62565b14155b156111e25760405163d71d60b560e01b815260040160405180910390fd5b8360a0015142106112065760405163497df9d160e01b815260040160405180910390fd5b61121483856040015161125f565b604051839083907f38d6042dbdae8e73a7f6afbabd3fbe0873f9f5ed3cd71294591c3908c2e65fee905f90a35f82815260208190526040902080546003919060ff1916600183610d7b565b61126982826108a6565b6112865760405163abab6bd760e01b815260040160405180910390fd5b5050565b6040516001600160a01b038316602482015260448101829052610c3490849063a9059cbb60e01b90606401610eb9565b5f61130e826040518060400160405280602081526020017f5361666545524332303a206c6f772d6c6576656c2063616c6c206661696c6564815250856001600160a01b03166113929092919063ffffffff16565b905080515f148061132e57508080602001905181019061132e9190611c1e565b610c345760405162461bcd60e51b815260206004820152602a60248201527f5361666545524332303a204552433230206f7065726174696f6e20646964206e6044820152691bdd081cdd58d8d9595960b21b60648201526084015b60405180910390fd5b60606113a084845f856113aa565b90505b9392505050565b60608247101561140b5760405162461bcd60e51b815260206004820152602660248201527f416464726573733a20696e73756666696369656e742062616c616e636520666f6044820152651c8818d85b1b60d21b6064820152608401611389565b5f80866001600160a01b031685876040516114269190611c5f565b5f6040518083038185875af1925050503d805f8114611460576040519150601f19603f3d011682016040523d82523d5f602084013e611465565b606091505b509150915061147687838387611483565b925050505b949350505050565b606083156114f15782515f036114ea576001600160a01b0385163b6114ea5760405162461bcd60e51b815260206004820152601d60248201527f416464726573733a2063616c6c20746f206e6f6e2d636f6e74726163740000006044820152606401611389565b508161147b565b61147b83838151156115065781518083602001fd5b8060405162461bcd60e51b81526004016113899190611c7a565b634e487b7160e01b5f52604160045260245ffd5b604051610120810167ffffffffffffffff8111828210171561155857611558611520565b60405290565b6040516080810167ffffffffffffffff8111828210171561155857611558611520565b604051601f8201601f1916810167ffffffffffffffff811182821017156115aa576115aa611520565b604052919050565b6001600160a01b03811681146115c6575f80fd5b50565b80356115d4816115b2565b919050565b5f61012082840312156115ea575f80fd5b6115f2611534565b90506115fd826115c9565b815261160b602083016115c9565b602082015260408201356040820152606082013560608201526080820135608082015260a082013560a082015261164460c083016115c9565b60c082015260e082013560e082015261010080830135818301525092915050565b5f806101408385031215611677575f80fd5b61168184846115d9565b94610120939093013593505050565b5f805f61016084860312156116a3575f80fd5b6116ad85856115d9565b925061012084013591506101408401356116c6816115b2565b809150509250925092565b803563ffffffff811681146115d4575f80fd5b803560ff811681146115d4575f80fd5b5f805f805f805f87890361024081121561170c575f80fd5b6101808082121561171b575f80fd5b61172361155e565b915061172f8b8b6115d9565b82526101208a013560208301526101408a013560408301526101608a0135611756816115b2565b606083015290975088013595506117706101a089016115c9565b945061177f6101c089016116d1565b935061178e6101e089016116e4565b92506102008801359150610220880135905092959891949750929550565b5f805f805f805f805f898b036101808112156117c6575f80fd5b8a35995060208b0135985060408b01356117df816115b2565b975060608b0135965060808b0135955060a08b01356117fd816115b2565b945060c08b0135935060e08b01359250608060ff198201121561181e575f80fd5b5061182761155e565b6101008b0135815261183c6101208c016116e4565b60208201526101408b013560408201526101608b01356060820152809150509295985092959850929598565b5f67ffffffffffffffff82111561188157611881611520565b5060051b60200190565b5f82601f83011261189a575f80fd5b813560206118af6118aa83611868565b611581565b82815260059290921b840181019181810190868411156118cd575f80fd5b8286015b848110156118e857803583529183019183016118d1565b509695505050505050565b5f805f60608486031215611905575f80fd5b833567ffffffffffffffff8082111561191c575f80fd5b818601915086601f83011261192f575f80fd5b8135602061193f6118aa83611868565b828152610120928302850182019282820191908b85111561195e575f80fd5b958301955b84871015611984576119758c886115d9565b83529586019591830191611963565b509750508701359250508082111561199a575f80fd5b506119a78682870161188b565b9250506119b6604085016115c9565b90509250925092565b5f80604083850312156119d0575f80fd5b50508035926020909101359150565b5f805f805f805f80610100898b0312156119f7575f80fd5b88359750602089013596506040890135611a10816115b2565b9550606089013594506080890135935060a0890135611a2e816115b2565b979a969950949793969295929450505060c08201359160e0013590565b5f60208284031215611a5b575f80fd5b5035919050565b634e487b7160e01b5f52602160045260245ffd5b6020810160048310611a9657634e487b7160e01b5f52602160045260245ffd5b91905290565b5f6101208284031215611aad575f80fd5b6113a383836115d9565b60018060a01b0380825116835280602083015116602084015260408201516040840152606082015160608401526080820151608084015260a082015160a08401528060c08301511660c08401525060e081015160e08301526101008082015181840152505050565b611b2a828251611ab7565b60208101516101208301526040810151610140830152606001516001600160a01b031661016090910152565b8281526101a081016113a36020830184611b1f565b60609290921b6bffffffffffffffffffffffff1916825260e01b6001600160e01b031916601482015260180190565b634e487b7160e01b5f52601160045260245ffd5b8181038181111561096c5761096c611b9a565b610180810161096c8284611b1f565b634e487b7160e01b5f52603260045260245ffd5b5f60018201611bf557611bf5611b9a565b5060010190565b610120810161096c8284611ab7565b8082018082111561096c5761096c611b9a565b5f60208284031215611c2e575f80fd5b815180151581146113a3575f80fd5b5f5b83811015611c57578181015183820152602001611c3f565b50505f910152565b5f8251611c70818460208701611c3d565b9190910192915050565b602081525f8251806020840152611c98816040850160208701611c3d565b601f01601f1916919091016040019291505056fea264697066735822122089e10e482080c82b58efc86c83fc96b40d3805940c79f2fbc21b6caf5b0c7bc564736f6c63430008150033",
 }
 
 // SwapCreatorABI is the input ABI used to generate the binding from.
@@ -306,6 +314,27 @@ func (_SwapCreator *SwapCreatorTransactorSession) Claim(_swap SwapCreatorSwap, _
 	return _SwapCreator.Contract.Claim(&_SwapCreator.TransactOpts, _swap, _secret)
 }
 
+// ClaimBatch is a paid mutator transaction binding the contract method 0xa2ede519.
+//
+// Solidity: function claimBatch((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256)[] _swaps, bytes32[] _secrets, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactor) ClaimBatch(opts *bind.TransactOpts, _swaps []SwapCreatorSwap, _secrets [][32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.contract.Transact(opts, "claimBatch", _swaps, _secrets, _payout)
+}
+
+// ClaimBatch is a paid mutator transaction binding the contract method 0xa2ede519.
+//
+// Solidity: function claimBatch((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256)[] _swaps, bytes32[] _secrets, address _payout) returns()
+func (_SwapCreator *SwapCreatorSession) ClaimBatch(_swaps []SwapCreatorSwap, _secrets [][32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.ClaimBatch(&_SwapCreator.TransactOpts, _swaps, _secrets, _payout)
+}
+
+// ClaimBatch is a paid mutator transaction binding the contract method 0xa2ede519.
+//
+// Solidity: function claimBatch((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256)[] _swaps, bytes32[] _secrets, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactorSession) ClaimBatch(_swaps []SwapCreatorSwap, _secrets [][32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.ClaimBatch(&_SwapCreator.TransactOpts, _swaps, _secrets, _payout)
+}
+
 // ClaimRelayer is a paid mutator transaction binding the contract method 0x87065c49.
 //
 // Solidity: function claimRelayer(((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256),uint256,bytes32,address) _relaySwap, bytes32 _secret, address _relayer, uint32 _salt, uint8 v, bytes32 r, bytes32 s) returns()
@@ -327,6 +356,27 @@ func (_SwapCreator *SwapCreatorTransactorSession) ClaimRelayer(_relaySwap SwapCr
 	return _SwapCreator.Contract.ClaimRelayer(&_SwapCreator.TransactOpts, _relaySwap, _secret, _relayer, _salt, v, r, s)
 }
 
+// ClaimTo is a paid mutator transaction binding the contract method 0xca53df57.
+//
+// Solidity: function claimTo((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap, bytes32 _secret, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactor) ClaimTo(opts *bind.TransactOpts, _swap SwapCreatorSwap, _secret [32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.contract.Transact(opts, "claimTo", _swap, _secret, _payout)
+}
+
+// ClaimTo is a paid mutator transaction binding the contract method 0xca53df57.
+//
+// Solidity: function claimTo((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap, bytes32 _secret, address _payout) returns()
+func (_SwapCreator *SwapCreatorSession) ClaimTo(_swap SwapCreatorSwap, _secret [32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.ClaimTo(&_SwapCreator.TransactOpts, _swap, _secret, _payout)
+}
+
+// ClaimTo is a paid mutator transaction binding the contract method 0xca53df57.
+//
+// Solidity: function claimTo((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap, bytes32 _secret, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactorSession) ClaimTo(_swap SwapCreatorSwap, _secret [32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.ClaimTo(&_SwapCreator.TransactOpts, _swap, _secret, _payout)
+}
+
 // NewSwap is a paid mutator transaction binding the contract method 0xc41e46cf.
 //
 // Solidity: function newSwap(bytes32 _claimCommitment, bytes32 _refundCommitment, address _claimer, uint256 _timeoutDuration1, uint256 _timeoutDuration2, address _asset, uint256 _value, uint256 _nonce) payable returns(bytes32)
@@ -348,6 +398,27 @@ func (_SwapCreator *SwapCreatorTransactorSession) NewSwap(_claimCommitment [32]b
 	return _SwapCreator.Contract.NewSwap(&_SwapCreator.TransactOpts, _claimCommitment, _refundCommitment, _claimer, _timeoutDuration1, _timeoutDuration2, _asset, _value, _nonce)
 }
 
+// NewSwapWithPermit is a paid mutator transaction binding the contract method 0x687044ae.
+//
+// Solidity: function newSwapWithPermit(bytes32 _claimCommitment, bytes32 _refundCommitment, address _claimer, uint256 _timeoutDuration1, uint256 _timeoutDuration2, address _asset, uint256 _value, uint256 _nonce, (uint256,uint8,bytes32,bytes32) _permit) returns(bytes32)
+func (_SwapCreator *SwapCreatorTransactor) NewSwapWithPermit(opts *bind.TransactOpts, _claimCommitment [32]byte, _refundCommitment [32]byte, _claimer common.Address, _timeoutDuration1 *big.Int, _timeoutDuration2 *big.Int, _asset common.Address, _value *big.Int, _nonce *big.Int, _permit SwapCreatorPermit) (*types.Transaction, error) {
+	return _SwapCreator.contract.Transact(opts, "newSwapWithPermit", _claimCommitment, _refundCommitment, _claimer, _timeoutDuration1, _timeoutDuration2, _asset, _value, _nonce, _permit)
+}
+
+// NewSwapWithPermit is a paid mutator transaction binding the contract method 0x687044ae.
+//
+// Solidity: function newSwapWithPermit(bytes32 _claimCommitment, bytes32 _refundCommitment, address _claimer, uint256 _timeoutDuration1, uint256 _timeoutDuration2, address _asset, uint256 _value, uint256 _nonce, (uint256,uint8,bytes32,bytes32) _permit) returns(bytes32)
+func (_SwapCreator *SwapCreatorSession) NewSwapWithPermit(_claimCommitment [32]byte, _refundCommitment [32]byte, _claimer common.Address, _timeoutDuration1 *big.Int, _timeoutDuration2 *big.Int, _asset common.Address, _value *big.Int, _nonce *big.Int, _permit SwapCreatorPermit) (*types.Transaction, error) {
+	return _SwapCreator.Contract.NewSwapWithPermit(&_SwapCreator.TransactOpts, _claimCommitment, _refundCommitment, _claimer, _timeoutDuration1, _timeoutDuration2, _asset, _value, _nonce, _permit)
+}
+
+// NewSwapWithPermit is a paid mutator transaction binding the contract method 0x687044ae.
+//
+// Solidity: function newSwapWithPermit(bytes32 _claimCommitment, bytes32 _refundCommitment, address _claimer, uint256 _timeoutDuration1, uint256 _timeoutDuration2, address _asset, uint256 _value, uint256 _nonce, (uint256,uint8,bytes32,bytes32) _permit) returns(bytes32)
+func (_SwapCreator *SwapCreatorTransactorSession) NewSwapWithPermit(_claimCommitment [32]byte, _refundCommitment [32]byte, _claimer common.Address, _timeoutDuration1 *big.Int, _timeoutDuration2 *big.Int, _asset common.Address, _value *big.Int, _nonce *big.Int, _permit SwapCreatorPermit) (*types.Transaction, error) {
+	return _SwapCreator.Contract.NewSwapWithPermit(&_SwapCreator.TransactOpts, _claimCommitment, _refundCommitment, _claimer, _timeoutDuration1, _timeoutDuration2, _asset, _value, _nonce, _permit)
+}
+
 // Refund is a paid mutator transaction binding the contract method 0x1e6c5acc.
 //
 // Solidity: function refund((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap, bytes32 _secret) returns()
@@ -369,6 +440,69 @@ func (_SwapCreator *SwapCreatorTransactorSession) Refund(_swap SwapCreatorSwap,
 	return _SwapCreator.Contract.Refund(&_SwapCreator.TransactOpts, _swap, _secret)
 }
 
+// RefundBatch is a paid mutator transaction binding the contract method 0xcd695006.
+//
+// Solidity: function refundBatch((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256)[] _swaps, bytes32[] _secrets, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactor) RefundBatch(opts *bind.TransactOpts, _swaps []SwapCreatorSwap, _secrets [][32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.contract.Transact(opts, "refundBatch", _swaps, _secrets, _payout)
+}
+
+// RefundBatch is a paid mutator transaction binding the contract method 0xcd695006.
+//
+// Solidity: function refundBatch((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256)[] _swaps, bytes32[] _secrets, address _payout) returns()
+func (_SwapCreator *SwapCreatorSession) RefundBatch(_swaps []SwapCreatorSwap, _secrets [][32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.RefundBatch(&_SwapCreator.TransactOpts, _swaps, _secrets, _payout)
+}
+
+// RefundBatch is a paid mutator transaction binding the contract method 0xcd695006.
+//
+// Solidity: function refundBatch((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256)[] _swaps, bytes32[] _secrets, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactorSession) RefundBatch(_swaps []SwapCreatorSwap, _secrets [][32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.RefundBatch(&_SwapCreator.TransactOpts, _swaps, _secrets, _payout)
+}
+
+// RefundRelayer is a paid mutator transaction binding the contract method 0x475a0253.
+//
+// Solidity: function refundRelayer(((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256),uint256,bytes32,address) _relaySwap, bytes32 _secret, address _relayer, uint32 _salt, uint8 v, bytes32 r, bytes32 s) returns()
+func (_SwapCreator *SwapCreatorTransactor) RefundRelayer(opts *bind.TransactOpts, _relaySwap SwapCreatorRelaySwap, _secret [32]byte, _relayer common.Address, _salt uint32, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _SwapCreator.contract.Transact(opts, "refundRelayer", _relaySwap, _secret, _relayer, _salt, v, r, s)
+}
+
+// RefundRelayer is a paid mutator transaction binding the contract method 0x475a0253.
+//
+// Solidity: function refundRelayer(((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256),uint256,bytes32,address) _relaySwap, bytes32 _secret, address _relayer, uint32 _salt, uint8 v, bytes32 r, bytes32 s) returns()
+func (_SwapCreator *SwapCreatorSession) RefundRelayer(_relaySwap SwapCreatorRelaySwap, _secret [32]byte, _relayer common.Address, _salt uint32, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _SwapCreator.Contract.RefundRelayer(&_SwapCreator.TransactOpts, _relaySwap, _secret, _relayer, _salt, v, r, s)
+}
+
+// RefundRelayer is a paid mutator transaction binding the contract method 0x475a0253.
+//
+// Solidity: function refundRelayer(((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256),uint256,bytes32,address) _relaySwap, bytes32 _secret, address _relayer, uint32 _salt, uint8 v, bytes32 r, bytes32 s) returns()
+func (_SwapCreator *SwapCreatorTransactorSession) RefundRelayer(_relaySwap SwapCreatorRelaySwap, _secret [32]byte, _relayer common.Address, _salt uint32, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _SwapCreator.Contract.RefundRelayer(&_SwapCreator.TransactOpts, _relaySwap, _secret, _relayer, _salt, v, r, s)
+}
+
+// RefundTo is a paid mutator transaction binding the contract method 0x3458bbf9.
+//
+// Solidity: function refundTo((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap, bytes32 _secret, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactor) RefundTo(opts *bind.TransactOpts, _swap SwapCreatorSwap, _secret [32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.contract.Transact(opts, "refundTo", _swap, _secret, _payout)
+}
+
+// RefundTo is a paid mutator transaction binding the contract method 0x3458bbf9.
+//
+// Solidity: function refundTo((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap, bytes32 _secret, address _payout) returns()
+func (_SwapCreator *SwapCreatorSession) RefundTo(_swap SwapCreatorSwap, _secret [32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.RefundTo(&_SwapCreator.TransactOpts, _swap, _secret, _payout)
+}
+
+// RefundTo is a paid mutator transaction binding the contract method 0x3458bbf9.
+//
+// Solidity: function refundTo((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap, bytes32 _secret, address _payout) returns()
+func (_SwapCreator *SwapCreatorTransactorSession) RefundTo(_swap SwapCreatorSwap, _secret [32]byte, _payout common.Address) (*types.Transaction, error) {
+	return _SwapCreator.Contract.RefundTo(&_SwapCreator.TransactOpts, _swap, _secret, _payout)
+}
+
 // SetReady is a paid mutator transaction binding the contract method 0xfcaf229c.
 //
 // Solidity: function setReady((address,address,bytes32,bytes32,uint256,uint256,address,uint256,uint256) _swap) returns()
@@ -627,3 +627,101 @@ func TestSwapCreator_MultipleSwaps(t *testing.T) {
 	}
 	wg.Wait() // status of all swaps checked
 }
+
+// A contract wallet, like a Safe multisig, can own swaps and receive the value
+// of refunds and claims, although receiving ETH uses more gas than the stipend
+// of `transfer`.
+func TestSwapCreator_contractWallet(t *testing.T) {
+	pkA := tests.GetTakerTestKey(t)
+	ec, _ := tests.NewEthClient(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+
+	swapCreatorAddr, swapCreator := DevDeploySwapCreator(t, ec, pkA)
+
+	walletAddr, tx, wallet, err := DeployTestWallet(tests.TxOptsWithValue(t, pkA, defaultSwapValue), ec, addr)
+	require.NoError(t, err)
+	getReceipt(t, ec, tx)
+
+	// the wallet creates and refunds a swap
+	dleq := &dleq.DefaultDLEq{}
+	proof, err := dleq.Prove()
+	require.NoError(t, err)
+	res, err := dleq.Verify(proof)
+	require.NoError(t, err)
+
+	nonce := GenerateNewSwapNonce()
+	data, err := SwapCreatorParsedABI.Pack("newSwap", dummySwapKey, res.Secp256k1PublicKey().Keccak256(), addr,
+		defaultTimeoutDuration, defaultTimeoutDuration, types.EthAssetETH.Address(), defaultSwapValue, nonce)
+	require.NoError(t, err)
+	tx, err = wallet.Execute(getAuth(t, pkA), swapCreatorAddr, defaultSwapValue, data)
+	require.NoError(t, err)
+	receipt := getReceipt(t, ec, tx)
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+
+	t1, t2, err := GetTimeoutsFromLog(receipt.Logs[0])
+	require.NoError(t, err)
+
+	swap := SwapCreatorSwap{
+		Owner:            walletAddr,
+		Claimer:          addr,
+		ClaimCommitment:  dummySwapKey,
+		RefundCommitment: res.Secp256k1PublicKey().Keccak256(),
+		Timeout1:         t1,
+		Timeout2:         t2,
+		Asset:            types.EthAssetETH.Address(),
+		Value:            defaultSwapValue,
+		Nonce:            nonce,
+	}
+
+	data, err = SwapCreatorParsedABI.Pack("refund", swap, proof.Secret())
+	require.NoError(t, err)
+	tx, err = wallet.Execute(getAuth(t, pkA), swapCreatorAddr, new(big.Int), data)
+	require.NoError(t, err)
+	receipt = getReceipt(t, ec, tx)
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+
+	received, err := wallet.Received(nil)
+	require.NoError(t, err)
+	require.Equal(t, defaultSwapValue, received)
+
+	// a claim pays out to the wallet
+	proof, err = dleq.Prove()
+	require.NoError(t, err)
+	res, err = dleq.Verify(proof)
+	require.NoError(t, err)
+
+	nonce = GenerateNewSwapNonce()
+	tx, err = swapCreator.NewSwap(tests.TxOptsWithValue(t, pkA, defaultSwapValue), res.Secp256k1PublicKey().Keccak256(),
+		dummySwapKey, addr, defaultTimeoutDuration, defaultTimeoutDuration, types.EthAssetETH.Address(),
+		defaultSwapValue, nonce)
+	require.NoError(t, err)
+	receipt = getReceipt(t, ec, tx)
+
+	t1, t2, err = GetTimeoutsFromLog(receipt.Logs[0])
+	require.NoError(t, err)
+
+	swap = SwapCreatorSwap{
+		Owner:            addr,
+		Claimer:          addr,
+		ClaimCommitment:  res.Secp256k1PublicKey().Keccak256(),
+		RefundCommitment: dummySwapKey,
+		Timeout1:         t1,
+		Timeout2:         t2,
+		Asset:            types.EthAssetETH.Address(),
+		Value:            defaultSwapValue,
+		Nonce:            nonce,
+	}
+
+	tx, err = swapCreator.SetReady(getAuth(t, pkA), swap)
+	require.NoError(t, err)
+	getReceipt(t, ec, tx)
+
+	tx, err = swapCreator.ClaimTo(getAuth(t, pkA), swap, proof.Secret(), walletAddr)
+	require.NoError(t, err)
+	receipt = getReceipt(t, ec, tx)
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+
+	received, err = wallet.Received(nil)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Mul(defaultSwapValue, big.NewInt(2)), received)
+}
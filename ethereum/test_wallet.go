@@ -0,0 +1,307 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TestWalletMetaData contains all meta data concerning the TestWallet contract.
+var TestWalletMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"stateMutability\":\"payable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"execute\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"received\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Bin: "0x60a060405260405161039838038061039883398101604081905261002291610033565b6001600160a01b0316608052610060565b5f60208284031215610043575f80fd5b81516001600160a01b0381168114610059575f80fd5b9392505050565b60805161031a61007e5f395f8181608f0152610102015261031a5ff3fe608060405260043610610036575f3560e01c806383a6deb5146100575780638da5cb5b1461007e578063b61d27f6146100c9575f80fd5b3661005357345f8082825461004b91906101d8565b925050819055005b5f80fd5b348015610062575f80fd5b5061006b5f5481565b6040519081526020015b60405180910390f35b348015610089575f80fd5b506100b17f000000000000000000000000000000000000000000000000000000000000000081565b6040516001600160a01b039091168152602001610075565b3480156100d4575f80fd5b506100e86100e33660046101fd565b6100f5565b604051610075919061028a565b6060336001600160a01b037f000000000000000000000000000000000000000000000000000000000000000016146101605760405162461bcd60e51b815260206004820152600a60248201526937b7363c9037bbb732b960b11b604482015260640160405180910390fd5b5f80866001600160a01b031686868660405161017d9291906102d5565b5f6040518083038185875af1925050503d805f81146101b7576040519150601f19603f3d011682016040523d82523d5f602084013e6101bc565b606091505b5091509150816101ce57805160208201fd5b9695505050505050565b808201808211156101f757634e487b7160e01b5f52601160045260245ffd5b92915050565b5f805f8060608587031215610210575f80fd5b84356001600160a01b0381168114610226575f80fd5b935060208501359250604085013567ffffffffffffffff80821115610249575f80fd5b818701915087601f83011261025c575f80fd5b81358181111561026a575f80fd5b88602082850101111561027b575f80fd5b95989497505060200194505050565b5f6020808352835180828501525f5b818110156102b557858101830151858201604001528201610299565b505f604082860101526040601f19601f8301168501019250505092915050565b818382375f910190815291905056fea26469706673582212203bd199b59c978215b58729e3d51e7b5d1372672dfafbfadc4004fc57ce964a8a64736f6c63430008150033",
+}
+
+// TestWalletABI is the input ABI used to generate the binding from.
+// Deprecated: Use TestWalletMetaData.ABI instead.
+var TestWalletABI = TestWalletMetaData.ABI
+
+// TestWalletBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use TestWalletMetaData.Bin instead.
+var TestWalletBin = TestWalletMetaData.Bin
+
+// DeployTestWallet deploys a new Ethereum contract, binding an instance of TestWallet to it.
+func DeployTestWallet(auth *bind.TransactOpts, backend bind.ContractBackend, _owner common.Address) (common.Address, *types.Transaction, *TestWallet, error) {
+	parsed, err := TestWalletMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(TestWalletBin), backend, _owner)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &TestWallet{TestWalletCaller: TestWalletCaller{contract: contract}, TestWalletTransactor: TestWalletTransactor{contract: contract}, TestWalletFilterer: TestWalletFilterer{contract: contract}}, nil
+}
+
+// TestWallet is an auto generated Go binding around an Ethereum contract.
+type TestWallet struct {
+	TestWalletCaller     // Read-only binding to the contract
+	TestWalletTransactor // Write-only binding to the contract
+	TestWalletFilterer   // Log filterer for contract events
+}
+
+// TestWalletCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TestWalletCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestWalletTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TestWalletTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestWalletFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TestWalletFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TestWalletSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TestWalletSession struct {
+	Contract     *TestWallet       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// TestWalletCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TestWalletCallerSession struct {
+	Contract *TestWalletCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// TestWalletTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TestWalletTransactorSession struct {
+	Contract     *TestWalletTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// TestWalletRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TestWalletRaw struct {
+	Contract *TestWallet // Generic contract binding to access the raw methods on
+}
+
+// TestWalletCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TestWalletCallerRaw struct {
+	Contract *TestWalletCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TestWalletTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TestWalletTransactorRaw struct {
+	Contract *TestWalletTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTestWallet creates a new instance of TestWallet, bound to a specific deployed contract.
+func NewTestWallet(address common.Address, backend bind.ContractBackend) (*TestWallet, error) {
+	contract, err := bindTestWallet(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TestWallet{TestWalletCaller: TestWalletCaller{contract: contract}, TestWalletTransactor: TestWalletTransactor{contract: contract}, TestWalletFilterer: TestWalletFilterer{contract: contract}}, nil
+}
+
+// NewTestWalletCaller creates a new read-only instance of TestWallet, bound to a specific deployed contract.
+func NewTestWalletCaller(address common.Address, caller bind.ContractCaller) (*TestWalletCaller, error) {
+	contract, err := bindTestWallet(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TestWalletCaller{contract: contract}, nil
+}
+
+// NewTestWalletTransactor creates a new write-only instance of TestWallet, bound to a specific deployed contract.
+func NewTestWalletTransactor(address common.Address, transactor bind.ContractTransactor) (*TestWalletTransactor, error) {
+	contract, err := bindTestWallet(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TestWalletTransactor{contract: contract}, nil
+}
+
+// NewTestWalletFilterer creates a new log filterer instance of TestWallet, bound to a specific deployed contract.
+func NewTestWalletFilterer(address common.Address, filterer bind.ContractFilterer) (*TestWalletFilterer, error) {
+	contract, err := bindTestWallet(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TestWalletFilterer{contract: contract}, nil
+}
+
+// bindTestWallet binds a generic wrapper to an already deployed contract.
+func bindTestWallet(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TestWalletMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TestWallet *TestWalletRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TestWallet.Contract.TestWalletCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TestWallet *TestWalletRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestWallet.Contract.TestWalletTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TestWallet *TestWalletRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TestWallet.Contract.TestWalletTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TestWallet *TestWalletCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TestWallet.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TestWallet *TestWalletTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestWallet.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TestWallet *TestWalletTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TestWallet.Contract.contract.Transact(opts, method, params...)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_TestWallet *TestWalletCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TestWallet.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_TestWallet *TestWalletSession) Owner() (common.Address, error) {
+	return _TestWallet.Contract.Owner(&_TestWallet.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_TestWallet *TestWalletCallerSession) Owner() (common.Address, error) {
+	return _TestWallet.Contract.Owner(&_TestWallet.CallOpts)
+}
+
+// Received is a free data retrieval call binding the contract method 0x83a6deb5.
+//
+// Solidity: function received() view returns(uint256)
+func (_TestWallet *TestWalletCaller) Received(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TestWallet.contract.Call(opts, &out, "received")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Received is a free data retrieval call binding the contract method 0x83a6deb5.
+//
+// Solidity: function received() view returns(uint256)
+func (_TestWallet *TestWalletSession) Received() (*big.Int, error) {
+	return _TestWallet.Contract.Received(&_TestWallet.CallOpts)
+}
+
+// Received is a free data retrieval call binding the contract method 0x83a6deb5.
+//
+// Solidity: function received() view returns(uint256)
+func (_TestWallet *TestWalletCallerSession) Received() (*big.Int, error) {
+	return _TestWallet.Contract.Received(&_TestWallet.CallOpts)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xb61d27f6.
+//
+// Solidity: function execute(address to, uint256 value, bytes data) returns(bytes)
+func (_TestWallet *TestWalletTransactor) Execute(opts *bind.TransactOpts, to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _TestWallet.contract.Transact(opts, "execute", to, value, data)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xb61d27f6.
+//
+// Solidity: function execute(address to, uint256 value, bytes data) returns(bytes)
+func (_TestWallet *TestWalletSession) Execute(to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _TestWallet.Contract.Execute(&_TestWallet.TransactOpts, to, value, data)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xb61d27f6.
+//
+// Solidity: function execute(address to, uint256 value, bytes data) returns(bytes)
+func (_TestWallet *TestWalletTransactorSession) Execute(to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _TestWallet.Contract.Execute(&_TestWallet.TransactOpts, to, value, data)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestWallet *TestWalletTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TestWallet.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestWallet *TestWalletSession) Receive() (*types.Transaction, error) {
+	return _TestWallet.Contract.Receive(&_TestWallet.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_TestWallet *TestWalletTransactorSession) Receive() (*types.Transaction, error) {
+	return _TestWallet.Contract.Receive(&_TestWallet.TransactOpts)
+}
@@ -11,8 +11,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
@@ -39,6 +41,10 @@ var (
 	claimedTopic  = common.GetTopic(ClaimedEventSignature)
 	refundedTopic = common.GetTopic(RefundedEventSignature)
 
+	// RefundRelayTypeHash is the REFUND_RELAY_TYPEHASH of the SwapCreator
+	// contract, which prefixes the RelaySwap that is signed for refundRelayer
+	RefundRelayTypeHash = crypto.Keccak256Hash([]byte("SwapCreator.refundRelayer"))
+
 	NewSwapFunctionSignature = SwapCreatorParsedABI.Methods["newSwap"].Sig //nolint:revive
 	ReadyEventSignature      = SwapCreatorParsedABI.Events["Ready"].Sig    //nolint:revive
 	ClaimedEventSignature    = SwapCreatorParsedABI.Events["Claimed"].Sig  //nolint:revive
@@ -61,8 +67,21 @@ func StageToString(stage byte) string {
 	}
 }
 
-// Hash abi-encodes the RelaySwap and returns the keccak256 hash of the encoded value.
+// Hash abi-encodes the RelaySwap and returns the keccak256 hash of the encoded
+// value, which the claimer signs for `claimRelayer`.
 func (s *SwapCreatorRelaySwap) Hash() types.Hash {
+	return crypto.Keccak256Hash(s.encode())
+}
+
+// RefundHash abi-encodes the RelaySwap prefixed by RefundRelayTypeHash and
+// returns the keccak256 hash of the encoded value, which the owner signs for
+// `refundRelayer`.
+func (s *SwapCreatorRelaySwap) RefundHash() types.Hash {
+	return crypto.Keccak256Hash(RefundRelayTypeHash[:], s.encode())
+}
+
+// encode abi-encodes the RelaySwap
+func (s *SwapCreatorRelaySwap) encode() []byte {
 	uint256Ty, err := abi.NewType("uint256", "", nil)
 	if err != nil {
 		panic(fmt.Sprintf("failed to create uint256 type: %s", err))
@@ -138,7 +157,7 @@ func (s *SwapCreatorRelaySwap) Hash() types.Hash {
 		panic(fmt.Sprintf("failed to pack arguments: %s", err))
 	}
 
-	return crypto.Keccak256Hash(args)
+	return args
 }
 
 // SwapID calculates and returns the same hashed swap identifier that newSwap
@@ -210,6 +229,81 @@ func (sfs *SwapCreatorSwap) SwapID() types.Hash {
 	return crypto.Keccak256Hash(args)
 }
 
+// CanClaim returns whether the claimer can claim the swap, when it is in the
+// passed contract stage, in a block with the passed timestamp. It follows the
+// rules of the contract's `claim` function.
+func (sfs *SwapCreatorSwap) CanClaim(stage byte, blockTime time.Time) bool {
+	if stage != StagePending && stage != StageReady {
+		return false
+	}
+
+	now := big.NewInt(blockTime.Unix())
+	if now.Cmp(sfs.Timeout1) < 0 && stage != StageReady {
+		return false
+	}
+
+	return now.Cmp(sfs.Timeout2) < 0
+}
+
+// CanRefund returns whether the owner can refund the swap, when it is in the
+// passed contract stage, in a block with the passed timestamp. It follows the
+// rules of the contract's `refund` function.
+func (sfs *SwapCreatorSwap) CanRefund(stage byte, blockTime time.Time) bool {
+	if stage != StagePending && stage != StageReady {
+		return false
+	}
+
+	now := big.NewInt(blockTime.Unix())
+	if now.Cmp(sfs.Timeout2) >= 0 {
+		return true
+	}
+
+	return now.Cmp(sfs.Timeout1) <= 0 && stage != StageReady
+}
+
+// FindSwapOwner returns the first of the candidate addresses that, as the swap's
+// owner, gives the passed swap ID. The owner of a swap is the address that
+// called newSwap, which is not the transaction's sender when the swap was
+// created by a contract wallet.
+func FindSwapOwner(
+	swap *SwapCreatorSwap,
+	swapID types.Hash,
+	candidates ...ethcommon.Address,
+) (ethcommon.Address, error) {
+	s := *swap
+	for _, owner := range candidates {
+		s.Owner = owner
+		if s.SwapID() == swapID {
+			return owner, nil
+		}
+	}
+
+	return ethcommon.Address{}, fmt.Errorf("owner of swap %s is not one of %d candidate addresses",
+		swapID, len(candidates))
+}
+
+// SwapOwnerCandidates returns the addresses in the receipt's logs that could be
+// the owner of a swap created by the receipt's transaction: the addresses that
+// emitted logs, like a contract wallet, and the addresses in indexed log
+// topics, like the sender of a token transfer.
+func SwapOwnerCandidates(receipt *ethtypes.Receipt) []ethcommon.Address {
+	var candidates []ethcommon.Address
+	for _, log := range receipt.Logs {
+		candidates = append(candidates, log.Address)
+		if len(log.Topics) == 0 {
+			continue
+		}
+		for _, topic := range log.Topics[1:] {
+			// addresses are left-padded with zeros in topics
+			if bytes.Equal(topic[:12], make([]byte, 12)) {
+				candidates = append(candidates, ethcommon.BytesToAddress(topic[12:]))
+			}
+		}
+	}
+
+	return candidates
+}
+
 // GetSecretFromLog returns the secret from a Claimed or Refunded log
 func GetSecretFromLog(log *ethtypes.Log, eventTopic [32]byte) (*mcrypto.PrivateSpendKey, error) {
 	if eventTopic != claimedTopic && eventTopic != refundedTopic {
@@ -4,11 +4,14 @@
 package contracts
 
 import (
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/athanorlabs/atomic-swap/common"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,3 +32,62 @@ func TestGetTopic(t *testing.T) {
 	refundedTopic := ethcommon.HexToHash("0x007c875846b687732a7579c19bb1dade66cd14e9f4f809565e2b2b5e76c72b4f")
 	require.Equal(t, common.GetTopic(RefundedEventSignature), refundedTopic)
 }
+
+func TestSwapCreatorSwap_CanClaim(t *testing.T) {
+	t1 := time.Unix(1000, 0)
+	t2 := time.Unix(2000, 0)
+	swap := &SwapCreatorSwap{Timeout1: big.NewInt(t1.Unix()), Timeout2: big.NewInt(t2.Unix())}
+	beforeT1 := t1.Add(-time.Second)
+
+	require.False(t, swap.CanClaim(StagePending, beforeT1))
+	require.True(t, swap.CanClaim(StageReady, beforeT1))
+	require.True(t, swap.CanClaim(StagePending, t1))
+	require.True(t, swap.CanClaim(StageReady, t2.Add(-time.Second)))
+	require.False(t, swap.CanClaim(StageReady, t2))
+	require.False(t, swap.CanClaim(StageCompleted, t1))
+	require.False(t, swap.CanClaim(StageInvalid, t1))
+}
+
+func TestSwapCreatorSwap_CanRefund(t *testing.T) {
+	t1 := time.Unix(1000, 0)
+	t2 := time.Unix(2000, 0)
+	swap := &SwapCreatorSwap{Timeout1: big.NewInt(t1.Unix()), Timeout2: big.NewInt(t2.Unix())}
+
+	require.True(t, swap.CanRefund(StagePending, t1))
+	require.False(t, swap.CanRefund(StageReady, t1))
+	require.False(t, swap.CanRefund(StagePending, t1.Add(time.Second)))
+	require.True(t, swap.CanRefund(StagePending, t2))
+	require.True(t, swap.CanRefund(StageReady, t2))
+	require.False(t, swap.CanRefund(StageCompleted, t2))
+}
+
+func TestFindSwapOwner(t *testing.T) {
+	wallet := ethcommon.Address{0x1}
+	swap := &SwapCreatorSwap{
+		Owner:    wallet,
+		Claimer:  ethcommon.Address{0x2},
+		Timeout1: big.NewInt(1000),
+		Timeout2: big.NewInt(2000),
+		Value:    big.NewInt(1),
+		Nonce:    big.NewInt(2),
+	}
+	swapID := swap.SwapID()
+
+	receipt := &ethtypes.Receipt{
+		Logs: []*ethtypes.Log{
+			{Address: ethcommon.Address{0x3}, Topics: []ethcommon.Hash{{0x4}}},
+			{Address: ethcommon.Address{0x5}, Topics: []ethcommon.Hash{{0x6}, ethcommon.BytesToHash(wallet[:])}},
+		},
+	}
+	candidates := SwapOwnerCandidates(receipt)
+	require.Equal(t, []ethcommon.Address{{0x3}, {0x5}, wallet}, candidates)
+
+	swap.Owner = ethcommon.Address{}
+	owner, err := FindSwapOwner(swap, swapID, candidates...)
+	require.NoError(t, err)
+	require.Equal(t, wallet, owner)
+	require.Equal(t, ethcommon.Address{}, swap.Owner)
+
+	_, err = FindSwapOwner(swap, swapID, candidates[:2]...)
+	require.ErrorContains(t, err, "is not one of 2 candidate addresses")
+}
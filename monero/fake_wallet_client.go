@@ -0,0 +1,520 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build !prod
+
+package monero
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MarinX/monerorpc/wallet"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+)
+
+// This file is only for test support. Use the build tag "prod" to prevent
+// symbols in this file from consuming space in production binaries.
+
+const (
+	// fakeTxFee is the fee of every transaction on the fake ledger in piconero
+	fakeTxFee = 30_000_000
+
+	// fakeStartHeight is the chain height of a new fake ledger, so funded
+	// outputs can be placed in blocks that are already unlocked.
+	fakeStartHeight = 100
+)
+
+var (
+	errFakeNotEnoughMoney = errors.New("not enough money")
+	errFakeViewOnly       = errors.New("view-only wallets cannot spend")
+	errFakeAccountIndex   = errors.New("fake wallets only have account 0")
+)
+
+// FakeLedger is an in-memory stand-in for the Monero chain, shared by the
+// FakeWalletClients of a test, so complete swaps can run without monerod and
+// monero-wallet-rpc. Blocks are mined at a fixed interval of the local clock,
+// and can be added on demand with MineBlocks. Outputs are tracked by address
+// in the clear: there are no ring signatures, key images or encrypted amounts.
+// Like on the real chain, a transaction is mined in the block after it is sent
+// and its outputs unlock after MinSpendConfirmations blocks.
+type FakeLedger struct {
+	env       common.Environment
+	blockTime time.Duration
+	start     time.Time
+
+	mu      sync.Mutex
+	mined   uint64 // blocks added by MineBlocks
+	txCount uint64
+	outputs []*fakeOutput
+}
+
+type fakeOutput struct {
+	txID    string
+	index   uint64
+	height  uint64 // height of the block that includes the transaction
+	address *mcrypto.Address
+	amount  uint64
+	spent   bool
+}
+
+// NewFakeLedger returns a fake Monero chain for the environment that mines a
+// block every blockTime.
+func NewFakeLedger(env common.Environment, blockTime time.Duration) *FakeLedger {
+	return &FakeLedger{
+		env:       env,
+		blockTime: blockTime,
+		start:     time.Now(),
+	}
+}
+
+// Height returns the number of blocks in the fake chain
+func (l *FakeLedger) Height() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.height()
+}
+
+func (l *FakeLedger) height() uint64 {
+	return fakeStartHeight + uint64(time.Since(l.start)/l.blockTime) + l.mined
+}
+
+// MineBlocks adds count empty blocks to the fake chain
+func (l *FakeLedger) MineBlocks(count uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mined += count
+}
+
+// Fund adds an unlocked output with the amount for the address to the ledger.
+// Nothing is added for a zero amount.
+func (l *FakeLedger) Fund(addr *mcrypto.Address, amount *coins.PiconeroAmount) error {
+	amt, err := amount.Uint64()
+	if err != nil || amt == 0 {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.outputs = append(l.outputs, &fakeOutput{
+		txID:    l.newTxID(),
+		height:  l.height() - MinSpendConfirmations,
+		address: addr,
+		amount:  amt,
+	})
+	return nil
+}
+
+// CreateWalletClient returns a fake wallet client of a new primary wallet
+// funded with the passed amount.
+func (l *FakeLedger) CreateWalletClient(t *testing.T, funding *coins.PiconeroAmount) *FakeWalletClient {
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	c := l.NewWalletClient(&WalletClientConf{
+		Env:            l.env,
+		WalletFilePath: path.Join(t.TempDir(), "test-wallet"),
+	}, kp.PublicKeyPair().Address(l.env), true)
+
+	require.NoError(t, l.Fund(c.PrimaryAddress(), funding))
+	return c
+}
+
+// NewWalletClient returns a fake wallet client of the address. Only the file
+// path, environment and transaction priority of the configuration are used.
+func (l *FakeLedger) NewWalletClient(conf *WalletClientConf, addr *mcrypto.Address, canSpend bool) *FakeWalletClient {
+	return &FakeWalletClient{
+		ledger:   l,
+		conf:     conf,
+		address:  addr,
+		canSpend: canSpend,
+	}
+}
+
+// walletFromKeys is the walletFromKeysFunc of the fake wallet clients' swap
+// wallet configurations.
+func (l *FakeLedger) walletFromKeys(
+	conf *WalletClientConf,
+	_ uint64,
+	privateSpendKey *mcrypto.PrivateSpendKey,
+	privateViewKey *mcrypto.PrivateViewKey,
+	address *mcrypto.Address,
+) (WalletClient, error) {
+	pubKeys, err := address.PublicKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	spendKey := pubKeys.SpendKey()
+	if privateSpendKey != nil {
+		spendKey = privateSpendKey.Public()
+	}
+
+	computed := mcrypto.NewPublicKeyPair(spendKey, privateViewKey.Public()).Address(conf.Env)
+	if !computed.Equal(address) {
+		return nil, fmt.Errorf("provided address %s does not match computed address %s", address, computed)
+	}
+
+	return l.NewWalletClient(conf, address, privateSpendKey != nil), nil
+}
+
+// newTxID returns a deterministic transaction ID. Must be called with the
+// mutex held.
+func (l *FakeLedger) newTxID() string {
+	l.txCount++
+	id := sha256.Sum256([]byte(fmt.Sprintf("fake-monero-tx-%d", l.txCount)))
+	return hex.EncodeToString(id[:])
+}
+
+// confirmations returns the number of blocks mined on top of the block at the
+// height, or zero if the block was not mined yet.
+func confirmations(chainHeight uint64, height uint64) uint64 {
+	if chainHeight <= height {
+		return 0
+	}
+	return chainHeight - height
+}
+
+// balance returns the total and unlocked balances of the address, and the
+// number of blocks until the total balance is unlocked. Must be called with the
+// mutex held.
+func (l *FakeLedger) balance(addr *mcrypto.Address) (uint64, uint64, uint64) {
+	chainHeight := l.height()
+	var total, unlocked, blocksToUnlock uint64
+	for _, out := range l.outputs {
+		if out.spent || !out.address.Equal(addr) {
+			continue
+		}
+
+		total += out.amount
+		confs := confirmations(chainHeight, out.height)
+		if confs >= MinSpendConfirmations {
+			unlocked += out.amount
+			continue
+		}
+
+		toUnlock := out.height + MinSpendConfirmations - chainHeight
+		if toUnlock > blocksToUnlock {
+			blocksToUnlock = toUnlock
+		}
+	}
+
+	return total, unlocked, blocksToUnlock
+}
+
+// send spends unlocked outputs of the address to pay the amount plus the fee
+// to the destination, with any change going back to the address. If sweep is
+// set, all outputs are spent and the amount is the balance minus the fee.
+func (l *FakeLedger) send(
+	from *mcrypto.Address,
+	to *mcrypto.Address,
+	amount uint64,
+	sweep bool,
+) (*wallet.Transfer, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var inputs []*fakeOutput
+	var inputsSum uint64
+	chainHeight := l.height()
+	for _, out := range l.outputs {
+		if !sweep && inputsSum >= amount+fakeTxFee {
+			break
+		}
+		if out.spent || !out.address.Equal(from) || confirmations(chainHeight, out.height) < MinSpendConfirmations {
+			continue
+		}
+		inputs = append(inputs, out)
+		inputsSum += out.amount
+	}
+
+	if sweep {
+		if inputsSum <= fakeTxFee {
+			return nil, errFakeNotEnoughMoney
+		}
+		amount = inputsSum - fakeTxFee
+	}
+	if inputsSum < amount+fakeTxFee {
+		return nil, errFakeNotEnoughMoney
+	}
+
+	for _, in := range inputs {
+		in.spent = true
+	}
+
+	// the transaction is included in the next block
+	txID := l.newTxID()
+	l.outputs = append(l.outputs, &fakeOutput{
+		txID:    txID,
+		height:  chainHeight,
+		address: to,
+		amount:  amount,
+	})
+	if change := inputsSum - amount - fakeTxFee; change > 0 {
+		l.outputs = append(l.outputs, &fakeOutput{
+			txID:    txID,
+			index:   1,
+			height:  chainHeight,
+			address: from,
+			amount:  change,
+		})
+	}
+
+	return &wallet.Transfer{
+		Address:      from.String(),
+		Amount:       amount,
+		Destinations: []wallet.Destination{{Amount: amount, Address: to.String()}},
+		Fee:          fakeTxFee,
+		Height:       chainHeight,
+		TxID:         txID,
+		Type:         "out",
+	}, nil
+}
+
+// waitForConfirmations waits until the transfer is mined and has at least
+// numConfirmations, and sets the transfer's confirmations.
+func (l *FakeLedger) waitForConfirmations(
+	ctx context.Context,
+	transfer *wallet.Transfer,
+	numConfirmations uint64,
+) error {
+	for {
+		confs := confirmations(l.Height(), transfer.Height)
+		if confs > 0 && confs >= numConfirmations {
+			transfer.Confirmations = confs
+			return nil
+		}
+
+		if err := common.SleepWithContext(ctx, l.blockTime); err != nil {
+			return err
+		}
+	}
+}
+
+// FakeWalletClient is a WalletClient of a wallet on a FakeLedger. The swap
+// wallets created with its CreateWalletConf are fake wallets of the same
+// ledger.
+type FakeWalletClient struct {
+	ledger   *FakeLedger
+	conf     *WalletClientConf
+	address  *mcrypto.Address
+	canSpend bool
+}
+
+var _ WalletClient = (*FakeWalletClient)(nil)
+
+// GetAccounts returns the wallet's single account
+func (c *FakeWalletClient) GetAccounts() (*wallet.GetAccountsResponse, error) {
+	balance, err := c.GetBalance(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet.GetAccountsResponse{
+		SubaddressAccounts: []wallet.SubaddressAcount{{
+			AccountIndex:    0,
+			Balance:         balance.Balance,
+			BaseAddress:     c.address.String(),
+			UnlockedBalance: balance.UnlockedBalance,
+		}},
+		TotalBalance:         balance.Balance,
+		TotalUnlockedBalance: balance.UnlockedBalance,
+	}, nil
+}
+
+// GetAddress returns the primary address of account 0
+func (c *FakeWalletClient) GetAddress(idx uint64) (*wallet.GetAddressResponse, error) {
+	if idx != 0 {
+		return nil, errFakeAccountIndex
+	}
+
+	return &wallet.GetAddressResponse{
+		Address:   c.address.String(),
+		Addresses: []wallet.Address{{Address: c.address.String(), Used: true}},
+	}, nil
+}
+
+// PrimaryAddress returns the wallet's address
+func (c *FakeWalletClient) PrimaryAddress() *mcrypto.Address {
+	return c.address
+}
+
+// GetBalance returns the balance of account 0
+func (c *FakeWalletClient) GetBalance(idx uint64) (*wallet.GetBalanceResponse, error) {
+	if idx != 0 {
+		return nil, errFakeAccountIndex
+	}
+
+	c.ledger.mu.Lock()
+	defer c.ledger.mu.Unlock()
+	total, unlocked, blocksToUnlock := c.ledger.balance(c.address)
+	return &wallet.GetBalanceResponse{
+		Balance:         total,
+		UnlockedBalance: unlocked,
+		BlocksToUnlock:  blocksToUnlock,
+	}, nil
+}
+
+// Transfer sends the amount to the address and waits for the confirmations
+func (c *FakeWalletClient) Transfer(
+	ctx context.Context,
+	to *mcrypto.Address,
+	accountIdx uint64,
+	amount *coins.PiconeroAmount,
+	numConfirmations uint64,
+	_ types.MoneroTxPriority,
+) (*wallet.Transfer, error) {
+	if err := c.checkSpend(accountIdx); err != nil {
+		return nil, err
+	}
+
+	amt, err := amount.Uint64()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Transferring %s XMR to %s (fake ledger)", amount.AsMoneroString(), to)
+	transfer, err := c.ledger.send(c.address, to, amt, false)
+	if err != nil {
+		return nil, fmt.Errorf("transfer failed: %w", err)
+	}
+
+	if err = c.ledger.waitForConfirmations(ctx, transfer, numConfirmations); err != nil {
+		return nil, fmt.Errorf("monero TXID=%s receipt failure: %w", transfer.TxID, err)
+	}
+
+	return transfer, nil
+}
+
+// SweepAll waits for the balance to unlock, sends all of it minus the fee to
+// the address and waits for the confirmations.
+func (c *FakeWalletClient) SweepAll(
+	ctx context.Context,
+	to *mcrypto.Address,
+	accountIdx uint64,
+	numConfirmations uint64,
+	_ types.MoneroTxPriority,
+) ([]*wallet.Transfer, error) {
+	if err := c.checkSpend(accountIdx); err != nil {
+		return nil, err
+	}
+
+	for {
+		balance, err := c.GetBalance(accountIdx)
+		if err != nil {
+			return nil, err
+		}
+		if balance.Balance == 0 {
+			return nil, fmt.Errorf("sweep from %s failed, no balance to sweep", c.address)
+		}
+		if balance.BlocksToUnlock == 0 {
+			break
+		}
+
+		if err = common.SleepWithContext(ctx, c.ledger.blockTime); err != nil {
+			return nil, fmt.Errorf("sweep operation failed waiting to unlock balance: %w", err)
+		}
+	}
+
+	transfer, err := c.ledger.send(c.address, to, 0, true)
+	if err != nil {
+		return nil, fmt.Errorf("sweep_all from %s failed: %w", c.address, err)
+	}
+
+	if err = c.ledger.waitForConfirmations(ctx, transfer, numConfirmations); err != nil {
+		return nil, fmt.Errorf("sweep of TXID=%s failed waiting for receipt: %w", transfer.TxID, err)
+	}
+
+	return []*wallet.Transfer{transfer}, nil
+}
+
+func (c *FakeWalletClient) checkSpend(accountIdx uint64) error {
+	if accountIdx != 0 {
+		return errFakeAccountIndex
+	}
+	if !c.canSpend {
+		return errFakeViewOnly
+	}
+	return nil
+}
+
+// CreateWalletConf returns the configuration of a swap wallet, which is
+// created as a fake wallet of the same ledger.
+func (c *FakeWalletClient) CreateWalletConf(walletNamePrefix string) *WalletClientConf {
+	walletName := fmt.Sprintf("%s-%s", walletNamePrefix, time.Now().Format(common.TimeFmtNSecs))
+	return &WalletClientConf{
+		Env:            c.conf.Env,
+		WalletFilePath: path.Join(path.Dir(c.conf.WalletFilePath), walletName),
+		WalletPassword: c.conf.WalletPassword,
+		TxPriority:     c.conf.TxPriority,
+		fromKeys:       c.ledger.walletFromKeys,
+	}
+}
+
+// WalletName returns the base name of the configured wallet file
+func (c *FakeWalletClient) WalletName() string {
+	return path.Base(c.conf.WalletFilePath)
+}
+
+// GetHeight returns the height of the fake chain
+func (c *FakeWalletClient) GetHeight() (uint64, error) {
+	return c.ledger.Height(), nil
+}
+
+// Endpoint returns a placeholder, as there is no RPC endpoint
+func (c *FakeWalletClient) Endpoint() string {
+	return "fake-monero-ledger"
+}
+
+// Close does nothing, as there is no wallet process
+func (c *FakeWalletClient) Close() {}
+
+// CloseAndRemoveWallet does nothing, as there are no wallet files
+func (c *FakeWalletClient) CloseAndRemoveWallet() {}
+
+// chainHeight implements blockSource, so the fake ledger can be scanned by an
+// OutputScanner.
+func (c *FakeWalletClient) chainHeight() (uint64, error) {
+	return c.ledger.Height(), nil
+}
+
+// blockOutputs implements blockSource
+func (c *FakeWalletClient) blockOutputs(
+	_ context.Context,
+	height uint64,
+	vk *mcrypto.PrivateViewKey,
+	spendKey *mcrypto.PublicKey,
+) ([]*ReceivedOutput, error) {
+	addr := mcrypto.NewPublicKeyPair(spendKey, vk.Public()).Address(c.ledger.env)
+
+	c.ledger.mu.Lock()
+	defer c.ledger.mu.Unlock()
+
+	var outputs []*ReceivedOutput
+	for _, out := range c.ledger.outputs {
+		if out.height != height || !out.address.Equal(addr) {
+			continue
+		}
+		outputs = append(outputs, &ReceivedOutput{
+			TxID:        out.txID,
+			OutputIndex: out.index,
+			Height:      out.height,
+			Amount:      coins.NewPiconeroAmount(out.amount),
+		})
+	}
+
+	return outputs, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monero
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+)
+
+func TestFakeWalletClient_swapWallet(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	ledger := NewFakeLedger(common.Development, 10*time.Millisecond)
+	c := ledger.CreateWalletClient(t, coins.MoneroToPiconero(coins.StrToDecimal("10")))
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	swapAddr := kp.PublicKeyPair().Address(common.Development)
+
+	startHeight, err := c.GetHeight()
+	require.NoError(t, err)
+	scanner, err := NewOutputScanner(c, swapAddr, kp.ViewKey(), startHeight)
+	require.NoError(t, err)
+
+	amount := coins.MoneroToPiconero(coins.StrToDecimal("3.5"))
+	transfer, err := c.Transfer(ctx, swapAddr, 0, amount, MinSpendConfirmations, types.PriorityDefault)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, transfer.Confirmations, uint64(MinSpendConfirmations))
+
+	// the change is locked for MinSpendConfirmations, like on the real chain
+	balance, err := c.GetBalance(0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10e12-3.5e12-fakeTxFee), balance.Balance)
+
+	outputs, err := scanner.Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.Equal(t, transfer.TxID, outputs[0].TxID)
+	require.Zero(t, outputs[0].Amount.Cmp(amount))
+
+	// swap wallets are created on the same ledger
+	conf := c.CreateWalletConf("swap-wallet")
+	viewOnly, err := CreateViewOnlyWalletFromKeys(conf, kp.ViewKey(), swapAddr, startHeight)
+	require.NoError(t, err)
+	_, err = viewOnly.SweepAll(ctx, c.PrimaryAddress(), 0, 1, types.PriorityDefault)
+	require.ErrorIs(t, err, errFakeViewOnly)
+
+	wrongKP, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	_, err = CreateViewOnlyWalletFromKeys(conf, wrongKP.ViewKey(), swapAddr, startHeight)
+	require.ErrorContains(t, err, "does not match")
+
+	swapWallet, err := CreateSpendWalletFromKeys(conf, kp, startHeight)
+	require.NoError(t, err)
+	transfers, err := swapWallet.SweepAll(ctx, c.PrimaryAddress(), 0, SweepToSelfConfirmations, types.PriorityDefault)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, uint64(3.5e12-fakeTxFee), transfers[0].Amount)
+
+	_, err = swapWallet.SweepAll(ctx, c.PrimaryAddress(), 0, SweepToSelfConfirmations, types.PriorityDefault)
+	require.ErrorContains(t, err, "no balance to sweep")
+}
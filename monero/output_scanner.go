@@ -0,0 +1,544 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monero
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	monerodaemon "github.com/MarinX/monerorpc/daemon"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+)
+
+const (
+	// scanReorgDepth is the number of blocks below the previous chain tip that
+	// are rescanned on each call to Scan, so outputs in blocks that were
+	// replaced by a small reorg are dropped or picked up again.
+	scanReorgDepth = 3
+
+	// maxTxsPerRequest limits the number of transactions requested from monerod
+	// in a single get_transactions call. Restricted RPC nodes reject larger
+	// requests.
+	maxTxsPerRequest = 100
+
+	// tx_extra field tags, see cryptonote_basic/tx_extra.h in the monero repo
+	txExtraTagPadding        = 0x00
+	txExtraTagPubKey         = 0x01
+	txExtraTagNonce          = 0x02
+	txExtraTagMergeMining    = 0x03
+	txExtraTagAdditionalKeys = 0x04
+	txExtraTagMinergate      = 0xde
+
+	pubKeySize = 32
+)
+
+var (
+	errSubaddressNotSupported = errors.New("output scanning of subaddresses is not supported")
+)
+
+// ReceivedOutput is a transaction output that was sent to the scanned address.
+type ReceivedOutput struct {
+	TxID          string                `json:"txID"`
+	OutputIndex   uint64                `json:"outputIndex"`
+	Height        uint64                `json:"height"`
+	Amount        *coins.PiconeroAmount `json:"amount"`
+	Confirmations uint64                `json:"confirmations"`
+}
+
+// blockSource provides the chain height and the outputs of each block that
+// are scanned by an OutputScanner.
+type blockSource interface {
+	// chainHeight returns the number of blocks in the chain
+	chainHeight() (uint64, error)
+
+	// blockOutputs returns the outputs in the block at the height that belong
+	// to the address with the private view key vk and public spend key
+	// spendKey.
+	blockOutputs(
+		ctx context.Context,
+		height uint64,
+		vk *mcrypto.PrivateViewKey,
+		spendKey *mcrypto.PublicKey,
+	) ([]*ReceivedOutput, error)
+}
+
+// OutputScanner finds the outputs sent to a single Monero address by scanning
+// blocks retrieved directly from monerod with the address's private view key.
+// Unlike a view-only wallet, no wallet files or monero-wallet-rpc process are
+// needed. The scanner is incremental: each call to Scan only requests the
+// blocks added since the previous call. OutputScanner is not safe for
+// concurrent use.
+type OutputScanner struct {
+	source      blockSource
+	viewKey     *mcrypto.PrivateViewKey
+	spendKey    *mcrypto.PublicKey
+	startHeight uint64
+	nextHeight  uint64                     // first height that has not been scanned
+	outputs     map[string]*ReceivedOutput // keyed by "txID:outputIndex"
+}
+
+// NewOutputScanner returns an OutputScanner for the address using the monerod
+// node of the passed wallet client. Blocks from startHeight onward are scanned.
+// Wallet clients that are not backed by monerod, like the fake wallet client of
+// the tests, are scanned if they provide the blocks themselves.
+func NewOutputScanner(
+	client WalletClient,
+	addr *mcrypto.Address,
+	vk *mcrypto.PrivateViewKey,
+	startHeight uint64,
+) (*OutputScanner, error) {
+	if source, ok := client.(blockSource); ok {
+		return newOutputScanner(source, addr, vk, startHeight)
+	}
+
+	c, ok := client.(*walletClient)
+	if !ok {
+		return nil, fmt.Errorf("wallet client of type %T can not be scanned", client)
+	}
+	return newOutputScanner(newDaemonBlockSource(c.dRPC, c.daemonURL), addr, vk, startHeight)
+}
+
+func newOutputScanner(
+	source blockSource,
+	addr *mcrypto.Address,
+	vk *mcrypto.PrivateViewKey,
+	startHeight uint64,
+) (*OutputScanner, error) {
+	if addr.Type() != mcrypto.Standard {
+		return nil, errSubaddressNotSupported
+	}
+
+	pubKeys, err := addr.PublicKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(vk.Public().Bytes(), pubKeys.ViewKey().Bytes()) {
+		return nil, errors.New("private view key does not match the address")
+	}
+
+	return &OutputScanner{
+		source:      source,
+		viewKey:     vk,
+		spendKey:    pubKeys.SpendKey(),
+		startHeight: startHeight,
+		nextHeight:  startHeight,
+		outputs:     make(map[string]*ReceivedOutput),
+	}, nil
+}
+
+// Scan scans the blocks added since the previous call and returns every output
+// found so far, with confirmations updated to the current chain height.
+// Outputs in the transaction pool are not reported.
+func (s *OutputScanner) Scan(ctx context.Context) ([]*ReceivedOutput, error) {
+	chainHeight, err := s.source.chainHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	// Rescan the last few blocks in case the chain reorganised since the
+	// previous scan, dropping any outputs we found in them.
+	from := s.startHeight
+	if s.nextHeight > s.startHeight+scanReorgDepth {
+		from = s.nextHeight - scanReorgDepth
+	}
+	for key, out := range s.outputs {
+		if out.Height >= from {
+			delete(s.outputs, key)
+		}
+	}
+
+	for height := from; height < chainHeight; height++ {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		outputs, err := s.source.blockOutputs(ctx, height, s.viewKey, s.spendKey)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, out := range outputs {
+			s.outputs[fmt.Sprintf("%s:%d", out.TxID, out.OutputIndex)] = out
+		}
+		s.nextHeight = height + 1
+	}
+
+	found := make([]*ReceivedOutput, 0, len(s.outputs))
+	for _, out := range s.outputs {
+		out.Confirmations = chainHeight - out.Height
+		found = append(found, out)
+	}
+
+	return found, nil
+}
+
+// ScanHeight returns the height of the next block that will be scanned.
+func (s *OutputScanner) ScanHeight() uint64 {
+	return s.nextHeight
+}
+
+// daemonBlockSource provides the blocks of an OutputScanner from monerod
+type daemonBlockSource struct {
+	daemon     monerodaemon.Daemon
+	daemonURL  string // base URL of monerod for the non-JSON-RPC endpoints
+	httpClient *http.Client
+}
+
+func newDaemonBlockSource(daemon monerodaemon.Daemon, daemonURL string) *daemonBlockSource {
+	return &daemonBlockSource{
+		daemon:     daemon,
+		daemonURL:  daemonURL,
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (d *daemonBlockSource) chainHeight() (uint64, error) {
+	countResp, err := d.daemon.GetBlockCount()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block count: %w", err)
+	}
+	return countResp.Count, nil
+}
+
+// blockJSON is the subset of the JSON formatted block returned by get_block
+// that we need.
+type blockJSON struct {
+	TxHashes []string `json:"tx_hashes"`
+}
+
+func (d *daemonBlockSource) blockOutputs(
+	ctx context.Context,
+	height uint64,
+	vk *mcrypto.PrivateViewKey,
+	spendKey *mcrypto.PublicKey,
+) ([]*ReceivedOutput, error) {
+	blockResp, err := d.daemon.GetBlock(&monerodaemon.GetBlockRequest{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+
+	block := new(blockJSON)
+	if err = json.Unmarshal([]byte(blockResp.JSON), block); err != nil {
+		return nil, fmt.Errorf("failed to decode block %d: %w", height, err)
+	}
+
+	var outputs []*ReceivedOutput
+	for start := 0; start < len(block.TxHashes); start += maxTxsPerRequest {
+		end := start + maxTxsPerRequest
+		if end > len(block.TxHashes) {
+			end = len(block.TxHashes)
+		}
+
+		txsResp, err := d.getTransactions(ctx, block.TxHashes[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range txsResp.Txs {
+			txOutputs, err := findOutputs(tx.TxHash, []byte(tx.AsJSON), vk, spendKey)
+			if err != nil {
+				return nil, err
+			}
+			for _, out := range txOutputs {
+				out.Height = height
+				log.Debugf("found output %s:%d of %s XMR at height %d",
+					out.TxID, out.OutputIndex, out.Amount.AsMoneroString(), height)
+			}
+			outputs = append(outputs, txOutputs...)
+		}
+	}
+
+	return outputs, nil
+}
+
+type getTransactionsRequest struct {
+	TxsHashes    []string `json:"txs_hashes"`
+	DecodeAsJSON bool     `json:"decode_as_json"`
+}
+
+type getTransactionsResponse struct {
+	Txs []struct {
+		AsJSON string `json:"as_json"`
+		TxHash string `json:"tx_hash"`
+	} `json:"txs"`
+	MissedTx []string `json:"missed_tx"`
+	Status   string   `json:"status"`
+}
+
+// getTransactions calls monerod's get_transactions endpoint, which is not part
+// of the JSON-RPC API and is not provided by the monerorpc library.
+func (d *daemonBlockSource) getTransactions(
+	ctx context.Context,
+	txHashes []string,
+) (*getTransactionsResponse, error) {
+	reqBody, err := json.Marshal(&getTransactionsRequest{
+		TxsHashes:    txHashes,
+		DecodeAsJSON: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.daemonURL+"/get_transactions",
+		bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get transactions: %s", httpResp.Status)
+	}
+
+	resp := new(getTransactionsResponse)
+	if err = json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("failed to decode transactions: %w", err)
+	}
+
+	if resp.Status != "OK" {
+		return nil, fmt.Errorf("failed to get transactions: status %q", resp.Status)
+	}
+	if len(resp.MissedTx) > 0 {
+		return nil, fmt.Errorf("monerod is missing transactions %v", resp.MissedTx)
+	}
+
+	return resp, nil
+}
+
+// txJSON is the subset of a JSON decoded transaction that is needed to find
+// outputs belonging to an address.
+type txJSON struct {
+	Vout []struct {
+		Amount uint64 `json:"amount"`
+		Target struct {
+			Key       string `json:"key"`
+			TaggedKey *struct {
+				Key     string `json:"key"`
+				ViewTag string `json:"view_tag"`
+			} `json:"tagged_key"`
+		} `json:"target"`
+	} `json:"vout"`
+	Extra         []uint `json:"extra"`
+	RctSignatures struct {
+		Type     int         `json:"type"`
+		EcdhInfo []ecdhTuple `json:"ecdhInfo"`
+		OutPk    []string    `json:"outPk"`
+	} `json:"rct_signatures"`
+}
+
+// ecdhTuple holds the encrypted amount of a RingCT output
+type ecdhTuple struct {
+	Amount string `json:"amount"`
+}
+
+// findOutputs returns the outputs of the JSON decoded transaction that belong
+// to the address with the private view key vk and public spend key spendKey.
+func findOutputs(
+	txID string,
+	txJSONData []byte,
+	vk *mcrypto.PrivateViewKey,
+	spendKey *mcrypto.PublicKey,
+) ([]*ReceivedOutput, error) {
+	tx := new(txJSON)
+	if err := json.Unmarshal(txJSONData, tx); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s: %w", txID, err)
+	}
+
+	extra := make([]byte, len(tx.Extra))
+	for i, b := range tx.Extra {
+		if b > 0xff {
+			return nil, fmt.Errorf("invalid extra field in transaction %s", txID)
+		}
+		extra[i] = byte(b)
+	}
+
+	txPubKey, additionalKeys := parseTxExtra(extra)
+	if txPubKey == nil && len(additionalKeys) == 0 {
+		return nil, nil // not a transaction we can scan
+	}
+
+	var mainDerivation *mcrypto.KeyDerivation
+	if txPubKey != nil {
+		var err error
+		mainDerivation, err = mcrypto.NewKeyDerivation(vk, txPubKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var outputs []*ReceivedOutput
+	for i, vout := range tx.Vout {
+		idx := uint64(i)
+
+		outKeyHex := vout.Target.Key
+		viewTag := ""
+		if vout.Target.TaggedKey != nil {
+			outKeyHex = vout.Target.TaggedKey.Key
+			viewTag = vout.Target.TaggedKey.ViewTag
+		}
+
+		outKey, err := hex.DecodeString(outKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output key in transaction %s: %w", txID, err)
+		}
+
+		// Outputs to subaddresses, or transactions with both standard and
+		// subaddress destinations, use a per-output transaction public key.
+		var derivations []*mcrypto.KeyDerivation
+		if mainDerivation != nil {
+			derivations = append(derivations, mainDerivation)
+		}
+		if i < len(additionalKeys) {
+			additionalDerivation, err := mcrypto.NewKeyDerivation(vk, additionalKeys[i])
+			if err != nil {
+				return nil, err
+			}
+			derivations = append(derivations, additionalDerivation)
+		}
+
+		for _, d := range derivations {
+			if viewTag != "" && fmt.Sprintf("%02x", d.ViewTag(idx)) != viewTag {
+				continue
+			}
+			if !bytes.Equal(d.OutputPublicKey(idx, spendKey).Bytes(), outKey) {
+				continue
+			}
+
+			amount := vout.Amount
+			if tx.RctSignatures.Type != 0 {
+				amount, err = decryptOutputAmount(tx.RctSignatures.EcdhInfo, tx.RctSignatures.OutPk, i, d)
+				if err != nil {
+					return nil, fmt.Errorf("transaction %s: %w", txID, err)
+				}
+			}
+
+			outputs = append(outputs, &ReceivedOutput{
+				TxID:        txID,
+				OutputIndex: idx,
+				Amount:      coins.NewPiconeroAmount(amount),
+			})
+			break
+		}
+	}
+
+	return outputs, nil
+}
+
+// decryptOutputAmount decrypts the amount of the RingCT output and checks it
+// against the output's commitment. Anyone can put any encrypted amount in a
+// transaction, so an amount that does not match the commitment is rejected.
+func decryptOutputAmount(
+	ecdhInfo []ecdhTuple,
+	outPk []string,
+	outputIndex int,
+	d *mcrypto.KeyDerivation,
+) (uint64, error) {
+	if outputIndex >= len(ecdhInfo) || outputIndex >= len(outPk) {
+		return 0, fmt.Errorf("missing encrypted amount for output %d", outputIndex)
+	}
+
+	encAmountBytes, err := hex.DecodeString(ecdhInfo[outputIndex].Amount)
+	if err != nil || len(encAmountBytes) != mcrypto.EncryptedAmountSize {
+		// Pre-bulletproof transactions used 32-byte amounts. Swap wallets are
+		// always funded by transactions long past that hard fork.
+		return 0, fmt.Errorf("unsupported encrypted amount for output %d", outputIndex)
+	}
+
+	commitment, err := hex.DecodeString(outPk[outputIndex])
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount commitment for output %d: %w", outputIndex, err)
+	}
+
+	var encAmount [mcrypto.EncryptedAmountSize]byte
+	copy(encAmount[:], encAmountBytes)
+	amount := d.DecryptAmount(uint64(outputIndex), encAmount)
+
+	if !bytes.Equal(d.AmountCommitment(uint64(outputIndex), amount), commitment) {
+		return 0, fmt.Errorf("amount of output %d does not match its commitment", outputIndex)
+	}
+
+	return amount, nil
+}
+
+// parseTxExtra returns the transaction public key and any additional public keys
+// in the tx_extra field. Parsing stops at the first unknown or malformed field,
+// which mirrors the best-effort parsing done by monero wallets.
+func parseTxExtra(extra []byte) (*mcrypto.PublicKey, []*mcrypto.PublicKey) {
+	var txPubKey *mcrypto.PublicKey
+	var additionalKeys []*mcrypto.PublicKey
+
+	for i := 0; i < len(extra); {
+		tag := extra[i]
+		i++
+
+		switch tag {
+		case txExtraTagPadding:
+			// padding is always at the end of the extra field
+			return txPubKey, additionalKeys
+		case txExtraTagPubKey:
+			if i+pubKeySize > len(extra) {
+				return txPubKey, additionalKeys
+			}
+			// only the first transaction public key is used by wallets
+			if txPubKey == nil {
+				key, err := mcrypto.NewPublicKeyFromBytes(extra[i : i+pubKeySize])
+				if err == nil {
+					txPubKey = key
+				}
+			}
+			i += pubKeySize
+		case txExtraTagNonce:
+			if i >= len(extra) {
+				return txPubKey, additionalKeys
+			}
+			i += 1 + int(extra[i])
+		case txExtraTagMergeMining, txExtraTagMinergate:
+			size, n := binary.Uvarint(extra[i:])
+			if n <= 0 || size > uint64(len(extra)) {
+				return txPubKey, additionalKeys
+			}
+			i += n + int(size)
+		case txExtraTagAdditionalKeys:
+			count, n := binary.Uvarint(extra[i:])
+			if n <= 0 || count > uint64(len(extra)/pubKeySize) {
+				return txPubKey, additionalKeys
+			}
+			i += n
+			if i+int(count)*pubKeySize > len(extra) {
+				return txPubKey, additionalKeys
+			}
+			keys := make([]*mcrypto.PublicKey, 0, count)
+			for k := 0; k < int(count); k++ {
+				key, err := mcrypto.NewPublicKeyFromBytes(extra[i : i+pubKeySize])
+				if err != nil {
+					return txPubKey, additionalKeys
+				}
+				keys = append(keys, key)
+				i += pubKeySize
+			}
+			if additionalKeys == nil {
+				additionalKeys = keys
+			}
+		default:
+			return txPubKey, additionalKeys
+		}
+	}
+
+	return txPubKey, additionalKeys
+}
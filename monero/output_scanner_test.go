@@ -0,0 +1,218 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package monero
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+)
+
+// fakeTx builds a JSON decoded transaction, in the format returned by monerod,
+// with one output to a random address followed by one output of amount to the
+// recipient.
+func fakeTx(t *testing.T, recipient *mcrypto.PublicKeyPair, amount uint64) []byte {
+	txKeys, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	txPrivKey := txKeys.ViewKey()
+	txPubKey := txPrivKey.Public()
+
+	other, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	// tx_extra: tx public key followed by a 3 byte nonce
+	extra := append([]byte{txExtraTagPubKey}, txPubKey.Bytes()...)
+	extra = append(extra, txExtraTagNonce, 3, 0xaa, 0xbb, 0xcc)
+	extraInts := make([]uint, len(extra))
+	for i, b := range extra {
+		extraInts[i] = uint(b)
+	}
+
+	otherDerivation, err := mcrypto.NewKeyDerivation(txPrivKey, other.ViewKey().Public())
+	require.NoError(t, err)
+	recipientDerivation, err := mcrypto.NewKeyDerivation(txPrivKey, recipient.ViewKey())
+	require.NoError(t, err)
+
+	var plainAmount, encAmount [mcrypto.EncryptedAmountSize]byte
+	binary.LittleEndian.PutUint64(plainAmount[:], amount)
+	binary.LittleEndian.PutUint64(encAmount[:], recipientDerivation.DecryptAmount(1, plainAmount))
+
+	type taggedKey struct {
+		Key     string `json:"key"`
+		ViewTag string `json:"view_tag"`
+	}
+	type target struct {
+		TaggedKey taggedKey `json:"tagged_key"`
+	}
+	type vout struct {
+		Amount uint64 `json:"amount"`
+		Target target `json:"target"`
+	}
+
+	tx := map[string]any{
+		"version":     2,
+		"unlock_time": 0,
+		"vout": []vout{
+			{Target: target{TaggedKey: taggedKey{
+				Key:     otherDerivation.OutputPublicKey(0, other.SpendKey().Public()).Hex(),
+				ViewTag: fmt.Sprintf("%02x", otherDerivation.ViewTag(0)),
+			}}},
+			{Target: target{TaggedKey: taggedKey{
+				Key:     recipientDerivation.OutputPublicKey(1, recipient.SpendKey()).Hex(),
+				ViewTag: fmt.Sprintf("%02x", recipientDerivation.ViewTag(1)),
+			}}},
+		},
+		"extra": extraInts,
+		"rct_signatures": map[string]any{
+			"type": 6,
+			"ecdhInfo": []map[string]string{
+				{"amount": "0000000000000000"},
+				{"amount": hex.EncodeToString(encAmount[:])},
+			},
+			"outPk": []string{
+				hex.EncodeToString(otherDerivation.AmountCommitment(0, 1e12)),
+				hex.EncodeToString(recipientDerivation.AmountCommitment(1, amount)),
+			},
+		},
+	}
+
+	data, err := json.Marshal(tx)
+	require.NoError(t, err)
+	return data
+}
+
+func TestFindOutputs(t *testing.T) {
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	const amount = uint64(2_500_000_000_000)
+	txData := fakeTx(t, kp.PublicKeyPair(), amount)
+
+	outputs, err := findOutputs("abcd", txData, kp.ViewKey(), kp.SpendKey().Public())
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.Equal(t, "abcd", outputs[0].TxID)
+	require.Equal(t, uint64(1), outputs[0].OutputIndex)
+	require.Equal(t, 0, outputs[0].Amount.CmpU64(amount))
+
+	// a different view key must not match any outputs
+	kp2, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	outputs, err = findOutputs("abcd", txData, kp2.ViewKey(), kp.SpendKey().Public())
+	require.NoError(t, err)
+	require.Empty(t, outputs)
+}
+
+func TestFindOutputs_tamperedAmount(t *testing.T) {
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	const amount = uint64(2_500_000_000_000)
+	txData := fakeTx(t, kp.PublicKeyPair(), amount)
+
+	tx := make(map[string]any)
+	require.NoError(t, json.Unmarshal(txData, &tx))
+	ecdhInfo := tx["rct_signatures"].(map[string]any)["ecdhInfo"].([]any)
+	encAmount, err := hex.DecodeString(ecdhInfo[1].(map[string]any)["amount"].(string))
+	require.NoError(t, err)
+
+	// flipping a bit of the encrypted amount flips the same bit of the
+	// decrypted amount, which no longer matches the output's commitment
+	encAmount[0] ^= 0x01
+	ecdhInfo[1].(map[string]any)["amount"] = hex.EncodeToString(encAmount)
+	txData, err = json.Marshal(tx)
+	require.NoError(t, err)
+
+	_, err = findOutputs("abcd", txData, kp.ViewKey(), kp.SpendKey().Public())
+	require.ErrorContains(t, err, "amount of output 1 does not match its commitment")
+}
+
+func TestParseTxExtra(t *testing.T) {
+	keys := make([]*mcrypto.PublicKey, 3)
+	for i := range keys {
+		kp, err := mcrypto.GenerateKeys()
+		require.NoError(t, err)
+		keys[i] = kp.SpendKey().Public()
+	}
+
+	extra := []byte{txExtraTagNonce, 2, 0x01, 0x01} // nonce containing the pub key tag
+	extra = append(extra, txExtraTagPubKey)
+	extra = append(extra, keys[0].Bytes()...)
+	extra = append(extra, txExtraTagAdditionalKeys, 2)
+	extra = append(extra, keys[1].Bytes()...)
+	extra = append(extra, keys[2].Bytes()...)
+	extra = append(extra, txExtraTagPadding, 0, 0)
+
+	txPubKey, additional := parseTxExtra(extra)
+	require.NotNil(t, txPubKey)
+	require.Equal(t, keys[0].Bytes(), txPubKey.Bytes())
+	require.Len(t, additional, 2)
+	require.Equal(t, keys[1].Bytes(), additional[0].Bytes())
+	require.Equal(t, keys[2].Bytes(), additional[1].Bytes())
+
+	// truncated extra fields must not panic
+	for i := range extra {
+		parseTxExtra(extra[:i])
+	}
+}
+
+func TestNewOutputScanner_wrongViewKey(t *testing.T) {
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	kp2, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	addr := kp.PublicKeyPair().Address(common.Mainnet)
+	_, err = newOutputScanner(nil, addr, kp2.ViewKey(), 0)
+	require.ErrorContains(t, err, "private view key does not match the address")
+
+	_, err = newOutputScanner(nil, addr, kp.ViewKey(), 0)
+	require.NoError(t, err)
+}
+
+func TestOutputScanner_Scan(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	transferAmt := coins.MoneroToPiconero(coins.StrToDecimal("3.5"))
+	transferAmtPlusFees := coins.MoneroToPiconero(coins.StrToDecimal("3.51"))
+
+	c := CreateWalletClient(t)
+	MineMinXMRBalance(t, c, transferAmtPlusFees)
+
+	startHeight, err := c.GetHeight()
+	require.NoError(t, err)
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	addr := kp.PublicKeyPair().Address(common.Development)
+
+	scanner, err := NewOutputScanner(c, addr, kp.ViewKey(), startHeight)
+	require.NoError(t, err)
+
+	outputs, err := scanner.Scan(ctx)
+	require.NoError(t, err)
+	require.Empty(t, outputs)
+
+	transfer, err := c.Transfer(ctx, addr, 0, transferAmt, SweepToSelfConfirmations, types.PriorityDefault)
+	require.NoError(t, err)
+
+	outputs, err = scanner.Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.Equal(t, transfer.TxID, outputs[0].TxID)
+	require.Equal(t, transfer.Height, outputs[0].Height)
+	require.Zero(t, outputs[0].Amount.Cmp(transferAmt))
+	require.GreaterOrEqual(t, outputs[0].Confirmations, uint64(SweepToSelfConfirmations))
+}
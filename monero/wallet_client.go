@@ -25,6 +25,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 )
 
@@ -52,12 +53,14 @@ type WalletClient interface {
 		accountIdx uint64,
 		amount *coins.PiconeroAmount,
 		numConfirmations uint64,
+		priority types.MoneroTxPriority,
 	) (*wallet.Transfer, error)
 	SweepAll(
 		ctx context.Context,
 		to *mcrypto.Address,
 		accountIdx uint64,
 		numConfirmations uint64,
+		priority types.MoneroTxPriority,
 	) ([]*wallet.Transfer, error)
 	CreateWalletConf(walletNamePrefix string) *WalletClientConf
 	WalletName() string
@@ -69,14 +72,29 @@ type WalletClient interface {
 
 // WalletClientConf wraps the configuration fields needed to call NewWalletClient
 type WalletClientConf struct {
-	Env                 common.Environment   // Required
-	WalletFilePath      string               // Required, wallet created if it does not exist
-	WalletPassword      string               // Optional, password used to open wallet or when creating a new wallet
-	WalletPort          uint                 // Optional, zero means OS picks a random port
-	MonerodNodes        []*common.MoneroNode // Optional, defaulted from environment if nil
-	MoneroWalletRPCPath string               // optional, path to monero-rpc-binary
-	LogPath             string               // optional, default is dir(WalletFilePath)/../monero-wallet-rpc.log
-}
+	Env                 common.Environment     // Required
+	WalletFilePath      string                 // Required, wallet created if it does not exist
+	WalletPassword      string                 // Optional, password used to open wallet or when creating a new wallet
+	WalletPort          uint                   // Optional, zero means OS picks a random port
+	MonerodNodes        []*common.MoneroNode   // Optional, defaulted from environment if nil
+	MoneroWalletRPCPath string                 // optional, path to monero-rpc-binary
+	LogPath             string                 // optional, default is dir(WalletFilePath)/../monero-wallet-rpc.log
+	TxPriority          types.MoneroTxPriority // optional, fee priority used when a call passes PriorityDefault
+
+	// fromKeys, if set, creates the wallets from keys instead of launching
+	// monero-wallet-rpc. It is set by the fake wallet client of the tests.
+	fromKeys walletFromKeysFunc
+}
+
+// walletFromKeysFunc creates a wallet client for a spend or view-only wallet
+// of the passed keys, see createWalletFromKeys.
+type walletFromKeysFunc func(
+	conf *WalletClientConf,
+	walletRestoreHeight uint64,
+	privateSpendKey *mcrypto.PrivateSpendKey, // nil for a view-only wallet
+	privateViewKey *mcrypto.PrivateViewKey,
+	address *mcrypto.Address,
+) (WalletClient, error)
 
 // Fill fills in the optional configuration values (Port, MonerodNodes, MoneroWalletRPCPath,
 // and LogPath) if they are not set.
@@ -130,6 +148,7 @@ type waitForReceiptRequest struct {
 type walletClient struct {
 	wRPC       wallet.Wallet       // full monero-wallet-rpc API (larger than the WalletClient interface)
 	dRPC       monerodaemon.Daemon // full monerod RPC API
+	daemonURL  string              // base URL of monerod, used for its non-JSON-RPC endpoints
 	endpoint   string
 	walletAddr *mcrypto.Address
 	conf       *WalletClientConf
@@ -204,12 +223,13 @@ func NewWalletClient(conf *WalletClientConf) (WalletClient, error) {
 
 // NewThinWalletClient returns a WalletClient for an existing monero-wallet-rpc process.
 func NewThinWalletClient(monerodHost string, monerodPort uint, walletPort uint) WalletClient {
-	monerodEndpoint := fmt.Sprintf("http://%s:%d/json_rpc", monerodHost, monerodPort)
+	daemonURL := fmt.Sprintf("http://%s:%d", monerodHost, monerodPort)
 	walletEndpoint := fmt.Sprintf("http://127.0.0.1:%d/json_rpc", walletPort)
 	return &walletClient{
-		dRPC:     monerorpc.New(monerodEndpoint, nil).Daemon,
-		wRPC:     monerorpc.New(walletEndpoint, nil).Wallet,
-		endpoint: walletEndpoint,
+		dRPC:      monerorpc.New(daemonURL+"/json_rpc", nil).Daemon,
+		wRPC:      monerorpc.New(walletEndpoint, nil).Wallet,
+		daemonURL: daemonURL,
+		endpoint:  walletEndpoint,
 	}
 }
 
@@ -279,19 +299,22 @@ func (c *walletClient) Transfer(
 	accountIdx uint64,
 	amount *coins.PiconeroAmount,
 	numConfirmations uint64,
+	priority types.MoneroTxPriority,
 ) (*wallet.Transfer, error) {
 	amt, err := amount.Uint64()
 	if err != nil {
 		return nil, err
 	}
 	amountStr := amount.AsMoneroString()
-	log.Infof("Transferring %s XMR to %s", amountStr, to)
+	priority = c.txPriority(priority)
+	log.Infof("Transferring %s XMR to %s (priority=%s)", amountStr, to, priority)
 	reqResp, err := c.wRPC.Transfer(&wallet.TransferRequest{
 		Destinations: []wallet.Destination{{
 			Amount:  amt,
 			Address: to.String(),
 		}},
 		AccountIndex: accountIdx,
+		Priority:     uint64(priority),
 	})
 	if err != nil {
 		log.Warnf("Transfer of %s XMR failed: %s", amountStr, err)
@@ -320,6 +343,7 @@ func (c *walletClient) SweepAll(
 	to *mcrypto.Address,
 	accountIdx uint64,
 	numConfirmations uint64,
+	priority types.MoneroTxPriority,
 ) ([]*wallet.Transfer, error) {
 	addrResp, err := c.GetAddress(accountIdx)
 	if err != nil {
@@ -345,6 +369,7 @@ func (c *walletClient) SweepAll(
 	reqResp, err := c.wRPC.SweepAll(&wallet.SweepAllRequest{
 		AccountIndex: accountIdx,
 		Address:      to.String(),
+		Priority:     uint64(c.txPriority(priority)),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("sweep_all from %s failed: %w", from, err)
@@ -385,10 +410,20 @@ func (c *walletClient) CreateWalletConf(walletNamePrefix string) *WalletClientCo
 		MonerodNodes:        c.conf.MonerodNodes,
 		MoneroWalletRPCPath: c.conf.MoneroWalletRPCPath,
 		LogPath:             c.conf.LogPath,
+		TxPriority:          c.conf.TxPriority,
 	}
 	return conf
 }
 
+// txPriority returns the passed priority, unless it is PriorityDefault, in
+// which case the priority from the wallet's configuration is returned.
+func (c *walletClient) txPriority(priority types.MoneroTxPriority) types.MoneroTxPriority {
+	if priority != types.PriorityDefault || c.conf == nil {
+		return priority
+	}
+	return c.conf.TxPriority
+}
+
 func createWalletFromKeys(
 	conf *WalletClientConf,
 	walletRestoreHeight uint64,
@@ -396,6 +431,10 @@ func createWalletFromKeys(
 	privateViewKey *mcrypto.PrivateViewKey,
 	address *mcrypto.Address,
 ) (WalletClient, error) {
+	if conf.fromKeys != nil {
+		return conf.fromKeys(conf, walletRestoreHeight, privateSpendKey, privateViewKey, address)
+	}
+
 	if conf.WalletPort == 0 { // swap wallets need randomized ports, so we expect this to be zero
 		var err error
 		conf.WalletPort, err = common.GetFreeTCPPort()
@@ -16,6 +16,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 )
 
@@ -54,7 +55,7 @@ func TestClient_Transfer(t *testing.T) {
 	vkABPriv := mcrypto.SumPrivateViewKeys(kpA.ViewKey(), kpB.ViewKey())
 
 	// Transfer from Bob's account to the Alice+Bob swap account
-	transfer, err := cXMRMaker.Transfer(ctx, abAddress, 0, transferAmt, MinSpendConfirmations)
+	transfer, err := cXMRMaker.Transfer(ctx, abAddress, 0, transferAmt, MinSpendConfirmations, types.PriorityDefault)
 	require.NoError(t, err)
 	t.Logf("Bob sent %s (+fee %s) XMR to A+B address with TX ID %s",
 		coins.FmtPiconeroAsXMR(transfer.Amount),
@@ -111,7 +112,7 @@ func TestClient_Transfer(t *testing.T) {
 	require.Equal(t, transferAmtU64, balanceABWal.UnlockedBalance)
 
 	// Alice transfers from A+B spend wallet to her primary wallet's address
-	transfers, err := abSpendCli.SweepAll(ctx, alicePrimaryAddr, 0, SweepToSelfConfirmations)
+	transfers, err := abSpendCli.SweepAll(ctx, alicePrimaryAddr, 0, SweepToSelfConfirmations, types.PriorityDefault)
 	require.NoError(t, err)
 	t.Logf("Alice swept AB wallet funds with %d transfers", len(transfers))
 	require.Len(t, transfers, 1) // In our case, it should always be a single transaction
@@ -146,7 +147,7 @@ func Test_walletClient_SweepAll_nothingToSweepReturnsError(t *testing.T) {
 	destAddr, err := mcrypto.NewAddress(addrResp.Address, common.Development)
 	require.NoError(t, err)
 
-	_, err = emptyWallet.SweepAll(context.Background(), destAddr, 0, SweepToSelfConfirmations)
+	_, err = emptyWallet.SweepAll(context.Background(), destAddr, 0, SweepToSelfConfirmations, types.PriorityDefault)
 	require.ErrorContains(t, err, "no balance to sweep")
 }
 
@@ -341,7 +342,7 @@ func Test_walletClient_waitForConfirmations_contextCancelled(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	_, err = c.Transfer(ctx, destAddr, 0, coins.NewPiconeroAmount(amount), numConfirmations)
+	_, err = c.Transfer(ctx, destAddr, 0, coins.NewPiconeroAmount(amount), numConfirmations, types.PriorityDefault)
 	require.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
@@ -7,8 +7,13 @@ import (
 	"errors"
 )
 
+// ErrRelayClaimRejected is returned when a relayer rejects our claim request.
+// The error includes the relayer's reason.
+var ErrRelayClaimRejected = errors.New("relayer rejected claim request")
+
 var (
 	errBootnodeCannotRelay   = errors.New("bootnode cannot be a relayer")
+	errNoChainName           = errors.New("ethereum chain name is required")
 	errNilHandler            = errors.New("handler is nil")
 	errNoOngoingSwap         = errors.New("no swap currently happening")
 	errOfferUnavailable      = errors.New("offer no longer available")
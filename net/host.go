@@ -83,6 +83,7 @@ type Host struct {
 type Config struct {
 	Ctx       context.Context
 	Env       common.Environment
+	ChainName string // name of the ethereum chain, ignored by bootnodes
 	DataDir   string
 	Port      uint16
 	KeyFile   string
@@ -93,11 +94,12 @@ type Config struct {
 
 // ChainProtocolID returns the versioned p2p protocol ID that includes the
 // Ethereum chain name being used. The streams that are opened between peers use
-// this prefix. All provided values advertised in the DHT also use this prefix.
-// Note that dedicated bootnodes don't have a chain name and don't open p2p
-// streams, so they just use the word "bootnode" in place of a chain name.
-func ChainProtocolID(env common.Environment) string {
-	return fmt.Sprintf("%s/%s/%d", baseProtocolID, common.ChainNameFromEnv(env), p2pAPIVersion)
+// this prefix. All provided values advertised in the DHT also use this prefix,
+// so peers only find offers on the same chain. Note that dedicated bootnodes
+// don't have a chain name and don't open p2p streams, so they just use the
+// word "bootnode" in place of a chain name.
+func ChainProtocolID(chainName string) string {
+	return fmt.Sprintf("%s/%s/%d", baseProtocolID, chainName, p2pAPIVersion)
 }
 
 // NewHost returns a new Host.
@@ -117,7 +119,15 @@ func NewHost(cfg *Config) (*Host, error) {
 		swaps:      make(map[types.Hash]*swap),
 	}
 
-	baseProtocolID := ChainProtocolID(cfg.Env)
+	chainName := cfg.ChainName
+	if isBootnode {
+		chainName = common.ChainNameFromEnv(common.Bootnode)
+	}
+	if chainName == "" {
+		return nil, errNoChainName
+	}
+
+	baseProtocolID := ChainProtocolID(chainName)
 	log.Debugf("using base protocol %s", baseProtocolID)
 
 	var err error
@@ -141,7 +151,7 @@ func NewHost(cfg *Config) (*Host, error) {
 func (h *Host) advertisedNamespaces() []string {
 	provides := []string{""}
 
-	if !h.isBootnode && len(h.makerHandler.GetOffers()) > 0 {
+	if h.makerHandler != nil && len(h.makerHandler.GetOffers()) > 0 {
 		provides = append(provides, string(coins.ProvidesXMR))
 	}
 
@@ -153,7 +163,8 @@ func (h *Host) advertisedNamespaces() []string {
 }
 
 // SetHandlers sets the maker and taker instances used by the host, and configures
-// the stream handlers.
+// the stream handlers. The maker handler is nil on relayer-only nodes, which
+// have no offers and do not swap.
 func (h *Host) SetHandlers(makerHandler MakerHandler, relayHandler RelayHandler) {
 	h.makerHandler = makerHandler
 	h.relayHandler = relayHandler
@@ -166,7 +177,7 @@ func (h *Host) SetHandlers(makerHandler MakerHandler, relayHandler RelayHandler)
 
 // Start starts the bootstrap and discovery process.
 func (h *Host) Start() error {
-	if (h.makerHandler == nil || h.relayHandler == nil) && !h.isBootnode {
+	if h.relayHandler == nil && !h.isBootnode {
 		return errNilHandler
 	}
 
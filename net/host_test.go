@@ -5,6 +5,7 @@ package net
 
 import (
 	"context"
+	"math/big"
 	"path"
 	"testing"
 
@@ -51,8 +52,13 @@ type mockRelayHandler struct {
 	t *testing.T
 }
 
-func (*mockRelayHandler) GetRelayerAddressHash() (types.Hash, error) {
-	return types.Hash{99}, nil
+func (*mockRelayHandler) GetRelayerQuote(_ peer.ID) (*message.RelayerQueryResponse, error) {
+	addressHash := types.Hash{99}
+	return &message.RelayerQueryResponse{
+		AddressHash: addressHash[:],
+		ETHFee:      big.NewInt(1e15),
+		TokenFee:    big.NewInt(2e15),
+	}, nil
 }
 
 func (*mockRelayHandler) HasOngoingSwapAsTaker(_ peer.ID) error {
@@ -65,6 +71,12 @@ func (*mockRelayHandler) HandleRelayClaimRequest(_ peer.ID, _ *RelayClaimRequest
 	}, nil
 }
 
+func (*mockRelayHandler) HandleRelayRefundRequest(_ peer.ID, _ *RelayRefundRequest) (*RelayClaimResponse, error) {
+	return &RelayClaimResponse{
+		TxHash: mockEthTXHash,
+	}, nil
+}
+
 type mockSwapState struct {
 	offerID types.Hash
 }
@@ -99,6 +111,7 @@ func basicTestConfig(t *testing.T) *Config {
 	return &Config{
 		Ctx:       ctx,
 		Env:       common.Development,
+		ChainName: common.ChainNameFromEnv(common.Development),
 		DataDir:   tmpDir,
 		Port:      0, // OS randomized libp2p port
 		KeyFile:   path.Join(tmpDir, "node.key"),
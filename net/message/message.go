@@ -29,6 +29,7 @@ const (
 	RelayClaimResponseType
 	SendKeysType
 	NotifyETHLockedType
+	RelayRefundRequestType
 )
 
 // TypeToString converts a message type into a string.
@@ -46,6 +47,8 @@ func TypeToString(t byte) string {
 		return "RelayClaimRequestType"
 	case RelayClaimResponseType:
 		return "RelayClaimResponse"
+	case RelayRefundRequestType:
+		return "RelayRefundRequest"
 	default:
 		return fmt.Sprintf("Unknown(%d)", t)
 	}
@@ -75,6 +78,8 @@ func DecodeMessage(b []byte) (common.Message, error) {
 		msg = new(SendKeysMessage)
 	case NotifyETHLockedType:
 		msg = new(NotifyETHLocked)
+	case RelayRefundRequestType:
+		msg = new(RelayRefundRequest)
 	default:
 		return nil, fmt.Errorf("invalid message type=%d", msgType)
 	}
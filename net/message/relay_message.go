@@ -5,6 +5,7 @@ package message
 
 import (
 	"fmt"
+	"math/big"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 
@@ -14,9 +15,13 @@ import (
 )
 
 // RelayerQueryResponse is sent from a relayer to the opener of
-// a /relayerquery/0 stream.
+// a /relayerquery/0 stream. ETHFee and TokenFee are the relayer's fees, in wei,
+// for claiming ETH and ERC20 token swaps using the address hash. Token swaps pay
+// the value of TokenFee in the token.
 type RelayerQueryResponse struct {
-	AddressHash []byte `json:"address" validate:"required,len=32"`
+	AddressHash []byte   `json:"address" validate:"required,len=32"`
+	ETHFee      *big.Int `json:"ethFee" validate:"required"`
+	TokenFee    *big.Int `json:"tokenFee" validate:"required"`
 }
 
 // String converts the RelayerQueryResponse to a string usable for debugging purposes
@@ -72,9 +77,57 @@ func (m *RelayClaimRequest) Type() byte {
 	return RelayClaimRequestType
 }
 
-// RelayClaimResponse implements common.Message for our p2p relay claim responses
+// RelayRefundRequest implements common.Message for our p2p relay refund
+// requests. The RelaySwap is signed by the swap owner, who has no ETH to pay
+// for the gas of a refund. Refund requests are only sent to relayers that
+// advertised in the DHT, and the relayer responds with a RelayClaimResponse.
+type RelayRefundRequest struct {
+	RelaySwap *contracts.SwapCreatorRelaySwap `json:"relaySwap" validate:"required"`
+	Secret    []byte                          `json:"secret" validate:"required,len=32"`
+	Signature []byte                          `json:"signature" validate:"required,len=65"`
+}
+
+// String converts the RelayRefundRequest to a string usable for debugging purposes
+func (m *RelayRefundRequest) String() string {
+	return fmt.Sprintf("RelayRefundRequest=%#v", m)
+}
+
+// Encode implements the Encode() method of the common.Message interface which
+// prepends a message type byte before the message's JSON encoding.
+func (m *RelayRefundRequest) Encode() ([]byte, error) {
+	b, err := vjson.MarshalStruct(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{RelayRefundRequestType}, b...), nil
+}
+
+// Type implements the Type() method of the common.Message interface
+func (m *RelayRefundRequest) Type() byte {
+	return RelayRefundRequestType
+}
+
+// RelayRejectReason is the machine-readable reason that a relayer rejected a
+// claim or refund request
+type RelayRejectReason string
+
+// Reasons that a relayer can reject a claim or refund request with
+const (
+	RelayRejectInvalid         RelayRejectReason = "invalid-request"
+	RelayRejectRateLimited     RelayRejectReason = "rate-limited"
+	RelayRejectPeerRateLimited RelayRejectReason = "peer-rate-limited"
+	RelayRejectUnprofitable    RelayRejectReason = "unprofitable"
+	RelayRejectSpendCapReached RelayRejectReason = "spend-cap-reached"
+	RelayRejectUnavailable     RelayRejectReason = "unavailable"
+)
+
+// RelayClaimResponse implements common.Message for our p2p relay claim and
+// refund responses. When the relayer rejected the request, the TxHash is not
+// set and the RejectReason says why.
 type RelayClaimResponse struct {
-	TxHash ethcommon.Hash `json:"transactionHash" validate:"required"`
+	TxHash       ethcommon.Hash    `json:"transactionHash"`
+	RejectReason RelayRejectReason `json:"rejectReason,omitempty"`
 }
 
 // String converts the RelayClaimRequest to a string usable for debugging purposes
@@ -23,8 +23,10 @@ const (
 func (h *Host) handleQueryStream(stream libp2pnetwork.Stream) {
 	defer func() { _ = stream.Close() }()
 
-	resp := &QueryResponse{
-		Offers: h.makerHandler.GetOffers(),
+	// relayer-only nodes have no maker handler and no offers
+	resp := &QueryResponse{}
+	if h.makerHandler != nil {
+		resp.Offers = h.makerHandler.GetOffers()
 	}
 
 	if err := p2pnet.WriteStreamMessage(stream, resp, stream.Conn().RemotePeer()); err != nil {
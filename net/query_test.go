@@ -27,3 +27,25 @@ func TestHost_Query(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []*types.Offer{}, resp.Offers)
 }
+
+func TestHost_Query_relayerOnly(t *testing.T) {
+	ha := newHost(t, basicTestConfig(t))
+	err := ha.Start()
+	require.NoError(t, err)
+
+	// relayer-only nodes have no maker handler
+	cfg := basicTestConfig(t)
+	cfg.IsRelayer = true
+	hb := newHost(t, cfg)
+	hb.SetHandlers(nil, &mockRelayHandler{t: t})
+	err = hb.Start()
+	require.NoError(t, err)
+	require.Equal(t, []string{"", RelayerProvidesStr}, hb.advertisedNamespaces())
+
+	err = ha.h.Connect(ha.ctx, hb.h.AddrInfo())
+	require.NoError(t, err)
+
+	resp, err := ha.Query(hb.h.PeerID())
+	require.NoError(t, err)
+	require.Empty(t, resp.Offers)
+}
@@ -10,11 +10,12 @@ import (
 	"time"
 
 	p2pnet "github.com/athanorlabs/go-p2p-net"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 
-	"github.com/athanorlabs/atomic-swap/common/types"
 	"github.com/athanorlabs/atomic-swap/net/message"
+	"github.com/athanorlabs/atomic-swap/relayer"
 )
 
 const (
@@ -35,8 +36,8 @@ func (h *Host) DiscoverRelayers() ([]peer.ID, error) {
 }
 
 // we need the relayer to send a message containing
-// the address to send the fee to, so that the requester
-// can sign it.
+// the address to send the fee to and the fees it charges,
+// so that the requester can sign it.
 func (h *Host) handleRelayerQueryStream(stream libp2pnetwork.Stream) {
 	defer func() { _ = stream.Close() }()
 
@@ -49,65 +50,57 @@ func (h *Host) handleRelayerQueryStream(stream libp2pnetwork.Stream) {
 		}
 	}
 
-	addressHash, err := h.relayHandler.GetRelayerAddressHash()
+	addrResp, err := h.relayHandler.GetRelayerQuote(stream.Conn().RemotePeer())
 	if err != nil {
-		log.Warnf("failed to get relayer address hash: %s", err)
+		log.Warnf("failed to get relayer quote: %s", err)
 		return
 	}
 
-	addrResp := &message.RelayerQueryResponse{
-		AddressHash: addressHash[:],
-	}
-
 	log.Debugf("sending RelayerQueryResponse to peer %s", stream.Conn().RemotePeer())
 	if err := p2pnet.WriteStreamMessage(stream, addrResp, stream.Conn().RemotePeer()); err != nil {
 		log.Warnf("failed to send RelayClaimResponse message to peer: %s", err)
 	}
 }
 
-// QueryRelayerAddress opens a relay stream with a peer, and if they are a relayer,
-// they will respond with their relayer payout address.
-func (h *Host) QueryRelayerAddress(relayerID peer.ID) (types.Hash, error) {
+// QueryRelayer opens a relay stream with a peer, and if they are a relayer,
+// they will respond with the hash of their relayer payout address and their
+// fee quote.
+func (h *Host) QueryRelayer(relayerID peer.ID) (*message.RelayerQueryResponse, error) {
 	ctx, cancel := context.WithTimeout(h.ctx, connectionTimeout)
 	defer cancel()
 
 	if err := h.h.Connect(ctx, peer.AddrInfo{ID: relayerID}); err != nil {
-		return types.Hash{}, err
+		return nil, err
 	}
 
 	stream, err := h.h.NewStream(ctx, relayerID, relayerQueryProtocolID)
 	if err != nil {
-		return types.Hash{}, fmt.Errorf("failed to open stream with peer: err=%w", err)
+		return nil, fmt.Errorf("failed to open stream with peer: err=%w", err)
 	}
 
 	log.Debugf("opened relayer query stream: %s", stream.Conn())
-	resp, err := receiveRelayerQueryResponse(stream)
-	if err != nil {
-		return types.Hash{}, err
-	}
-
-	return resp, nil
+	return receiveRelayerQueryResponse(stream)
 }
 
-func receiveRelayerQueryResponse(stream libp2pnetwork.Stream) (types.Hash, error) {
+func receiveRelayerQueryResponse(stream libp2pnetwork.Stream) (*message.RelayerQueryResponse, error) {
 	const relayResponseTimeout = time.Second * 15
 
 	select {
 	case msg := <-nextStreamMessage(stream, maxRelayMessageSize):
 		if msg == nil {
-			return types.Hash{}, errors.New("failed to read RelayerQueryResponse")
+			return nil, errors.New("failed to read RelayerQueryResponse")
 		}
 
 		resp, ok := msg.(*message.RelayerQueryResponse)
 		if !ok {
-			return types.Hash{}, fmt.Errorf("expected %s message but received %s",
-				message.TypeToString(message.RelayClaimResponseType),
+			return nil, fmt.Errorf("expected %s message but received %s",
+				message.TypeToString(message.RelayerQueryResponseType),
 				message.TypeToString(msg.Type()))
 		}
 
-		return [32]byte(resp.AddressHash), nil
+		return resp, nil
 	case <-time.After(relayResponseTimeout):
-		return types.Hash{}, errors.New("timed out waiting for QueryResponse")
+		return nil, errors.New("timed out waiting for QueryResponse")
 	}
 }
 
@@ -123,6 +116,11 @@ func (h *Host) handleRelayStream(stream libp2pnetwork.Stream) {
 
 	curPeer := stream.Conn().RemotePeer()
 
+	if refundReq, ok := msg.(*RelayRefundRequest); ok {
+		h.handleRelayRefundRequest(stream, refundReq)
+		return
+	}
+
 	req, ok := msg.(*RelayClaimRequest)
 	if !ok {
 		log.Debugf("ignoring wrong message type=%s sent to relay stream from %s",
@@ -150,18 +148,53 @@ func (h *Host) handleRelayStream(stream libp2pnetwork.Stream) {
 	resp, err := h.relayHandler.HandleRelayClaimRequest(curPeer, req)
 	if err != nil {
 		log.Debugf("did not handle relay request: %s", err)
-		return
+		// only the reason is sent back, to avoid leaking the details of errors
+		resp = &RelayClaimResponse{RejectReason: relayer.RejectReason(err)}
+	} else {
+		log.Debugf("Relayed claim for %s with tx=%s", req.RelaySwap.Swap.Claimer, resp.TxHash)
 	}
 
-	log.Debugf("Relayed claim for %s with tx=%s", req.RelaySwap.Swap.Claimer, resp.TxHash)
 	if err := p2pnet.WriteStreamMessage(stream, resp, stream.Conn().RemotePeer()); err != nil {
 		log.Warnf("failed to send RelayClaimResponse message to peer: %s", err)
 		return
 	}
 }
 
+// handleRelayRefundRequest relays the refund of a swap owner. Unlike claims,
+// our swap counterparty never asks us to relay their refund, so only nodes that
+// advertise as relayers handle refund requests.
+func (h *Host) handleRelayRefundRequest(stream libp2pnetwork.Stream, req *RelayRefundRequest) {
+	curPeer := stream.Conn().RemotePeer()
+	if !h.isRelayer {
+		log.Debugf("ignoring relay refund request from %s, we are not a relayer", curPeer)
+		return
+	}
+
+	resp, err := h.relayHandler.HandleRelayRefundRequest(curPeer, req)
+	if err != nil {
+		log.Debugf("did not handle relay refund request: %s", err)
+		resp = &RelayClaimResponse{RejectReason: relayer.RejectReason(err)}
+	} else {
+		log.Debugf("Relayed refund for %s with tx=%s", req.RelaySwap.Swap.Owner, resp.TxHash)
+	}
+
+	if err := p2pnet.WriteStreamMessage(stream, resp, curPeer); err != nil {
+		log.Warnf("failed to send RelayClaimResponse message to peer: %s", err)
+	}
+}
+
 // SubmitRelayRequest sends a request to relay a swap claim to a peer.
 func (h *Host) SubmitRelayRequest(relayerID peer.ID, request *RelayClaimRequest) (*RelayClaimResponse, error) {
+	return h.submitRelayMessage(relayerID, request)
+}
+
+// SubmitRelayRefundRequest sends a request to relay a swap refund to a peer
+// that advertised as a relayer.
+func (h *Host) SubmitRelayRefundRequest(relayerID peer.ID, request *RelayRefundRequest) (*RelayClaimResponse, error) {
+	return h.submitRelayMessage(relayerID, request)
+}
+
+func (h *Host) submitRelayMessage(relayerID peer.ID, request Message) (*RelayClaimResponse, error) {
 	ctx, cancel := context.WithTimeout(h.ctx, connectionTimeout)
 	defer cancel()
 
@@ -177,7 +210,7 @@ func (h *Host) SubmitRelayRequest(relayerID peer.ID, request *RelayClaimRequest)
 	log.Debugf("opened relay stream with peer %s", relayerID)
 
 	if err := p2pnet.WriteStreamMessage(stream, request, relayerID); err != nil {
-		log.Warnf("failed to send RelayClaimRequest to peer: err=%s", err)
+		log.Warnf("failed to send %s to peer: err=%s", message.TypeToString(request.Type()), err)
 		return nil, err
 	}
 
@@ -203,6 +236,14 @@ func receiveRelayClaimResponse(stream libp2pnetwork.Stream) (*RelayClaimResponse
 				message.TypeToString(msg.Type()))
 		}
 
+		if resp.RejectReason != "" {
+			return nil, fmt.Errorf("%w: %s", ErrRelayClaimRejected, resp.RejectReason)
+		}
+
+		if (resp.TxHash == ethcommon.Hash{}) {
+			return nil, errors.New("relay claim response has no transaction hash")
+		}
+
 		return resp, nil
 	case <-time.After(relayResponseTimeout):
 		return nil, errors.New("timed out waiting for QueryResponse")
@@ -87,8 +87,11 @@ func createTestClaimRequest() *message.RelayClaimRequest {
 func TestHost_SubmitClaimToRelayer_dhtRelayer(t *testing.T) {
 	ha, hb := twoHostRelayerSetup(t)
 
-	_, err := ha.QueryRelayerAddress(hb.PeerID())
+	quote, err := ha.QueryRelayer(hb.PeerID())
 	require.NoError(t, err)
+	require.Equal(t, types.Hash{99}, types.Hash(quote.AddressHash))
+	require.Equal(t, big.NewInt(1e15), quote.ETHFee)
+	require.Equal(t, big.NewInt(2e15), quote.TokenFee)
 
 	// success path ha->hb, hb is a DHT relayer
 	resp, err := ha.SubmitRelayRequest(hb.PeerID(), createTestClaimRequest())
@@ -103,6 +106,26 @@ func TestHost_SubmitClaimToRelayer_dhtRelayer(t *testing.T) {
 	require.ErrorContains(t, err, "failed to read RelayClaimResponse")
 }
 
+func TestHost_SubmitRefundToRelayer(t *testing.T) {
+	ha, hb := twoHostRelayerSetup(t)
+
+	claimReq := createTestClaimRequest()
+	req := &message.RelayRefundRequest{
+		RelaySwap: claimReq.RelaySwap,
+		Secret:    claimReq.Secret,
+		Signature: claimReq.Signature,
+	}
+
+	// success path ha->hb, hb is a DHT relayer
+	resp, err := ha.SubmitRelayRefundRequest(hb.PeerID(), req)
+	require.NoError(t, err)
+	require.Equal(t, mockEthTXHash, resp.TxHash)
+
+	// failure path hb->ha, only DHT relayers relay refunds
+	_, err = hb.SubmitRelayRefundRequest(ha.PeerID(), req)
+	require.ErrorContains(t, err, "failed to read RelayClaimResponse")
+}
+
 func TestHost_SubmitClaimToRelayer_xmrTakerRelayer(t *testing.T) {
 	ha, hb := twoHostRelayerSetup(t)
 
@@ -22,6 +22,7 @@ type (
 	SendKeysMessage    = message.SendKeysMessage
 	RelayClaimRequest  = message.RelayClaimRequest
 	RelayClaimResponse = message.RelayClaimResponse
+	RelayRefundRequest = message.RelayRefundRequest
 )
 
 // MakerHandler handles swap initiation messages and offer queries. It is
@@ -31,11 +32,12 @@ type MakerHandler interface {
 	HandleInitiateMessage(peerID peer.ID, msg *SendKeysMessage) (SwapState, error)
 }
 
-// RelayHandler handles relay claim requests. It is implemented by
+// RelayHandler handles relay claim and refund requests. It is implemented by
 // *backend.backend.
 type RelayHandler interface {
-	GetRelayerAddressHash() (types.Hash, error)
+	GetRelayerQuote(remotePeer peer.ID) (*message.RelayerQueryResponse, error)
 	HandleRelayClaimRequest(remotePeer peer.ID, msg *RelayClaimRequest) (*RelayClaimResponse, error)
+	HandleRelayRefundRequest(remotePeer peer.ID, msg *RelayRefundRequest) (*RelayClaimResponse, error)
 	HasOngoingSwapAsTaker(remotePeer peer.ID) error
 }
 
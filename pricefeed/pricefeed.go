@@ -8,6 +8,7 @@ package pricefeed
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/cockroachdb/apd/v3"
@@ -20,22 +21,17 @@ import (
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 )
 
-const (
-	// mainnetEndpoint is a mainnet ethereum endpoint, from
-	// https://chainlist.org/chain/1, which stagenet users get pointed at for
-	// price feeds, as Sepolia doesn't have an XMR feed. Mainnet users will use
-	// the same ethereum endpoint that they use for other swap transactions.
-	mainnetEndpoint = "https://eth-rpc.gateway.pokt.network"
-
-	// https://data.chain.link/ethereum/mainnet/crypto-usd/eth-usd
-	chainlinkETHToUSDProxy = "0x5f4ec3df9cbd43714fe2740f5e3616155c5b8419"
-
-	// https://data.chain.link/ethereum/mainnet/crypto-usd/xmr-usd
-	chainlinkXMRToUSDProxy = "0xfa66458cce7dd15d8650015c4fce4d278271618f"
-)
+// mainnetEndpoint is a mainnet ethereum endpoint, from
+// https://chainlist.org/chain/1, which users of chains without their own price
+// feeds (like Sepolia, which doesn't have an XMR feed) get pointed at. Users of
+// chains with price feeds use the same ethereum endpoint that they use for
+// other swap transactions.
+const mainnetEndpoint = "https://eth-rpc.gateway.pokt.network"
 
 var (
 	errUnsupportedNetwork = errors.New("unsupported network")
+	errNoPriceFeed        = errors.New("no price feeds")
+	errNoTokenPriceFeed   = errors.New("no USD price feed for token")
 	log                   = logging.Logger("pricefeed")
 )
 
@@ -47,70 +43,117 @@ type PriceFeed struct {
 }
 
 // GetETHUSDPrice returns the current ETH/USD price from the Chainlink oracle.
-// It errors if the chain ID is not the Ethereum mainnet.
+// On chains whose native coin is not ETH, it is the price of the native coin.
+// It errors if the chain ID is not a registered chain.
 func GetETHUSDPrice(ctx context.Context, ec *ethclient.Client) (*PriceFeed, error) {
+	return getPriceFeed(ctx, ec, func(chain *common.EthChain) ethcommon.Address {
+		return chain.ETHUSDPriceFeed
+	}, &PriceFeed{
+		Description: "ETH / USD (fake)",
+		Price:       apd.New(123412345678, -8), // 1234.12345678
+		UpdatedAt:   time.Now(),
+	})
+}
+
+// GetXMRUSDPrice returns the current XMR/USD price from the Chainlink oracle.
+// It errors if the chain ID is not a registered chain.
+func GetXMRUSDPrice(ctx context.Context, ec *ethclient.Client) (*PriceFeed, error) {
+	return getPriceFeed(ctx, ec, func(chain *common.EthChain) ethcommon.Address {
+		return chain.XMRUSDPriceFeed
+	}, &PriceFeed{
+		Description: "XMR / USD (fake)",
+		Price:       apd.New(12312345678, -8), // 123.12345678
+		UpdatedAt:   time.Now(),
+	})
+}
+
+// GetTokenUSDPrice returns the current USD price of the token from the
+// Chainlink oracle configured for the token on the chain of the client.
+// Development chains return a fake price of $1, the price of the test tokens.
+func GetTokenUSDPrice(ctx context.Context, ec *ethclient.Client, token ethcommon.Address) (*PriceFeed, error) {
 	chainID, err := ec.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	switch chainID.Uint64() {
-	case common.MainnetChainID:
-		// No extra work to do
-	case common.SepoliaChainID:
-		// Push stagenet/sepolia users to a mainnet endpoint
-		ec, err = ethclient.Dial(mainnetEndpoint)
-		if err != nil {
-			return nil, err
-		}
-		defer ec.Close()
-	case common.GanacheChainID, common.HardhatChainID:
+	if !chainID.IsUint64() {
+		return nil, errUnsupportedNetwork
+	}
+
+	chain, err := common.EthChainByID(chainID.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedNetwork, err)
+	}
+
+	if chain.Env == common.Development {
 		return &PriceFeed{
-			Description: "ETH / USD (fake)",
-			Price:       apd.New(123412345678, -8), // 1234.12345678
+			Description: "TOKEN / USD (fake)",
+			Price:       apd.New(1, 0),
 			UpdatedAt:   time.Now(),
 		}, nil
-	default:
-		return nil, errUnsupportedNetwork
 	}
 
-	return getChainlinkPriceFeed(ctx, chainlinkETHToUSDProxy, ec)
+	feedAddr, ok := chain.TokenUSDPriceFeeds[token]
+	if !ok {
+		return nil, fmt.Errorf("%w %s on chain %s", errNoTokenPriceFeed, token, chain)
+	}
+
+	return getChainlinkPriceFeed(ctx, feedAddr, ec)
 }
 
-// GetXMRUSDPrice returns the current XMR/USD price from the Chainlink oracle.
-// It errors if the chain ID is not the Ethereum mainnet.
-func GetXMRUSDPrice(ctx context.Context, ec *ethclient.Client) (*PriceFeed, error) {
+// getPriceFeed returns the price feed selected by feedAddr from the chain of
+// the client. Development chains return the fake feed, and chains without
+// their own price feeds use the Ethereum mainnet feed if their native coin is
+// ETH.
+func getPriceFeed(
+	ctx context.Context,
+	ec *ethclient.Client,
+	feedAddr func(chain *common.EthChain) ethcommon.Address,
+	fakeFeed *PriceFeed,
+) (*PriceFeed, error) {
 	chainID, err := ec.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	switch chainID.Uint64() {
-	case common.MainnetChainID:
-		// No extra work to do
-	case common.SepoliaChainID:
-		// Push stagenet/sepolia users to a mainnet endpoint
+	if !chainID.IsUint64() {
+		return nil, errUnsupportedNetwork
+	}
+
+	chain, err := common.EthChainByID(chainID.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedNetwork, err)
+	}
+
+	if chain.Env == common.Development {
+		return fakeFeed, nil
+	}
+
+	if !chain.HasPriceFeeds() {
+		// the mainnet ETH price is not the price of other native coins
+		if !chain.NativeCoinETH {
+			return nil, fmt.Errorf("%w on chain %s", errNoPriceFeed, chain)
+		}
+
+		// Push users of chains without price feeds to a mainnet endpoint
+		chain, err = common.EthChainByID(common.MainnetChainID)
+		if err != nil {
+			return nil, err
+		}
+
 		ec, err = ethclient.Dial(mainnetEndpoint)
 		if err != nil {
 			return nil, err
 		}
 		defer ec.Close()
-	case common.GanacheChainID, common.HardhatChainID:
-		return &PriceFeed{
-			Description: "XMR / USD (fake)",
-			Price:       apd.New(12312345678, -8), // 123.12345678
-			UpdatedAt:   time.Now(),
-		}, nil
-	default:
-		return nil, errUnsupportedNetwork
 	}
 
-	return getChainlinkPriceFeed(ctx, chainlinkXMRToUSDProxy, ec)
+	return getChainlinkPriceFeed(ctx, feedAddr(chain), ec)
 }
 
 // getChainlinkPriceFeed retries the latest price feed data from the given contract address.
-func getChainlinkPriceFeed(ctx context.Context, feedAddress string, ec *ethclient.Client) (*PriceFeed, error) {
-	chainlinkPriceFeedProxy, err := contracts.NewAggregatorV3Interface(ethcommon.HexToAddress(feedAddress), ec)
+func getChainlinkPriceFeed(ctx context.Context, feedAddress ethcommon.Address, ec *ethclient.Client) (*PriceFeed, error) {
+	chainlinkPriceFeedProxy, err := contracts.NewAggregatorV3Interface(feedAddress, ec)
 	if err != nil {
 		return nil, err
 	}
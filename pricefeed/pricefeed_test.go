@@ -5,12 +5,19 @@ package pricefeed
 
 import (
 	"context"
+	"math/big"
 	"testing"
+	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/athanorlabs/atomic-swap/common"
 	"github.com/athanorlabs/atomic-swap/tests"
 )
 
@@ -55,3 +62,56 @@ func TestGetXMRUSDPrice_dev(t *testing.T) {
 	assert.Equal(t, "XMR / USD (fake)", feed.Description)
 	assert.Equal(t, "123.12345678", feed.Price.String())
 }
+
+func TestGetTokenUSDPrice_mainnet(t *testing.T) {
+	ec := tests.NewEthMainnetClient(t)
+	usdc := ethcommon.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	feed, err := GetTokenUSDPrice(context.Background(), ec, usdc)
+	require.NoError(t, err)
+	t.Logf("%s is $%s (updated: %s)", feed.Description, feed.Price, feed.UpdatedAt)
+	assert.Equal(t, "USDC / USD", feed.Description)
+	assert.False(t, feed.Price.IsZero())
+
+	_, err = GetTokenUSDPrice(context.Background(), ec, ethcommon.Address{0x1})
+	require.ErrorIs(t, err, errNoTokenPriceFeed)
+}
+
+func TestGetTokenUSDPrice_dev(t *testing.T) {
+	ec, _ := tests.NewEthClient(t)
+	feed, err := GetTokenUSDPrice(context.Background(), ec, ethcommon.Address{0x1})
+	require.NoError(t, err)
+	assert.Equal(t, "TOKEN / USD (fake)", feed.Description)
+	assert.Equal(t, "1", feed.Price.String())
+}
+
+// chainIDService is the eth_chainId method of a JSON-RPC server
+type chainIDService struct {
+	chainID uint64
+}
+
+func (s *chainIDService) ChainId() *hexutil.Big { //nolint:revive,stylecheck
+	return (*hexutil.Big)(new(big.Int).SetUint64(s.chainID))
+}
+
+func TestGetETHUSDPrice_nativeCoinNotETH(t *testing.T) {
+	// a chain without price feeds whose native coin is not ETH
+	chain := &common.EthChain{
+		ChainID:   987654321,
+		Name:      "pricefeed-test-chain",
+		Env:       common.Mainnet,
+		BlockTime: time.Second,
+	}
+	require.NoError(t, common.RegisterEthChain(chain))
+
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("eth", &chainIDService{chainID: chain.ChainID}))
+	ec := ethclient.NewClient(rpc.DialInProc(server))
+	t.Cleanup(ec.Close)
+
+	_, err := GetETHUSDPrice(context.Background(), ec)
+	require.ErrorIs(t, err, errNoPriceFeed)
+	_, err = GetXMRUSDPrice(context.Background(), ec)
+	require.ErrorIs(t, err, errNoPriceFeed)
+}
@@ -7,7 +7,6 @@ package backend
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"sync"
@@ -15,11 +14,11 @@ import (
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 	"github.com/athanorlabs/atomic-swap/db"
@@ -37,9 +36,10 @@ type NetSender interface {
 	SendSwapMessage(common.Message, types.Hash) error
 	DeleteOngoingSwap(offerID types.Hash)
 	CloseProtocolStream(id types.Hash)
-	DiscoverRelayers() ([]peer.ID, error)                                                        // Only used by Maker
-	QueryRelayerAddress(peer.ID) (types.Hash, error)                                             // only used by taker
-	SubmitRelayRequest(peer.ID, *message.RelayClaimRequest) (*message.RelayClaimResponse, error) // only used by taker
+	DiscoverRelayers() ([]peer.ID, error)
+	QueryRelayer(peer.ID) (*message.RelayerQueryResponse, error)
+	SubmitRelayRequest(peer.ID, *message.RelayClaimRequest) (*message.RelayClaimResponse, error)        // only used by maker
+	SubmitRelayRefundRequest(peer.ID, *message.RelayRefundRequest) (*message.RelayClaimResponse, error) // only used by taker
 }
 
 // RecoveryDB is implemented by *db.RecoveryDB
@@ -52,6 +52,8 @@ type RecoveryDB interface {
 	GetCounterpartySwapPrivateKey(id types.Hash) (*mcrypto.PrivateSpendKey, error)
 	PutSwapRelayerInfo(id types.Hash, info *types.OfferExtra) error
 	GetSwapRelayerInfo(id types.Hash) (*types.OfferExtra, error)
+	PutSwapXMRPriority(id types.Hash, priority types.MoneroTxPriority) error
+	GetSwapXMRPriority(id types.Hash) (types.MoneroTxPriority, error)
 	PutCounterpartySwapKeys(id types.Hash, sk *mcrypto.PublicKey, vk *mcrypto.PrivateViewKey) error
 	GetCounterpartySwapKeys(id types.Hash) (*mcrypto.PublicKey, *mcrypto.PrivateViewKey, error)
 	PutNewSwapTxHash(id types.Hash, txHash types.Hash) error
@@ -71,25 +73,47 @@ type Backend interface {
 	// NewTxSender creates a new transaction sender, called per-swap
 	NewTxSender(asset ethcommon.Address, erc20Contract *contracts.IERC20) (txsender.Sender, error)
 
+	// ForChain returns the backend of the EVM chain with the given chain ID,
+	// which shares everything except the ethereum client and swap contract.
+	// Zero is the chain ID of the primary chain.
+	ForChain(chainID uint64) (Backend, error)
+	ETHClientForChain(chainID uint64) (extethclient.EthClient, error)
+	SwapCreatorAddrForChain(chainID uint64) (ethcommon.Address, error)
+
 	// helpers
+	WaitForTimestamp(ctx context.Context, ts time.Time) error
 	NewSwapCreator(addr ethcommon.Address) (*contracts.SwapCreator, error)
 	HandleRelayClaimRequest(remotePeer peer.ID, request *message.RelayClaimRequest) (*message.RelayClaimResponse, error)
-	GetRelayerAddressHash() (types.Hash, error)
+	HandleRelayRefundRequest(
+		remotePeer peer.ID,
+		request *message.RelayRefundRequest,
+	) (*message.RelayClaimResponse, error)
+	GetRelayerQuote(remotePeer peer.ID) (*message.RelayerQueryResponse, error)
+	Relayer() *relayer.Relayer
 	HasOngoingSwapAsTaker(peer.ID) error
 	SubmitClaimToRelayer(
 		peer.ID,
 		*types.Hash,
 		*contracts.SwapCreatorRelaySwap,
 		[32]byte,
+	) (*message.RelayClaimResponse, error) // Only used by Maker
+	SubmitRefundToRelayer(
+		peer.ID,
+		*contracts.SwapCreatorRelaySwap,
+		[32]byte,
 	) (*message.RelayClaimResponse, error) // Only used by Taker
 
 	// getters
 	Ctx() context.Context
 	Env() common.Environment
+	Clock() clock.Clock
 	SwapManager() swap.Manager
 	SwapCreator() *contracts.SwapCreator
 	SwapCreatorAddr() ethcommon.Address
+	PayoutAddress() ethcommon.Address
+	PayoutFallback() bool
 	SwapTimeout() time.Duration
+	TokenPolicy() *extethclient.TokenPolicy
 	XMRDepositAddress(offerID *types.Hash) *mcrypto.Address
 
 	// setters
@@ -98,21 +122,33 @@ type Backend interface {
 	ClearXMRDepositAddress(types.Hash)
 
 	// transfer helpers
-	TransferXMR(to *mcrypto.Address, amount *coins.PiconeroAmount) (string, error)
-	SweepXMR(to *mcrypto.Address) ([]string, error)
+	TransferXMR(
+		to *mcrypto.Address,
+		amount *coins.PiconeroAmount,
+		priority types.MoneroTxPriority,
+	) (string, error)
+	SweepXMR(to *mcrypto.Address, priority types.MoneroTxPriority) ([]string, error)
 	TransferETH(to ethcommon.Address, amount *coins.WeiAmount, gasLimit *uint64) (*ethtypes.Receipt, error)
 	SweepETH(to ethcommon.Address) (*ethtypes.Receipt, error)
 }
 
+// backend is the Backend of one EVM chain. The backends of all chains share
+// the Monero wallet, swap manager, recovery database and network.
 type backend struct {
+	*sharedBackend
+	*chainBackend
+}
+
+// sharedBackend is the state of the backend that is independent of the chain
+type sharedBackend struct {
 	ctx         context.Context
 	env         common.Environment
+	clock       clock.Clock
 	swapManager swap.Manager
 	recoveryDB  RecoveryDB
 
 	// wallet/node endpoints
 	moneroWallet monero.WalletClient
-	ethClient    extethclient.EthClient
 
 	// Monero deposit address. When the XMR maker has noTransferBack set to
 	// false (default), claimed funds are swept into the primary XMR wallet
@@ -122,58 +158,166 @@ type backend struct {
 	perSwapXMRDepositAddrRWMu sync.RWMutex
 	perSwapXMRDepositAddr     map[types.Hash]*mcrypto.Address
 
-	// swap contract
-	swapCreator     *contracts.SwapCreator
-	swapCreatorAddr ethcommon.Address
-	swapTimeout     time.Duration
+	swapTimeout time.Duration
+	tokenPolicy *extethclient.TokenPolicy
+
+	// address that the value of our claims and refunds is transferred to,
+	// zero for the address of our ethereum key
+	payoutAddr ethcommon.Address
+	// whether claims and refunds pay our address when the swap contract can't
+	// pay payoutAddr, instead of failing
+	payoutFallback bool
 
 	// network interface
 	NetSender
 
-	// map of hash(relayer address || salt) -> salt
-	relayerHashMu sync.RWMutex
-	relayerHash   map[types.Hash][4]byte
+	// relays the claims of other nodes
+	relayer *relayer.Relayer
+
+	// per chain ethereum clients and swap contracts, keyed by chain ID
+	chains         map[uint64]*chainBackend
+	primaryChainID uint64
+}
+
+// chainBackend is the ethereum client and swap contract of a chain
+type chainBackend struct {
+	ethClient extethclient.EthClient
+
+	// swap contract
+	swapCreator     *contracts.SwapCreator
+	swapCreatorAddr ethcommon.Address
 }
 
-// Config is the config for the Backend
+// ChainConfig is the config of an additional EVM chain of the Backend
+type ChainConfig struct {
+	EthereumClient  extethclient.EthClient
+	SwapCreatorAddr ethcommon.Address
+}
+
+// Config is the config for the Backend. The EthereumClient and SwapCreatorAddr
+// are of the primary chain.
 type Config struct {
 	Ctx             context.Context
 	MoneroClient    monero.WalletClient
 	EthereumClient  extethclient.EthClient
 	Environment     common.Environment
+	Clock           clock.Clock // nil uses the local clock
 	SwapCreatorAddr ethcommon.Address
+	ExtraChains     []*ChainConfig
+	TokenPolicy     *extethclient.TokenPolicy // nil allows all tokens
+	RelayerLimits   *relayer.Limits           // nil is unlimited
+	PayoutAddress   ethcommon.Address         // zero pays to the EthereumClient's address
+	PayoutFallback  bool                      // pay the EthereumClient's address if PayoutAddress fails
 	SwapManager     swap.Manager
 	RecoveryDB      RecoveryDB
 	Net             NetSender
 }
 
-// NewBackend returns a new Backend
+// NewBackend returns a new Backend of the primary chain
 func NewBackend(cfg *Config) (Backend, error) {
-	if (cfg.SwapCreatorAddr == ethcommon.Address{}) {
-		return nil, errNilSwapContractOrAddress
-	}
-
-	swapCreator, err := contracts.NewSwapCreator(cfg.SwapCreatorAddr, cfg.EthereumClient.Raw())
+	primary, err := newChainBackend(cfg.EthereumClient, cfg.SwapCreatorAddr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &backend{
+	clk := cfg.Clock
+	if clk == nil {
+		clk = clock.Local()
+	}
+
+	shared := &sharedBackend{
 		ctx:                   cfg.Ctx,
 		env:                   cfg.Environment,
+		clock:                 clk,
 		moneroWallet:          cfg.MoneroClient,
-		ethClient:             cfg.EthereumClient,
-		swapCreator:           swapCreator,
-		swapCreatorAddr:       cfg.SwapCreatorAddr,
 		swapManager:           cfg.SwapManager,
 		swapTimeout:           common.SwapTimeoutFromEnv(cfg.Environment),
+		tokenPolicy:           cfg.TokenPolicy,
+		payoutAddr:            cfg.PayoutAddress,
+		payoutFallback:        cfg.PayoutFallback,
 		NetSender:             cfg.Net,
 		perSwapXMRDepositAddr: make(map[types.Hash]*mcrypto.Address),
 		recoveryDB:            cfg.RecoveryDB,
-		relayerHash:           make(map[types.Hash][4]byte),
+		relayer: relayer.NewRelayer(&relayer.Config{
+			Ctx:             cfg.Ctx,
+			EthClient:       cfg.EthereumClient,
+			SwapCreatorAddr: cfg.SwapCreatorAddr,
+			Limits:          cfg.RelayerLimits,
+		}),
+		chains:         make(map[uint64]*chainBackend),
+		primaryChainID: cfg.EthereumClient.ChainID().Uint64(),
+	}
+	shared.chains[shared.primaryChainID] = primary
+
+	for _, chainCfg := range cfg.ExtraChains {
+		chainID := chainCfg.EthereumClient.ChainID().Uint64()
+		if _, ok := shared.chains[chainID]; ok {
+			return nil, fmt.Errorf("chain ID %d is configured more than once", chainID)
+		}
+
+		shared.chains[chainID], err = newChainBackend(chainCfg.EthereumClient, chainCfg.SwapCreatorAddr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &backend{
+		sharedBackend: shared,
+		chainBackend:  primary,
 	}, nil
 }
 
+func newChainBackend(ec extethclient.EthClient, swapCreatorAddr ethcommon.Address) (*chainBackend, error) {
+	if (swapCreatorAddr == ethcommon.Address{}) {
+		return nil, errNilSwapContractOrAddress
+	}
+
+	swapCreator, err := contracts.NewSwapCreator(swapCreatorAddr, ec.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainBackend{
+		ethClient:       ec,
+		swapCreator:     swapCreator,
+		swapCreatorAddr: swapCreatorAddr,
+	}, nil
+}
+
+func (b *backend) ForChain(chainID uint64) (Backend, error) {
+	if chainID == 0 {
+		chainID = b.primaryChainID
+	}
+
+	chain, ok := b.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errChainNotConfigured, chainID)
+	}
+
+	return &backend{
+		sharedBackend: b.sharedBackend,
+		chainBackend:  chain,
+	}, nil
+}
+
+func (b *backend) ETHClientForChain(chainID uint64) (extethclient.EthClient, error) {
+	chainBackend, err := b.ForChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainBackend.ETHClient(), nil
+}
+
+func (b *backend) SwapCreatorAddrForChain(chainID uint64) (ethcommon.Address, error) {
+	chainBackend, err := b.ForChain(chainID)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+
+	return chainBackend.SwapCreatorAddr(), nil
+}
+
 func (b *backend) XMRClient() monero.WalletClient {
 	return b.moneroWallet
 }
@@ -187,7 +331,9 @@ func (b *backend) NewTxSender(asset ethcommon.Address, erc20Contract *contracts.
 		return txsender.NewExternalSender(b.ctx, b.env, b.ethClient.Raw(), b.swapCreatorAddr, asset)
 	}
 
-	return txsender.NewSenderWithPrivateKey(b.ctx, b.ETHClient(), b.swapCreatorAddr, b.swapCreator, erc20Contract), nil
+	sender := txsender.NewSenderWithPrivateKey(b.ctx, b.ETHClient(), b.swapCreatorAddr, b.swapCreator, erc20Contract)
+	sender.SetPayoutAddress(b.payoutAddr, b.payoutFallback)
+	return sender, nil
 }
 
 func (b *backend) RecoveryDB() RecoveryDB {
@@ -202,6 +348,14 @@ func (b *backend) SwapCreatorAddr() ethcommon.Address {
 	return b.swapCreatorAddr
 }
 
+func (b *backend) PayoutAddress() ethcommon.Address {
+	return b.payoutAddr
+}
+
+func (b *backend) PayoutFallback() bool {
+	return b.payoutFallback
+}
+
 func (b *backend) Ctx() context.Context {
 	return b.ctx
 }
@@ -210,6 +364,21 @@ func (b *backend) Env() common.Environment {
 	return b.env
 }
 
+func (b *backend) Clock() clock.Clock {
+	return b.clock
+}
+
+// WaitForTimestamp waits until the backend's clock reaches ts, and then until
+// the chain has a block with a timestamp of at least ts, so transactions that
+// the contract only allows after ts no longer revert.
+func (b *backend) WaitForTimestamp(ctx context.Context, ts time.Time) error {
+	if err := clock.WaitUntil(ctx, b.clock, ts); err != nil {
+		return err
+	}
+
+	return b.ethClient.WaitForTimestamp(ctx, ts)
+}
+
 func (b *backend) SwapManager() swap.Manager {
 	return b.swapManager
 }
@@ -218,6 +387,12 @@ func (b *backend) SwapTimeout() time.Duration {
 	return b.swapTimeout
 }
 
+// TokenPolicy returns the allow and deny list of ERC20 tokens that can be
+// swapped, which is nil if all tokens are allowed.
+func (b *backend) TokenPolicy() *extethclient.TokenPolicy {
+	return b.tokenPolicy
+}
+
 // SetSwapTimeout sets the duration between the swap being initiated on-chain and the timeout t1,
 // and the duration between t1 and t2.
 func (b *backend) SetSwapTimeout(timeout time.Duration) {
@@ -284,13 +459,14 @@ func (b *backend) HasOngoingSwapAsTaker(remotePeer peer.ID) error {
 	return fmt.Errorf("do not have an ongoing swap with peer %s as taker", remotePeer)
 }
 
-// HandleRelayClaimRequest validates and sends the transaction for a relay claim request
+// HandleRelayClaimRequest validates and sends the transaction for a relay
+// claim request. Claims of our swap counterparty, who set the offer ID, are not
+// subject to the relay policy, as we relay them as a last resort for our own
+// swap.
 func (b *backend) HandleRelayClaimRequest(
 	remotePeer peer.ID,
 	request *message.RelayClaimRequest,
 ) (*message.RelayClaimResponse, error) {
-	defer b.clearRelayerAddressHash(request.RelaySwap.RelayerHash)
-
 	if request.OfferID != nil {
 		has := b.swapManager.HasOngoingSwap(*request.OfferID)
 		if !has {
@@ -328,55 +504,40 @@ func (b *backend) HandleRelayClaimRequest(
 		}
 	}
 
-	b.relayerHashMu.RLock()
-	salt := b.relayerHash[request.RelaySwap.RelayerHash]
-	b.relayerHashMu.RUnlock()
-
-	return relayer.ValidateAndSendTransaction(
-		b.Ctx(),
-		request,
-		b.ETHClient(),
-		b.SwapCreatorAddr(),
-		salt,
-	)
+	return b.relayer.RelayClaim(remotePeer.String(), request, request.OfferID == nil)
 }
 
-func (b *backend) GetRelayerAddressHash() (types.Hash, error) {
-	address := b.ETHClient().Address()
-	var salt [4]byte
-	_, err := rand.Read(salt[:])
-	if err != nil {
-		return types.Hash{}, err
-	}
+// HandleRelayRefundRequest validates and sends the transaction for a relay
+// refund request. Refund requests are only from peers that found us in the
+// DHT, so they are always subject to the relay policy.
+func (b *backend) HandleRelayRefundRequest(
+	remotePeer peer.ID,
+	request *message.RelayRefundRequest,
+) (*message.RelayClaimResponse, error) {
+	return b.relayer.RelayRefund(remotePeer.String(), request, true)
+}
 
-	hash := crypto.Keccak256Hash(append(address.Bytes(), salt[:]...))
-	b.relayerHashMu.Lock()
-	defer b.relayerHashMu.Unlock()
-	b.relayerHash[hash] = salt
-	return hash, nil
+// GetRelayerQuote returns a new hash of our relayer payout address and our
+// current fees for relaying claims using the hash. The quote is honoured until
+// it expires.
+func (b *backend) GetRelayerQuote(remotePeer peer.ID) (*message.RelayerQueryResponse, error) {
+	return b.relayer.Quote(remotePeer.String())
 }
 
-func (b *backend) clearRelayerAddressHash(hash types.Hash) {
-	b.relayerHashMu.Lock()
-	defer b.relayerHashMu.Unlock()
-	delete(b.relayerHash, hash)
+// Relayer returns the relayer of claims of other nodes
+func (b *backend) Relayer() *relayer.Relayer {
+	return b.relayer
 }
 
+// SubmitClaimToRelayer signs the relayed claim and submits it to the relayer.
+// The relayer hash and fee of the claim must be set from the relayer's quote.
 func (b *backend) SubmitClaimToRelayer(
 	relayerID peer.ID,
 	offerID *types.Hash,
 	relaySwap *contracts.SwapCreatorRelaySwap,
 	secret [32]byte,
 ) (*message.RelayClaimResponse, error) {
-	// get the relayer's address hash
-	relayerAddrHash, err := b.QueryRelayerAddress(relayerID)
-	if err != nil {
-		return nil, err
-	}
-
-	// set relayer address hash and sign as front-run prevention
-	relaySwap.RelayerHash = relayerAddrHash
-
+	// the relayer hash is signed as front-run prevention
 	req, err := relayer.CreateRelayClaimRequest(b.ETHClient().PrivateKey(), relaySwap, secret)
 	if err != nil {
 		return nil, err
@@ -389,8 +550,28 @@ func (b *backend) SubmitClaimToRelayer(
 	return b.SubmitRelayRequest(relayerID, req)
 }
 
-func (b *backend) TransferXMR(to *mcrypto.Address, amount *coins.PiconeroAmount) (string, error) {
-	res, err := b.moneroWallet.Transfer(b.ctx, to, 0, amount, 1)
+// SubmitRefundToRelayer signs the relayed refund and submits it to the
+// relayer. The relayer hash and fee of the refund must be set from the
+// relayer's quote.
+func (b *backend) SubmitRefundToRelayer(
+	relayerID peer.ID,
+	relaySwap *contracts.SwapCreatorRelaySwap,
+	secret [32]byte,
+) (*message.RelayClaimResponse, error) {
+	req, err := relayer.CreateRelayRefundRequest(b.ETHClient().PrivateKey(), relaySwap, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.SubmitRelayRefundRequest(relayerID, req)
+}
+
+func (b *backend) TransferXMR(
+	to *mcrypto.Address,
+	amount *coins.PiconeroAmount,
+	priority types.MoneroTxPriority,
+) (string, error) {
+	res, err := b.moneroWallet.Transfer(b.ctx, to, 0, amount, 1, priority)
 	if err != nil {
 		return "", err
 	}
@@ -399,8 +580,8 @@ func (b *backend) TransferXMR(to *mcrypto.Address, amount *coins.PiconeroAmount)
 
 }
 
-func (b *backend) SweepXMR(to *mcrypto.Address) ([]string, error) {
-	res, err := b.moneroWallet.SweepAll(b.ctx, to, 0, 1)
+func (b *backend) SweepXMR(to *mcrypto.Address, priority types.MoneroTxPriority) ([]string, error) {
+	res, err := b.moneroWallet.SweepAll(b.ctx, to, 0, 1, priority)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
 	"github.com/athanorlabs/atomic-swap/tests"
@@ -43,10 +44,38 @@ func TestWaitForReceipt(t *testing.T) {
 	require.NoError(t, err)
 
 	b := &backend{
-		ethClient: ec,
+		chainBackend: &chainBackend{ethClient: ec},
 	}
 
 	receipt, err := b.ETHClient().WaitForReceipt(ctx, tx.Hash())
 	require.NoError(t, err)
 	require.Equal(t, tx.Hash(), receipt.TxHash)
 }
+
+func TestBackend_ForChain(t *testing.T) {
+	primary := &chainBackend{swapCreatorAddr: ethcommon.Address{0x1}}
+	l2 := &chainBackend{swapCreatorAddr: ethcommon.Address{0x2}}
+	shared := &sharedBackend{
+		chains: map[uint64]*chainBackend{
+			1:     primary,
+			42161: l2,
+		},
+		primaryChainID: 1,
+	}
+	b := &backend{sharedBackend: shared, chainBackend: primary}
+
+	l2Backend, err := b.ForChain(42161)
+	require.NoError(t, err)
+	require.Equal(t, l2.swapCreatorAddr, l2Backend.SwapCreatorAddr())
+
+	// the chain backends share the swap timeout
+	l2Backend.SetSwapTimeout(time.Minute)
+	require.Equal(t, time.Minute, b.SwapTimeout())
+
+	primaryBackend, err := l2Backend.ForChain(0)
+	require.NoError(t, err)
+	require.Equal(t, primary.swapCreatorAddr, primaryBackend.SwapCreatorAddr())
+
+	_, err = b.ForChain(10)
+	require.ErrorIs(t, err, errChainNotConfigured)
+}
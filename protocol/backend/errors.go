@@ -9,4 +9,5 @@ import (
 
 var (
 	errNilSwapContractOrAddress = errors.New("must provide swap contract and address")
+	errChainNotConfigured       = errors.New("no ethereum client is configured for chain ID")
 )
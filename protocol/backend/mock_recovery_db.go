@@ -143,6 +143,21 @@ func (mr *MockRecoveryDBMockRecorder) GetSwapRelayerInfo(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapRelayerInfo", reflect.TypeOf((*MockRecoveryDB)(nil).GetSwapRelayerInfo), arg0)
 }
 
+// GetSwapXMRPriority mocks base method.
+func (m *MockRecoveryDB) GetSwapXMRPriority(arg0 common.Hash) (types.MoneroTxPriority, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwapXMRPriority", arg0)
+	ret0, _ := ret[0].(types.MoneroTxPriority)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwapXMRPriority indicates an expected call of GetSwapXMRPriority.
+func (mr *MockRecoveryDBMockRecorder) GetSwapXMRPriority(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapXMRPriority", reflect.TypeOf((*MockRecoveryDB)(nil).GetSwapXMRPriority), arg0)
+}
+
 // PutContractSwapInfo mocks base method.
 func (m *MockRecoveryDB) PutContractSwapInfo(arg0 common.Hash, arg1 *db.EthereumSwapInfo) error {
 	m.ctrl.T.Helper()
@@ -226,3 +241,17 @@ func (mr *MockRecoveryDBMockRecorder) PutSwapRelayerInfo(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSwapRelayerInfo", reflect.TypeOf((*MockRecoveryDB)(nil).PutSwapRelayerInfo), arg0, arg1)
 }
+
+// PutSwapXMRPriority mocks base method.
+func (m *MockRecoveryDB) PutSwapXMRPriority(arg0 common.Hash, arg1 types.MoneroTxPriority) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSwapXMRPriority", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutSwapXMRPriority indicates an expected call of PutSwapXMRPriority.
+func (mr *MockRecoveryDBMockRecorder) PutSwapXMRPriority(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSwapXMRPriority", reflect.TypeOf((*MockRecoveryDB)(nil).PutSwapXMRPriority), arg0, arg1)
+}
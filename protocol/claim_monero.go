@@ -79,25 +79,86 @@ func ClaimMonero(
 		return err
 	}
 
+	err = checkSwapWalletBalance(ctx, info, xmrClient, abWalletCli, address, kpAB.ViewKey())
+	if err != nil {
+		return err
+	}
+
 	err = setSweepStatus(info, sm)
 	if err != nil {
 		return err
 	}
 	log.Debugf("set swap's status to SweepingXMR; swap ID %s", info.OfferID)
 
-	transfers, err := abWalletCli.SweepAll(ctx, depositAddr, 0, monero.SweepToSelfConfirmations)
+	transfers, err := abWalletCli.SweepAll(
+		ctx,
+		depositAddr,
+		0,
+		monero.SweepToSelfConfirmations,
+		types.PriorityDefault, // uses the priority inherited from the primary wallet's config
+	)
 	if err != nil {
 		return fmt.Errorf("failed to send funds to deposit account: %w", err)
 	}
 
 	log.Debugf("got %d sweep receipts", len(transfers))
 	for _, transfer := range transfers {
+		info.AddMoneroFee(transfer.Fee)
 		log.Infof("transferred %s XMR to primary wallet (%s XMR lost to fees)",
 			coins.FmtPiconeroAsXMR(transfer.Amount),
 			coins.FmtPiconeroAsXMR(transfer.Fee),
 		)
 	}
 
+	return sm.WriteSwapToDB(info)
+}
+
+// checkSwapWalletBalance scans the swap address with its view key and checks
+// that the swap wallet's monero-wallet-rpc sees all of the XMR that was sent to
+// the address, so a sweep does not leave any of it behind.
+func checkSwapWalletBalance(
+	ctx context.Context,
+	info *swap.Info,
+	xmrClient monero.WalletClient,
+	abWalletCli monero.WalletClient,
+	address *mcrypto.Address,
+	vk *mcrypto.PrivateViewKey,
+) error {
+	scanner, err := monero.NewOutputScanner(xmrClient, address, vk, info.MoneroStartHeight)
+	if err != nil {
+		return fmt.Errorf("failed to create output scanner for swap address: %w", err)
+	}
+
+	outputs, err := scanner.Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to scan swap address for outputs: %w", err)
+	}
+
+	var scanned uint64
+	for _, out := range outputs {
+		amount, err := out.Amount.Uint64()
+		if err != nil {
+			return fmt.Errorf("invalid output amount: %w", err)
+		}
+		scanned += amount
+	}
+
+	if scanned == 0 {
+		log.Warnf("found no transaction outputs to swap address %s since height %d",
+			address, info.MoneroStartHeight)
+		return nil
+	}
+
+	balance, err := abWalletCli.GetBalance(0)
+	if err != nil {
+		return err
+	}
+
+	if balance.Balance < scanned {
+		return fmt.Errorf("swap wallet has a balance of %s XMR, but %s XMR was sent to %s",
+			coins.FmtPiconeroAsXMR(balance.Balance), coins.FmtPiconeroAsXMR(scanned), address)
+	}
+
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
@@ -115,3 +116,35 @@ func TestClaimMonero_WithTransferBack(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestCheckSwapWalletBalance(t *testing.T) {
+	env := common.Development
+	ledger := monero.NewFakeLedger(env, time.Second)
+	primary := ledger.CreateWalletClient(t, coins.NewPiconeroAmount(0))
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	swapAddr := kp.PublicKeyPair().Address(env)
+	info := &swap.Info{MoneroStartHeight: ledger.Height() - monero.MinSpendConfirmations}
+	ctx := context.Background()
+
+	// nothing was sent to the swap address yet
+	swapWallet := ledger.NewWalletClient(primary.CreateWalletConf("swap-wallet"), swapAddr, true)
+	err = checkSwapWalletBalance(ctx, info, primary, swapWallet, swapAddr, kp.ViewKey())
+	require.NoError(t, err)
+
+	require.NoError(t, ledger.Fund(swapAddr, coins.MoneroToPiconero(coins.StrToDecimal("1.5"))))
+	err = checkSwapWalletBalance(ctx, info, primary, swapWallet, swapAddr, kp.ViewKey())
+	require.NoError(t, err)
+
+	// a swap wallet that does not see the scanned outputs is not swept
+	otherKP, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	otherWallet := ledger.NewWalletClient(
+		primary.CreateWalletConf("other-wallet"),
+		otherKP.PublicKeyPair().Address(env),
+		true,
+	)
+	err = checkSwapWalletBalance(ctx, info, primary, otherWallet, swapAddr, kp.ViewKey())
+	require.ErrorContains(t, err, "swap wallet has a balance of 0 XMR, but 1.5 XMR was sent to")
+}
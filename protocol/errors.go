@@ -11,6 +11,10 @@ var (
 	// ErrLogNotForUs is returned when a log is found that doesn't have the given contract swap ID.
 	ErrLogNotForUs = errors.New("found log that isn't for our swap")
 
+	// ErrRelayedPayout is returned when a claim or refund would be relayed
+	// while a payout address is set without the fallback to our own address.
+	ErrRelayedPayout = errors.New("relayed claims and refunds can not pay the payout address")
+
 	errLogMissingParams    = errors.New("log didn't have enough topics")
 	errInvalidEventTopic   = errors.New("log did not have correct event as first topic")
 	errInvalidSecp256k1Key = errors.New("secp256k1 public key resulting from proof verification does not match key sent")
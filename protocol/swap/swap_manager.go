@@ -8,8 +8,8 @@ package swap
 import (
 	"errors"
 	"sync"
-	"time"
 
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 
 	"github.com/ChainSafe/chaindb"
@@ -39,7 +39,8 @@ type Manager interface {
 // are only stored in memory if they've completed during
 // this swapd run, or if they've recently been retrieved.
 type manager struct {
-	db Database
+	db    Database
+	clock clock.Clock
 	sync.RWMutex
 	ongoing map[types.Hash]*Info
 	past    map[types.Hash]*Info
@@ -48,10 +49,11 @@ type manager struct {
 
 var _ Manager = (*manager)(nil)
 
-// NewManager returns a new Manager that uses the given database.
+// NewManager returns a new Manager that uses the given database and takes the
+// times of swap updates from the given clock.
 // It loads all ongoing swaps into memory on construction.
 // Completed swaps are not loaded into memory.
-func NewManager(db Database) (Manager, error) {
+func NewManager(db Database, clk clock.Clock) (Manager, error) {
 	ongoing := make(map[types.Hash]*Info)
 
 	stored, err := db.GetAllSwaps()
@@ -64,11 +66,13 @@ func NewManager(db Database) (Manager, error) {
 			continue
 		}
 
+		s.clock = clk
 		ongoing[s.OfferID] = s
 	}
 
 	return &manager{
 		db:            db,
+		clock:         clk,
 		ongoing:       ongoing,
 		past:          make(map[types.Hash]*Info),
 		statusManager: newStatusManager(),
@@ -80,6 +84,10 @@ func (m *manager) AddSwap(info *Info) error {
 	m.Lock()
 	defer m.Unlock()
 
+	if info.clock == nil {
+		info.clock = m.clock
+	}
+
 	switch info.Status.IsOngoing() {
 	case true:
 		m.ongoing[info.OfferID] = info
@@ -224,7 +232,7 @@ func (m *manager) CompleteOngoingSwap(info *Info) error {
 		return errNoSwapWithOfferID
 	}
 
-	now := time.Now()
+	now := m.clock.Now()
 	info.EndTime = &now
 
 	m.past[info.OfferID] = info
@@ -252,5 +260,6 @@ func (m *manager) getSwapFromDB(id types.Hash) (*Info, error) {
 		return nil, err
 	}
 
+	s.clock = m.clock
 	return s, nil
 }
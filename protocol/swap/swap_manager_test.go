@@ -5,10 +5,12 @@ package swap
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/apd/v3"
 
 	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 
 	"github.com/golang/mock/gomock"
@@ -23,7 +25,7 @@ func TestNewManager(t *testing.T) {
 
 	db.EXPECT().GetAllSwaps()
 
-	mgr, err := NewManager(db)
+	mgr, err := NewManager(db, clock.Local())
 	require.NoError(t, err)
 	m := mgr.(*manager)
 
@@ -38,6 +40,7 @@ func TestNewManager(t *testing.T) {
 		types.EthAssetETH,
 		types.ExpectingKeys,
 		100,
+		clock.Local(),
 	)
 	db.EXPECT().PutSwap(infoA)
 	err = m.AddSwap(infoA)
@@ -53,13 +56,14 @@ func TestNewManager(t *testing.T) {
 		types.EthAssetETH,
 		types.CompletedSuccess,
 		100,
+		clock.Local(),
 	)
 	db.EXPECT().PutSwap(infoB)
 	err = m.AddSwap(infoB)
 	require.NoError(t, err)
 
 	db.EXPECT().GetAllSwaps().Return([]*Info{infoA, infoB}, nil)
-	mgr, err = NewManager(db)
+	mgr, err = NewManager(db, clock.Local())
 	require.NoError(t, err)
 	m = mgr.(*manager)
 	require.Equal(t, 1, len(m.ongoing))
@@ -73,7 +77,7 @@ func TestManager_AddSwap_Ongoing(t *testing.T) {
 
 	db.EXPECT().GetAllSwaps()
 
-	mgr, err := NewManager(db)
+	mgr, err := NewManager(db, clock.Local())
 	m := mgr.(*manager)
 	require.NoError(t, err)
 	info := NewInfo(
@@ -86,6 +90,7 @@ func TestManager_AddSwap_Ongoing(t *testing.T) {
 		types.EthAssetETH,
 		types.ExpectingKeys,
 		100,
+		clock.Local(),
 	)
 
 	db.EXPECT().PutSwap(info)
@@ -121,7 +126,7 @@ func TestManager_AddSwap_Past(t *testing.T) {
 
 	db.EXPECT().GetAllSwaps()
 
-	m, err := NewManager(db)
+	m, err := NewManager(db, clock.Local())
 	require.NoError(t, err)
 
 	info := &Info{
@@ -164,3 +169,36 @@ func TestManager_AddSwap_Past(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, len(ids))
 }
+
+func TestManager_clock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	db := NewMockDatabase(ctrl)
+
+	db.EXPECT().GetAllSwaps()
+
+	clk := clock.NewMock(time.Unix(1700000000, 0))
+	m, err := NewManager(db, clk)
+	require.NoError(t, err)
+
+	// swaps that are added or read from the database get the manager's clock
+	added := &Info{
+		OfferID: types.Hash{1},
+		Status:  types.CompletedSuccess,
+	}
+	db.EXPECT().PutSwap(added)
+	require.NoError(t, m.AddSwap(added))
+	require.Equal(t, clk, added.clock)
+
+	stored := &Info{
+		OfferID: types.Hash{2},
+		Status:  types.CompletedSuccess,
+	}
+	db.EXPECT().GetSwap(stored.OfferID).Return(stored, nil)
+	s, err := m.GetPastSwap(stored.OfferID)
+	require.NoError(t, err)
+	require.Equal(t, clk, s.clock)
+
+	s.MarkSwapComplete()
+	require.Equal(t, clk.Now(), *s.EndTime)
+}
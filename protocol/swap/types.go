@@ -15,6 +15,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	"github.com/athanorlabs/atomic-swap/common/vjson"
 )
@@ -23,6 +24,11 @@ var (
 	// CurInfoVersion is the latest supported version of a serialised Info struct
 	CurInfoVersion, _ = semver.NewVersion("0.3.0")
 
+	// timeoutsRenamedVersion is the Info version that renamed the timeouts
+	// from timeout0 and timeout1 to timeout1 and timeout2, following the
+	// timeout names of the SwapCreator contract.
+	timeoutsRenamedVersion, _ = semver.NewVersion("0.3.0")
+
 	errInfoVersionMissing = errors.New("required 'version' field missing in swap Info")
 )
 
@@ -64,6 +70,17 @@ type Info struct {
 	// after this timeout, the ETH-taker can no longer claim, only
 	// the ETH-maker can refund.
 	Timeout2 *time.Time `json:"timeout2,omitempty"`
+	// MoneroFee is the sum of the Monero network fees that we paid for the
+	// transactions of the swap (the maker's lock transaction and any sweeps
+	// out of the shared swap wallet).
+	MoneroFee *coins.PiconeroAmount `json:"moneroFee,omitempty"`
+	// ChainID is the EVM chain of the swap. It is zero for swaps that were
+	// started before swapd supported multiple chains, which are on the primary
+	// chain.
+	ChainID uint64 `json:"chainID,omitempty"`
+	// LastTxError is the decoded contract error of the last swap transaction
+	// that we did not send, because it reverted when simulated.
+	LastTxError string `json:"lastTxError,omitempty"`
 
 	// rwMu handles synchronization when LastStatusUpdateTime, Timeout1,
 	// Timeout2 and EndTime are updated. This Info struct is modified by the
@@ -75,9 +92,16 @@ type Info struct {
 	// copy, which grabs the read lock ensuring that they always capture the
 	// up-to-date state of this Info struct.
 	rwMu sync.RWMutex
+
+	// clock is the source of the status update and end times. The swap
+	// manager sets its clock on the Infos that it adds or reads from the
+	// database, so only Infos that never passed through a manager use the
+	// local clock.
+	clock clock.Clock
 }
 
-// NewInfo creates a new *Info from the given parameters.
+// NewInfo creates a new *Info from the given parameters. The start and status
+// update times are taken from the passed clock.
 // Note that the swap ID is the same as the offer ID.
 func NewInfo(
 	peerID peer.ID,
@@ -88,7 +112,9 @@ func NewInfo(
 	ethAsset types.EthAsset,
 	status Status,
 	moneroStartHeight uint64,
+	clk clock.Clock,
 ) *Info {
+	now := clk.Now()
 	info := &Info{
 		Version:              CurInfoVersion,
 		PeerID:               peerID,
@@ -99,13 +125,14 @@ func NewInfo(
 		ExchangeRate:         exchangeRate,
 		EthAsset:             ethAsset,
 		Status:               status,
-		LastStatusUpdateTime: time.Now(),
+		LastStatusUpdateTime: now,
 		MoneroStartHeight:    moneroStartHeight,
-		StartTime:            time.Now(),
+		StartTime:            now,
 		EndTime:              nil,
 		Timeout1:             nil,
 		Timeout2:             nil,
 		rwMu:                 sync.RWMutex{},
+		clock:                clk,
 	}
 	return info
 }
@@ -116,7 +143,7 @@ func (i *Info) SetStatus(s Status) {
 	defer i.rwMu.Unlock()
 
 	i.Status = s
-	i.LastStatusUpdateTime = time.Now()
+	i.LastStatusUpdateTime = i.now()
 }
 
 // SetTimeouts sets the 2 timeout fields, , grabbing the needed lock before
@@ -129,16 +156,24 @@ func (i *Info) SetTimeouts(t1 *time.Time, t2 *time.Time) {
 	i.Timeout2 = t2
 }
 
-// MarkSwapComplete sets the EndTime field to the current wall time, grabbing the
+// MarkSwapComplete sets the EndTime field to the current time, grabbing the
 // needed lock before modifying fields.
 func (i *Info) MarkSwapComplete() {
 	i.rwMu.Lock()
 	defer i.rwMu.Unlock()
 
-	now := time.Now()
+	now := i.now()
 	i.EndTime = &now
 }
 
+// now returns the current time of the Info's clock
+func (i *Info) now() time.Time {
+	if i.clock == nil {
+		return time.Now()
+	}
+	return i.clock.Now()
+}
+
 // SetRelayerFee updates the RelayerFee field
 func (i *Info) SetRelayerFee(relayerFee *apd.Decimal) {
 	i.rwMu.Lock()
@@ -147,6 +182,30 @@ func (i *Info) SetRelayerFee(relayerFee *apd.Decimal) {
 	i.RelayerFee = relayerFee
 }
 
+// SetLastTxError updates the LastTxError field
+func (i *Info) SetLastTxError(msg string) {
+	i.rwMu.Lock()
+	defer i.rwMu.Unlock()
+
+	i.LastTxError = msg
+}
+
+// AddMoneroFee adds the fee of a Monero transaction sent for the swap to the
+// MoneroFee field
+func (i *Info) AddMoneroFee(fee uint64) {
+	i.rwMu.Lock()
+	defer i.rwMu.Unlock()
+
+	total := fee
+	if i.MoneroFee != nil {
+		prevTotal, err := i.MoneroFee.Uint64()
+		if err == nil {
+			total += prevTotal
+		}
+	}
+	i.MoneroFee = coins.NewPiconeroAmount(total)
+}
+
 // IsTaker returns true if the node is the xmr-taker in the swap.
 func (i *Info) IsTaker() bool {
 	return i.Provides == coins.ProvidesETH
@@ -179,10 +238,14 @@ func (i *Info) UnmarshalJSON(jsonData []byte) error {
 		return fmt.Errorf("info version %q not supported, latest is %q", iv.Version, CurInfoVersion)
 	}
 
-	// Assuming any version less than the current version is forwards
-	// compatible. If that is not the case in the future, add code here to
-	// upgrade the older version to the current version when deserializing.
-	// (Or error if it is completely incompatible.)
+	// Older versions are upgraded to the current layout, the fields of each
+	// version that are not changed here are forwards compatible.
+	if iv.Version.LessThan(timeoutsRenamedVersion) {
+		var err error
+		if jsonData, err = upgradeInfoTimeouts(jsonData); err != nil {
+			return err
+		}
+	}
 
 	// Unmarshal without recursion
 	type _Info Info
@@ -195,6 +258,28 @@ func (i *Info) UnmarshalJSON(jsonData []byte) error {
 	return nil
 }
 
+// upgradeInfoTimeouts renames the timeout0 and timeout1 fields of an Info
+// older than version 0.3.0 to timeout1 and timeout2.
+func upgradeInfoTimeouts(jsonData []byte) ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(jsonData, &fields); err != nil {
+		return nil, err
+	}
+
+	timeout0, hasTimeout0 := fields["timeout0"]
+	timeout1, hasTimeout1 := fields["timeout1"]
+	delete(fields, "timeout0")
+	delete(fields, "timeout1")
+	if hasTimeout0 {
+		fields["timeout1"] = timeout0
+	}
+	if hasTimeout1 {
+		fields["timeout2"] = timeout1
+	}
+
+	return json.Marshal(fields)
+}
+
 // DeepCopy returns a deep copy of the Info data structure
 func (i *Info) DeepCopy() (*Info, error) {
 	i.rwMu.RLock()
@@ -212,6 +297,7 @@ func (i *Info) DeepCopy() (*Info, error) {
 	if err = clone.UnmarshalJSON(jsonData); err != nil {
 		return nil, err
 	}
+	clone.clock = i.clock
 
 	return clone, nil
 }
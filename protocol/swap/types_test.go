@@ -6,6 +6,7 @@ package swap
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/apd/v3"
 	ethcommon "github.com/ethereum/go-ethereum/common"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	"github.com/athanorlabs/atomic-swap/common/vjson"
 )
@@ -32,6 +34,7 @@ func Test_InfoMarshal(t *testing.T) {
 		types.EthAssetETH,
 		types.CompletedSuccess,
 		200,
+		clock.Local(),
 	)
 	err := info.StartTime.UnmarshalJSON([]byte("\"2023-02-20T17:29:43.471020297-05:00\""))
 	require.NoError(t, err)
@@ -71,3 +74,90 @@ func TestUnmarshalInfo_versionTooNew(t *testing.T) {
 	_, err := UnmarshalInfo([]byte(offerJSON))
 	require.ErrorContains(t, err, fmt.Sprintf("info version %q not supported", unsupportedVersion))
 }
+
+func TestUnmarshalInfo_timeoutsBeforeV0_3_0(t *testing.T) {
+	infoJSON := `{
+		"version": "0.2.0",
+		"peerID": "12D3KooWQQRJuKTZ35eiHGNPGDpQqjpJSdaxEMJRxi6NWFrrvQVi",
+		"offerID": "0x0102030405060708091011121314151617181920212223242526272829303132",
+		"provides": "XMR",
+		"providedAmount": "1.25",
+		"expectedAmount": "1",
+		"exchangeRate": "0.33",
+		"ethAsset": "ETH",
+		"moneroStartHeight": 200,
+		"status": "XMRLocked",
+		"lastStatusUpdateTime": "2023-02-20T17:29:43.471020297-05:00",
+		"startTime": "2023-02-20T17:29:43.471020297-05:00",
+		"timeout0": "2023-02-20T18:29:43-05:00",
+		"timeout1": "2023-02-20T19:29:43-05:00"
+	}`
+	info, err := UnmarshalInfo([]byte(infoJSON))
+	require.NoError(t, err)
+	require.Equal(t, "0.2.0", info.Version.String())
+	require.Equal(t, "2023-02-20T18:29:43-05:00", info.Timeout1.Format(time.RFC3339))
+	require.Equal(t, "2023-02-20T19:29:43-05:00", info.Timeout2.Format(time.RFC3339))
+
+	// the timeouts of the current version are not renamed
+	info.Version = CurInfoVersion
+	infoBytes, err := vjson.MarshalStruct(info)
+	require.NoError(t, err)
+	info, err = UnmarshalInfo(infoBytes)
+	require.NoError(t, err)
+	require.Equal(t, "2023-02-20T18:29:43-05:00", info.Timeout1.Format(time.RFC3339))
+	require.Equal(t, "2023-02-20T19:29:43-05:00", info.Timeout2.Format(time.RFC3339))
+}
+
+func TestInfo_AddMoneroFee(t *testing.T) {
+	info := NewInfo(
+		testPeerID,
+		types.Hash{0x1},
+		coins.ProvidesXMR,
+		apd.New(1, 0),
+		apd.New(1, 0),
+		coins.ToExchangeRate(apd.New(1, -1)),
+		types.EthAssetETH,
+		types.ExpectingKeys,
+		200,
+		clock.Local(),
+	)
+	require.Nil(t, info.MoneroFee)
+
+	info.AddMoneroFee(30_000_000)
+	info.AddMoneroFee(12_000_000)
+	require.Equal(t, "0.000042", info.MoneroFee.AsMoneroString())
+
+	infoCopy, err := info.DeepCopy()
+	require.NoError(t, err)
+	require.Zero(t, info.MoneroFee.Cmp(infoCopy.MoneroFee))
+}
+
+func TestInfo_clock(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	clk := clock.NewMock(start)
+	info := NewInfo(
+		testPeerID,
+		types.Hash{0x1},
+		coins.ProvidesXMR,
+		apd.New(1, 0),
+		apd.New(1, 0),
+		coins.ToExchangeRate(apd.New(1, -1)),
+		types.EthAssetETH,
+		types.ExpectingKeys,
+		200,
+		clk,
+	)
+	require.Equal(t, start, info.StartTime)
+	require.Equal(t, start, info.LastStatusUpdateTime)
+
+	clk.Add(time.Minute)
+	info.SetStatus(types.ETHLocked)
+	require.Equal(t, start.Add(time.Minute), info.LastStatusUpdateTime)
+
+	// copies keep using the clock of the swap
+	infoCopy, err := info.DeepCopy()
+	require.NoError(t, err)
+	clk.Add(time.Minute)
+	infoCopy.MarkSwapComplete()
+	require.Equal(t, start.Add(2*time.Minute), *infoCopy.EndTime)
+}
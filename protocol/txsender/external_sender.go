@@ -26,6 +26,7 @@ import (
 
 var (
 	errTransactionTimeout = errors.New("timed out waiting for transaction to be signed")
+	errEmptyBatch         = errors.New("batch has no swaps")
 	transactionTimeout    = time.Minute * 2 // amount of time user has to sign message
 )
 
@@ -76,6 +77,10 @@ func NewExternalSender(
 	}, nil
 }
 
+// SetPayoutAddress is a no-op, as the value of claims and refunds is
+// transferred to the external wallet that signs them
+func (s *ExternalSender) SetPayoutAddress(_ ethcommon.Address, _ bool) {}
+
 // SetSwapCreator sets the bound contract for the SwapCreator
 func (s *ExternalSender) SetSwapCreator(_ *contracts.SwapCreator) {}
 
@@ -109,7 +114,9 @@ func (s *ExternalSender) approve(
 	return s.sendAndReceive(input, s.erc20Addr)
 }
 
-// NewSwap prompts the external sender to sign a new_swap transaction
+// NewSwap prompts the external sender to sign a newSwap transaction. The
+// external sender can be a contract wallet, in which case the wallet is the
+// owner of the swap.
 func (s *ExternalSender) NewSwap(
 	claimCommitment [32]byte,
 	refundCommitment [32]byte,
@@ -124,8 +131,8 @@ func (s *ExternalSender) NewSwap(
 		return nil, errors.New("external sender does not support ERC20 token swaps")
 	}
 
-	input, err := s.abi.Pack("new_swap", claimCommitment, refundCommitment, claimer, timeoutDuration,
-		amount.TokenAddress(), amount.BigInt(), nonce)
+	input, err := s.abi.Pack("newSwap", claimCommitment, refundCommitment, claimer, timeoutDuration,
+		timeoutDuration, amount.TokenAddress(), amount.BigInt(), nonce)
 	if err != nil {
 		return nil, err
 	}
@@ -154,9 +161,9 @@ func (s *ExternalSender) NewSwap(
 	return block.WaitForReceipt(s.ctx, s.ec, txHash)
 }
 
-// SetReady prompts the external sender to sign a set_ready transaction
+// SetReady prompts the external sender to sign a setReady transaction
 func (s *ExternalSender) SetReady(swap *contracts.SwapCreatorSwap) (*ethtypes.Receipt, error) {
-	input, err := s.abi.Pack("set_ready", swap)
+	input, err := s.abi.Pack("setReady", swap)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +197,40 @@ func (s *ExternalSender) Refund(
 	return s.sendAndReceive(input, s.contractAddr)
 }
 
+// ClaimBatch prompts the external sender to sign a claimBatch transaction
+func (s *ExternalSender) ClaimBatch(
+	swaps []*contracts.SwapCreatorSwap,
+	secrets [][32]byte,
+) (*ethtypes.Receipt, error) {
+	if len(swaps) == 0 {
+		return nil, errEmptyBatch
+	}
+
+	input, err := s.abi.Pack("claimBatch", swapValues(swaps), secrets, swaps[0].Claimer)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sendAndReceive(input, s.contractAddr)
+}
+
+// RefundBatch prompts the external sender to sign a refundBatch transaction
+func (s *ExternalSender) RefundBatch(
+	swaps []*contracts.SwapCreatorSwap,
+	secrets [][32]byte,
+) (*ethtypes.Receipt, error) {
+	if len(swaps) == 0 {
+		return nil, errEmptyBatch
+	}
+
+	input, err := s.abi.Pack("refundBatch", swapValues(swaps), secrets, swaps[0].Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sendAndReceive(input, s.contractAddr)
+}
+
 func (s *ExternalSender) sendAndReceive(input []byte, to ethcommon.Address) (*ethtypes.Receipt, error) {
 	tx := &Transaction{To: to, Data: input}
 
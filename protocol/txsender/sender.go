@@ -13,8 +13,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/cockroachdb/apd/v3"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -27,6 +29,11 @@ import (
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
 )
 
+// permitValidity is how long the EIP-2612 permit signature of a new swap is
+// valid for. The permit is used in the transaction that we send right after
+// signing it.
+const permitValidity = 30 * time.Minute
+
 var (
 	log = logging.Logger("txsender")
 )
@@ -35,6 +42,7 @@ var (
 type Sender interface {
 	SetSwapCreator(*contracts.SwapCreator)
 	SetSwapCreatorAddr(ethcommon.Address)
+	SetPayoutAddress(addr ethcommon.Address, allowFallback bool)
 	NewSwap(
 		claimCommitment [32]byte,
 		refundCommitment [32]byte,
@@ -47,6 +55,8 @@ type Sender interface {
 	SetReady(swap *contracts.SwapCreatorSwap) (*ethtypes.Receipt, error)
 	Claim(swap *contracts.SwapCreatorSwap, secret [32]byte) (*ethtypes.Receipt, error)
 	Refund(swap *contracts.SwapCreatorSwap, secret [32]byte) (*ethtypes.Receipt, error)
+	ClaimBatch(swaps []*contracts.SwapCreatorSwap, secrets [][32]byte) (*ethtypes.Receipt, error)
+	RefundBatch(swaps []*contracts.SwapCreatorSwap, secrets [][32]byte) (*ethtypes.Receipt, error)
 }
 
 type privateKeySender struct {
@@ -55,6 +65,8 @@ type privateKeySender struct {
 	swapCreatorAddr ethcommon.Address
 	swapCreator     *contracts.SwapCreator
 	erc20Contract   *contracts.IERC20
+	payoutAddr      ethcommon.Address // zero pays claims and refunds to our address
+	payoutFallback  bool              // pay our address if the swap contract can't pay payoutAddr
 }
 
 // NewSenderWithPrivateKey returns a new *privateKeySender
@@ -78,7 +90,18 @@ func (s *privateKeySender) SetSwapCreator(contract *contracts.SwapCreator) {
 	s.swapCreator = contract
 }
 
-func (s *privateKeySender) SetSwapCreatorAddr(_ ethcommon.Address) {}
+func (s *privateKeySender) SetSwapCreatorAddr(addr ethcommon.Address) {
+	s.swapCreatorAddr = addr
+}
+
+// SetPayoutAddress sets the address that the value of our claims and refunds
+// is transferred to, instead of our own address. If allowFallback is set, the
+// value is transferred to our address when the swap contract can't pay the
+// payout address, instead of failing the claim or refund.
+func (s *privateKeySender) SetPayoutAddress(addr ethcommon.Address, allowFallback bool) {
+	s.payoutAddr = addr
+	s.payoutFallback = allowFallback
+}
 
 func (s *privateKeySender) NewSwap(
 	claimCommitment [32]byte,
@@ -98,9 +121,15 @@ func (s *privateKeySender) NewSwap(
 
 	value := amount.BigInt()
 
+	// For tokens that support EIP-2612, we pass a permit signature to the
+	// contract instead of sending an approve transaction first.
+	var permit *contracts.SwapCreatorPermit
 	if amount.IsToken() {
-		if err := s.approveTransferFrom(amount); err != nil {
-			return nil, err
+		permit = s.signNewSwapPermit(claimCommitment, refundCommitment, claimer, timeoutDuration, nonce, amount)
+		if permit == nil {
+			if err := s.approveTransferFrom(amount); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -114,8 +143,25 @@ func (s *privateKeySender) NewSwap(
 		txOpts.Value = value
 	}
 
-	tx, err := s.swapCreator.NewSwap(txOpts, claimCommitment, refundCommitment, claimer, timeoutDuration, timeoutDuration,
-		amount.TokenAddress(), value, nonce)
+	if permit != nil {
+		err = s.simulate(nil, "newSwapWithPermit", claimCommitment, refundCommitment, claimer,
+			timeoutDuration, timeoutDuration, amount.TokenAddress(), value, nonce, *permit)
+	} else {
+		err = s.simulate(txOpts.Value, "newSwap", claimCommitment, refundCommitment, claimer,
+			timeoutDuration, timeoutDuration, amount.TokenAddress(), value, nonce)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tx *ethtypes.Transaction
+	if permit != nil {
+		tx, err = s.swapCreator.NewSwapWithPermit(txOpts, claimCommitment, refundCommitment, claimer,
+			timeoutDuration, timeoutDuration, amount.TokenAddress(), value, nonce, *permit)
+	} else {
+		tx, err = s.swapCreator.NewSwap(txOpts, claimCommitment, refundCommitment, claimer,
+			timeoutDuration, timeoutDuration, amount.TokenAddress(), value, nonce)
+	}
 	if err != nil {
 		err = fmt.Errorf("new_swap tx creation failed, %w", err)
 		return nil, err
@@ -135,6 +181,69 @@ func (s *privateKeySender) NewSwap(
 	return receipt, nil
 }
 
+// signNewSwapPermit returns an EIP-2612 permit signature for the swap's token
+// amount, if both the token and the SwapCreator contract support permits. The
+// newSwapWithPermit call is simulated, so nil is returned for contracts
+// deployed before permit support, and for tokens with an incompatible permit
+// implementation. Callers fall back to approving the transfer when nil is
+// returned.
+func (s *privateKeySender) signNewSwapPermit(
+	claimCommitment [32]byte,
+	refundCommitment [32]byte,
+	claimer ethcommon.Address,
+	timeoutDuration *big.Int,
+	nonce *big.Int,
+	amount coins.EthAssetAmount,
+) *contracts.SwapCreatorPermit {
+	// the token compares the deadline against the block timestamp
+	now, err := s.ethClient.LatestBlockTimestamp(s.ctx)
+	if err != nil {
+		log.Debugf("not using permit for token %s: %s", amount.TokenAddress(), err)
+		return nil
+	}
+	deadline := big.NewInt(now.Add(permitValidity).Unix())
+
+	permit, err := contracts.SignERC20Permit(
+		s.ctx,
+		s.ethClient.Raw(),
+		amount.TokenAddress(),
+		s.ethClient.PrivateKey(),
+		s.swapCreatorAddr,
+		amount.BigInt(),
+		deadline,
+	)
+	if err != nil {
+		log.Debugf("not using permit for token %s: %s", amount.TokenAddress(), err)
+		return nil
+	}
+
+	swapCreatorABI, err := contracts.SwapCreatorMetaData.GetAbi()
+	if err != nil {
+		log.Debugf("not using permit for token %s: %s", amount.TokenAddress(), err)
+		return nil
+	}
+
+	data, err := swapCreatorABI.Pack("newSwapWithPermit", claimCommitment, refundCommitment, claimer,
+		timeoutDuration, timeoutDuration, amount.TokenAddress(), amount.BigInt(), nonce, *permit)
+	if err != nil {
+		log.Debugf("not using permit for token %s: %s", amount.TokenAddress(), err)
+		return nil
+	}
+
+	msg := ethereum.CallMsg{
+		From: s.ethClient.Address(),
+		To:   &s.swapCreatorAddr,
+		Data: data,
+	}
+	if _, err = s.ethClient.Raw().CallContract(s.ctx, msg, nil); err != nil {
+		log.Debugf("not using permit for token %s, simulated newSwapWithPermit failed: %s",
+			amount.TokenAddress(), err)
+		return nil
+	}
+
+	return permit
+}
+
 // approveTransferFrom grants the SwapCreator contract permission to transfer
 // the passed amount of tokens. Since the transfer happens inside the NewSwap
 // transaction, we need to grant the contract's address approval for the
@@ -226,6 +335,10 @@ func (s *privateKeySender) SetReady(swap *contracts.SwapCreatorSwap) (*ethtypes.
 		return nil, err
 	}
 
+	if err = s.simulate(nil, "setReady", *swap); err != nil {
+		return nil, err
+	}
+
 	tx, err := s.swapCreator.SetReady(txOpts, *swap)
 	if err != nil {
 		err = fmt.Errorf("set_ready tx creation failed, %w", err)
@@ -252,7 +365,8 @@ func (s *privateKeySender) Claim(
 		return nil, err
 	}
 
-	tx, err := s.swapCreator.Claim(txOpts, *swap, secret)
+	tx, err := contracts.ClaimWithPayout(s.ctx, s.ethClient.Raw(), s.swapCreatorAddr, txOpts, swap, secret,
+		s.payoutAddr, s.payoutFallback)
 	if err != nil {
 		err = fmt.Errorf("claim tx creation failed, %w", err)
 		return nil, err
@@ -278,7 +392,8 @@ func (s *privateKeySender) Refund(
 		return nil, err
 	}
 
-	tx, err := s.swapCreator.Refund(txOpts, *swap, secret)
+	tx, err := contracts.RefundWithPayout(s.ctx, s.ethClient.Raw(), s.swapCreatorAddr, txOpts, swap, secret,
+		s.payoutAddr, s.payoutFallback)
 	if err != nil {
 		err = fmt.Errorf("refund tx creation failed, %w", err)
 		return nil, err
@@ -292,3 +407,100 @@ func (s *privateKeySender) Refund(
 
 	return receipt, nil
 }
+
+func (s *privateKeySender) ClaimBatch(
+	swaps []*contracts.SwapCreatorSwap,
+	secrets [][32]byte,
+) (*ethtypes.Receipt, error) {
+	s.ethClient.Lock()
+	defer s.ethClient.Unlock()
+	txOpts, err := s.ethClient.TxOpts(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.simulate(nil, "claimBatch", swapValues(swaps), secrets, s.payout()); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.swapCreator.ClaimBatch(txOpts, swapValues(swaps), secrets, s.payout())
+	if err != nil {
+		err = fmt.Errorf("claim_batch tx creation failed, %w", err)
+		return nil, err
+	}
+
+	receipt, err := block.WaitForReceipt(s.ctx, s.ethClient.Raw(), tx.Hash())
+	if err != nil {
+		err = fmt.Errorf("claim_batch failed, %w", err)
+		return nil, err
+	}
+
+	log.Infof("claimBatch TX for %d swaps succeeded, %s", len(swaps), common.ReceiptInfo(receipt))
+
+	return receipt, nil
+}
+
+func (s *privateKeySender) RefundBatch(
+	swaps []*contracts.SwapCreatorSwap,
+	secrets [][32]byte,
+) (*ethtypes.Receipt, error) {
+	s.ethClient.Lock()
+	defer s.ethClient.Unlock()
+	txOpts, err := s.ethClient.TxOpts(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.simulate(nil, "refundBatch", swapValues(swaps), secrets, s.payout()); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.swapCreator.RefundBatch(txOpts, swapValues(swaps), secrets, s.payout())
+	if err != nil {
+		err = fmt.Errorf("refund_batch tx creation failed, %w", err)
+		return nil, err
+	}
+
+	receipt, err := block.WaitForReceipt(s.ctx, s.ethClient.Raw(), tx.Hash())
+	if err != nil {
+		err = fmt.Errorf("refund_batch failed, %w", err)
+		return nil, err
+	}
+
+	log.Infof("refundBatch TX for %d swaps succeeded, %s", len(swaps), common.ReceiptInfo(receipt))
+
+	return receipt, nil
+}
+
+// simulate simulates our transaction calling the SwapCreator method at the
+// pending block, so that we don't send transactions that would revert. Claims
+// and refunds are simulated by contracts.ClaimWithPayout and
+// contracts.RefundWithPayout.
+func (s *privateKeySender) simulate(value *big.Int, method string, args ...any) error {
+	err := contracts.SimulateSwapCreatorCall(s.ctx, s.ethClient.Raw(), s.swapCreatorAddr, s.ethClient.Address(),
+		value, method, args...)
+	if err != nil {
+		log.Warnf("not sending %s transaction: %s", method, err)
+		return err
+	}
+
+	return nil
+}
+
+// payout returns the address that batch claims and refunds transfer to
+func (s *privateKeySender) payout() ethcommon.Address {
+	if s.payoutAddr != (ethcommon.Address{}) {
+		return s.payoutAddr
+	}
+	return s.ethClient.Address()
+}
+
+// swapValues converts the swaps to the value slice taken by the batch
+// methods of the SwapCreator binding
+func swapValues(swaps []*contracts.SwapCreatorSwap) []contracts.SwapCreatorSwap {
+	values := make([]contracts.SwapCreatorSwap, len(swaps))
+	for i, swap := range swaps {
+		values[i] = *swap
+	}
+	return values
+}
@@ -2,15 +2,19 @@ package txsender
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/cockroachdb/apd/v3"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
 	"github.com/athanorlabs/atomic-swap/cliutil"
 	"github.com/athanorlabs/atomic-swap/coins"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/ethereum/simbackend"
 	"github.com/athanorlabs/atomic-swap/tests"
 )
 
@@ -82,3 +86,51 @@ func Test_privateKeySender_approve(t *testing.T) {
 	err = sender.approveTransferFrom(amt)
 	require.NoError(t, err)
 }
+
+// Tokens implementing EIP-2612 are locked with a permit signature in the
+// newSwapWithPermit transaction, without approving the transfer first.
+func Test_privateKeySender_NewSwap_permit(t *testing.T) {
+	ctx := context.Background()
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain := simbackend.CreateTestChain(t, pk)
+	ec := chain.CreateTestClient(t, pk)
+
+	swapCreatorAddr, swapCreator, err := contracts.DeploySwapCreatorWithKey(ctx, ec.Raw(), pk)
+	require.NoError(t, err)
+
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	supply := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	tokenAddr, tx, token, err := contracts.DeployTestERC20Permit(txOpts, ec.Raw(), "Permit Token", "PERMIT", 18,
+		ec.Address(), supply)
+	require.NoError(t, err)
+	_, err = ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	tokenInfo := coins.NewERC20TokenInfo(tokenAddr, 18, "Permit Token", "PERMIT")
+	tokenBinding, err := contracts.NewIERC20(tokenAddr, ec.Raw())
+	require.NoError(t, err)
+	sender := NewSenderWithPrivateKey(ctx, ec, swapCreatorAddr, swapCreator, tokenBinding)
+
+	startNonce, err := ec.Raw().NonceAt(ctx, ec.Address(), nil)
+	require.NoError(t, err)
+
+	amount := coins.NewTokenAmountFromDecimals(coins.StrToDecimal("3"), tokenInfo)
+	_, err = sender.NewSwap([32]byte{1}, [32]byte{2}, ethcommon.Address{0x1}, big.NewInt(60),
+		contracts.GenerateNewSwapNonce(), amount, func(ethcommon.Hash) error { return nil })
+	require.NoError(t, err)
+
+	// only the newSwapWithPermit transaction was sent
+	nonce, err := ec.Raw().NonceAt(ctx, ec.Address(), nil)
+	require.NoError(t, err)
+	require.Equal(t, startNonce+1, nonce)
+
+	callOpts := ec.CallOpts(ctx)
+	permitNonce, err := token.Nonces(callOpts, ec.Address())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), permitNonce.Int64())
+	bal, err := token.BalanceOf(callOpts, swapCreatorAddr)
+	require.NoError(t, err)
+	require.Equal(t, amount.BigInt(), bal)
+}
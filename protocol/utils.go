@@ -4,12 +4,16 @@
 package protocol
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/protocol/backend"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -45,3 +49,35 @@ func CheckSwapID(log *ethtypes.Log, eventNameTopic [32]byte, contractSwapID type
 
 	return nil
 }
+
+// RecordTxError saves the decoded contract error of a swap transaction that
+// was not sent, because it reverted when simulated, in the swap's info, so that
+// it is shown in the swap's status. A nil error clears the saved error.
+func RecordTxError(info *swap.Info, err error) {
+	var revertErr *contracts.RevertError
+	switch {
+	case err == nil:
+		info.SetLastTxError("")
+	case errors.As(err, &revertErr):
+		info.SetLastTxError(revertErr.Error())
+	}
+}
+
+// CheckRelayedPayout checks whether a claim or refund may be relayed given the
+// payout address configuration. The contract's relayed claims and refunds pay
+// the swap's claimer or owner, which is always our own address, so they are
+// refused when a payout address is set, unless falling back to our own address
+// was allowed.
+func CheckRelayedPayout(b backend.Backend) error {
+	payoutAddr := b.PayoutAddress()
+	if payoutAddr == (ethcommon.Address{}) {
+		return nil
+	}
+
+	if !b.PayoutFallback() {
+		return fmt.Errorf("%w %s", ErrRelayedPayout, payoutAddr)
+	}
+
+	log.Warnf("relayed transaction will pay our own address instead of the payout address %s", payoutAddr)
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/athanorlabs/atomic-swap/protocol/backend"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// payoutBackend only implements the payout methods of backend.Backend
+type payoutBackend struct {
+	backend.Backend
+	payoutAddr     ethcommon.Address
+	payoutFallback bool
+}
+
+func (b *payoutBackend) PayoutAddress() ethcommon.Address {
+	return b.payoutAddr
+}
+
+func (b *payoutBackend) PayoutFallback() bool {
+	return b.payoutFallback
+}
+
+func TestCheckRelayedPayout(t *testing.T) {
+	payoutAddr := ethcommon.HexToAddress("0xda9dfa130df4de4673b89022ee50ff26f6ea73cf")
+
+	require.NoError(t, CheckRelayedPayout(&payoutBackend{}))
+	require.NoError(t, CheckRelayedPayout(&payoutBackend{payoutAddr: payoutAddr, payoutFallback: true}))
+
+	err := CheckRelayedPayout(&payoutBackend{payoutAddr: payoutAddr})
+	require.ErrorIs(t, err, ErrRelayedPayout)
+	require.ErrorContains(t, err, payoutAddr.Hex())
+}
@@ -4,18 +4,37 @@
 package xmrmaker
 
 import (
+	"fmt"
+
 	"github.com/athanorlabs/atomic-swap/common/types"
+	pcommon "github.com/athanorlabs/atomic-swap/protocol"
+	"github.com/athanorlabs/atomic-swap/protocol/backend"
+	"github.com/athanorlabs/atomic-swap/relayer"
 )
 
-// MakeOffer makes a new swap offer.
+// MakeOffer makes a new swap offer. The extra data is local to this node and
+// is not advertised. If it is nil, default values are used.
 func (inst *Instance) MakeOffer(
 	o *types.Offer,
-	useRelayer bool,
+	extra *types.OfferExtra,
 ) (*types.OfferExtra, error) {
-	err := validateMinBalance(
-		inst.backend.Ctx(),
-		inst.backend.XMRClient(),
-		inst.backend.ETHClient(),
+	if extra == nil {
+		extra = types.NewOfferExtra(false)
+	}
+
+	b, err := inst.backend.ForChain(o.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = b.TokenPolicy().Check(o.EthAsset); err != nil {
+		return nil, err
+	}
+
+	err = validateMinBalance(
+		b.Ctx(),
+		b.XMRClient(),
+		b.ETHClient(),
 		o.MaxAmount,
 		o.EthAsset,
 	)
@@ -23,12 +42,18 @@ func (inst *Instance) MakeOffer(
 		return nil, err
 	}
 
-	if o.EthAsset.IsToken() {
-		if useRelayer {
-			return nil, errRelayingWithNonEthAsset
+	if err = checkOfferRelayerFee(b, o, extra.UseRelayer); err != nil {
+		return nil, err
+	}
+
+	if extra.UseRelayer {
+		if err = pcommon.CheckRelayedPayout(b); err != nil {
+			return nil, err
 		}
+	}
 
-		token, err := inst.backend.ETHClient().ERC20Info(inst.backend.Ctx(), o.EthAsset.Address()) //nolint:govet
+	if o.EthAsset.IsToken() {
+		token, err := b.ETHClient().ERC20Info(b.Ctx(), o.EthAsset.Address()) //nolint:govet
 		if err != nil {
 			return nil, err
 		}
@@ -52,7 +77,7 @@ func (inst *Instance) MakeOffer(
 
 	}
 
-	extra, err := inst.offerManager.AddOffer(o, useRelayer)
+	extra, err = inst.offerManager.AddOffer(o, extra)
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +87,25 @@ func (inst *Instance) MakeOffer(
 	return extra, nil
 }
 
+// checkOfferRelayerFee checks that the offer's min amount is greater than the
+// relayer fee. Relayer fees follow the gas price, so we estimate the fee with
+// the quote that we would give as a relayer. The relayer fee of token swaps is
+// paid in the token, so we also make sure that it can be priced when the offer
+// uses a relayer.
+func checkOfferRelayerFee(b backend.Backend, o *types.Offer, useRelayer bool) error {
+	fee, err := relayer.EstimateFee(b.Ctx(), b.ETHClient(), o.EthAsset)
+	if err != nil {
+		if o.EthAsset.IsETH() || useRelayer {
+			return fmt.Errorf("cannot estimate relayer fee of %s: %w", o.EthAsset, err)
+		}
+
+		log.Warnf("Relayers can't claim the offer, the relayer fee of %s can't be priced: %s", o.EthAsset, err)
+		return nil
+	}
+
+	return o.CheckMinAmount(fee)
+}
+
 // GetOffers returns all current offers.
 func (inst *Instance) GetOffers() []*types.Offer {
 	return inst.offerManager.GetOffers()
@@ -98,6 +98,18 @@ func (s *swapState) checkContract(txHash ethcommon.Hash) error {
 		)
 	}
 
+	// Fee-on-transfer and rebasing tokens can leave the contract with less
+	// than the swap value, so our claim would revert after revealing our
+	// secret. We abort before locking XMR if the contract did not receive
+	// exactly the swap value.
+	asset := types.EthAsset(s.contractSwap.Asset)
+	if asset.IsToken() {
+		err = s.ETHClient().CheckERC20Transfer(s.ctx, asset.Address(), receipt, s.swapCreatorAddr, s.contractSwap.Value)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -123,7 +135,7 @@ func (s *swapState) checkAndSetTimeouts(t1, t2 *big.Int) error {
 		return errInvalidT2
 	}
 
-	if time.Now().Add(expectedTimeout).Sub(s.t1).Abs() > allowableTimeDiff {
+	if s.Clock().Now().Add(expectedTimeout).Sub(s.t1).Abs() > allowableTimeDiff {
 		return errInvalidT1
 	}
 
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
@@ -22,6 +24,8 @@ import (
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/ethereum/block"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	pcommon "github.com/athanorlabs/atomic-swap/protocol"
+	"github.com/athanorlabs/atomic-swap/relayer"
 )
 
 // claimFunds redeems XMRMaker's ETH funds by calling Claim() on the contract
@@ -60,6 +64,7 @@ func (s *swapState) claimFunds() (*ethtypes.Receipt, error) {
 		// claim and wait for tx to be included
 		sc := s.getSecret()
 		receipt, err = s.sender.Claim(s.contractSwap, sc)
+		pcommon.RecordTxError(s.info, err)
 		if err != nil {
 			if strings.Contains(err.Error(), "insufficient funds for gas * price + value") {
 				// if we get this error, we need to use a relayer
@@ -135,19 +140,51 @@ func checkForMinClaimBalance(ctx context.Context, ec extethclient.EthClient) (bo
 	return true, nil
 }
 
-// relayClaimWithXMRTaker relays the claim to the swap's XMR taker, who should
-// process the claim even if they are not relaying claims for everyone.
-func (s *swapState) relayClaimWithXMRTaker() (*ethtypes.Receipt, error) {
-	secret := s.getSecret()
+// relayerCandidate is a relayer with its quote for claiming our swap
+type relayerCandidate struct {
+	peerID      peer.ID
+	relayerHash types.Hash
+	fee         coins.EthAssetAmount
+}
+
+// queryRelayer gets the relayer's fee quote for claiming our swap. It returns an
+// error if the fee is not less than the swap value.
+func (s *swapState) queryRelayer(relayerID peer.ID) (*relayerCandidate, error) {
+	resp, err := s.Backend.QueryRelayer(relayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	asset := types.EthAsset(s.contractSwap.Asset)
+	quote := &relayer.FeeQuote{ETHFee: resp.ETHFee, TokenFee: resp.TokenFee}
+	fee, err := relayer.FeeForAsset(s.ctx, s.ETHClient().Raw(), asset, quote.FeeWei(asset))
+	if err != nil {
+		return nil, err
+	}
+
+	if fee.BigInt().Cmp(s.contractSwap.Value) >= 0 {
+		return nil, fmt.Errorf("relayer fee of %s %s is not less than the swap value",
+			fee.AsStdString(), fee.StdSymbol())
+	}
+
+	return &relayerCandidate{
+		peerID:      relayerID,
+		relayerHash: types.Hash(resp.AddressHash),
+		fee:         fee,
+	}, nil
+}
+
+// claimWithRelayer relays the claim to the relayer at its quoted fee and waits
+// for the relayer's claim transaction.
+func (s *swapState) claimWithRelayer(candidate *relayerCandidate, offerID *types.Hash) (*ethtypes.Receipt, error) {
 	relaySwap := &contracts.SwapCreatorRelaySwap{
 		Swap:        *s.contractSwap,
 		SwapCreator: s.swapCreatorAddr,
-		Fee:         coins.RelayerFeeWei,
-		// this is set when we receive the relayer's address hash
-		RelayerHash: types.Hash{},
+		Fee:         candidate.fee.BigInt(),
+		RelayerHash: candidate.relayerHash,
 	}
 
-	response, err := s.Backend.SubmitClaimToRelayer(s.info.PeerID, &s.offer.ID, relaySwap, secret)
+	resp, err := s.Backend.SubmitClaimToRelayer(candidate.peerID, offerID, relaySwap, s.getSecret())
 	if err != nil {
 		return nil, err
 	}
@@ -155,94 +192,114 @@ func (s *swapState) relayClaimWithXMRTaker() (*ethtypes.Receipt, error) {
 	receipt, err := waitForClaimRelayerReceipt(
 		s.ctx,
 		s.ETHClient().Raw(),
-		response.TxHash,
+		resp.TxHash,
 		s.swapCreatorAddr,
 		s.contractSwapID,
 		s.getSecret(),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get receipt of relayer's tx: %s", err)
+		return nil, fmt.Errorf("failed to get receipt of relayer's tx=%s: %w", resp.TxHash.Hex(), err)
 	}
 
-	log.Infof("relayer's claim via counterparty included and validated %s", common.ReceiptInfo(receipt))
 	return receipt, nil
 }
 
-// claimWithAdvertisedRelayers relays the claim to nodes that advertise
-// themselves as relayers in the DHT until the claim succeeds, all relayers have
-// been tried, or the context is cancelled.
-func (s *swapState) claimWithAdvertisedRelayers() (*ethtypes.Receipt, error) {
-	secret := s.getSecret()
-	relaySwap := &contracts.SwapCreatorRelaySwap{
-		Swap:        *s.contractSwap,
-		SwapCreator: s.swapCreatorAddr,
-		Fee:         coins.RelayerFeeWei,
-		// this is set when we receive the relayer's address hash
-		RelayerHash: types.Hash{},
+// relayClaimWithXMRTaker relays the claim to the swap's XMR taker, who should
+// process the claim even if they are not relaying claims for everyone.
+func (s *swapState) relayClaimWithXMRTaker() (*ethtypes.Receipt, coins.EthAssetAmount, error) {
+	candidate, err := s.queryRelayer(s.info.PeerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := s.claimWithRelayer(candidate, &s.offer.ID)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	log.Infof("relayer's claim via counterparty included and validated %s", common.ReceiptInfo(receipt))
+	return receipt, candidate.fee, nil
+}
+
+// claimWithAdvertisedRelayers gets the fee quotes of the nodes that advertise
+// themselves as relayers in the DHT and relays the claim to them, cheapest
+// first, until the claim succeeds, all relayers have been tried, or the context
+// is cancelled.
+func (s *swapState) claimWithAdvertisedRelayers() (*ethtypes.Receipt, coins.EthAssetAmount, error) {
 	relayers, err := s.Backend.DiscoverRelayers()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(relayers) == 0 {
-		return nil, errors.New("no relayers found to submit claim to")
+		return nil, nil, errors.New("no relayers found to submit claim to")
 	}
 	log.Debugf("Found %d relayers to submit claim to", len(relayers))
+
+	var candidates []*relayerCandidate
 	for _, relayerPeerID := range relayers {
 		if relayerPeerID == s.info.PeerID {
 			log.Debugf("skipping DHT-advertised relayer that is our swap counterparty")
 			continue
 		}
 
-		log.Debugf("submitting claim to relayer with peer ID %s", relayerPeerID)
-		resp, err := s.Backend.SubmitClaimToRelayer(relayerPeerID, nil, relaySwap, secret)
+		candidate, err := s.queryRelayer(relayerPeerID) //nolint:govet
 		if err != nil {
-			log.Warnf("failed to submit tx to relayer: %s", err)
+			log.Debugf("skipping relayer with peer ID %s: %s", relayerPeerID, err)
 			continue
 		}
 
-		receipt, err := waitForClaimRelayerReceipt(
-			s.ctx,
-			s.ETHClient().Raw(),
-			resp.TxHash,
-			s.swapCreatorAddr,
-			s.contractSwapID,
-			s.getSecret(),
-		)
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].fee.BigInt().Cmp(candidates[j].fee.BigInt()) < 0
+	})
+
+	for _, candidate := range candidates {
+		log.Debugf("submitting claim to relayer with peer ID %s and fee %s %s",
+			candidate.peerID, candidate.fee.AsStdString(), candidate.fee.StdSymbol())
+		receipt, err := s.claimWithRelayer(candidate, nil)
 		if err != nil {
-			log.Warnf("failed to get receipt of relayer's tx=%s: %s", resp.TxHash.Hex(), err)
+			log.Warnf("failed to relay claim with relayer %s: %s", candidate.peerID, err)
 			continue
 		}
 
 		log.Infof("DHT relayer's claim included and validated %s", common.ReceiptInfo(receipt))
 
-		return receipt, nil
+		return receipt, candidate.fee, nil
 	}
 
-	return nil, errors.New("failed to relay claim with any non-counterparty relayer")
+	return nil, nil, errors.New("failed to relay claim with any non-counterparty relayer")
 }
 
-// claimWithRelay first tries to relay sequentially with all relayers
-// advertising in the DHT that are not the XMR taker and, if that fails, falls
-// back to the XMR taker who, if using our software, will act as a relayer of
-// last resort for their own swap, even if they are not performing relay
+// claimWithRelay first tries to relay with the relayers advertising in the DHT
+// that are not the XMR taker, cheapest fee quote first, and, if that fails,
+// falls back to the XMR taker who, if using our software, will act as a relayer
+// of last resort for their own swap, even if they are not performing relay
 // operations more generally. Note that the receipt returned is for a
-// transaction created by the remote relayer, not by us.
+// transaction created by the remote relayer, not by us. The relayer fee of
+// token swaps is paid in the token, priced with the price feeds. Relayed claims
+// pay our own address, so they are refused if a payout address is set without
+// the fallback.
 func (s *swapState) claimWithRelay() (*ethtypes.Receipt, error) {
-	receipt, err := s.claimWithAdvertisedRelayers()
+	if err := pcommon.CheckRelayedPayout(s.Backend); err != nil {
+		return nil, err
+	}
+
+	receipt, fee, err := s.claimWithAdvertisedRelayers()
 	if err != nil {
 		log.Warnf("failed to relay with DHT-advertised relayers: %s", err)
 		log.Infof("falling back to swap counterparty as relayer")
-		receipt, err = s.relayClaimWithXMRTaker()
+		receipt, fee, err = s.relayClaimWithXMRTaker()
 		if err != nil {
 			return nil, err
 		}
 	}
+	log.Infof("paid relayer fee of %s %s", fee.AsStdString(), fee.StdSymbol())
 
 	// Save the relayer fee to the database
-	s.info.SetRelayerFee(coins.RelayerFeeETH)
+	s.info.SetRelayerFee(fee.AsStd())
 	swapManager := s.SwapManager()
 	err = swapManager.WriteSwapToDB(s.info)
 	if err != nil {
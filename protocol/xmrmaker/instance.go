@@ -186,8 +186,20 @@ func (inst *Instance) createOngoingSwap(s *swap.Info) error {
 		relayerInfo = types.NewOfferExtra(false)
 	}
 
+	// swaps started before the priority had its own key stored it with the
+	// relayer info
+	priority, err := inst.backend.RecoveryDB().GetSwapXMRPriority(s.OfferID)
+	if err == nil {
+		relayerInfo.XMRPriority = priority
+	}
+
+	b, err := inst.backend.ForChain(s.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to restart ongoing swap, offer ID %s: %w", s.OfferID, err)
+	}
+
 	ss, err := newSwapStateFromOngoing(
-		inst.backend,
+		b,
 		offer,
 		relayerInfo,
 		inst.offerManager,
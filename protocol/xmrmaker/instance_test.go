@@ -17,6 +17,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 	"github.com/athanorlabs/atomic-swap/db"
@@ -65,11 +66,18 @@ func (*mockNet) SubmitRelayRequest(_ peer.ID, _ *message.RelayClaimRequest) (*me
 	return new(message.RelayClaimResponse), nil
 }
 
+func (*mockNet) SubmitRelayRefundRequest(
+	_ peer.ID,
+	_ *message.RelayRefundRequest,
+) (*message.RelayClaimResponse, error) {
+	return new(message.RelayClaimResponse), nil
+}
+
 func (*mockNet) CloseProtocolStream(_ types.Hash) {}
 func (*mockNet) DeleteOngoingSwap(_ types.Hash)   {}
 
-func (n *mockNet) QueryRelayerAddress(_ peer.ID) (types.Hash, error) {
-	return types.Hash{}, nil
+func (*mockNet) QueryRelayer(_ peer.ID) (*message.RelayerQueryResponse, error) {
+	return nil, errors.New("not implemented")
 }
 
 func newSwapManager(t *testing.T) pswap.Manager {
@@ -79,7 +87,7 @@ func newSwapManager(t *testing.T) pswap.Manager {
 	db.EXPECT().GetAllSwaps()
 	db.EXPECT().PutSwap(gomock.Any()).AnyTimes()
 
-	sm, err := pswap.NewManager(db)
+	sm, err := pswap.NewManager(db, clock.Local())
 	require.NoError(t, err)
 	return sm
 }
@@ -187,7 +195,7 @@ func TestInstance_createOngoingSwap(t *testing.T) {
 	offer := types.NewOffer(coins.ProvidesXMR, one, one, rate, types.EthAssetETH)
 
 	offerDB.EXPECT().PutOffer(offer).Return(nil)
-	_, err = inst.offerManager.AddOffer(offer, false)
+	_, err = inst.offerManager.AddOffer(offer, nil)
 	require.NoError(t, err)
 
 	s := &pswap.Info{
@@ -204,6 +212,7 @@ func TestInstance_createOngoingSwap(t *testing.T) {
 	require.NoError(t, err)
 
 	rdb.EXPECT().GetSwapRelayerInfo(s.OfferID).Return(nil, errors.New("some error"))
+	rdb.EXPECT().GetSwapXMRPriority(s.OfferID).Return(types.PriorityDefault, errors.New("some error"))
 	rdb.EXPECT().GetCounterpartySwapPrivateKey(s.OfferID).Return(nil, errors.New("some error"))
 	rdb.EXPECT().GetContractSwapInfo(s.OfferID).Return(&db.EthereumSwapInfo{
 		StartNumber:     big.NewInt(1),
@@ -16,6 +16,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	"github.com/athanorlabs/atomic-swap/db"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
@@ -189,10 +190,10 @@ func (s *swapState) handleNotifyETHLocked(msg *message.NotifyETHLocked) error {
 func (s *swapState) runT1ExpirationHandler() {
 	log.Debugf("time until t1 (%s): %vs",
 		s.t1.Format(common.TimeFmtSecs),
-		time.Until(s.t1).Seconds(),
+		clock.Until(s.Clock(), s.t1).Seconds(),
 	)
 
-	if time.Until(s.t2) < 0 {
+	if clock.Until(s.Clock(), s.t2) < 0 {
 		log.Debugf("t2 (%s) has already passed; not starting t1 expiration handler",
 			s.t2.Format(common.TimeFmtSecs),
 		)
@@ -206,7 +207,7 @@ func (s *swapState) runT1ExpirationHandler() {
 	// with --miner.blockTime!!!
 	waitCh := make(chan error)
 	go func() {
-		waitCh <- s.ETHClient().WaitForTimestamp(waitCtx, s.t1)
+		waitCh <- s.WaitForTimestamp(waitCtx, s.t1)
 		close(waitCh)
 	}()
 
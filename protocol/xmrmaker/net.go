@@ -13,6 +13,7 @@ import (
 	"github.com/athanorlabs/atomic-swap/net"
 	"github.com/athanorlabs/atomic-swap/net/message"
 	pcommon "github.com/athanorlabs/atomic-swap/protocol"
+	"github.com/athanorlabs/atomic-swap/protocol/backend"
 
 	"github.com/fatih/color"
 )
@@ -23,6 +24,7 @@ func (inst *Instance) Provides() coins.ProvidesCoin {
 }
 
 func (inst *Instance) initiate(
+	b backend.Backend,
 	takerPeerID peer.ID,
 	offer *types.Offer,
 	offerExtra *types.OfferExtra,
@@ -33,7 +35,7 @@ func (inst *Instance) initiate(
 		return nil, errProtocolAlreadyInProgress
 	}
 
-	balance, err := inst.backend.XMRClient().GetBalance(0)
+	balance, err := b.XMRClient().GetBalance(0)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +57,7 @@ func (inst *Instance) initiate(
 	}
 
 	s, err := newSwapStateFromStart(
-		inst.backend,
+		b,
 		takerPeerID,
 		offer,
 		offerExtra,
@@ -74,7 +76,7 @@ func (inst *Instance) initiate(
 		delete(inst.swapStates, offer.ID)
 	}()
 
-	symbol, err := pcommon.AssetSymbol(inst.backend, offer.EthAsset)
+	symbol, err := pcommon.AssetSymbol(b, offer.EthAsset)
 	if err != nil {
 		_ = s.Exit()
 		return nil, err
@@ -115,10 +117,20 @@ func (inst *Instance) HandleInitiateMessage(
 		return nil, err
 	}
 
+	b, err := inst.backend.ForChain(offer.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token policy can be stricter than when the offer was made
+	if err = b.TokenPolicy().Check(offer.EthAsset); err != nil {
+		return nil, err
+	}
+
 	maxDecimals := uint8(coins.NumEtherDecimals)
 	var token *coins.ERC20TokenInfo
 	if offer.EthAsset.IsToken() {
-		token, err = inst.backend.ETHClient().ERC20Info(inst.backend.Ctx(), offer.EthAsset.Address())
+		token, err = b.ETHClient().ERC20Info(b.Ctx(), offer.EthAsset.Address())
 		if err != nil {
 			return nil, err
 		}
@@ -153,7 +165,7 @@ func (inst *Instance) HandleInitiateMessage(
 
 	providedPiconero := coins.MoneroToPiconero(providedAmtAsXMR)
 
-	state, err := inst.initiate(takerPeerID, offer, offerExtra, providedPiconero, expectedAmount)
+	state, err := inst.initiate(b, takerPeerID, offer, offerExtra, providedPiconero, expectedAmount)
 	if err != nil {
 		return nil, err
 	}
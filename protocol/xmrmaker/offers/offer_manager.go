@@ -76,8 +76,9 @@ func (m *Manager) GetOffer(id types.Hash) (*types.Offer, *types.OfferExtra, erro
 	return offer.offer, offer.extra, nil
 }
 
-// AddOffer adds a new offer to the manager and returns its OffersExtra data
-func (m *Manager) AddOffer(offer *types.Offer, useRelayer bool) (*types.OfferExtra, error) {
+// AddOffer adds a new offer to the manager and returns its OffersExtra data. If
+// the passed extra data is nil, default values are used.
+func (m *Manager) AddOffer(offer *types.Offer, extra *types.OfferExtra) (*types.OfferExtra, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -92,7 +93,9 @@ func (m *Manager) AddOffer(offer *types.Offer, useRelayer bool) (*types.OfferExt
 		return nil, err
 	}
 
-	extra := types.NewOfferExtra(useRelayer)
+	if extra == nil {
+		extra = types.NewOfferExtra(false)
+	}
 
 	m.offers[id] = &offerWithExtra{
 		offer: offer,
@@ -119,6 +119,12 @@ func newSwapStateFromStart(
 		}
 	}
 
+	if offerExtra.XMRPriority != types.PriorityDefault {
+		if err := b.RecoveryDB().PutSwapXMRPriority(offer.ID, offerExtra.XMRPriority); err != nil {
+			return nil, err
+		}
+	}
+
 	moneroStartHeight, err := b.XMRClient().GetHeight()
 	if err != nil {
 		return nil, err
@@ -143,7 +149,9 @@ func newSwapStateFromStart(
 		offer.EthAsset,
 		stage,
 		moneroStartHeight,
+		b.Clock(),
 	)
+	info.ChainID = b.ETHClient().ChainID().Uint64()
 
 	if err = b.SwapManager().AddSwap(info); err != nil {
 		return nil, err
@@ -510,7 +518,7 @@ func (s *swapState) exit() error {
 
 		if s.info.Status != types.CompletedSuccess && s.offer.IsSet() {
 			// re-add offer, as it wasn't taken successfully
-			_, err = s.offerManager.AddOffer(s.offer, s.offerExtra.UseRelayer)
+			_, err = s.offerManager.AddOffer(s.offer, s.offerExtra)
 			if err != nil {
 				log.Warnf("failed to re-add offer %s: %s", s.offer.ID, err)
 			}
@@ -706,11 +714,25 @@ func (s *swapState) lockFunds(amount *coins.PiconeroAmount) error {
 		return fmt.Errorf("failed to set next expected event to EventContractReadyType: %w", err)
 	}
 
-	transfer, err := s.XMRClient().Transfer(s.ctx, swapDestAddr, 0, amount, monero.MinSpendConfirmations)
+	transfer, err := s.XMRClient().Transfer(
+		s.ctx,
+		swapDestAddr,
+		0,
+		amount,
+		monero.MinSpendConfirmations,
+		s.offerExtra.XMRPriority,
+	)
 	if err != nil {
 		return err
 	}
 
+	// the fee is only known once the transfer was sent, so it is written right
+	// away instead of with the next status update
+	s.info.AddMoneroFee(transfer.Fee)
+	if err = s.SwapManager().WriteSwapToDB(s.info); err != nil {
+		return err
+	}
+
 	log.Infof("Successfully locked XMR funds: txID=%s address=%s block=%d",
 		transfer.TxID, swapDestAddr, transfer.Height)
 	return nil
@@ -27,6 +27,16 @@ var (
 	errCounterpartyKeysNotSet  = errors.New("counterparty's keys aren't set")
 	errSwapInstantiationNoLogs = errors.New("expected 1 log, got 0")
 	errSwapCompleted           = errors.New("swap is already completed")
+	errNoRelayersFound         = errors.New("no relayers found to submit refund to")
+	errRefundNotRelayed        = errors.New("failed to relay refund with any relayer")
+	errRefundRelayUnsupported  = errors.New("swap contract does not support relayed refunds")
+
+	// relayed refund receipt errors
+	errRefundedLogInvalidContractAddr = errors.New("log was not emitted by correct contract")
+	errRefundedLogWrongTopicLength    = errors.New("log did not have 3 topics")
+	errRefundedLogWrongEvent          = errors.New("log did not have the Refunded event as its first topic")
+	errRefundedLogWrongSwapID         = errors.New("log did not have the correct swap ID as its second topic")
+	errRefundedLogWrongSecret         = errors.New("log did not have the correct secret as its third topic")
 
 	// initiation errors
 	errProtocolAlreadyInProgress = errors.New("protocol already in progress")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/net/message"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
@@ -349,7 +350,7 @@ func (s *swapState) handleEventShouldRefund(event *EventShouldRefund) error {
 	receipt, err := s.refund()
 	if err != nil {
 		// TODO: could this ever happen anymore?
-		if !strings.Contains(err.Error(), revertSwapCompleted) {
+		if !strings.Contains(err.Error(), revertSwapCompleted) && !contracts.IsRevertError(err, "SwapCompleted") {
 			return err
 		}
 
@@ -30,7 +30,7 @@ func lockXMRAndCheckForReadyLog(t *testing.T, s *swapState, xmrAddr *mcrypto.Add
 	amtu64, err := amt.Uint64()
 	require.NoError(t, err)
 	// lock xmr
-	transfer, err := backend.XMRClient().Transfer(s.ctx, xmrAddr, 0, amt, monero.MinSpendConfirmations)
+	transfer, err := backend.XMRClient().Transfer(s.ctx, xmrAddr, 0, amt, monero.MinSpendConfirmations, types.PriorityDefault)
 	require.NoError(t, err)
 	require.Equal(t, transfer.Amount, amtu64)
 	t.Logf("Transferred %d pico XMR (fees %d) to account %s", transfer.Amount, transfer.Fee, xmrAddr)
@@ -158,7 +158,12 @@ func (inst *Instance) abortOngoingSwap(s *swap.Info) error {
 func (inst *Instance) refundOrCancelNewSwap(s *swap.Info, txHash ethcommon.Hash) error {
 	log.Infof("found ongoing swap %s with status %s in DB, checking to either refund or cancel", s.OfferID, s.Status)
 
-	cancelled, err := inst.maybeCancelNewSwap(txHash)
+	b, err := inst.backend.ForChain(s.ChainID)
+	if err != nil {
+		return err
+	}
+
+	cancelled, err := maybeCancelNewSwap(b, txHash)
 	if err != nil {
 		return err
 	}
@@ -167,7 +172,7 @@ func (inst *Instance) refundOrCancelNewSwap(s *swap.Info, txHash ethcommon.Hash)
 		return nil
 	}
 
-	receipt, err := block.WaitForReceipt(inst.backend.Ctx(), inst.backend.ETHClient().Raw(), txHash)
+	receipt, err := block.WaitForReceipt(b.Ctx(), b.ETHClient().Raw(), txHash)
 	if err != nil {
 		return fmt.Errorf("failed to get newSwap transaction receipt: %w", err)
 	}
@@ -189,7 +194,7 @@ func (inst *Instance) refundOrCancelNewSwap(s *swap.Info, txHash ethcommon.Hash)
 	}
 
 	// we have a tx hash, so we can assume that the swap is ongoing
-	params, err := getNewSwapParametersFromTx(inst.backend.Ctx(), inst.backend.ETHClient().Raw(), txHash)
+	params, err := getNewSwapParametersFromTx(b.Ctx(), b.ETHClient().Raw(), txHash)
 	if err != nil {
 		return fmt.Errorf("failed to get newSwap parameters from tx %s: %w", txHash, err)
 	}
@@ -207,13 +212,13 @@ func (inst *Instance) refundOrCancelNewSwap(s *swap.Info, txHash ethcommon.Hash)
 	}
 
 	// our secret value
-	secret, err := inst.backend.RecoveryDB().GetSwapPrivateKey(s.OfferID)
+	secret, err := b.RecoveryDB().GetSwapPrivateKey(s.OfferID)
 	if err != nil {
 		return fmt.Errorf("failed to get private key for ongoing swap from db with offer id %s: %w",
 			s.OfferID, err)
 	}
 
-	swapCreator, err := contracts.NewSwapCreator(params.swapCreatorAddr, inst.backend.ETHClient().Raw())
+	swapCreator, err := contracts.NewSwapCreator(params.swapCreatorAddr, b.ETHClient().Raw())
 	if err != nil {
 		return fmt.Errorf("failed to instantiate SwapCreator contract: %w", err)
 	}
@@ -233,18 +238,19 @@ func (inst *Instance) refundOrCancelNewSwap(s *swap.Info, txHash ethcommon.Hash)
 
 	// TODO: check for t1/t2? if between t1 and t2, we need to wait for t2
 
-	txOpts, err := inst.backend.ETHClient().TxOpts(inst.backend.Ctx())
+	txOpts, err := b.ETHClient().TxOpts(b.Ctx())
 	if err != nil {
 		return fmt.Errorf("failed to get tx opts: %w", err)
 	}
 
-	refundTx, err := swapCreator.Refund(txOpts, swap, [32]byte(common.Reverse(secret.Bytes())))
+	refundTx, err := contracts.RefundWithPayout(b.Ctx(), b.ETHClient().Raw(), params.swapCreatorAddr, txOpts,
+		&swap, [32]byte(common.Reverse(secret.Bytes())), b.PayoutAddress(), b.PayoutFallback())
 	if err != nil {
 		return fmt.Errorf("failed to create refund tx: %w", err)
 	}
 
 	log.Infof("submit refund tx %s for swap %s", refundTx.Hash(), s.OfferID)
-	receipt, err = block.WaitForReceipt(inst.backend.Ctx(), inst.backend.ETHClient().Raw(), refundTx.Hash())
+	receipt, err = block.WaitForReceipt(b.Ctx(), b.ETHClient().Raw(), refundTx.Hash())
 	if err != nil {
 		return fmt.Errorf("failed to get refund transaction receipt: %w", err)
 	}
@@ -253,11 +259,11 @@ func (inst *Instance) refundOrCancelNewSwap(s *swap.Info, txHash ethcommon.Hash)
 
 	// set status to refunded
 	s.Status = types.CompletedRefund
-	return inst.backend.SwapManager().CompleteOngoingSwap(s)
+	return b.SwapManager().CompleteOngoingSwap(s)
 }
 
-func (inst *Instance) maybeCancelNewSwap(txHash ethcommon.Hash) (bool, error) {
-	tx, isPending, err := inst.backend.ETHClient().Raw().TransactionByHash(inst.backend.Ctx(), txHash)
+func maybeCancelNewSwap(b backend.Backend, txHash ethcommon.Hash) (bool, error) {
+	tx, isPending, err := b.ETHClient().Raw().TransactionByHash(b.Ctx(), txHash)
 	if err != nil {
 		return false, fmt.Errorf("failed to get newSwap transaction: %w", err)
 	}
@@ -271,7 +277,7 @@ func (inst *Instance) maybeCancelNewSwap(txHash ethcommon.Hash) (bool, error) {
 
 	// just double the gas price for now, this is higher than needed for a replacement tx though
 	gasPrice := new(big.Int).Mul(tx.GasPrice(), big.NewInt(2))
-	receipt, err := inst.backend.ETHClient().CancelTxWithNonce(inst.backend.Ctx(), tx.Nonce(), gasPrice)
+	receipt, err := b.ETHClient().CancelTxWithNonce(b.Ctx(), tx.Nonce(), gasPrice)
 	if err != nil && !errors.Is(err, ethereum.NotFound) {
 		return false, fmt.Errorf("failed to get cancel transaction receipt: %w", err)
 	}
@@ -312,10 +318,15 @@ func (inst *Instance) createOngoingSwap(s *swap.Info) error {
 		return err
 	}
 
+	b, err := inst.backend.ForChain(s.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to restart ongoing swap, offer id %s: %w", s.OfferID, err)
+	}
+
 	inst.swapMu.Lock()
 	defer inst.swapMu.Unlock()
 	ss, err := newSwapStateFromOngoing(
-		inst.backend,
+		b,
 		s,
 		inst.noTransferBack,
 		ethSwapInfo,
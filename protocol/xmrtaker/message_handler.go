@@ -11,7 +11,9 @@ import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
 
+	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 	"github.com/athanorlabs/atomic-swap/monero"
@@ -146,32 +148,45 @@ func (s *swapState) checkForXMRLock() {
 	// check that XMR was locked in expected account, and confirm amount
 	lockedAddr, vk := s.expectedXMRLockAccount()
 
-	conf := s.XMRClient().CreateWalletConf("xmrtaker-swap-wallet-verify-funds")
-	abViewCli, err := monero.CreateViewOnlyWalletFromKeys(conf, vk, lockedAddr, s.walletScanHeight)
+	scanner, err := monero.NewOutputScanner(s.XMRClient(), lockedAddr, vk, s.walletScanHeight)
 	if err != nil {
-		log.Errorf("failed to generate view-only wallet to verify locked XMR: %s", err)
+		log.Errorf("failed to create output scanner to verify locked XMR: %s", err)
 		return
 	}
-	defer abViewCli.CloseAndRemoveWallet()
-
-	log.Debugf("generated view-only wallet to check funds: %s", abViewCli.WalletName())
 
 	timer := time.NewTicker(checkForXMRLockInterval)
+	defer timer.Stop()
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
 		case <-timer.C:
-			balance, err := abViewCli.GetBalance(0)
+			outputs, err := scanner.Scan(s.ctx)
 			if err != nil {
-				log.Errorf("failed to get balance: %s", err)
+				log.Errorf("failed to scan for locked XMR: %s", err)
 				continue
 			}
 
-			log.Debugf("checking locked wallet, address=%s balance=%d blocks-to-unlock=%d",
-				lockedAddr, balance.Balance, balance.BlocksToUnlock)
+			// Only outputs that the XMR maker can no longer double spend count
+			// towards the locked amount.
+			var unlockedBalance uint64
+			for _, out := range outputs {
+				log.Debugf("found output in locked wallet, address=%s tx=%s amount=%s XMR confirmations=%d",
+					lockedAddr, out.TxID, out.Amount.AsMoneroString(), out.Confirmations)
+				if out.Confirmations >= monero.MinSpendConfirmations {
+					amount, err := out.Amount.Uint64()
+					if err != nil {
+						log.Errorf("invalid output amount: %s", err)
+						continue
+					}
+					unlockedBalance += amount
+				}
+			}
+
+			log.Debugf("checked locked wallet up to height %d, address=%s unlocked-balance=%s XMR",
+				scanner.ScanHeight(), lockedAddr, coins.FmtPiconeroAsXMR(unlockedBalance))
 
-			if s.expectedPiconeroAmount().CmpU64(balance.UnlockedBalance) <= 0 {
+			if s.expectedPiconeroAmount().CmpU64(unlockedBalance) <= 0 {
 				event := newEventXMRLocked()
 				s.eventCh <- event
 				err := <-event.errCh
@@ -188,7 +203,7 @@ func (s *swapState) checkForXMRLock() {
 func (s *swapState) runT1ExpirationHandler() {
 	defer log.Debugf("returning from runT1ExpirationHandler")
 
-	if time.Until(s.t1) <= 0 {
+	if clock.Until(s.Clock(), s.t1) <= 0 {
 		log.Debugf("T1 already passed, not starting T1 expiration handler")
 		return
 	}
@@ -203,8 +218,8 @@ func (s *swapState) runT1ExpirationHandler() {
 	// research is needed.
 	t1Delta := s.t2.Sub(s.t1) // time between swap start and T1 is equal to T2-T1
 	deltaBeforeT1ToGiveUp := time.Duration(float64(t1Delta) * 0.15)
-	deltaUntilGiveUp := time.Until(s.t1) - deltaBeforeT1ToGiveUp
-	giveUpAndRefundTimer := time.NewTimer(deltaUntilGiveUp)
+	deltaUntilGiveUp := clock.Until(s.Clock(), s.t1) - deltaBeforeT1ToGiveUp
+	giveUpAndRefundTimer := s.Clock().NewTimer(deltaUntilGiveUp)
 	defer giveUpAndRefundTimer.Stop() // don't wait for the timeout to garbage collect
 	log.Debugf("time until refund: %vs", deltaUntilGiveUp.Seconds())
 
@@ -213,7 +228,7 @@ func (s *swapState) runT1ExpirationHandler() {
 		return
 	case <-s.xmrLockedCh:
 		return
-	case <-giveUpAndRefundTimer.C:
+	case <-giveUpAndRefundTimer.C():
 		log.Infof("approaching T1, attempting to refund ETH")
 		event := newEventShouldRefund()
 		s.eventCh <- event
@@ -246,7 +261,7 @@ func (s *swapState) handleNotifyXMRLock() error {
 func (s *swapState) runT2ExpirationHandler() {
 	log.Debugf("time until t2 (%s): %vs",
 		s.t2.Format(common.TimeFmtSecs),
-		time.Until(s.t2).Seconds(),
+		clock.Until(s.Clock(), s.t2).Seconds(),
 	)
 
 	defer log.Debugf("returning from runT2ExpirationHandler")
@@ -256,7 +271,7 @@ func (s *swapState) runT2ExpirationHandler() {
 
 	waitCh := make(chan error)
 	go func() {
-		waitCh <- s.ETHClient().WaitForTimestamp(waitCtx, s.t2)
+		waitCh <- s.WaitForTimestamp(waitCtx, s.t2)
 		close(waitCh)
 	}()
 
@@ -4,6 +4,8 @@
 package xmrtaker
 
 import (
+	"fmt"
+
 	"github.com/cockroachdb/apd/v3"
 	"github.com/libp2p/go-libp2p/core/peer"
 
@@ -12,6 +14,8 @@ import (
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
 	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/protocol/backend"
+	"github.com/athanorlabs/atomic-swap/relayer"
 )
 
 // Provides returns types.ProvidesETH
@@ -26,18 +30,30 @@ func (inst *Instance) InitiateProtocol(
 	providesAmount *apd.Decimal,
 	offer *types.Offer,
 ) (common.SwapState, error) {
+	b, err := inst.backend.ForChain(offer.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = b.TokenPolicy().Check(offer.EthAsset); err != nil {
+		return nil, err
+	}
+
+	if err = checkOfferRelayerFee(b, offer); err != nil {
+		return nil, err
+	}
+
 	maxDecimals := uint8(coins.NumEtherDecimals)
 	var token *coins.ERC20TokenInfo
 	if offer.EthAsset.IsToken() {
-		var err error
-		token, err = inst.backend.ETHClient().ERC20Info(inst.backend.Ctx(), offer.EthAsset.Address())
+		token, err = b.ETHClient().ERC20Info(b.Ctx(), offer.EthAsset.Address())
 		if err != nil {
 			return nil, err
 		}
 		maxDecimals = token.NumDecimals
 	}
 
-	err := coins.ValidatePositive("providesAmount", maxDecimals, providesAmount)
+	err = coins.ValidatePositive("providesAmount", maxDecimals, providesAmount)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +84,8 @@ func (inst *Instance) InitiateProtocol(
 	}
 
 	err = validateMinBalance(
-		inst.backend.Ctx(),
-		inst.backend.ETHClient(),
+		b.Ctx(),
+		b.ETHClient(),
 		providesAmount,
 		offer.EthAsset,
 	)
@@ -77,7 +93,22 @@ func (inst *Instance) InitiateProtocol(
 		return nil, err
 	}
 
-	state, err := inst.initiate(makerPeerID, providedAssetAmount, offer.ExchangeRate, offer.EthAsset, offer.ID)
+	// Catch tokens that cannot be transferred to the swap contract, or that
+	// take a fee on transfer, before we lock anything. Tokens that rebase are
+	// caught by the maker after we lock, and we refund in that case.
+	if offer.EthAsset.IsToken() {
+		err = b.ETHClient().SimulateERC20Transfer(
+			b.Ctx(),
+			offer.EthAsset.Address(),
+			b.SwapCreatorAddr(),
+			providedAssetAmount.BigInt(),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := inst.initiate(b, makerPeerID, providedAssetAmount, offer.ExchangeRate, offer.EthAsset, offer.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +117,7 @@ func (inst *Instance) InitiateProtocol(
 }
 
 func (inst *Instance) initiate(
+	b backend.Backend,
 	makerPeerID peer.ID,
 	providesAmount coins.EthAssetAmount,
 	exchangeRate *coins.ExchangeRate,
@@ -100,7 +132,7 @@ func (inst *Instance) initiate(
 	}
 
 	s, err := newSwapStateFromStart(
-		inst.backend,
+		b,
 		makerPeerID,
 		offerID,
 		inst.noTransferBack,
@@ -124,3 +156,35 @@ func (inst *Instance) initiate(
 	inst.swapStates[offerID] = s
 	return s, nil
 }
+
+// CheckOfferMinAmount checks that the offer's min amount is greater than the
+// current relayer fee of its swap. Makers check this when making an offer, but
+// relayer fees follow the gas price, so offers of other nodes are checked again
+// with a fresh estimate. Offers on chains that we don't swap on are not checked.
+func (inst *Instance) CheckOfferMinAmount(offer *types.Offer) error {
+	b, err := inst.backend.ForChain(offer.ChainID)
+	if err != nil {
+		return nil
+	}
+
+	return checkOfferRelayerFee(b, offer)
+}
+
+// checkOfferRelayerFee checks that the offer's min amount is greater than the
+// relayer fee that we would quote as a relayer. Makers of token offers that
+// don't use a relayer can still claim the swap when the relayer fee of the
+// token can't be priced, so only a warning is logged in that case.
+func checkOfferRelayerFee(b backend.Backend, offer *types.Offer) error {
+	fee, err := relayer.EstimateFee(b.Ctx(), b.ETHClient(), offer.EthAsset)
+	if err != nil {
+		if offer.EthAsset.IsETH() {
+			return fmt.Errorf("cannot estimate relayer fee of %s: %w", offer.EthAsset, err)
+		}
+
+		log.Warnf("Not checking the min amount of offer %s, the relayer fee of %s can't be priced: %s",
+			offer.ID, offer.EthAsset, err)
+		return nil
+	}
+
+	return offer.CheckMinAmount(fee)
+}
@@ -0,0 +1,300 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package xmrtaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/block"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	pcommon "github.com/athanorlabs/atomic-swap/protocol"
+	"github.com/athanorlabs/atomic-swap/relayer"
+)
+
+// canRefundWithRelay returns true if the refund can be relayed. Relayed
+// refunds are signed by the swap owner, so the swap must be owned by the
+// account of our ETH client's private key.
+func (s *swapState) canRefundWithRelay() bool {
+	return s.ETHClient().HasPrivateKey() && s.contractSwap.Owner == s.ETHClient().Address()
+}
+
+// checkForMinRefundBalance check if we have enough balance to call refund.
+// return true if we do, false otherwise.
+func checkForMinRefundBalance(ctx context.Context, ec extethclient.EthClient) (bool, error) {
+	// the gas cost of a refund is about the same as a claim, add a bit of
+	// leeway to allow for sudden gas price spikes
+	const refundGas = 50000
+
+	balance, err := ec.Balance(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if balance.Decimal().IsZero() {
+		return false, nil
+	}
+
+	gasPrice, err := ec.SuggestGasPrice(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	txCost := new(big.Int).Mul(gasPrice, big.NewInt(refundGas))
+	if balance.BigInt().Cmp(txCost) < 0 {
+		log.Infof("balance %s ETH is under the minimum %s ETH to call refund, using a relayer",
+			balance.AsEtherString(),
+			coins.FmtWeiAsETH(txCost),
+		)
+		return false, nil
+	}
+
+	log.Debugf("balance %s ETH is above the minimum %s ETH to call refund",
+		balance.AsEtherString(),
+		coins.FmtWeiAsETH(txCost),
+	)
+	return true, nil
+}
+
+// relayerCandidate is a relayer with its quote for refunding our swap
+type relayerCandidate struct {
+	peerID      peer.ID
+	relayerHash types.Hash
+	fee         coins.EthAssetAmount
+}
+
+// queryRelayer gets the relayer's fee quote for refunding our swap. It returns
+// an error if the fee is not less than the swap value.
+func (s *swapState) queryRelayer(relayerID peer.ID) (*relayerCandidate, error) {
+	resp, err := s.Backend.QueryRelayer(relayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	asset := types.EthAsset(s.contractSwap.Asset)
+	quote := &relayer.FeeQuote{ETHFee: resp.ETHFee, TokenFee: resp.TokenFee}
+	fee, err := relayer.FeeForAsset(s.ctx, s.ETHClient().Raw(), asset, quote.FeeWei(asset))
+	if err != nil {
+		return nil, err
+	}
+
+	if fee.BigInt().Cmp(s.contractSwap.Value) >= 0 {
+		return nil, fmt.Errorf("relayer fee of %s %s is not less than the swap value",
+			fee.AsStdString(), fee.StdSymbol())
+	}
+
+	return &relayerCandidate{
+		peerID:      relayerID,
+		relayerHash: types.Hash(resp.AddressHash),
+		fee:         fee,
+	}, nil
+}
+
+// refundWithRelayer relays the refund to the relayer at its quoted fee and
+// waits for the relayer's refund transaction.
+func (s *swapState) refundWithRelayer(candidate *relayerCandidate) (*ethtypes.Receipt, error) {
+	relaySwap := &contracts.SwapCreatorRelaySwap{
+		Swap:        *s.contractSwap,
+		SwapCreator: s.SwapCreatorAddr(),
+		Fee:         candidate.fee.BigInt(),
+		RelayerHash: candidate.relayerHash,
+	}
+
+	resp, err := s.Backend.SubmitRefundToRelayer(candidate.peerID, relaySwap, s.getSecret())
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := waitForRefundRelayerReceipt(
+		s.ctx,
+		s.ETHClient().Raw(),
+		resp.TxHash,
+		s.SwapCreatorAddr(),
+		s.contractSwapID,
+		s.getSecret(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of relayer's tx=%s: %w", resp.TxHash.Hex(), err)
+	}
+
+	return receipt, nil
+}
+
+// refundWithRelay gets the fee quotes of the nodes that advertise themselves
+// as relayers in the DHT and relays the refund to them, cheapest first, until
+// the refund succeeds or all relayers have been tried. Our swap counterparty
+// is skipped, as they have no reason to help us refund. Note that the receipt
+// returned is for a transaction created by the remote relayer, not by us.
+// Relayed refunds pay our own address, so they are refused if a payout address
+// is set without the fallback.
+func (s *swapState) refundWithRelay() (*ethtypes.Receipt, error) {
+	if err := pcommon.CheckRelayedPayout(s.Backend); err != nil {
+		return nil, err
+	}
+
+	// The first release of the swap contract, which is still deployed at the
+	// default addresses, has no refundRelayer method
+	version, err := contracts.GetSwapCreatorVersion(s.ctx, s.ETHClient().Raw(), s.SwapCreatorAddr())
+	if err != nil {
+		return nil, err
+	}
+	if version < contracts.SwapCreatorV2 {
+		return nil, fmt.Errorf("%w: %s is a %s contract", errRefundRelayUnsupported, s.SwapCreatorAddr(), version)
+	}
+
+	relayers, err := s.Backend.DiscoverRelayers()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*relayerCandidate
+	for _, relayerPeerID := range relayers {
+		if relayerPeerID == s.info.PeerID {
+			log.Debugf("skipping DHT-advertised relayer that is our swap counterparty")
+			continue
+		}
+
+		candidate, err := s.queryRelayer(relayerPeerID) //nolint:govet
+		if err != nil {
+			log.Debugf("skipping relayer with peer ID %s: %s", relayerPeerID, err)
+			continue
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	if len(candidates) == 0 {
+		return nil, errNoRelayersFound
+	}
+	log.Debugf("Found %d relayers to submit refund to", len(candidates))
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].fee.BigInt().Cmp(candidates[j].fee.BigInt()) < 0
+	})
+
+	for _, candidate := range candidates {
+		log.Debugf("submitting refund to relayer with peer ID %s and fee %s %s",
+			candidate.peerID, candidate.fee.AsStdString(), candidate.fee.StdSymbol())
+		receipt, err := s.refundWithRelayer(candidate)
+		if err != nil {
+			log.Warnf("failed to relay refund with relayer %s: %s", candidate.peerID, err)
+			continue
+		}
+
+		log.Infof("relayer's refund included and validated %s", common.ReceiptInfo(receipt))
+		log.Infof("paid relayer fee of %s %s", candidate.fee.AsStdString(), candidate.fee.StdSymbol())
+
+		// Save the relayer fee to the database
+		s.info.SetRelayerFee(candidate.fee.AsStd())
+		if err = s.SwapManager().WriteSwapToDB(s.info); err != nil {
+			return nil, err
+		}
+
+		return receipt, nil
+	}
+
+	return nil, errRefundNotRelayed
+}
+
+func waitForRefundRelayerReceipt(
+	ctx context.Context,
+	ec *ethclient.Client,
+	txHash ethcommon.Hash,
+	contractAddr ethcommon.Address,
+	contractSwapID [32]byte,
+	secret [32]byte,
+) (*ethtypes.Receipt, error) {
+	const (
+		checkInterval = 1500 * time.Millisecond // 1.5 seconds between poll attempts
+		maxPolls      = 10                      // We'll wait up to 15 seconds
+	)
+
+	// The relayer can see the transaction as included in a block and send us
+	// the hash before our end sees it included in a block.
+	for i := 0; i < maxPolls; i++ {
+		receipt, err := ec.TransactionReceipt(ctx, txHash)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		// If err is still set, the error was ethereum.NotFound, which is returned
+		// by TransactionReceipt even if our endpoint sees the TX as pending.
+		if err != nil {
+			if err = common.SleepWithContext(ctx, checkInterval); err != nil {
+				return nil, err // context expired
+			}
+			continue
+		}
+
+		// no error, return the receipt if validation passes
+		return validateRefundRelayerReceipt(ctx, ec, receipt, contractAddr, contractSwapID, secret)
+	}
+
+	// if we made it here, we exceeded maxPolls of the error ethereum.NotFound
+	return nil, ethereum.NotFound
+}
+
+func validateRefundRelayerReceipt(
+	ctx context.Context,
+	ec *ethclient.Client,
+	receipt *ethtypes.Receipt,
+	contractAddr ethcommon.Address,
+	contractSwapID [32]byte,
+	secret [32]byte,
+) (*ethtypes.Receipt, error) {
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		err := fmt.Errorf("relayer's refund transaction failed (gas-lost=%d tx=%s block=%d), %w",
+			receipt.GasUsed, receipt.TxHash, receipt.BlockNumber, block.ErrorFromBlock(ctx, ec, receipt))
+		return nil, err
+	}
+
+	if len(receipt.Logs) == 0 {
+		return nil, fmt.Errorf("relayer's refund transaction had no logs (tx=%s block=%d)",
+			receipt.TxHash, receipt.BlockNumber)
+	}
+
+	if err := checkRefundedLog(receipt.Logs[0], contractAddr, contractSwapID, secret); err != nil {
+		return nil, fmt.Errorf("relayer's refund had logs error (tx=%s block=%d): %w",
+			receipt.TxHash, receipt.BlockNumber, err)
+	}
+
+	return receipt, nil
+}
+
+func checkRefundedLog(log *ethtypes.Log, contractAddr ethcommon.Address, contractSwapID, secret [32]byte) error {
+	if log.Address != contractAddr {
+		return errRefundedLogInvalidContractAddr
+	}
+
+	if len(log.Topics) != 3 {
+		return errRefundedLogWrongTopicLength
+	}
+
+	if log.Topics[0] != refundedTopic {
+		return errRefundedLogWrongEvent
+	}
+
+	if log.Topics[1] != contractSwapID {
+		return errRefundedLogWrongSwapID
+	}
+
+	if log.Topics[2] != secret {
+		return errRefundedLogWrongSecret
+	}
+
+	return nil
+}
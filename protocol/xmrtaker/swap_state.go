@@ -39,7 +39,10 @@ import (
 
 const revertSwapCompleted = "swap is already completed"
 
-var claimedTopic = common.GetTopic(contracts.ClaimedEventSignature)
+var (
+	claimedTopic  = common.GetTopic(contracts.ClaimedEventSignature)
+	refundedTopic = common.GetTopic(contracts.RefundedEventSignature)
+)
 
 // swapState is an instance of a swap. it holds the info needed for the swap,
 // and its current state.
@@ -135,7 +138,10 @@ func newSwapStateFromStart(
 		ethAsset,
 		stage,
 		moneroStartNumber,
+		b.Clock(),
 	)
+	info.ChainID = b.ETHClient().ChainID().Uint64()
+
 	if err = b.SwapManager().AddSwap(info); err != nil {
 		return nil, err
 	}
@@ -416,7 +422,8 @@ func (s *swapState) exit() error {
 		// we should also refund in this case, since we might be past t2.
 		receipt, err := s.tryRefund()
 		if err != nil {
-			if errors.Is(err, errRefundSwapCompleted) || strings.Contains(err.Error(), revertSwapCompleted) {
+			if errors.Is(err, errRefundSwapCompleted) || strings.Contains(err.Error(), revertSwapCompleted) ||
+				contracts.IsRevertError(err, "SwapCompleted") {
 				log.Infof("swap was already completed")
 
 				err = s.tryClaim()
@@ -510,7 +517,7 @@ func (s *swapState) tryRefund() (*ethtypes.Receipt, error) {
 
 	waitCh := make(chan error)
 	go func() {
-		waitCh <- s.ETHClient().WaitForTimestamp(waitCtx, s.t2)
+		waitCh <- s.WaitForTimestamp(waitCtx, s.t2)
 		close(waitCh)
 	}()
 
@@ -641,8 +648,7 @@ func (s *swapState) lockAsset() (*ethtypes.Receipt, error) {
 	s.fundsLocked = true
 	s.setTimeouts(t1, t2)
 
-	s.contractSwap = &contracts.SwapCreatorSwap{
-		Owner:            s.ETHClient().Address(),
+	contractSwap := &contracts.SwapCreatorSwap{
 		Claimer:          s.xmrmakerAddress,
 		ClaimCommitment:  cmtXMRMaker,
 		RefundCommitment: cmtXMRTaker,
@@ -652,6 +658,11 @@ func (s *swapState) lockAsset() (*ethtypes.Receipt, error) {
 		Value:            s.providedAmount.BigInt(),
 		Nonce:            nonce,
 	}
+	contractSwap.Owner, err = s.swapOwner(receipt, contractSwap)
+	if err != nil {
+		return nil, err
+	}
+	s.contractSwap = contractSwap
 
 	ethInfo := &db.EthereumSwapInfo{
 		StartNumber:     receipt.BlockNumber,
@@ -668,6 +679,33 @@ func (s *swapState) lockAsset() (*ethtypes.Receipt, error) {
 	return receipt, nil
 }
 
+// swapOwner returns the owner of the swap created by the newSwap receipt. It is
+// our address, unless the swap was created with an external signer, which can
+// be a contract wallet.
+func (s *swapState) swapOwner(
+	receipt *ethtypes.Receipt,
+	swap *contracts.SwapCreatorSwap,
+) (ethcommon.Address, error) {
+	candidates := []ethcommon.Address{s.ETHClient().Address()}
+
+	if _, ok := s.sender.(*txsender.ExternalSender); ok {
+		tx, _, err := s.ETHClient().Raw().TransactionByHash(s.ctx, receipt.TxHash)
+		if err != nil {
+			return ethcommon.Address{}, fmt.Errorf("failed to get newSwap transaction: %w", err)
+		}
+
+		from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return ethcommon.Address{}, fmt.Errorf("failed to get newSwap transaction sender: %w", err)
+		}
+
+		candidates = append(candidates, from)
+		candidates = append(candidates, contracts.SwapOwnerCandidates(receipt)...)
+	}
+
+	return contracts.FindSwapOwner(swap, s.contractSwapID, candidates...)
+}
+
 func (s *swapState) lockAndWaitForReceipt(
 	cmtXMRMaker, cmtXMRTaker [32]byte,
 	nonce *big.Int,
@@ -695,6 +733,7 @@ func (s *swapState) lockAndWaitForReceipt(
 		s.providedAmount,
 		saveNewSwapTxCallback,
 	)
+	pcommon.RecordTxError(s.info, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to instantiate swap on-chain: %w", err)
 	}
@@ -732,8 +771,11 @@ func (s *swapState) setReady() error {
 	}
 
 	receipt, err := s.sender.SetReady(s.contractSwap)
+	pcommon.RecordTxError(s.info, err)
 	if err != nil {
-		if strings.Contains(err.Error(), revertSwapCompleted) && !s.info.Status.IsOngoing() {
+		swapCompleted := strings.Contains(err.Error(), revertSwapCompleted) ||
+			contracts.IsRevertError(err, "SwapNotPending")
+		if swapCompleted && !s.info.Status.IsOngoing() {
 			return nil
 		}
 		return err
@@ -747,15 +789,50 @@ func (s *swapState) setReady() error {
 // refund calls the Refund() method in the Swap contract, revealing XMRTaker's secret
 // and returns to her the ether in the contract.
 // If time t_1 passes and Claim() has not been called, XMRTaker should call Refund().
+// If XMRTaker does not have enough ETH to pay for the gas, the refund is relayed.
 func (s *swapState) refund() (*ethtypes.Receipt, error) {
 	sc := s.getSecret()
 
-	log.Infof("attempting to call Refund()...")
-	receipt, err := s.sender.Refund(s.contractSwap, sc)
-	if err != nil {
-		return nil, err
+	canRelay := s.canRefundWithRelay()
+	hasBalanceToRefund := true
+	if canRelay {
+		var err error
+		hasBalanceToRefund, err = checkForMinRefundBalance(s.ctx, s.ETHClient())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		receipt *ethtypes.Receipt
+		err     error
+	)
+
+	if !hasBalanceToRefund {
+		receipt, err = s.refundWithRelay()
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund using relayers: %w", err)
+		}
+		log.Infof("refund transaction was relayed: %s", common.ReceiptInfo(receipt))
+	} else {
+		log.Infof("attempting to call Refund()...")
+		receipt, err = s.sender.Refund(s.contractSwap, sc)
+		pcommon.RecordTxError(s.info, err)
+		if err != nil {
+			if !canRelay || !strings.Contains(err.Error(), "insufficient funds for gas * price + value") {
+				return nil, err
+			}
+
+			// if we get this error, we need to use a relayer
+			receipt, err = s.refundWithRelay()
+			if err != nil {
+				return nil, fmt.Errorf("failed to refund using relayers: %w", err)
+			}
+			log.Infof("refund transaction was relayed: %s", common.ReceiptInfo(receipt))
+		} else {
+			log.Infof("refund succeeded %s", common.ReceiptInfo(receipt))
+		}
 	}
-	log.Infof("refund succeeded %s", common.ReceiptInfo(receipt))
 
 	s.clearNextExpectedEvent(types.CompletedRefund)
 	return receipt, nil
@@ -21,6 +21,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
@@ -69,11 +70,18 @@ func (*mockNet) SubmitRelayRequest(_ peer.ID, _ *message.RelayClaimRequest) (*me
 	return new(message.RelayClaimResponse), nil
 }
 
+func (*mockNet) SubmitRelayRefundRequest(
+	_ peer.ID,
+	_ *message.RelayRefundRequest,
+) (*message.RelayClaimResponse, error) {
+	return new(message.RelayClaimResponse), nil
+}
+
 func (*mockNet) CloseProtocolStream(_ types.Hash) {}
 func (*mockNet) DeleteOngoingSwap(_ types.Hash)   {}
 
-func (*mockNet) QueryRelayerAddress(_ peer.ID) (types.Hash, error) {
-	return types.Hash{99}, nil
+func (*mockNet) QueryRelayer(_ peer.ID) (*message.RelayerQueryResponse, error) {
+	return nil, errors.New("not implemented")
 }
 
 func newSwapManager(t *testing.T) pswap.Manager {
@@ -83,7 +91,7 @@ func newSwapManager(t *testing.T) pswap.Manager {
 	db.EXPECT().GetAllSwaps()
 	db.EXPECT().PutSwap(gomock.Any()).AnyTimes()
 
-	sm, err := pswap.NewManager(db)
+	sm, err := pswap.NewManager(db, clock.Local())
 	require.NoError(t, err)
 	return sm
 }
@@ -281,7 +289,7 @@ func lockXMRFunds(
 	amount *coins.PiconeroAmount,
 ) {
 	monero.MineMinXMRBalance(t, wc, amount)
-	_, err := wc.Transfer(ctx, destAddr, 0, amount, monero.MinSpendConfirmations)
+	_, err := wc.Transfer(ctx, destAddr, 0, amount, monero.MinSpendConfirmations, types.PriorityDefault)
 	require.NoError(t, err)
 }
 
@@ -8,9 +8,11 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	logging "github.com/ipfs/go-log/v2"
 
+	"github.com/athanorlabs/atomic-swap/common/types"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/net/message"
 )
@@ -24,10 +26,7 @@ func CreateRelayClaimRequest(
 	relaySwap *contracts.SwapCreatorRelaySwap,
 	secret [32]byte,
 ) (*message.RelayClaimRequest, error) {
-	signature, err := createRelayClaimSignature(
-		claimerEthKey,
-		relaySwap,
-	)
+	signature, err := createRelaySignature(claimerEthKey, relaySwap.Hash(), relaySwap.Swap.Claimer, "claimer")
 	if err != nil {
 		return nil, err
 	}
@@ -40,17 +39,40 @@ func CreateRelayClaimRequest(
 	}, nil
 }
 
-func createRelayClaimSignature(
-	claimerEthKey *ecdsa.PrivateKey,
+// CreateRelayRefundRequest fills and returns a RelayRefundRequest ready for
+// submission to a relayer.
+func CreateRelayRefundRequest(
+	ownerEthKey *ecdsa.PrivateKey,
 	relaySwap *contracts.SwapCreatorRelaySwap,
+	secret [32]byte,
+) (*message.RelayRefundRequest, error) {
+	signature, err := createRelaySignature(ownerEthKey, relaySwap.RefundHash(), relaySwap.Swap.Owner, "owner")
+	if err != nil {
+		return nil, err
+	}
+
+	return &message.RelayRefundRequest{
+		RelaySwap: relaySwap,
+		Secret:    secret[:],
+		Signature: signature,
+	}, nil
+}
+
+// createRelaySignature signs the digest of a relay swap with the key of the
+// swap party with the passed role and address
+func createRelaySignature(
+	ethKey *ecdsa.PrivateKey,
+	digest types.Hash,
+	expectedSigner ethcommon.Address,
+	role string,
 ) ([]byte, error) {
-	signerAddress := ethcrypto.PubkeyToAddress(claimerEthKey.PublicKey)
-	if relaySwap.Swap.Claimer != signerAddress {
-		return nil, fmt.Errorf("signing key %s does not match claimer %s", signerAddress, relaySwap.Swap.Claimer)
+	signerAddress := ethcrypto.PubkeyToAddress(ethKey.PublicKey)
+	if expectedSigner != signerAddress {
+		return nil, fmt.Errorf("signing key %s does not match %s %s", signerAddress, role, expectedSigner)
 	}
 
 	// signature format is (r || s || v), v = 27/28
-	signature, err := Sign(claimerEthKey, relaySwap.Hash())
+	signature, err := Sign(ethKey, digest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign relay request: %w", err)
 	}
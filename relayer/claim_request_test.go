@@ -56,3 +56,34 @@ func TestCreateRelayClaimRequest(t *testing.T) {
 	_, err = CreateRelayClaimRequest(ethKey, relaySwap, secret)
 	require.ErrorContains(t, err, "does not match claimer")
 }
+
+func TestCreateRelayRefundRequest(t *testing.T) {
+	ethKey := tests.GetTakerTestKey(t)
+	owner := crypto.PubkeyToAddress(*ethKey.Public().(*ecdsa.PublicKey))
+	secret := [32]byte{0x1}
+
+	// success path
+	swap := createTestSwap(ethcommon.Address{0x2})
+	swap.Owner = owner
+	relaySwap := &contracts.SwapCreatorRelaySwap{
+		Swap:        *swap,
+		Fee:         big.NewInt(1),
+		SwapCreator: ethcommon.Address{0x3},
+		RelayerHash: types.Hash{},
+	}
+	req, err := CreateRelayRefundRequest(ethKey, relaySwap, secret)
+	require.NoError(t, err)
+	require.NoError(t, validateRelaySignature(req.RelaySwap.RefundHash(), req.Signature, owner, "owner"))
+
+	// a refund request is not a valid claim request signature, even if the
+	// owner is the claimer
+	err = validateRelaySignature(req.RelaySwap.Hash(), req.Signature, owner, "owner")
+	require.ErrorContains(t, err, "signer of message is not swap owner")
+	err = validateRelaySignature(req.RelaySwap.RefundHash(), req.Signature, swap.Claimer, "claimer")
+	require.ErrorContains(t, err, "signer of message is not swap claimer")
+
+	// change the ethkey to not match the owner address to trigger the error path
+	ethKey = tests.GetMakerTestKey(t)
+	_, err = CreateRelayRefundRequest(ethKey, relaySwap, secret)
+	require.ErrorContains(t, err, "does not match owner")
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/pricefeed"
+)
+
+const (
+	// FeeMarginPercent is the margin, in percent, that relayers add to the
+	// worst case gas cost of a claim when quoting their fees.
+	FeeMarginPercent = 20
+
+	// tokenFeeTolerancePercent is how far, in percent, the fee of a token swap
+	// can be below the fee that the relayer calculates. The claimer and
+	// relayer read the price feeds at different times, so their fees can
+	// differ slightly.
+	tokenFeeTolerancePercent = 5
+)
+
+// FeeQuote is a relayer's fees, in wei, for relaying the claims of ETH and
+// ERC20 token swaps. Token swaps pay the value of TokenFee in the token, priced
+// with the USD price feeds of ETH and the token.
+type FeeQuote struct {
+	ETHFee   *big.Int
+	TokenFee *big.Int
+}
+
+// FeeWei returns the quoted fee, in wei, for claiming a swap of the asset.
+func (q *FeeQuote) FeeWei(asset types.EthAsset) *big.Int {
+	if asset.IsToken() {
+		return q.TokenFee
+	}
+	return q.ETHFee
+}
+
+// QuoteFees returns the fees that we charge for relaying claims at the current
+// gas price, which are the worst case gas costs of the claims plus
+// FeeMarginPercent.
+func QuoteFees(ctx context.Context, ec extethclient.EthClient) (*FeeQuote, error) {
+	gasPrice, err := ec.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return quoteFees(gasPrice), nil
+}
+
+func quoteFees(gasPrice *big.Int) *FeeQuote {
+	fee := func(gas int64) *big.Int {
+		f := new(big.Int).Mul(gasPrice, big.NewInt(gas))
+		f.Mul(f, big.NewInt(100+FeeMarginPercent))
+		return f.Div(f, big.NewInt(100))
+	}
+
+	return &FeeQuote{
+		ETHFee:   fee(maxClaimRelayerETHGas),
+		TokenFee: fee(maxClaimRelayerERC20Gas),
+	}
+}
+
+// EstimateFee returns the relayer fee, in the asset, that we would quote for
+// claiming a swap of the asset at the current gas price
+func EstimateFee(ctx context.Context, ec extethclient.EthClient, asset types.EthAsset) (coins.EthAssetAmount, error) {
+	quote, err := QuoteFees(ctx, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	return FeeForAsset(ctx, ec.Raw(), asset, quote.FeeWei(asset))
+}
+
+// FeeForAsset returns the relayer fee for claiming a swap of the asset, given
+// the fee in wei. ETH swaps pay the fee in wei. Token swaps pay the same value
+// in the token, priced with the USD price feeds of ETH and the token.
+func FeeForAsset(
+	ctx context.Context,
+	ec *ethclient.Client,
+	asset types.EthAsset,
+	feeWei *big.Int,
+) (coins.EthAssetAmount, error) {
+	if asset.IsETH() {
+		return coins.NewWeiAmount(feeWei), nil
+	}
+
+	token, err := erc20Info(ctx, ec, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	ethFeed, err := pricefeed.GetETHUSDPrice(ctx, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenFeed, err := pricefeed.GetTokenUSDPrice(ctx, ec, token.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return coins.RelayerFeeTokenAmount(coins.NewWeiAmount(feeWei), ethFeed.Price, tokenFeed.Price, token)
+}
+
+// minAcceptedFee returns the smallest relayer fee that we accept for a swap
+// whose expected fee is the passed value.
+func minAcceptedFee(expectedFee coins.EthAssetAmount) *big.Int {
+	fee := expectedFee.BigInt()
+	if !expectedFee.IsToken() {
+		return fee
+	}
+
+	fee.Mul(fee, big.NewInt(100-tokenFeeTolerancePercent))
+	return fee.Div(fee, big.NewInt(100))
+}
+
+// minFeeValueWei returns the minimum value, in wei, of the fee of a claim that
+// passed validation against our quote. Token fees can be below the value of
+// our quote by the tolerance for price movements.
+func minFeeValueWei(asset types.EthAsset, fee *big.Int, quote *FeeQuote) *big.Int {
+	if asset.IsETH() {
+		return fee
+	}
+
+	value := new(big.Int).Mul(quote.TokenFee, big.NewInt(100-tokenFeeTolerancePercent))
+	return value.Div(value, big.NewInt(100))
+}
+
+// assetAmount returns the amount, in the smallest denomination, as an amount of
+// the same asset as the passed amount.
+func assetAmount(amount *big.Int, like coins.EthAssetAmount) coins.EthAssetAmount {
+	if tokenAmt, ok := like.(*coins.ERC20TokenAmount); ok {
+		return coins.NewERC20TokenAmountFromBigInt(amount, tokenAmt.TokenInfo)
+	}
+	return coins.NewWeiAmount(amount)
+}
+
+func erc20Info(ctx context.Context, ec *ethclient.Client, asset types.EthAsset) (*coins.ERC20TokenInfo, error) {
+	tokenContract, err := contracts.NewIERC20(asset.Address(), ec)
+	if err != nil {
+		return nil, err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	decimals, err := tokenContract.Decimals(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decimals of token %s: %w", asset, err)
+	}
+
+	name, err := tokenContract.Name(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get name of token %s: %w", asset, err)
+	}
+
+	symbol, err := tokenContract.Symbol(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get symbol of token %s: %w", asset, err)
+	}
+
+	return coins.NewERC20TokenInfo(asset.Address(), decimals, name, symbol), nil
+}
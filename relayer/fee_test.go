@@ -0,0 +1,61 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/tests"
+)
+
+func TestQuoteFees(t *testing.T) {
+	quote := quoteFees(big.NewInt(1e9)) // 1 Gwei
+
+	// 100000 gas * 1 Gwei * 1.2
+	require.Equal(t, big.NewInt(120_000e9), quote.ETHFee)
+	require.Equal(t, quote.ETHFee, quote.FeeWei(types.EthAssetETH))
+
+	// 130000 gas * 1 Gwei * 1.2
+	require.Equal(t, big.NewInt(156_000e9), quote.TokenFee)
+	require.Equal(t, quote.TokenFee, quote.FeeWei(types.EthAsset(ethcommon.Address{0x1})))
+}
+
+func TestFeeForAsset(t *testing.T) {
+	ctx := context.Background()
+	ec, _ := tests.NewEthClient(t)
+	token := contracts.GetMockTether(t, ec, tests.GetMakerTestKey(t))
+	feeWei := big.NewInt(1e16)
+
+	fee, err := FeeForAsset(ctx, ec, types.EthAssetETH, feeWei)
+	require.NoError(t, err)
+	require.False(t, fee.IsToken())
+	require.Equal(t, feeWei, fee.BigInt())
+
+	// dev chains price test tokens at $1
+	ethFeedPrice := coins.StrToDecimal("1234.12345678")
+	expected, err := coins.RelayerFeeTokenAmount(coins.NewWeiAmount(feeWei), ethFeedPrice, coins.StrToDecimal("1"), token)
+	require.NoError(t, err)
+
+	fee, err = FeeForAsset(ctx, ec, types.EthAsset(token.Address), feeWei)
+	require.NoError(t, err)
+	require.True(t, fee.IsToken())
+	require.Equal(t, expected.BigInt(), fee.BigInt())
+}
+
+func Test_minAcceptedFee(t *testing.T) {
+	ethFee := coins.NewWeiAmount(big.NewInt(1e16))
+	require.Equal(t, big.NewInt(1e16), minAcceptedFee(ethFee))
+
+	token := coins.NewERC20TokenInfo(ethcommon.Address{0x1}, 6, "Test", "TEST")
+	tokenFee := coins.NewERC20TokenAmountFromBigInt(big.NewInt(20_000_000), token)
+	require.Equal(t, big.NewInt(19_000_000), minAcceptedFee(tokenFee))
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+
+	"github.com/athanorlabs/atomic-swap/common/vjson"
+	"github.com/athanorlabs/atomic-swap/net/message"
+)
+
+const (
+	// HTTPQuotePath is the path of the HTTP endpoint that returns a fee quote
+	HTTPQuotePath = "/quote"
+	// HTTPRelayPath is the path of the HTTP endpoint that relays claims
+	HTTPRelayPath = "/relay"
+
+	// maxHTTPRequestSize is the max size of a relay claim request body, which
+	// is a few times larger than a valid request
+	maxHTTPRequestSize = 1 << 14
+
+	// the request body is at most maxHTTPRequestSize bytes
+	httpReadTimeout = 10 * time.Second
+
+	// relaying a claim waits for the receipt of the claim transaction
+	httpWriteTimeout = 5 * time.Minute
+)
+
+var errHTTPOfferID = errors.New("claims of our swap counterparty can't be submitted over HTTP")
+
+// HTTPServer serves the HTTP endpoints of a relayer, for claimers that do not
+// run a p2p node. Claimers get a fee quote from HTTPQuotePath, then post their
+// signed message.RelayClaimRequest, with the relayer hash and fee of the
+// quote, to HTTPRelayPath. The response is a message.RelayClaimResponse.
+type HTTPServer struct {
+	ctx        context.Context
+	listener   net.Listener
+	httpServer *http.Server
+}
+
+// NewHTTPServer returns a new HTTP server for the relayer, listening on the
+// passed "IP:port" address. Browsers can only submit claims from the allowed
+// origins, "*" allows all origins. If no origins are passed, the server does
+// not send CORS headers.
+func NewHTTPServer(
+	ctx context.Context,
+	addr string,
+	relayer *Relayer,
+	allowedOrigins []string,
+) (*HTTPServer, error) {
+	lc := net.ListenConfig{}
+	ln, err := lc.Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	r := mux.NewRouter()
+	r.Handle(HTTPQuotePath, &quoteHandler{relayer: relayer}).Methods(http.MethodGet)
+	r.Handle(HTTPRelayPath, &relayHandler{relayer: relayer}).Methods(http.MethodPost)
+
+	var handler http.Handler = r
+	if len(allowedOrigins) > 0 {
+		// wallets in browsers can submit claims too
+		headersOk := handlers.AllowedHeaders([]string{"content-type"})
+		methodsOk := handlers.AllowedMethods([]string{"GET", "POST", "OPTIONS"})
+		originsOk := handlers.AllowedOrigins(allowedOrigins)
+		handler = handlers.CORS(headersOk, methodsOk, originsOk)(r)
+	}
+
+	return &HTTPServer{
+		ctx:      ctx,
+		listener: ln,
+		httpServer: &http.Server{
+			Addr:              ln.Addr().String(),
+			ReadHeaderTimeout: time.Second,
+			ReadTimeout:       httpReadTimeout,
+			WriteTimeout:      httpWriteTimeout,
+			Handler:           handler,
+			BaseContext: func(listener net.Listener) context.Context {
+				return ctx
+			},
+		},
+	}, nil
+}
+
+// Addr returns the "IP:port" address that the server listens on
+func (s *HTTPServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Serve serves HTTP requests until the server's context is cancelled
+func (s *HTTPServer) Serve() error {
+	log.Infof("Starting relayer HTTP server on %s", s.Addr())
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- s.httpServer.Serve(s.listener)
+	}()
+
+	select {
+	case <-s.ctx.Done():
+		// the context is already cancelled, so the shutdown is immediate
+		_ = s.httpServer.Shutdown(s.ctx)
+		return s.ctx.Err()
+	case err := <-serverErr:
+		return err
+	}
+}
+
+type quoteHandler struct {
+	relayer *Relayer
+}
+
+func (h *quoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// HTTP requesters are limited by IP address
+	requester, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, "invalid remote address", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.relayer.Quote(requester)
+	if err != nil {
+		log.Warnf("failed to quote fees for HTTP claimer: %s", err)
+		http.Error(w, "failed to quote fees", http.StatusServiceUnavailable)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type relayHandler struct {
+	relayer *Relayer
+}
+
+func (h *relayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.relayClaim(w, r)
+	if err != nil {
+		log.Debugf("rejected HTTP relay claim request from %s: %s", r.RemoteAddr, err)
+		reason := RejectReason(err)
+		writeJSON(w, httpStatus(reason), &message.RelayClaimResponse{RejectReason: reason})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *relayHandler) relayClaim(w http.ResponseWriter, r *http.Request) (*message.RelayClaimResponse, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxHTTPRequestSize))
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(message.RelayClaimRequest)
+	if err = vjson.UnmarshalStruct(body, req); err != nil {
+		return nil, err
+	}
+
+	if req.OfferID != nil {
+		return nil, errHTTPOfferID
+	}
+
+	// HTTP claimers are rate limited by IP address
+	claimer, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.relayer.RelayClaim(claimer, req, true)
+}
+
+// httpStatus returns the HTTP status code of a rejected relay claim request
+func httpStatus(reason message.RelayRejectReason) int {
+	switch reason {
+	case message.RelayRejectRateLimited, message.RelayRejectPeerRateLimited:
+		return http.StatusTooManyRequests
+	case message.RelayRejectUnprofitable:
+		return http.StatusUnprocessableEntity
+	case message.RelayRejectSpendCapReached, message.RelayRejectUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warnf("failed to write HTTP response: %s", err)
+	}
+}
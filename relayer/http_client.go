@@ -0,0 +1,132 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/athanorlabs/atomic-swap/common/vjson"
+	"github.com/athanorlabs/atomic-swap/net/message"
+)
+
+// maxHTTPResponseSize is the max size of a response body that we read from a
+// relayer's HTTP server
+const maxHTTPResponseSize = 1 << 14
+
+// HTTPClient submits relay claim requests to the HTTP server of a relayer.
+type HTTPClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewHTTPClient returns a new client for the relayer HTTP server at the passed
+// endpoint, eg. "http://127.0.0.1:9911".
+func NewHTTPClient(endpoint string) *HTTPClient {
+	return &HTTPClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{
+			Timeout: httpWriteTimeout,
+		},
+	}
+}
+
+// Quote returns the relayer's current fee quote. The relayer hash and fee of
+// the quote are used to create the claim request.
+func (c *HTTPClient) Quote(ctx context.Context) (*message.RelayerQueryResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+HTTPQuotePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	status, body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("relayer returned HTTP status %d for quote", status)
+	}
+
+	resp := new(message.RelayerQueryResponse)
+	if err = vjson.UnmarshalStruct(body, resp); err != nil {
+		return nil, fmt.Errorf("failed to decode relayer quote: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SubmitClaim submits the relay claim request to the relayer and returns the
+// hash of the claim transaction. If the relayer rejected the request, the
+// returned error is a *RejectError with the relayer's reason.
+func (c *HTTPClient) SubmitClaim(
+	ctx context.Context,
+	request *message.RelayClaimRequest,
+) (ethcommon.Hash, error) {
+	if request.OfferID != nil {
+		return ethcommon.Hash{}, errHTTPOfferID
+	}
+
+	data, err := vjson.MarshalStruct(request)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+HTTPRelayPath, bytes.NewReader(data))
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	status, body, err := c.do(req)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	return parseRelayClaimResponse(status, body)
+}
+
+func (c *HTTPClient) do(req *http.Request) (int, []byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to reach relayer: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseSize))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read relayer response: %w", err)
+	}
+
+	return resp.StatusCode, body, nil
+}
+
+func parseRelayClaimResponse(status int, body []byte) (ethcommon.Hash, error) {
+	resp := new(message.RelayClaimResponse)
+	if err := json.Unmarshal(body, resp); err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("relayer returned HTTP status %d with invalid response: %w", status, err)
+	}
+
+	if resp.RejectReason != "" {
+		return ethcommon.Hash{}, reject(resp.RejectReason, fmt.Errorf("relayer returned HTTP status %d", status))
+	}
+
+	if status != http.StatusOK {
+		return ethcommon.Hash{}, fmt.Errorf("relayer returned HTTP status %d", status)
+	}
+
+	if (resp.TxHash == ethcommon.Hash{}) {
+		return ethcommon.Hash{}, errors.New("relay claim response has no transaction hash")
+	}
+
+	return resp.TxHash, nil
+}
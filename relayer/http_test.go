@@ -0,0 +1,140 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/net/message"
+)
+
+func newTestRelaySwap() *contracts.SwapCreatorRelaySwap {
+	return &contracts.SwapCreatorRelaySwap{
+		Swap: *createTestSwap(ethcommon.Address{0x2}),
+		Fee:  big.NewInt(1),
+	}
+}
+
+func postRelayClaim(t *testing.T, body string) (int, *message.RelayClaimResponse) {
+	// requests that are rejected before reaching the relayer need no relayer
+	h := &relayHandler{}
+	req := httptest.NewRequest(http.MethodPost, HTTPRelayPath, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	resp := new(message.RelayClaimResponse)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	return w.Code, resp
+}
+
+func TestRelayHandler_invalidRequest(t *testing.T) {
+	status, resp := postRelayClaim(t, `{"secret":"0x01"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, message.RelayRejectInvalid, resp.RejectReason)
+
+	status, resp = postRelayClaim(t, strings.Repeat("x", maxHTTPRequestSize+1))
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, message.RelayRejectInvalid, resp.RejectReason)
+}
+
+func TestRelayHandler_offerID(t *testing.T) {
+	req := &message.RelayClaimRequest{
+		OfferID:   new(types.Hash),
+		RelaySwap: newTestRelaySwap(),
+		Secret:    make([]byte, 32),
+		Signature: make([]byte, 65),
+	}
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	status, resp := postRelayClaim(t, string(body))
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, message.RelayRejectInvalid, resp.RejectReason)
+}
+
+func TestHTTPStatus(t *testing.T) {
+	require.Equal(t, http.StatusTooManyRequests, httpStatus(message.RelayRejectPeerRateLimited))
+	require.Equal(t, http.StatusUnprocessableEntity, httpStatus(message.RelayRejectUnprofitable))
+	require.Equal(t, http.StatusServiceUnavailable, httpStatus(message.RelayRejectSpendCapReached))
+	require.Equal(t, http.StatusBadRequest, httpStatus(message.RelayRejectInvalid))
+}
+
+func TestHTTPClient_SubmitClaim(t *testing.T) {
+	txHash := ethcommon.Hash{0x1}
+	reason := message.RelayRejectReason("")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, HTTPRelayPath, r.URL.Path)
+		if reason != "" {
+			writeJSON(w, httpStatus(reason), &message.RelayClaimResponse{RejectReason: reason})
+			return
+		}
+		writeJSON(w, http.StatusOK, &message.RelayClaimResponse{TxHash: txHash})
+	}))
+	defer server.Close()
+
+	c := NewHTTPClient(server.URL + "/")
+	req := &message.RelayClaimRequest{
+		RelaySwap: newTestRelaySwap(),
+		Secret:    make([]byte, 32),
+		Signature: make([]byte, 65),
+	}
+
+	hash, err := c.SubmitClaim(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, txHash, hash)
+
+	reason = message.RelayRejectUnprofitable
+	_, err = c.SubmitClaim(context.Background(), req)
+	require.Error(t, err)
+	require.Equal(t, message.RelayRejectUnprofitable, RejectReason(err))
+
+	// requests to our swap counterparty are never sent over HTTP
+	req.OfferID = new(types.Hash)
+	_, err = c.SubmitClaim(context.Background(), req)
+	require.ErrorIs(t, err, errHTTPOfferID)
+}
+
+func TestParseRelayClaimResponse(t *testing.T) {
+	_, err := parseRelayClaimResponse(http.StatusBadGateway, []byte("<html>bad gateway</html>"))
+	require.ErrorContains(t, err, "HTTP status 502 with invalid response")
+
+	_, err = parseRelayClaimResponse(http.StatusInternalServerError, []byte("{}"))
+	require.ErrorContains(t, err, "HTTP status 500")
+
+	_, err = parseRelayClaimResponse(http.StatusOK, []byte("{}"))
+	require.ErrorContains(t, err, "no transaction hash")
+}
+
+func TestHTTPServer_allowedOrigins(t *testing.T) {
+	getAllowedOrigin := func(allowedOrigins []string) string {
+		s, err := NewHTTPServer(context.Background(), "127.0.0.1:0", nil, allowedOrigins)
+		require.NoError(t, err)
+		defer s.listener.Close() //nolint:errcheck
+		require.Equal(t, httpReadTimeout, s.httpServer.ReadTimeout)
+
+		req := httptest.NewRequest(http.MethodOptions, HTTPRelayPath, nil)
+		req.Header.Set("Origin", "https://wallet.example")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w := httptest.NewRecorder()
+		s.httpServer.Handler.ServeHTTP(w, req)
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	require.Empty(t, getAllowedOrigin(nil))
+	require.Empty(t, getAllowedOrigin([]string{"https://other.example"}))
+	require.Equal(t, "https://wallet.example", getAllowedOrigin([]string{"https://wallet.example"}))
+	require.Equal(t, "*", getAllowedOrigin([]string{"*"}))
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/net/message"
+)
+
+const (
+	claimRateWindow  = time.Hour
+	spendLimitWindow = 24 * time.Hour
+)
+
+// Limits are the limits on the claims that we relay for other nodes. Zero
+// values are unlimited.
+type Limits struct {
+	MaxClaimsPerPeer int      // max claim requests per claimer, per hour
+	MaxClaims        int      // max claim requests from all claimers, per hour
+	DailySpendCap    *big.Int // max wei spent on gas per 24 hours
+}
+
+// RejectError is returned when we reject a relay claim request. The reason is
+// sent back to the claimer.
+type RejectError struct {
+	Reason message.RelayRejectReason
+	Err    error
+}
+
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("relay claim rejected (%s): %s", e.Reason, e.Err)
+}
+
+func (e *RejectError) Unwrap() error {
+	return e.Err
+}
+
+func reject(reason message.RelayRejectReason, err error) error {
+	return &RejectError{Reason: reason, Err: err}
+}
+
+// RejectReason returns the reason to send to the claimer for the error that we
+// rejected their claim request with. Errors that are not a RejectError are
+// reported as invalid requests, so that we do not leak the details of errors.
+func RejectReason(err error) message.RelayRejectReason {
+	var rejectErr *RejectError
+	if errors.As(err, &rejectErr) {
+		return rejectErr.Reason
+	}
+	return message.RelayRejectInvalid
+}
+
+// Policy enforces Limits on the claims that we relay for other nodes and
+// checks that the claims are profitable. It is safe for concurrent use.
+type Policy struct {
+	limits Limits
+	now    func() time.Time
+
+	mu     sync.Mutex
+	claims []claimRecord  // valid claim requests within the claim rate window
+	spends []*spendRecord // gas spends within the spend limit window
+}
+
+type claimRecord struct {
+	time    time.Time
+	claimer string
+}
+
+type spendRecord struct {
+	time time.Time
+	wei  *big.Int
+}
+
+// NewPolicy returns a new Policy with the passed limits
+func NewPolicy(limits *Limits) *Policy {
+	return &Policy{limits: *limits, now: time.Now}
+}
+
+// AllowClaim checks the per-claimer and global claim rate limits and, if the
+// claim request is allowed, records it. It is called for validated requests
+// only, so that invalid requests don't use up the claimer's quota. The claimer
+// is the peer ID of p2p claimers or the IP address of HTTP claimers.
+func (p *Policy) AllowClaim(claimer string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.pruneClaims(now)
+
+	if p.limits.MaxClaims > 0 && len(p.claims) >= p.limits.MaxClaims {
+		return reject(message.RelayRejectRateLimited,
+			fmt.Errorf("relayed %d claims in the last %s", len(p.claims), claimRateWindow))
+	}
+
+	if p.limits.MaxClaimsPerPeer > 0 {
+		claimerClaims := 0
+		for _, c := range p.claims {
+			if c.claimer == claimer {
+				claimerClaims++
+			}
+		}
+
+		if claimerClaims >= p.limits.MaxClaimsPerPeer {
+			return reject(message.RelayRejectPeerRateLimited,
+				fmt.Errorf("claimer %s made %d claim requests in the last %s", claimer, claimerClaims, claimRateWindow))
+		}
+	}
+
+	p.claims = append(p.claims, claimRecord{time: now, claimer: claimer})
+	return nil
+}
+
+// reserveSpend checks that the gas cost of a claim is less than the fee, and
+// that spending it would not exceed the daily spend cap. If both checks pass,
+// the cost is recorded as spent until the returned record is settled with the
+// actual cost of the claim.
+func (p *Policy) reserveSpend(gasCost *big.Int, feeWei *big.Int) (*spendRecord, error) {
+	if gasCost.Cmp(feeWei) >= 0 {
+		return nil, reject(message.RelayRejectUnprofitable,
+			fmt.Errorf("gas cost of %s ETH is not less than the fee of %s ETH",
+				coins.FmtWeiAsETH(gasCost), coins.FmtWeiAsETH(feeWei)))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.pruneSpends(now)
+
+	if p.limits.DailySpendCap != nil && p.limits.DailySpendCap.Sign() > 0 {
+		spent := p.spentLocked()
+		spent.Add(spent, gasCost)
+		if spent.Cmp(p.limits.DailySpendCap) > 0 {
+			return nil, reject(message.RelayRejectSpendCapReached,
+				fmt.Errorf("spending %s ETH would exceed the daily spend cap of %s ETH",
+					coins.FmtWeiAsETH(gasCost), coins.FmtWeiAsETH(p.limits.DailySpendCap)))
+		}
+	}
+
+	record := &spendRecord{time: now, wei: new(big.Int).Set(gasCost)}
+	p.spends = append(p.spends, record)
+	return record, nil
+}
+
+// settleSpend replaces the reserved cost of the spend record with the actual
+// cost of the claim, which is zero if its transaction was never sent
+func (p *Policy) settleSpend(record *spendRecord, spent *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	record.wei = new(big.Int).Set(spent)
+}
+
+func (p *Policy) spentLocked() *big.Int {
+	spent := new(big.Int)
+	for _, s := range p.spends {
+		spent.Add(spent, s.wei)
+	}
+	return spent
+}
+
+func (p *Policy) pruneClaims(now time.Time) {
+	i := 0
+	for i < len(p.claims) && now.Sub(p.claims[i].time) >= claimRateWindow {
+		i++
+	}
+	p.claims = p.claims[i:]
+}
+
+func (p *Policy) pruneSpends(now time.Time) {
+	i := 0
+	for i < len(p.spends) && now.Sub(p.spends[i].time) >= spendLimitWindow {
+		i++
+	}
+	p.spends = p.spends[i:]
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/net/message"
+)
+
+func newTestPolicy(limits *Limits) (*Policy, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	p := NewPolicy(limits)
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+func TestPolicy_AllowClaim_perPeer(t *testing.T) {
+	p, now := newTestPolicy(&Limits{MaxClaimsPerPeer: 2})
+	peerA := "a"
+	peerB := "b"
+
+	require.NoError(t, p.AllowClaim(peerA))
+	require.NoError(t, p.AllowClaim(peerA))
+	err := p.AllowClaim(peerA)
+	require.Equal(t, message.RelayRejectPeerRateLimited, RejectReason(err))
+
+	// other peers have their own limit
+	require.NoError(t, p.AllowClaim(peerB))
+
+	// peerA's requests leave the window after an hour
+	*now = now.Add(claimRateWindow)
+	require.NoError(t, p.AllowClaim(peerA))
+}
+
+func TestPolicy_AllowClaim_global(t *testing.T) {
+	p, now := newTestPolicy(&Limits{MaxClaims: 2})
+
+	require.NoError(t, p.AllowClaim("a"))
+	*now = now.Add(30 * time.Minute)
+	require.NoError(t, p.AllowClaim("b"))
+	err := p.AllowClaim("c")
+	require.Equal(t, message.RelayRejectRateLimited, RejectReason(err))
+
+	// only the first request has left the window
+	*now = now.Add(30 * time.Minute)
+	require.NoError(t, p.AllowClaim("c"))
+	err = p.AllowClaim("d")
+	require.Equal(t, message.RelayRejectRateLimited, RejectReason(err))
+}
+
+func TestPolicy_AllowClaim_unlimited(t *testing.T) {
+	p, _ := newTestPolicy(&Limits{})
+	for i := 0; i < 100; i++ {
+		require.NoError(t, p.AllowClaim("a"))
+	}
+}
+
+func TestPolicy_reserveSpend_unprofitable(t *testing.T) {
+	p, _ := newTestPolicy(&Limits{})
+
+	_, err := p.reserveSpend(big.NewInt(100), big.NewInt(100))
+	require.Equal(t, message.RelayRejectUnprofitable, RejectReason(err))
+
+	_, err = p.reserveSpend(big.NewInt(99), big.NewInt(100))
+	require.NoError(t, err)
+}
+
+func TestPolicy_reserveSpend_spendCap(t *testing.T) {
+	p, now := newTestPolicy(&Limits{DailySpendCap: big.NewInt(1000)})
+	fee := big.NewInt(1e6)
+
+	_, err := p.reserveSpend(big.NewInt(600), fee)
+	require.NoError(t, err)
+	_, err = p.reserveSpend(big.NewInt(400), fee)
+	require.NoError(t, err)
+	_, err = p.reserveSpend(big.NewInt(1), fee)
+	require.Equal(t, message.RelayRejectSpendCapReached, RejectReason(err))
+
+	// rejected spends are not recorded, and spends leave the window after 24 hours
+	*now = now.Add(spendLimitWindow)
+	_, err = p.reserveSpend(big.NewInt(1000), fee)
+	require.NoError(t, err)
+}
+
+func TestPolicy_settleSpend(t *testing.T) {
+	p, _ := newTestPolicy(&Limits{DailySpendCap: big.NewInt(1000)})
+	fee := big.NewInt(1e6)
+
+	spend, err := p.reserveSpend(big.NewInt(600), fee)
+	require.NoError(t, err)
+	_, err = p.reserveSpend(big.NewInt(401), fee)
+	require.Equal(t, message.RelayRejectSpendCapReached, RejectReason(err))
+
+	// the claim used less gas than reserved
+	p.settleSpend(spend, big.NewInt(500))
+	_, err = p.reserveSpend(big.NewInt(401), fee)
+	require.NoError(t, err)
+
+	// the transaction of a reservation that was never sent costs nothing
+	spend, err = p.reserveSpend(big.NewInt(99), fee)
+	require.NoError(t, err)
+	_, err = p.reserveSpend(big.NewInt(1), fee)
+	require.Equal(t, message.RelayRejectSpendCapReached, RejectReason(err))
+	p.settleSpend(spend, new(big.Int))
+	_, err = p.reserveSpend(big.NewInt(99), fee)
+	require.NoError(t, err)
+}
+
+func TestRejectReason(t *testing.T) {
+	require.Equal(t, message.RelayRejectInvalid, RejectReason(errors.New("some error")))
+
+	err := reject(message.RelayRejectUnavailable, errors.New("low balance"))
+	require.Equal(t, message.RelayRejectUnavailable, RejectReason(err))
+}
@@ -0,0 +1,371 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/net/message"
+)
+
+// QuoteValidity is how long we honour the fees that we quote as a relayer
+const QuoteValidity = 15 * time.Minute
+
+const (
+	// maxQuotesPerRequester is the max number of unexpired quotes of a single
+	// requester, which is the peer ID of p2p requesters or the IP address of
+	// HTTP requesters
+	maxQuotesPerRequester = 10
+
+	// maxQuotes is the max number of unexpired quotes of all requesters
+	maxQuotes = 10000
+)
+
+// Config is the configuration of a Relayer
+type Config struct {
+	Ctx             context.Context
+	EthClient       extethclient.EthClient
+	SwapCreatorAddr ethcommon.Address
+	Limits          *Limits // nil is unlimited
+}
+
+// Relayer relays the claims and refunds of other nodes. It quotes our fees to
+// claimers and refunders,
+// remembers the quotes until they are used or expire, enforces our relay
+// Policy and keeps Stats of the claims and refunds that we relayed.
+type Relayer struct {
+	ctx             context.Context
+	ec              extethclient.EthClient
+	swapCreatorAddr ethcommon.Address
+	policy          *Policy
+
+	// map of hash(relayer address || salt) -> salt and fee quote
+	quotesMu sync.Mutex
+	quotes   map[types.Hash]*quote
+
+	statsMu sync.Mutex
+	stats   Stats
+}
+
+// quote is the salt of a relayer address hash that we sent to a claimer,
+// along with the fees that we quoted for it
+type quote struct {
+	requester string
+	salt      [4]byte
+	fees      *FeeQuote
+	expires   time.Time
+}
+
+// Stats are the totals of the claims and refunds that we relayed
+type Stats struct {
+	ClaimsRelayed   uint64                      // claims that we submitted and were mined
+	ClaimsRejected  uint64                      // claim requests that we rejected or failed to relay
+	RefundsRelayed  uint64                      // refunds that we submitted and were mined
+	RefundsRejected uint64                      // refund requests that we rejected or failed to relay
+	GasSpent        *big.Int                    // wei spent on the gas of relayed claims and refunds
+	ETHFeesEarned   *big.Int                    // fees of relayed ETH swaps, in wei
+	TokenFeesEarned map[types.EthAsset]*big.Int // fees of relayed token swaps, in the token's smallest unit
+}
+
+// NewRelayer returns a new Relayer
+func NewRelayer(cfg *Config) *Relayer {
+	limits := cfg.Limits
+	if limits == nil {
+		limits = new(Limits)
+	}
+
+	return &Relayer{
+		ctx:             cfg.Ctx,
+		ec:              cfg.EthClient,
+		swapCreatorAddr: cfg.SwapCreatorAddr,
+		policy:          NewPolicy(limits),
+		quotes:          make(map[types.Hash]*quote),
+		stats: Stats{
+			GasSpent:        new(big.Int),
+			ETHFeesEarned:   new(big.Int),
+			TokenFeesEarned: make(map[types.EthAsset]*big.Int),
+		},
+	}
+}
+
+// Quote returns a new hash of our relayer payout address and our current fees
+// for relaying claims using the hash. The quote is honoured until it expires.
+// The requester is the peer ID of p2p requesters or the IP address of HTTP
+// requesters, whose number of unexpired quotes is limited.
+func (r *Relayer) Quote(requester string) (*message.RelayerQueryResponse, error) {
+	// check the limits before getting the gas price for the quote
+	if err := r.checkQuoteLimits(requester); err != nil {
+		return nil, err
+	}
+
+	fees, err := QuoteFees(r.ctx, r.ec)
+	if err != nil {
+		return nil, err
+	}
+
+	var salt [4]byte
+	_, err = rand.Read(salt[:])
+	if err != nil {
+		return nil, err
+	}
+
+	hash := crypto.Keccak256Hash(append(r.ec.Address().Bytes(), salt[:]...))
+
+	r.quotesMu.Lock()
+	defer r.quotesMu.Unlock()
+
+	if err = r.checkQuoteLimitsLocked(requester); err != nil {
+		return nil, err
+	}
+
+	r.quotes[types.Hash(hash)] = &quote{
+		requester: requester,
+		salt:      salt,
+		fees:      fees,
+		expires:   time.Now().Add(QuoteValidity),
+	}
+
+	return &message.RelayerQueryResponse{
+		AddressHash: hash[:],
+		ETHFee:      fees.ETHFee,
+		TokenFee:    fees.TokenFee,
+	}, nil
+}
+
+func (r *Relayer) checkQuoteLimits(requester string) error {
+	r.quotesMu.Lock()
+	defer r.quotesMu.Unlock()
+	return r.checkQuoteLimitsLocked(requester)
+}
+
+// checkQuoteLimitsLocked removes the expired quotes and returns an error if the
+// requester, or all requesters together, have the max number of unexpired
+// quotes
+func (r *Relayer) checkQuoteLimitsLocked(requester string) error {
+	now := time.Now()
+	requesterQuotes := 0
+	for h, q := range r.quotes {
+		if now.After(q.expires) {
+			delete(r.quotes, h)
+			continue
+		}
+		if q.requester == requester {
+			requesterQuotes++
+		}
+	}
+
+	if len(r.quotes) >= maxQuotes {
+		return fmt.Errorf("%d fee quotes are unexpired", len(r.quotes))
+	}
+
+	if requesterQuotes >= maxQuotesPerRequester {
+		return fmt.Errorf("requester %s has %d unexpired fee quotes", requester, requesterQuotes)
+	}
+
+	return nil
+}
+
+// RelayClaim validates and sends the claim transaction of a request whose
+// relayer hash is from one of our unexpired quotes. Each quote can only be
+// used once, by the first valid request. When limited is true, the valid
+// request is subject to our relay policy, which limits the rate of claims by
+// claimer. The claimer is the peer ID of p2p claimers or the IP address of
+// HTTP claimers.
+func (r *Relayer) RelayClaim(
+	claimer string,
+	req *message.RelayClaimRequest,
+	limited bool,
+) (*message.RelayClaimResponse, error) {
+	receipt, err := r.relayClaim(claimer, req, limited)
+	if err != nil {
+		r.statsMu.Lock()
+		r.stats.ClaimsRejected++
+		r.statsMu.Unlock()
+		return nil, err
+	}
+
+	r.recordClaim(req, receipt)
+	return &message.RelayClaimResponse{TxHash: receipt.TxHash}, nil
+}
+
+// RelayRefund validates and sends the refund transaction of a request whose
+// relayer hash is from one of our unexpired quotes, like RelayClaim. Refunds
+// are subject to the same relay policy as claims, with the refunder in place of
+// the claimer.
+func (r *Relayer) RelayRefund(
+	refunder string,
+	req *message.RelayRefundRequest,
+	limited bool,
+) (*message.RelayClaimResponse, error) {
+	receipt, err := r.relayRefund(refunder, req, limited)
+	if err != nil {
+		r.statsMu.Lock()
+		r.stats.RefundsRejected++
+		r.statsMu.Unlock()
+		return nil, err
+	}
+
+	r.recordRelay(req.RelaySwap, receipt, true)
+	return &message.RelayClaimResponse{TxHash: receipt.TxHash}, nil
+}
+
+func (r *Relayer) relayClaim(
+	claimer string,
+	req *message.RelayClaimRequest,
+	limited bool,
+) (*ethtypes.Receipt, error) {
+	q, err := r.getQuote(req.RelaySwap.RelayerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateClaimRequest(r.ctx, req, r.ec.Raw(), r.ec.Address(), q.salt, r.swapCreatorAddr, q.fees)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *Policy
+	if limited {
+		policy = r.policy
+		if err = policy.AllowClaim(claimer); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = r.takeQuote(req.RelaySwap.RelayerHash); err != nil {
+		return nil, err
+	}
+
+	return sendRelayTransaction(r.ctx, claimRelayerMethod, req.RelaySwap, req.Secret, req.Signature,
+		r.ec, q.salt, q.fees, policy)
+}
+
+func (r *Relayer) relayRefund(
+	refunder string,
+	req *message.RelayRefundRequest,
+	limited bool,
+) (*ethtypes.Receipt, error) {
+	q, err := r.getQuote(req.RelaySwap.RelayerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateRefundRequest(r.ctx, req, r.ec.Raw(), r.ec.Address(), q.salt, r.swapCreatorAddr, q.fees)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *Policy
+	if limited {
+		policy = r.policy
+		if err = policy.AllowClaim(refunder); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = r.takeQuote(req.RelaySwap.RelayerHash); err != nil {
+		return nil, err
+	}
+
+	return sendRelayTransaction(r.ctx, refundRelayerMethod, req.RelaySwap, req.Secret, req.Signature,
+		r.ec, q.salt, q.fees, policy)
+}
+
+// getQuote returns the unexpired quote of the relayer hash
+func (r *Relayer) getQuote(hash types.Hash) (*quote, error) {
+	r.quotesMu.Lock()
+	defer r.quotesMu.Unlock()
+
+	q, ok := r.quotes[hash]
+	if !ok || time.Now().After(q.expires) {
+		return nil, fmt.Errorf("relayer hash %s is unknown or its fee quote expired", hash)
+	}
+
+	return q, nil
+}
+
+// takeQuote removes the unexpired quote of the relayer hash, so that it can't
+// be used again. Requests are validated against the quote before it is taken,
+// so invalid requests don't use up the quote of a valid one.
+func (r *Relayer) takeQuote(hash types.Hash) error {
+	r.quotesMu.Lock()
+	defer r.quotesMu.Unlock()
+
+	q, ok := r.quotes[hash]
+	delete(r.quotes, hash)
+	if !ok || time.Now().After(q.expires) {
+		return fmt.Errorf("relayer hash %s is unknown or its fee quote expired", hash)
+	}
+
+	return nil
+}
+
+func (r *Relayer) recordClaim(req *message.RelayClaimRequest, receipt *ethtypes.Receipt) {
+	r.recordRelay(req.RelaySwap, receipt, false)
+}
+
+func (r *Relayer) recordRelay(relaySwap *contracts.SwapCreatorRelaySwap, receipt *ethtypes.Receipt, refund bool) {
+	gasSpent := receiptGasCost(receipt)
+
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+
+	if refund {
+		r.stats.RefundsRelayed++
+	} else {
+		r.stats.ClaimsRelayed++
+	}
+	r.stats.GasSpent.Add(r.stats.GasSpent, gasSpent)
+
+	asset := types.EthAsset(relaySwap.Swap.Asset)
+	if asset.IsETH() {
+		r.stats.ETHFeesEarned.Add(r.stats.ETHFeesEarned, relaySwap.Fee)
+		return
+	}
+
+	earned, ok := r.stats.TokenFeesEarned[asset]
+	if !ok {
+		earned = new(big.Int)
+		r.stats.TokenFeesEarned[asset] = earned
+	}
+	earned.Add(earned, relaySwap.Fee)
+}
+
+// SwapCreatorAddr returns the address of the swap contract whose claims we relay
+func (r *Relayer) SwapCreatorAddr() ethcommon.Address {
+	return r.swapCreatorAddr
+}
+
+// Stats returns a copy of the totals of the claims and refunds that we relayed
+func (r *Relayer) Stats() *Stats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+
+	stats := &Stats{
+		ClaimsRelayed:   r.stats.ClaimsRelayed,
+		ClaimsRejected:  r.stats.ClaimsRejected,
+		RefundsRelayed:  r.stats.RefundsRelayed,
+		RefundsRejected: r.stats.RefundsRejected,
+		GasSpent:        new(big.Int).Set(r.stats.GasSpent),
+		ETHFeesEarned:   new(big.Int).Set(r.stats.ETHFeesEarned),
+		TokenFeesEarned: make(map[types.EthAsset]*big.Int, len(r.stats.TokenFeesEarned)),
+	}
+	for asset, earned := range r.stats.TokenFeesEarned {
+		stats.TokenFeesEarned[asset] = new(big.Int).Set(earned)
+	}
+
+	return stats
+}
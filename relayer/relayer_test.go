@@ -0,0 +1,169 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/common/types"
+	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/net/message"
+	"github.com/athanorlabs/atomic-swap/tests"
+)
+
+func TestRelayer_Quote(t *testing.T) {
+	ec := extethclient.CreateTestClient(t, tests.GetTakerTestKey(t))
+	r := NewRelayer(&Config{Ctx: context.Background(), EthClient: ec})
+
+	resp, err := r.Quote("requester")
+	require.NoError(t, err)
+	require.Positive(t, resp.ETHFee.Sign())
+	require.Greater(t, resp.TokenFee.Cmp(resp.ETHFee), 0)
+
+	hash := types.Hash(resp.AddressHash)
+	q, ok := r.quotes[hash]
+	require.True(t, ok)
+	expectedHash := crypto.Keccak256Hash(ec.Address().Bytes(), q.salt[:])
+	require.Equal(t, expectedHash, ethcommon.Hash(hash))
+
+	// expired quotes are removed when a new quote is made
+	q.expires = time.Now().Add(-time.Second)
+	_, err = r.Quote("requester")
+	require.NoError(t, err)
+	require.NotContains(t, r.quotes, hash)
+	require.Len(t, r.quotes, 1)
+}
+
+func TestRelayer_Quote_limits(t *testing.T) {
+	ec := extethclient.CreateTestClient(t, tests.GetTakerTestKey(t))
+	r := NewRelayer(&Config{Ctx: context.Background(), EthClient: ec})
+
+	for i := 0; i < maxQuotesPerRequester; i++ {
+		_, err := r.Quote("requester")
+		require.NoError(t, err)
+	}
+
+	_, err := r.Quote("requester")
+	require.ErrorContains(t, err, "unexpired fee quotes")
+
+	// other requesters are not limited by the requester's quotes
+	_, err = r.Quote("other")
+	require.NoError(t, err)
+
+	// the requester can get new quotes when their quotes expire
+	for _, q := range r.quotes {
+		q.expires = time.Now().Add(-time.Second)
+	}
+	_, err = r.Quote("requester")
+	require.NoError(t, err)
+
+	for i := len(r.quotes); i < maxQuotes; i++ {
+		r.quotes[types.Hash{byte(i), byte(i >> 8)}] = &quote{expires: time.Now().Add(QuoteValidity)}
+	}
+	_, err = r.Quote("other")
+	require.ErrorContains(t, err, "fee quotes are unexpired")
+}
+
+func TestRelayer_takeQuote(t *testing.T) {
+	r := NewRelayer(&Config{Ctx: context.Background()})
+	hash := types.Hash{0x1}
+	r.quotes[hash] = &quote{expires: time.Now().Add(QuoteValidity)}
+
+	// getting the quote does not use it up
+	_, err := r.getQuote(hash)
+	require.NoError(t, err)
+	_, err = r.getQuote(hash)
+	require.NoError(t, err)
+
+	require.NoError(t, r.takeQuote(hash))
+
+	// quotes can only be used once
+	_, err = r.getQuote(hash)
+	require.ErrorContains(t, err, "unknown or its fee quote expired")
+	err = r.takeQuote(hash)
+	require.ErrorContains(t, err, "unknown or its fee quote expired")
+
+	r.quotes[hash] = &quote{expires: time.Now().Add(-time.Second)}
+	_, err = r.getQuote(hash)
+	require.ErrorContains(t, err, "unknown or its fee quote expired")
+	err = r.takeQuote(hash)
+	require.ErrorContains(t, err, "unknown or its fee quote expired")
+}
+
+func TestRelayer_RelayClaim_invalid(t *testing.T) {
+	ec := extethclient.CreateTestClient(t, tests.GetTakerTestKey(t))
+	r := NewRelayer(&Config{
+		Ctx:       context.Background(),
+		EthClient: ec,
+		Limits:    &Limits{MaxClaimsPerPeer: 1},
+	})
+
+	resp, err := r.Quote("claimer")
+	require.NoError(t, err)
+	hash := types.Hash(resp.AddressHash)
+
+	// there is no SwapCreator contract at the request's address
+	req := &message.RelayClaimRequest{
+		RelaySwap: &contracts.SwapCreatorRelaySwap{
+			Fee:         resp.ETHFee,
+			RelayerHash: hash,
+			SwapCreator: ethcommon.Address{0x1},
+		},
+	}
+	_, err = r.RelayClaim("claimer", req, true)
+	require.Error(t, err)
+	require.Equal(t, uint64(1), r.Stats().ClaimsRejected)
+
+	// the invalid request used up neither the quote nor the claimer's quota
+	_, err = r.getQuote(hash)
+	require.NoError(t, err)
+	require.NoError(t, r.policy.AllowClaim("claimer"))
+}
+
+func TestRelayer_recordClaim(t *testing.T) {
+	r := NewRelayer(&Config{Ctx: context.Background()})
+	token := types.EthAsset(ethcommon.Address{0x1})
+
+	newRequest := func(asset types.EthAsset, fee int64) *message.RelayClaimRequest {
+		return &message.RelayClaimRequest{
+			RelaySwap: &contracts.SwapCreatorRelaySwap{
+				Swap: contracts.SwapCreatorSwap{Asset: asset.Address()},
+				Fee:  big.NewInt(fee),
+			},
+		}
+	}
+	receipt := &ethtypes.Receipt{GasUsed: 100, EffectiveGasPrice: big.NewInt(10)}
+
+	r.recordClaim(newRequest(types.EthAssetETH, 5000), receipt)
+	r.recordClaim(newRequest(types.EthAssetETH, 6000), receipt)
+	r.recordClaim(newRequest(token, 7), receipt)
+
+	stats := r.Stats()
+	require.Equal(t, uint64(3), stats.ClaimsRelayed)
+	require.Zero(t, stats.ClaimsRejected)
+	require.Equal(t, big.NewInt(3000), stats.GasSpent)
+	require.Equal(t, big.NewInt(11000), stats.ETHFeesEarned)
+	require.Equal(t, big.NewInt(7), stats.TokenFeesEarned[token])
+
+	// the returned stats are a copy
+	stats.GasSpent.SetInt64(0)
+	require.Equal(t, big.NewInt(3000), r.Stats().GasSpent)
+
+	// relayed refunds are counted separately, but share the gas and fee totals
+	r.recordRelay(newRequest(types.EthAssetETH, 1000).RelaySwap, receipt, true)
+	stats = r.Stats()
+	require.Equal(t, uint64(3), stats.ClaimsRelayed)
+	require.Equal(t, uint64(1), stats.RefundsRelayed)
+	require.Equal(t, big.NewInt(4000), stats.GasSpent)
+	require.Equal(t, big.NewInt(12000), stats.ETHFeesEarned)
+}
@@ -12,10 +12,11 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/types"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/ethereum/block"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
@@ -23,36 +24,102 @@ import (
 )
 
 const (
+	claimRelayerMethod  = "claimRelayer"
+	refundRelayerMethod = "refundRelayer"
+
 	maxClaimRelayerETHGas = 100000 // worst case gas usage for the claimRelayer call (ether)
 	// actual cost is 85040 but that fails in unit tests on "out of gas".
+	maxClaimRelayerERC20Gas = 130000 // worst case gas usage for the claimRelayer call (ERC20 token)
+
+	// The gas limit of refundRelayer has to cover the gas that the call uses
+	// before its storage refunds, measured at 85083 (ether) and 129228 (ERC20
+	// token), of which 66361 and 102360 are left after the refunds.
+	maxRefundRelayerETHGas   = 100000 // worst case gas usage for the refundRelayer call (ether)
+	maxRefundRelayerERC20Gas = 150000 // worst case gas usage for the refundRelayer call (ERC20 token)
 )
 
-// ValidateAndSendTransaction sends the relayed transaction to the network if it validates successfully.
+// ValidateAndSendTransaction sends the relayed transaction to the network if it
+// validates successfully. The quote is the fee quote that we sent to the
+// claimer with the relayer hash of the request. If the policy is not nil, the
+// claim must be profitable and within the policy's daily spend cap. Rejected
+// requests return a *RejectError.
 func ValidateAndSendTransaction(
 	ctx context.Context,
 	req *message.RelayClaimRequest,
 	ec extethclient.EthClient,
 	ourSwapCreatorAddr ethcommon.Address,
 	salt [4]byte,
+	quote *FeeQuote,
+	policy *Policy,
 ) (*message.RelayClaimResponse, error) {
-	err := validateClaimRequest(ctx, req, ec.Raw(), ec.Address(), salt, ourSwapCreatorAddr)
+	receipt, err := validateAndSendTransaction(ctx, req, ec, ourSwapCreatorAddr, salt, quote, policy)
 	if err != nil {
 		return nil, err
 	}
 
-	reqSwapCreator, err := contracts.NewSwapCreator(req.RelaySwap.SwapCreator, ec.Raw())
+	return &message.RelayClaimResponse{TxHash: receipt.TxHash}, nil
+}
+
+func validateAndSendTransaction(
+	ctx context.Context,
+	req *message.RelayClaimRequest,
+	ec extethclient.EthClient,
+	ourSwapCreatorAddr ethcommon.Address,
+	salt [4]byte,
+	quote *FeeQuote,
+	policy *Policy,
+) (*ethtypes.Receipt, error) {
+	err := validateClaimRequest(ctx, req, ec.Raw(), ec.Address(), salt, ourSwapCreatorAddr, quote)
 	if err != nil {
 		return nil, err
 	}
 
-	// The size of request.Secret was vetted when it was deserialized
-	secret := [32]byte(req.Secret)
+	return sendRelayTransaction(ctx, claimRelayerMethod, req.RelaySwap, req.Secret, req.Signature,
+		ec, salt, quote, policy)
+}
 
-	gasPrice, err := checkForMinClaimBalance(ctx, ec)
+func validateAndSendRefundTransaction(
+	ctx context.Context,
+	req *message.RelayRefundRequest,
+	ec extethclient.EthClient,
+	ourSwapCreatorAddr ethcommon.Address,
+	salt [4]byte,
+	quote *FeeQuote,
+	policy *Policy,
+) (*ethtypes.Receipt, error) {
+	err := validateRefundRequest(ctx, req, ec.Raw(), ec.Address(), salt, ourSwapCreatorAddr, quote)
 	if err != nil {
 		return nil, err
 	}
 
+	return sendRelayTransaction(ctx, refundRelayerMethod, req.RelaySwap, req.Secret, req.Signature,
+		ec, salt, quote, policy)
+}
+
+// sendRelayTransaction sends a validated relay request to the network with the
+// passed SwapCreator method, which is claimRelayer or refundRelayer, and waits
+// for its receipt.
+func sendRelayTransaction(
+	ctx context.Context,
+	method string,
+	relaySwap *contracts.SwapCreatorRelaySwap,
+	reqSecret []byte,
+	signature []byte,
+	ec extethclient.EthClient,
+	salt [4]byte,
+	quote *FeeQuote,
+	policy *Policy,
+) (*ethtypes.Receipt, error) {
+	// The size of the secret was vetted when the request was deserialized
+	secret := [32]byte(reqSecret)
+
+	gasLimit := relayGasLimit(method, types.EthAsset(relaySwap.Swap.Asset))
+
+	gasPrice, err := checkForMinClaimBalance(ctx, ec, gasLimit)
+	if err != nil {
+		return nil, reject(message.RelayRejectUnavailable, err)
+	}
+
 	// Lock the wallet's nonce until we get a receipt
 	ec.Lock()
 	defer ec.Unlock()
@@ -62,55 +129,90 @@ func ValidateAndSendTransaction(
 		return nil, err
 	}
 	txOpts.GasPrice = gasPrice
-	txOpts.GasLimit = maxClaimRelayerETHGas
-	log.Debugf("relaying tx with gas price %s and gas limit %d", gasPrice, txOpts.GasLimit)
+	txOpts.GasLimit = gasLimit
+	log.Debugf("relaying %s tx with gas price %s and gas limit %d", method, gasPrice, txOpts.GasLimit)
 
-	v := req.Signature[64]
-	r := [32]byte(req.Signature[:32])
-	s := [32]byte(req.Signature[32:64])
+	v := signature[64]
+	r := [32]byte(signature[:32])
+	s := [32]byte(signature[32:64])
 
 	saltU32 := binary.BigEndian.Uint32(salt[:])
-	err = simulateClaimRelayer(
-		ctx,
-		ec,
-		txOpts,
-		req.RelaySwap,
-		secret,
-		ec.Address(),
-		saltU32,
-		v, r, s,
-	)
+	args := []any{*relaySwap, secret, ec.Address(), saltU32, v, r, s}
+
+	gas, err := simulateRelayTransaction(ctx, ec, txOpts, relaySwap.SwapCreator, method, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err := reqSwapCreator.ClaimRelayer(
-		txOpts,
-		*req.RelaySwap,
-		secret,
-		ec.Address(),
-		saltU32,
-		v,
-		r,
-		s,
+	var spend *spendRecord
+	if policy != nil {
+		gasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+		feeWei := minFeeValueWei(types.EthAsset(relaySwap.Swap.Asset), relaySwap.Fee, quote)
+		spend, err = policy.reserveSpend(gasCost, feeWei)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reqSwapCreator := bind.NewBoundContract(
+		relaySwap.SwapCreator,
+		*contracts.SwapCreatorParsedABI,
+		ec.Raw(),
+		ec.Raw(),
+		ec.Raw(),
 	)
+
+	tx, err := reqSwapCreator.Transact(txOpts, method, args...)
 	if err != nil {
-		log.Errorf("failed to call ClaimRelayer: %s", err)
+		log.Errorf("failed to call %s: %s", method, err)
+		if spend != nil {
+			policy.settleSpend(spend, new(big.Int))
+		}
 		return nil, err
 	}
 
+	// If we don't get the receipt, the transaction may still be mined, so the
+	// reserved cost stays recorded as spent
 	receipt, err := block.WaitForReceipt(ctx, ec.Raw(), tx.Hash())
 	if err != nil {
 		return nil, err
 	}
 
-	log.Infof("relayed claim %s", common.ReceiptInfo(receipt))
-	return &message.RelayClaimResponse{TxHash: tx.Hash()}, nil
+	if spend != nil {
+		policy.settleSpend(spend, receiptGasCost(receipt))
+	}
+
+	log.Infof("relayed %s %s", method, common.ReceiptInfo(receipt))
+	return receipt, nil
+}
+
+// receiptGasCost returns the wei spent on the gas of the receipt's transaction
+func receiptGasCost(receipt *ethtypes.Receipt) *big.Int {
+	cost := new(big.Int).SetUint64(receipt.GasUsed)
+	if receipt.EffectiveGasPrice != nil {
+		cost.Mul(cost, receipt.EffectiveGasPrice)
+	}
+	return cost
+}
+
+// relayGasLimit returns the gas limit of a claimRelayer or refundRelayer call
+// of a swap of the asset
+func relayGasLimit(method string, asset types.EthAsset) uint64 {
+	switch {
+	case method == refundRelayerMethod && asset.IsToken():
+		return maxRefundRelayerERC20Gas
+	case method == refundRelayerMethod:
+		return maxRefundRelayerETHGas
+	case asset.IsToken():
+		return maxClaimRelayerERC20Gas
+	default:
+		return maxClaimRelayerETHGas
+	}
 }
 
 // checkForMinClaimBalance verifies that we have enough gas to relay a claim and
 // returns the gas price that was used for the calculation.
-func checkForMinClaimBalance(ctx context.Context, ec extethclient.EthClient) (*big.Int, error) {
+func checkForMinClaimBalance(ctx context.Context, ec extethclient.EthClient, gasLimit uint64) (*big.Int, error) {
 	balance, err := ec.Balance(ctx)
 	if err != nil {
 		return nil, err
@@ -121,7 +223,7 @@ func checkForMinClaimBalance(ctx context.Context, ec extethclient.EthClient) (*b
 		return nil, err
 	}
 
-	txCost := new(big.Int).Mul(gasPrice, big.NewInt(maxClaimRelayerETHGas))
+	txCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
 	if balance.BigInt().Cmp(txCost) < 0 {
 		return nil, fmt.Errorf("balance %s ETH is under the minimum %s ETH to relay claim",
 			balance.AsEtherString(), coins.FmtWeiAsETH(txCost))
@@ -130,53 +232,41 @@ func checkForMinClaimBalance(ctx context.Context, ec extethclient.EthClient) (*b
 	return gasPrice, nil
 }
 
-// simulateExecute calls the swap creator's ClaimRelayer function
-// with CallContract which executes the method call without mining it into the blockchain.
-// https://pkg.go.dev/github.com/ethereum/go-ethereum/ethclient#Client.CallContract
-func simulateClaimRelayer(
+// simulateRelayTransaction calls the swap creator's claimRelayer or
+// refundRelayer method with EstimateGas, which executes the method call without
+// mining it into the blockchain, and returns the gas that the call used. A
+// *contracts.RevertError is returned if the call reverts with a SwapCreator error.
+// https://pkg.go.dev/github.com/ethereum/go-ethereum/ethclient#Client.EstimateGas
+func simulateRelayTransaction(
 	ctx context.Context,
 	ec extethclient.EthClient,
 	txOpts *bind.TransactOpts,
-	relaySwap *contracts.SwapCreatorRelaySwap,
-	secret [32]byte,
-	relayer ethcommon.Address,
-	salt uint32,
-	v uint8,
-	r, s [32]byte,
-) error {
-	// Pack the "claimRelayer" method call
-	packed, err := contracts.SwapCreatorParsedABI.Pack(
-		"claimRelayer",
-		*relaySwap,
-		secret,
-		relayer,
-		salt,
-		v,
-		r,
-		s,
-	)
+	swapCreatorAddr ethcommon.Address,
+	method string,
+	args ...any,
+) (uint64, error) {
+	packed, err := contracts.SwapCreatorParsedABI.Pack(method, args...)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	callMessage := ethereum.CallMsg{
 		From:       txOpts.From,
-		To:         &relaySwap.SwapCreator,
+		To:         &swapCreatorAddr,
 		Gas:        txOpts.GasLimit,
 		GasPrice:   txOpts.GasPrice,
 		GasFeeCap:  txOpts.GasFeeCap,
 		GasTipCap:  txOpts.GasTipCap,
 		Value:      txOpts.Value,
 		Data:       packed,
-		AccessList: []types.AccessTuple{},
+		AccessList: []ethtypes.AccessTuple{},
 	}
 
-	// Call the "claimRelayer" method
 	// will return a revert error on failure
-	_, err = ec.Raw().CallContract(ctx, callMessage, nil)
+	gas, err := ec.Raw().EstimateGas(ctx, callMessage)
 	if err != nil {
-		return err
+		return 0, contracts.DecodeRevertError(method, err)
 	}
 
-	return nil
+	return gas, nil
 }
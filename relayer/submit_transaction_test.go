@@ -7,18 +7,22 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
+	"encoding/binary"
 	"math/big"
 	"testing"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
-	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/crypto/secp256k1"
 	"github.com/athanorlabs/atomic-swap/dleq"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/ethereum/block"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/net/message"
 	"github.com/athanorlabs/atomic-swap/tests"
 )
 
@@ -119,13 +123,13 @@ func Test_ValidateAndSendTransaction(t *testing.T) {
 		Swap:        swap,
 		SwapCreator: swapCreatorAddr,
 		RelayerHash: relayerHash,
-		Fee:         coins.RelayerFeeWei,
+		Fee:         testFeeQuote.ETHFee,
 	}
 
 	req, err := CreateRelayClaimRequest(claimerSk, relaySwap, secret)
 	require.NoError(t, err)
 
-	resp, err := ValidateAndSendTransaction(ctx, req, ec, swapCreatorAddr, salt)
+	resp, err := ValidateAndSendTransaction(ctx, req, ec, swapCreatorAddr, salt, testFeeQuote, nil)
 	require.NoError(t, err)
 
 	receipt, err = block.WaitForReceipt(ctx, ec.Raw(), resp.TxHash)
@@ -148,6 +152,146 @@ func Test_ValidateAndSendTransaction(t *testing.T) {
 	req, err = CreateRelayClaimRequest(claimerSk, relaySwap, secret)
 	require.NoError(t, err)
 
-	_, err = ValidateAndSendTransaction(ctx, req, ec, swapCreatorAddr, salt)
+	_, err = ValidateAndSendTransaction(ctx, req, ec, swapCreatorAddr, salt, testFeeQuote, nil)
 	require.ErrorContains(t, err, "revert")
 }
+
+// newRefundRelaySwap creates a swap of the owner, and returns its relay swap for the relayer with the given salt, and the swap's
+// refund secret. Token swaps are of a new TestERC20 token.
+func newRefundRelaySwap(
+	t *testing.T,
+	owner extethclient.EthClient,
+	relayerAddr ethcommon.Address,
+	salt [4]byte,
+	swapCreatorAddr ethcommon.Address,
+	swapCreator *contracts.SwapCreator,
+	isToken bool,
+) (*contracts.SwapCreatorRelaySwap, [32]byte) {
+	ctx := context.Background()
+
+	refundKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	var secret, x, y [32]byte
+	refundKey.D.FillBytes(secret[:])
+	refundKey.X.FillBytes(x[:])
+	refundKey.Y.FillBytes(y[:])
+
+	swap := contracts.SwapCreatorSwap{
+		Owner:            owner.Address(),
+		Claimer:          ethcommon.Address{0x1},
+		ClaimCommitment:  [32]byte{0x1},
+		RefundCommitment: secp256k1.NewPublicKey(x, y).Keccak256(),
+		Value:            big.NewInt(9e16),
+		Nonce:            contracts.GenerateNewSwapNonce(),
+	}
+	fee := testFeeQuote.ETHFee
+
+	txOpts, err := owner.TxOpts(ctx)
+	require.NoError(t, err)
+	if isToken {
+		tokenAddr, tx, token, err := contracts.DeployTestERC20(txOpts, owner.Raw(), "Test", "TEST", 18, //nolint:govet
+			owner.Address(), swap.Value)
+		require.NoError(t, err)
+		_, err = owner.WaitForReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+
+		txOpts, err = owner.TxOpts(ctx)
+		require.NoError(t, err)
+		tx, err = token.Approve(txOpts, swapCreatorAddr, swap.Value)
+		require.NoError(t, err)
+		_, err = owner.WaitForReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+
+		swap.Asset = tokenAddr
+		fee = testFeeQuote.TokenFee
+		txOpts, err = owner.TxOpts(ctx)
+		require.NoError(t, err)
+	} else {
+		txOpts.Value = swap.Value
+	}
+
+	timeout := big.NewInt(300)
+	tx, err := swapCreator.NewSwap(txOpts, swap.ClaimCommitment, swap.RefundCommitment, swap.Claimer,
+		timeout, timeout, swap.Asset, swap.Value, swap.Nonce)
+	require.NoError(t, err)
+	receipt, err := owner.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	swap.Timeout1, swap.Timeout2, err = contracts.GetTimeoutsFromLog(receipt.Logs[len(receipt.Logs)-1])
+	require.NoError(t, err)
+
+	return &contracts.SwapCreatorRelaySwap{
+		Swap:        swap,
+		Fee:         fee,
+		RelayerHash: crypto.Keccak256Hash(relayerAddr[:], salt[:]),
+		SwapCreator: swapCreatorAddr,
+	}, secret
+}
+
+func Test_validateAndSendRefundTransaction(t *testing.T) {
+	ctx := context.Background()
+	relayerKey := tests.GetMakerTestKey(t)
+	ownerKey := tests.GetTakerTestKey(t)
+	ec := extethclient.CreateTestClient(t, relayerKey)
+	owner := extethclient.CreateTestClient(t, ownerKey)
+	swapCreatorAddr, swapCreator := contracts.DevDeploySwapCreator(t, ec.Raw(), relayerKey)
+
+	var salt [4]byte
+	_, err := rand.Read(salt[:])
+	require.NoError(t, err)
+	relaySwap, secret := newRefundRelaySwap(t, owner, ec.Address(), salt, swapCreatorAddr, swapCreator, false)
+
+	// the owner's signature of the relay swap without the refund type hash,
+	// which is what claimers sign, does not refund
+	claimSig, err := Sign(ownerKey, relaySwap.Hash())
+	require.NoError(t, err)
+	req := &message.RelayRefundRequest{RelaySwap: relaySwap, Secret: secret[:], Signature: claimSig}
+	_, err = validateAndSendRefundTransaction(ctx, req, ec, swapCreatorAddr, salt, testFeeQuote, nil)
+	require.ErrorContains(t, err, "signer of message is not swap owner")
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	_, err = swapCreator.RefundRelayer(txOpts, *relaySwap, secret, ec.Address(), binary.BigEndian.Uint32(salt[:]),
+		claimSig[64], [32]byte(claimSig[:32]), [32]byte(claimSig[32:64]))
+	require.ErrorContains(t, err, "revert")
+
+	req, err = CreateRelayRefundRequest(ownerKey, relaySwap, secret)
+	require.NoError(t, err)
+	receipt, err := validateAndSendRefundTransaction(ctx, req, ec, swapCreatorAddr, salt, testFeeQuote, nil)
+	require.NoError(t, err)
+	t.Logf("gas cost to call refundRelayer: %d (delta %d)",
+		receipt.GasUsed, maxRefundRelayerETHGas-int(receipt.GasUsed))
+	require.GreaterOrEqual(t, maxRefundRelayerETHGas, int(receipt.GasUsed), "refundRelayer")
+
+	stage, err := swapCreator.Swaps(ec.CallOpts(ctx), relaySwap.Swap.SwapID())
+	require.NoError(t, err)
+	require.Equal(t, contracts.StageCompleted, stage)
+}
+
+// Token fees are priced with price feeds that the test chain does not have, so
+// the token refund is sent without the relayer's validation.
+func Test_refundRelayer_tokenGas(t *testing.T) {
+	ctx := context.Background()
+	relayerKey := tests.GetMakerTestKey(t)
+	ownerKey := tests.GetTakerTestKey(t)
+	ec := extethclient.CreateTestClient(t, relayerKey)
+	owner := extethclient.CreateTestClient(t, ownerKey)
+	swapCreatorAddr, swapCreator := contracts.DevDeploySwapCreator(t, ec.Raw(), relayerKey)
+
+	var salt [4]byte
+	relaySwap, secret := newRefundRelaySwap(t, owner, ec.Address(), salt, swapCreatorAddr, swapCreator, true)
+	req, err := CreateRelayRefundRequest(ownerKey, relaySwap, secret)
+	require.NoError(t, err)
+
+	txOpts, err := ec.TxOpts(ctx)
+	require.NoError(t, err)
+	txOpts.GasLimit = maxRefundRelayerERC20Gas
+	sig := req.Signature
+	tx, err := swapCreator.RefundRelayer(txOpts, *relaySwap, secret, ec.Address(), binary.BigEndian.Uint32(salt[:]),
+		sig[64], [32]byte(sig[:32]), [32]byte(sig[32:64]))
+	require.NoError(t, err)
+	receipt, err := ec.WaitForReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	t.Logf("gas cost to call token refundRelayer: %d (delta %d)",
+		receipt.GasUsed, maxRefundRelayerERC20Gas-int(receipt.GasUsed))
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+	require.GreaterOrEqual(t, maxRefundRelayerERC20Gas, int(receipt.GasUsed), "token refundRelayer")
+}
@@ -11,7 +11,6 @@ import (
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 
-	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/net/message"
@@ -24,8 +23,9 @@ func validateClaimRequest(
 	ourAddress ethcommon.Address,
 	salt [4]byte,
 	ourSwapCreatorAddr ethcommon.Address,
+	quote *FeeQuote,
 ) error {
-	err := validateClaimValues(ctx, request, ec, ourAddress, salt, ourSwapCreatorAddr)
+	err := validateClaimValues(ctx, request, ec, ourAddress, salt, ourSwapCreatorAddr, quote)
 	if err != nil {
 		return err
 	}
@@ -33,12 +33,39 @@ func validateClaimRequest(
 	return validateClaimSignature(request)
 }
 
-// validateClaimValues validates the non-signature aspects of the claim request:
-//  1. the claim request's SwapCreator bytecode matches ours
-//  2. the swap is for ETH and not an ERC20 token
-//  3. the swap value is strictly greater than the relayer fee
-//  4. the claim request's relayer hash matches keccak256(ourAddress || salt)
-//  5. the relayer fee is greater than or equal the expected relayer fee
+// validateRefundRequest validates a relayed refund request like
+// validateClaimRequest, except that the request is signed by the swap owner.
+// Refund requests are never from our swap counterparty.
+func validateRefundRequest(
+	ctx context.Context,
+	request *message.RelayRefundRequest,
+	ec *ethclient.Client,
+	ourAddress ethcommon.Address,
+	salt [4]byte,
+	ourSwapCreatorAddr ethcommon.Address,
+	quote *FeeQuote,
+) error {
+	err := validateRelayValues(ctx, request.RelaySwap, false, ec, ourAddress, salt, ourSwapCreatorAddr, quote)
+	if err != nil {
+		return err
+	}
+
+	// Only the first release of the swap contract is without refundRelayer
+	version, err := contracts.GetSwapCreatorVersion(ctx, ec, request.RelaySwap.SwapCreator)
+	if err != nil {
+		return err
+	}
+	if version < contracts.SwapCreatorV2 {
+		return fmt.Errorf("swap creator %s is a %s contract without relayed refunds",
+			request.RelaySwap.SwapCreator, version)
+	}
+
+	return validateRelaySignature(request.RelaySwap.RefundHash(), request.Signature, request.RelaySwap.Swap.Owner,
+		"owner")
+}
+
+// validateClaimValues validates the non-signature aspects of the claim request
+// with validateRelayValues
 func validateClaimValues(
 	ctx context.Context,
 	request *message.RelayClaimRequest,
@@ -46,46 +73,70 @@ func validateClaimValues(
 	ourAddress ethcommon.Address,
 	salt [4]byte,
 	ourSwapCreatorAddr ethcommon.Address,
+	quote *FeeQuote,
 ) error {
 	isTakerRelay := request.OfferID != nil
+	return validateRelayValues(ctx, request.RelaySwap, isTakerRelay, ec, ourAddress, salt, ourSwapCreatorAddr, quote)
+}
 
+// validateRelayValues validates the non-signature aspects of a relay request:
+//  1. the request's SwapCreator bytecode matches a known version
+//  2. the swap value is strictly greater than the relayer fee
+//  3. the request's relayer hash matches keccak256(ourAddress || salt)
+//  4. the relayer fee is greater than or equal our quoted fee, which for token
+//     swaps is priced with the price feeds (see FeeForAsset)
+//
+// Requests of our swap counterparty (isTakerRelay) must use our SwapCreator.
+func validateRelayValues(
+	ctx context.Context,
+	relaySwap *contracts.SwapCreatorRelaySwap,
+	isTakerRelay bool,
+	ec *ethclient.Client,
+	ourAddress ethcommon.Address,
+	salt [4]byte,
+	ourSwapCreatorAddr ethcommon.Address,
+	quote *FeeQuote,
+) error {
 	// Validate the requested SwapCreator contract, if it is not at the same address
 	// as our own.
-	if request.RelaySwap.SwapCreator != ourSwapCreatorAddr {
+	if relaySwap.SwapCreator != ourSwapCreatorAddr {
 		if isTakerRelay {
 			return fmt.Errorf("taker claim swap creator mismatch found=%s expected=%s",
-				request.RelaySwap.SwapCreator, ourSwapCreatorAddr)
+				relaySwap.SwapCreator, ourSwapCreatorAddr)
 		}
-		err := contracts.CheckSwapCreatorContractCode(ctx, ec, request.RelaySwap.SwapCreator)
+		err := contracts.CheckSwapCreatorContractCode(ctx, ec, relaySwap.SwapCreator)
 		if err != nil {
 			return err
 		}
 	}
 
-	asset := types.EthAsset(request.RelaySwap.Swap.Asset)
-	if asset != types.EthAssetETH {
-		return fmt.Errorf("relaying for ETH Asset %s is not supported", asset)
+	asset := types.EthAsset(relaySwap.Swap.Asset)
+	expectedFee, err := FeeForAsset(ctx, ec, asset, quote.FeeWei(asset))
+	if err != nil {
+		return err
 	}
+	minFee := minAcceptedFee(expectedFee)
 
 	// The relayer fee must be strictly less than the swap value
-	if coins.RelayerFeeWei.Cmp(request.RelaySwap.Swap.Value) >= 0 {
-		return fmt.Errorf("swap value of %s ETH is too low to support %s ETH relayer fee",
-			coins.FmtWeiAsETH(request.RelaySwap.Swap.Value), coins.RelayerFeeETH.Text('f'))
+	if minFee.Cmp(relaySwap.Swap.Value) >= 0 {
+		return fmt.Errorf("swap value of %s %s is too low to support %s %s relayer fee",
+			assetAmount(relaySwap.Swap.Value, expectedFee).AsStdString(), expectedFee.StdSymbol(),
+			expectedFee.AsStdString(), expectedFee.StdSymbol())
 	}
 
 	hash := ethcrypto.Keccak256Hash(append(ourAddress.Bytes(), salt[:]...))
-	if request.RelaySwap.RelayerHash != hash {
+	if relaySwap.RelayerHash != hash {
 		return fmt.Errorf("relay request payout address hash %s does not match expected (%s)",
-			request.RelaySwap.RelayerHash,
+			relaySwap.RelayerHash,
 			hash,
 		)
 	}
 
 	// the relayer fee must be greater than or equal the expected relayer fee
-	if coins.RelayerFeeWei.Cmp(request.RelaySwap.Fee) > 0 {
-		return fmt.Errorf("relayer fee of %s ETH is less than expected %s ETH",
-			coins.FmtWeiAsETH(request.RelaySwap.Fee),
-			coins.RelayerFeeETH.Text('f'),
+	if minFee.Cmp(relaySwap.Fee) > 0 {
+		return fmt.Errorf("relayer fee of %s %s is less than expected %s %s",
+			assetAmount(relaySwap.Fee, expectedFee).AsStdString(), expectedFee.StdSymbol(),
+			expectedFee.AsStdString(), expectedFee.StdSymbol(),
 		)
 	}
 
@@ -97,9 +148,20 @@ func validateClaimValues(
 func validateClaimSignature(
 	request *message.RelayClaimRequest,
 ) error {
-	msg := request.RelaySwap.Hash()
+	return validateRelaySignature(request.RelaySwap.Hash(), request.Signature, request.RelaySwap.Swap.Claimer,
+		"claimer")
+}
+
+// validateRelaySignature validates that the signature of a relay swap's digest
+// is from the expected signer, who is the swap party with the passed role
+func validateRelaySignature(
+	msg types.Hash,
+	signature []byte,
+	expectedSigner ethcommon.Address,
+	role string,
+) error {
 	var sig [65]byte
-	copy(sig[:], request.Signature)
+	copy(sig[:], signature)
 	sig[64] -= 27 // ecrecover requires 0/1 while EVM requires 27/28
 
 	signer, err := ethcrypto.Ecrecover(msg[:], sig[:])
@@ -112,8 +174,8 @@ func validateClaimSignature(
 		return err
 	}
 
-	if ethcrypto.PubkeyToAddress(*pubkey) != request.RelaySwap.Swap.Claimer {
-		return fmt.Errorf("signer of message is not swap claimer")
+	if ethcrypto.PubkeyToAddress(*pubkey) != expectedSigner {
+		return fmt.Errorf("signer of message is not swap %s", role)
 	}
 
 	return nil
@@ -14,13 +14,18 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
-	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
 	"github.com/athanorlabs/atomic-swap/net/message"
 	"github.com/athanorlabs/atomic-swap/tests"
 )
 
+// testFeeQuote is the fee quote of the relayer in the tests
+var testFeeQuote = &FeeQuote{
+	ETHFee:   big.NewInt(1e16),
+	TokenFee: big.NewInt(2e16),
+}
+
 func TestValidateRelayerFee(t *testing.T) {
 	ctx := context.Background()
 	ec, _ := tests.NewEthClient(t)
@@ -40,17 +45,17 @@ func TestValidateRelayerFee(t *testing.T) {
 	testCases := []testCase{
 		{
 			description: "swap value equal to relayer fee",
-			value:       coins.RelayerFeeWei,
+			value:       testFeeQuote.ETHFee,
 			expectErr:   "swap value of 0.01 ETH is too low to support 0.01 ETH relayer fee",
 		},
 		{
 			description: "swap value less than relayer fee",
-			value:       new(big.Int).Sub(coins.RelayerFeeWei, big.NewInt(1e15)),
+			value:       new(big.Int).Sub(testFeeQuote.ETHFee, big.NewInt(1e15)),
 			expectErr:   "swap value of 0.009 ETH is too low to support 0.01 ETH relayer fee",
 		},
 		{
 			description: "swap value larger than min fee",
-			value:       new(big.Int).Add(coins.RelayerFeeWei, big.NewInt(1e15)),
+			value:       new(big.Int).Add(testFeeQuote.ETHFee, big.NewInt(1e15)),
 		},
 	}
 
@@ -70,14 +75,14 @@ func TestValidateRelayerFee(t *testing.T) {
 		request := &message.RelayClaimRequest{
 			RelaySwap: &contracts.SwapCreatorRelaySwap{
 				Swap:        swap,
-				Fee:         coins.RelayerFeeWei,
+				Fee:         testFeeQuote.ETHFee,
 				SwapCreator: swapCreatorAddr,
 				RelayerHash: relayerHash,
 			},
 			Secret: make([]byte, 32),
 		}
 
-		err := validateClaimValues(ctx, request, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr)
+		err := validateClaimValues(ctx, request, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr, testFeeQuote)
 		if tc.expectErr != "" {
 			require.ErrorContains(t, err, tc.expectErr, tc.description)
 		} else {
@@ -102,7 +107,7 @@ func Test_validateClaimValues_takerClaim_contractAddressNotEqualFail(t *testing.
 		},
 	}
 
-	err := validateClaimValues(context.Background(), request, nil, ethcommon.Address{}, [4]byte{}, swapCreatorAddrOurs)
+	err := validateClaimValues(context.Background(), request, nil, ethcommon.Address{}, [4]byte{}, swapCreatorAddrOurs, testFeeQuote)
 	require.ErrorContains(t, err, "taker claim swap creator mismatch")
 }
 
@@ -122,7 +127,7 @@ func Test_validateClaimValues_dhtClaim_contractAddressNotEqual(t *testing.T) {
 		},
 	}
 
-	err := validateClaimValues(context.Background(), request, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr)
+	err := validateClaimValues(context.Background(), request, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr, testFeeQuote)
 	require.ErrorContains(t, err, "contract address does not contain correct SwapCreator code")
 }
 
@@ -172,19 +177,64 @@ func Test_validateClaimRequest(t *testing.T) {
 		SwapCreator: swapCreatorAddr,
 		Swap:        *swap,
 		RelayerHash: relayerHash,
-		Fee:         coins.RelayerFeeWei,
+		Fee:         testFeeQuote.ETHFee,
 	}
 
 	req, err := CreateRelayClaimRequest(ethKey, relaySwap, secret)
 	require.NoError(t, err)
 
 	// success path
-	err = validateClaimRequest(ctx, req, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr)
+	err = validateClaimRequest(ctx, req, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr, testFeeQuote)
+	require.NoError(t, err)
+
+	// test failure path by passing an asset that is not a token contract
+	req.RelaySwap.Swap.Asset = ethcommon.Address{0x1}
+	err = validateClaimRequest(ctx, req, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr, testFeeQuote)
+	require.ErrorContains(t, err, "failed to get decimals of token")
+}
+
+func Test_validateClaimRequest_token(t *testing.T) {
+	ctx := context.Background()
+	ethKey := tests.GetMakerTestKey(t)
+	claimer := crypto.PubkeyToAddress(*ethKey.Public().(*ecdsa.PublicKey))
+	ec, _ := tests.NewEthClient(t)
+	secret := [32]byte{0x1}
+	swapCreatorAddr, _ := contracts.DevDeploySwapCreator(t, ec, ethKey)
+	token := contracts.GetMockTether(t, ec, ethKey)
+
+	// 20-byte empty address, 4-byte zero salt
+	empty := [24]byte{}
+	relayerHash := crypto.Keccak256Hash(empty[:])
+
+	fee, err := FeeForAsset(ctx, ec, types.EthAsset(token.Address), testFeeQuote.TokenFee)
+	require.NoError(t, err)
+	require.True(t, fee.IsToken())
+
+	swap := createTestSwap(claimer)
+	swap.Asset = token.Address
+	swap.Value = new(big.Int).Mul(fee.BigInt(), big.NewInt(10))
+	relaySwap := &contracts.SwapCreatorRelaySwap{
+		SwapCreator: swapCreatorAddr,
+		Swap:        *swap,
+		RelayerHash: relayerHash,
+		Fee:         fee.BigInt(),
+	}
+
+	req, err := CreateRelayClaimRequest(ethKey, relaySwap, secret)
+	require.NoError(t, err)
+	err = validateClaimRequest(ctx, req, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr, testFeeQuote)
 	require.NoError(t, err)
 
-	// test failure path by passing a non-eth asset
-	asset := ethcommon.Address{0x1}
-	req.RelaySwap.Swap.Asset = asset
-	err = validateClaimRequest(ctx, req, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr)
-	require.ErrorContains(t, err, fmt.Sprintf("relaying for ETH Asset %s is not supported", types.EthAsset(asset)))
+	// fees slightly below ours are accepted
+	relaySwap.Fee = minAcceptedFee(fee)
+	req, err = CreateRelayClaimRequest(ethKey, relaySwap, secret)
+	require.NoError(t, err)
+	err = validateClaimRequest(ctx, req, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr, testFeeQuote)
+	require.NoError(t, err)
+
+	relaySwap.Fee = new(big.Int).Sub(minAcceptedFee(fee), big.NewInt(1))
+	req, err = CreateRelayClaimRequest(ethKey, relaySwap, secret)
+	require.NoError(t, err)
+	err = validateClaimRequest(ctx, req, ec, ethcommon.Address{}, [4]byte{}, swapCreatorAddr, testFeeQuote)
+	require.ErrorContains(t, err, fmt.Sprintf("is less than expected %s %s", fee.AsStdString(), fee.StdSymbol()))
 }
@@ -0,0 +1,179 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package relayernode is responsible for assembling, running and cleanly
+// shutting down a relayer-only node. Relayer-only nodes relay the claims of
+// other nodes for a fee. They only need an ethereum key and endpoint, as they
+// do not swap.
+package relayernode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/go-multierror"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
+	"github.com/athanorlabs/atomic-swap/net"
+	"github.com/athanorlabs/atomic-swap/net/message"
+	"github.com/athanorlabs/atomic-swap/relayer"
+	"github.com/athanorlabs/atomic-swap/rpc"
+)
+
+var log = logging.Logger("relayernode")
+
+var errNoSwaps = errors.New("relayer-only nodes do not swap")
+
+// Config provides the configuration for a relayer-only node.
+type Config struct {
+	EnvConf        *common.Config
+	EthereumClient extethclient.EthClient
+	Libp2pPort     uint16
+	Libp2pKeyfile  string
+	RPCPort        uint16
+	Limits         *relayer.Limits // nil relays claims without limits
+
+	// HTTPAddr is the "IP:port" address that claim requests are accepted on
+	// over HTTP. If empty, claims are only relayed over libp2p.
+	HTTPAddr string
+
+	// HTTPAllowedOrigins are the origins that browsers can submit claims to
+	// HTTPAddr from. If empty, cross-origin requests are not allowed.
+	HTTPAllowedOrigins []string
+}
+
+// RunRelayerNode assembles and runs a relayer-only node, blocking until the
+// node is shut down. Typically, shutdown happens because a signal handler
+// cancels the passed in context, or when the shutdown RPC method is called.
+func RunRelayerNode(ctx context.Context, cfg *Config) (err error) {
+	// Note: err can be modified in defer blocks, so it needs to be a named return
+	//       value above.
+	if cfg.Libp2pKeyfile == "" {
+		cfg.Libp2pKeyfile = path.Join(cfg.EnvConf.DataDir, common.DefaultLibp2pKeyFileName)
+	}
+
+	if cfg.EnvConf.SwapCreatorAddr == (ethcommon.Address{}) {
+		panic("swap creator address not specified")
+	}
+
+	hostListenIP := "0.0.0.0"
+	if cfg.EnvConf.Env == common.Development {
+		hostListenIP = "127.0.0.1"
+	}
+
+	host, err := net.NewHost(&net.Config{
+		Ctx:       ctx,
+		Env:       cfg.EnvConf.Env,
+		ChainName: cfg.EnvConf.EthChain.Name,
+		DataDir:   cfg.EnvConf.DataDir,
+		Port:      cfg.Libp2pPort,
+		KeyFile:   cfg.Libp2pKeyfile,
+		Bootnodes: cfg.EnvConf.Bootnodes,
+		ListenIP:  hostListenIP,
+		IsRelayer: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if hostErr := host.Stop(); hostErr != nil {
+			err = multierror.Append(err, fmt.Errorf("error shutting down peer-to-peer services: %w", hostErr))
+		}
+	}()
+
+	claimRelayer := relayer.NewRelayer(&relayer.Config{
+		Ctx:             ctx,
+		EthClient:       cfg.EthereumClient,
+		SwapCreatorAddr: cfg.EnvConf.SwapCreatorAddr,
+		Limits:          cfg.Limits,
+	})
+
+	// we have no offers, so there is no maker handler
+	host.SetHandlers(nil, &relayHandler{relayer: claimRelayer})
+	if err = host.Start(); err != nil {
+		return err
+	}
+
+	if cfg.HTTPAddr != "" {
+		var httpServer *relayer.HTTPServer
+		httpServer, err = relayer.NewHTTPServer(ctx, cfg.HTTPAddr, claimRelayer, cfg.HTTPAllowedOrigins)
+		if err != nil {
+			return fmt.Errorf("failed to start relayer HTTP server: %w", err)
+		}
+		go func() {
+			if httpErr := httpServer.Serve(); httpErr != nil && !errors.Is(httpErr, context.Canceled) {
+				log.Errorf("relayer HTTP server failed: %s", httpErr)
+			}
+		}()
+	}
+
+	rpcServer, err := rpc.NewServer(&rpc.Config{
+		Ctx:      ctx,
+		Env:      cfg.EnvConf.Env,
+		EthChain: cfg.EnvConf.EthChain,
+		Address:  fmt.Sprintf("127.0.0.1:%d", cfg.RPCPort),
+		Net:      host,
+		Relayer:  claimRelayer,
+		Namespaces: map[string]struct{}{
+			rpc.DaemonNamespace:  {},
+			rpc.NetNamespace:     {},
+			rpc.RelayerNamespace: {},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("starting relayer with data-dir %s", cfg.EnvConf.DataDir)
+	err = rpcServer.Start()
+
+	if errors.Is(err, http.ErrServerClosed) {
+		// Remove the error for a clean program exit, as ErrServerClosed only
+		// happens when the server is told to shut down
+		err = nil
+	}
+
+	// err can get set in defer blocks, so return err or use an empty
+	// return statement below (not nil)
+	return err
+}
+
+// relayHandler implements net.RelayHandler for nodes that only relay the
+// claims and refunds of peers that found us in the DHT.
+type relayHandler struct {
+	relayer *relayer.Relayer
+}
+
+func (h *relayHandler) GetRelayerQuote(remotePeer peer.ID) (*message.RelayerQueryResponse, error) {
+	return h.relayer.Quote(remotePeer.String())
+}
+
+func (h *relayHandler) HandleRelayClaimRequest(
+	remotePeer peer.ID,
+	request *message.RelayClaimRequest,
+) (*message.RelayClaimResponse, error) {
+	// only our own swap counterparties set the offer ID
+	if request.OfferID != nil {
+		return nil, errNoSwaps
+	}
+
+	return h.relayer.RelayClaim(remotePeer.String(), request, true)
+}
+
+func (h *relayHandler) HandleRelayRefundRequest(
+	remotePeer peer.ID,
+	request *message.RelayRefundRequest,
+) (*message.RelayClaimResponse, error) {
+	return h.relayer.RelayRefund(remotePeer.String(), request, true)
+}
+
+func (h *relayHandler) HasOngoingSwapAsTaker(_ peer.ID) error {
+	return errNoSwaps
+}
@@ -14,15 +14,22 @@ import (
 type DaemonService struct {
 	stopServer      func()
 	env             common.Environment
+	chainName       string
 	swapCreatorAddr *ethcommon.Address
 }
 
 // NewDaemonService creates a new daemon service. `swapCreatorAddr` is optional
 // and not set by bootnodes.
-func NewDaemonService(stopServer func(), env common.Environment, swapCreatorAddr *ethcommon.Address) *DaemonService {
+func NewDaemonService(
+	stopServer func(),
+	env common.Environment,
+	chainName string,
+	swapCreatorAddr *ethcommon.Address,
+) *DaemonService {
 	return &DaemonService{
 		stopServer:      stopServer,
 		env:             env,
+		chainName:       chainName,
 		swapCreatorAddr: swapCreatorAddr,
 	}
 }
@@ -45,7 +52,7 @@ type VersionResponse struct {
 // Version returns version & misc info about swapd and its dependencies
 func (s *DaemonService) Version(_ *http.Request, _ *any, resp *VersionResponse) error {
 	resp.SwapdVersion = cliutil.GetVersion()
-	resp.P2PVersion = net.ChainProtocolID(s.env)
+	resp.P2PVersion = net.ChainProtocolID(s.chainName)
 	resp.Env = s.env
 	resp.SwapCreatorAddr = s.swapCreatorAddr
 	return nil
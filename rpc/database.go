@@ -4,8 +4,10 @@
 package rpc
 
 import (
+	"fmt"
 	"math/big"
 	"net/http"
+	"path/filepath"
 
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
@@ -22,15 +24,22 @@ type RecoveryDB interface {
 	GetCounterpartySwapPrivateKey(id types.Hash) (*mcrypto.PrivateSpendKey, error)
 }
 
+// Database contains methods for backing up the database.
+type Database interface {
+	Backup(archivePath string) (*db.Archive, error)
+}
+
 // DatabaseService ...
 type DatabaseService struct {
 	rdb RecoveryDB
+	sdb Database
 }
 
 // NewDatabaseService returns a new DatabaseService.
-func NewDatabaseService(rdb RecoveryDB) *DatabaseService {
+func NewDatabaseService(rdb RecoveryDB, sdb Database) *DatabaseService {
 	return &DatabaseService{
 		rdb: rdb,
+		sdb: sdb,
 	}
 }
 
@@ -89,3 +98,36 @@ func (s *DatabaseService) GetSwapSecret(
 	resp.Secret = key
 	return nil
 }
+
+// BackupRequest ...
+type BackupRequest struct {
+	Path string `json:"path" validate:"required"`
+}
+
+// BackupResponse ...
+type BackupResponse struct {
+	Path          string `json:"path" validate:"required"`
+	SchemaVersion uint64 `json:"schemaVersion"`
+	NumOffers     int    `json:"numOffers"`
+	NumSwaps      int    `json:"numSwaps"`
+}
+
+// Backup writes a consistent snapshot of the offers, swaps and recovery data
+// to a new archive file on the swapd host. The path must be absolute, and an
+// existing file is not overwritten.
+func (s *DatabaseService) Backup(_ *http.Request, req *BackupRequest, resp *BackupResponse) error {
+	if !filepath.IsAbs(req.Path) {
+		return fmt.Errorf("backup path %q is not absolute", req.Path)
+	}
+
+	archive, err := s.sdb.Backup(req.Path)
+	if err != nil {
+		return err
+	}
+
+	resp.Path = req.Path
+	resp.SchemaVersion = archive.SchemaVersion
+	resp.NumOffers = archive.NumOffers()
+	resp.NumSwaps = archive.NumSwaps()
+	return nil
+}
@@ -9,8 +9,11 @@ import (
 
 var (
 	// net_ errors
-	errNoOfferWithID          = errors.New("peer does not have offer with given ID")
-	errUnsupportedForBootnode = errors.New("unsupported for bootnode")
+	errNoOfferWithID           = errors.New("peer does not have offer with given ID")
+	errUnsupportedWithoutSwaps = errors.New("unsupported by nodes that do not swap")
+
+	// relayer_ errors
+	errNotRelayer = errors.New("not a relayer")
 
 	// ws errors
 	errInvalidMethod       = errors.New("invalid method")
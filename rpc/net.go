@@ -4,6 +4,7 @@
 package rpc
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -35,21 +36,21 @@ type Net interface {
 
 // NetService is the RPC service prefixed by net_.
 type NetService struct {
-	net        Net
-	xmrtaker   XMRTaker
-	xmrmaker   XMRMaker
-	sm         swap.Manager
-	isBootnode bool
+	net      Net
+	xmrtaker XMRTaker
+	xmrmaker XMRMaker
+	sm       swap.Manager
+	noSwaps  bool // bootnodes and relayer-only nodes do not swap
 }
 
 // NewNetService ...
-func NewNetService(net Net, xmrtaker XMRTaker, xmrmaker XMRMaker, sm swap.Manager, isBootnode bool) *NetService {
+func NewNetService(net Net, xmrtaker XMRTaker, xmrmaker XMRMaker, sm swap.Manager, noSwaps bool) *NetService {
 	return &NetService{
-		net:        net,
-		xmrtaker:   xmrtaker,
-		xmrmaker:   xmrmaker,
-		sm:         sm,
-		isBootnode: isBootnode,
+		net:      net,
+		xmrtaker: xmrtaker,
+		xmrmaker: xmrmaker,
+		sm:       sm,
+		noSwaps:  noSwaps,
 	}
 }
 
@@ -75,8 +76,8 @@ func (s *NetService) Peers(_ *http.Request, _ *interface{}, resp *rpctypes.Peers
 
 // QueryAll discovers peers who provide a certain coin and queries all of them for their current offers.
 func (s *NetService) QueryAll(_ *http.Request, req *rpctypes.QueryAllRequest, resp *rpctypes.QueryAllResponse) error {
-	if s.isBootnode {
-		return errUnsupportedForBootnode
+	if s.noSwaps {
+		return errUnsupportedWithoutSwaps
 	}
 
 	peerIDs, err := s.discover(req)
@@ -91,10 +92,11 @@ func (s *NetService) QueryAll(_ *http.Request, req *rpctypes.QueryAllRequest, re
 			log.Debugf("Failed to query peer ID %s", p)
 			continue
 		}
-		if len(msg.Offers) > 0 {
+		offers := s.checkOffers(msg.Offers)
+		if len(offers) > 0 {
 			resp.PeersWithOffers = append(resp.PeersWithOffers, &rpctypes.PeerWithOffers{
 				PeerID: p,
-				Offers: msg.Offers,
+				Offers: offers,
 			})
 		}
 	}
@@ -140,8 +142,8 @@ func (s *NetService) QueryPeer(
 	req *rpctypes.QueryPeerRequest,
 	resp *rpctypes.QueryPeerResponse,
 ) error {
-	if s.isBootnode {
-		return errUnsupportedForBootnode
+	if s.noSwaps {
+		return errUnsupportedWithoutSwaps
 	}
 
 	msg, err := s.net.Query(req.PeerID)
@@ -149,18 +151,37 @@ func (s *NetService) QueryPeer(
 		return err
 	}
 
-	resp.Offers = msg.Offers
+	resp.Offers = s.checkOffers(msg.Offers)
 	return nil
 }
 
+// checkOffers returns the offers without those whose min amount is not greater
+// than the current relayer fee, as the maker could not claim them with a
+// relayer. Offers that can't be checked are kept.
+func (s *NetService) checkOffers(offers []*types.Offer) []*types.Offer {
+	checked := make([]*types.Offer, 0, len(offers))
+	for _, offer := range offers {
+		err := s.xmrtaker.CheckOfferMinAmount(offer)
+		if errors.Is(err, types.ErrMinAmountBelowRelayerFee) {
+			log.Debugf("Skipping offer %s: %s", offer.ID, err)
+			continue
+		}
+		if err != nil {
+			log.Debugf("Failed to check the min amount of offer %s: %s", offer.ID, err)
+		}
+		checked = append(checked, offer)
+	}
+	return checked
+}
+
 // TakeOffer initiates a swap with the given peer by taking an offer they've made.
 func (s *NetService) TakeOffer(
 	_ *http.Request,
 	req *rpctypes.TakeOfferRequest,
 	_ *interface{},
 ) error {
-	if s.isBootnode {
-		return errUnsupportedForBootnode
+	if s.noSwaps {
+		return errUnsupportedWithoutSwaps
 	}
 
 	err := s.takeOffer(req.PeerID, req.OfferID, req.ProvidesAmount)
@@ -213,8 +234,8 @@ func (s *NetService) MakeOffer(
 	req *rpctypes.MakeOfferRequest,
 	resp *rpctypes.MakeOfferResponse,
 ) error {
-	if s.isBootnode {
-		return errUnsupportedForBootnode
+	if s.noSwaps {
+		return errUnsupportedWithoutSwaps
 	}
 
 	offerResp, err := s.makeOffer(req)
@@ -226,7 +247,8 @@ func (s *NetService) MakeOffer(
 }
 
 func (s *NetService) makeOffer(req *rpctypes.MakeOfferRequest) (*rpctypes.MakeOfferResponse, error) {
-	offer := types.NewOffer(
+	offer := types.NewOfferForChain(
+		req.ChainID,
 		coins.ProvidesXMR,
 		req.MinAmount,
 		req.MaxAmount,
@@ -234,7 +256,10 @@ func (s *NetService) makeOffer(req *rpctypes.MakeOfferRequest) (*rpctypes.MakeOf
 		req.EthAsset,
 	)
 
-	_, err := s.xmrmaker.MakeOffer(offer, req.UseRelayer)
+	extra := types.NewOfferExtra(req.UseRelayer)
+	extra.XMRPriority = req.XMRPriority
+
+	_, err := s.xmrmaker.MakeOffer(offer, extra)
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common/rpctypes"
+	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 
 	"github.com/cockroachdb/apd/v3"
@@ -126,8 +127,9 @@ func (s *PersonalService) Balances(
 
 // TransferXMRRequest ...
 type TransferXMRRequest struct {
-	To     *mcrypto.Address `json:"to" validate:"required"`
-	Amount *apd.Decimal     `json:"amount" validate:"required"`
+	To       *mcrypto.Address       `json:"to" validate:"required"`
+	Amount   *apd.Decimal           `json:"amount" validate:"required"`
+	Priority types.MoneroTxPriority `json:"priority,omitempty"`
 }
 
 // TransferXMRResponse ...
@@ -137,7 +139,7 @@ type TransferXMRResponse struct {
 
 // TransferXMR transfers XMR from the swapd wallet.
 func (s *PersonalService) TransferXMR(_ *http.Request, req *TransferXMRRequest, resp *TransferXMRResponse) error {
-	txID, err := s.pb.TransferXMR(req.To, coins.MoneroToPiconero(req.Amount))
+	txID, err := s.pb.TransferXMR(req.To, coins.MoneroToPiconero(req.Amount), req.Priority)
 	if err != nil {
 		return err
 	}
@@ -148,7 +150,8 @@ func (s *PersonalService) TransferXMR(_ *http.Request, req *TransferXMRRequest,
 
 // SweepXMRRequest ...
 type SweepXMRRequest struct {
-	To *mcrypto.Address `json:"to" validate:"required"`
+	To       *mcrypto.Address       `json:"to" validate:"required"`
+	Priority types.MoneroTxPriority `json:"priority,omitempty"`
 }
 
 // SweepXMRResponse ...
@@ -158,7 +161,7 @@ type SweepXMRResponse struct {
 
 // SweepXMR sweeps XMR from the swapd wallet.
 func (s *PersonalService) SweepXMR(_ *http.Request, req *SweepXMRRequest, resp *SweepXMRResponse) error {
-	txIDs, err := s.pb.SweepXMR(req.To)
+	txIDs, err := s.pb.SweepXMR(req.To, req.Priority)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
@@ -201,6 +202,88 @@ func SetupMetrics(
 	}
 }
 
+// relayerCollector collects the metrics of the claims that we relayed for
+// other nodes. Token fees are labeled by token, so their metrics are created
+// on each collection.
+type relayerCollector struct {
+	relayer         Relayer
+	claimsRelayed   *prometheus.Desc
+	claimsRejected  *prometheus.Desc
+	refundsRelayed  *prometheus.Desc
+	refundsRejected *prometheus.Desc
+	gasSpent        *prometheus.Desc
+	feesEarned      *prometheus.Desc
+}
+
+// SetupRelayerMetrics registers the prometheus metrics of the relayer
+func SetupRelayerMetrics(reg *prometheus.Registry, relayer Relayer) error {
+	return reg.Register(&relayerCollector{
+		relayer: relayer,
+		claimsRelayed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "relayer", "claims_relayed_total"),
+			"The number of claims that we relayed",
+			nil, nil,
+		),
+		claimsRejected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "relayer", "claims_rejected_total"),
+			"The number of claim requests that we rejected or failed to relay",
+			nil, nil,
+		),
+		refundsRelayed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "relayer", "refunds_relayed_total"),
+			"The number of refunds that we relayed",
+			nil, nil,
+		),
+		refundsRejected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "relayer", "refunds_rejected_total"),
+			"The number of refund requests that we rejected or failed to relay",
+			nil, nil,
+		),
+		gasSpent: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "relayer", "gas_spent_wei_total"),
+			"The wei spent on gas relaying claims",
+			nil, nil,
+		),
+		feesEarned: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "relayer", "fees_earned_total"),
+			"The fees earned relaying claims, in the smallest unit of the asset",
+			[]string{"asset"}, nil,
+		),
+	})
+}
+
+// Describe implements prometheus.Collector
+func (c *relayerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.claimsRelayed
+	ch <- c.claimsRejected
+	ch <- c.refundsRelayed
+	ch <- c.refundsRejected
+	ch <- c.gasSpent
+	ch <- c.feesEarned
+}
+
+// Collect implements prometheus.Collector
+func (c *relayerCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.relayer.Stats()
+
+	bigToFloat := func(n *big.Int) float64 {
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.claimsRelayed, prometheus.CounterValue, float64(stats.ClaimsRelayed))
+	ch <- prometheus.MustNewConstMetric(c.claimsRejected, prometheus.CounterValue, float64(stats.ClaimsRejected))
+	ch <- prometheus.MustNewConstMetric(c.refundsRelayed, prometheus.CounterValue, float64(stats.RefundsRelayed))
+	ch <- prometheus.MustNewConstMetric(c.refundsRejected, prometheus.CounterValue, float64(stats.RefundsRejected))
+	ch <- prometheus.MustNewConstMetric(c.gasSpent, prometheus.CounterValue, bigToFloat(stats.GasSpent))
+	ch <- prometheus.MustNewConstMetric(
+		c.feesEarned, prometheus.CounterValue, bigToFloat(stats.ETHFeesEarned), types.EthAssetETH.String(),
+	)
+	for asset, earned := range stats.TokenFeesEarned {
+		ch <- prometheus.MustNewConstMetric(c.feesEarned, prometheus.CounterValue, bigToFloat(earned), asset.String())
+	}
+}
+
 // NewPrometheusRegistry returns a new prometheus registry with default collectors registered
 func NewPrometheusRegistry() (*prometheus.Registry, error) {
 	reg := prometheus.NewRegistry()
@@ -0,0 +1,53 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/types"
+)
+
+// RelayerService handles RPC requests about the claims that we relay for other
+// nodes.
+type RelayerService struct {
+	relayer Relayer
+}
+
+// NewRelayerService creates a new relayer service. The relayer is nil if we
+// do not relay claims.
+func NewRelayerService(relayer Relayer) *RelayerService {
+	return &RelayerService{relayer: relayer}
+}
+
+// RelayerStatsResponse contains the totals of the claims that we relayed since
+// we started.
+type RelayerStatsResponse struct {
+	ClaimsRelayed    uint64                      `json:"claimsRelayed"`
+	ClaimsRejected   uint64                      `json:"claimsRejected"`
+	RefundsRelayed   uint64                      `json:"refundsRelayed"`
+	RefundsRejected  uint64                      `json:"refundsRejected"`
+	GasSpentWei      *coins.WeiAmount            `json:"gasSpentWei" validate:"required"`
+	ETHFeesEarnedWei *coins.WeiAmount            `json:"ethFeesEarnedWei" validate:"required"`
+	TokenFeesEarned  map[types.EthAsset]*big.Int `json:"tokenFeesEarned"` // in each token's smallest unit
+}
+
+// Stats returns the totals of the claims that we relayed
+func (s *RelayerService) Stats(_ *http.Request, _ *any, resp *RelayerStatsResponse) error {
+	if s.relayer == nil {
+		return errNotRelayer
+	}
+
+	stats := s.relayer.Stats()
+	resp.ClaimsRelayed = stats.ClaimsRelayed
+	resp.ClaimsRejected = stats.ClaimsRejected
+	resp.RefundsRelayed = stats.RefundsRelayed
+	resp.RefundsRejected = stats.RefundsRejected
+	resp.GasSpentWei = coins.NewWeiAmount(stats.GasSpent)
+	resp.ETHFeesEarnedWei = coins.NewWeiAmount(stats.ETHFeesEarned)
+	resp.TokenFeesEarned = stats.TokenFeesEarned
+	return nil
+}
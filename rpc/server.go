@@ -27,11 +27,13 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
 	"github.com/athanorlabs/atomic-swap/protocol/swap"
 	"github.com/athanorlabs/atomic-swap/protocol/txsender"
+	"github.com/athanorlabs/atomic-swap/relayer"
 )
 
 const (
@@ -40,6 +42,7 @@ const (
 	NetNamespace      = "net"      //nolint:revive
 	PersonalName      = "personal" //nolint:revive
 	SwapNamespace     = "swap"     //nolint:revive
+	RelayerNamespace  = "relayer"  //nolint:revive
 )
 
 var log = logging.Logger("rpc")
@@ -55,12 +58,15 @@ type Server struct {
 type Config struct {
 	Ctx             context.Context
 	Env             common.Environment
-	Address         string // "IP:port"
+	EthChain        *common.EthChain // defaults to the chain of Env, unused by bootnodes
+	Address         string           // "IP:port"
 	Net             Net
 	XMRTaker        XMRTaker        // nil on bootnodes
 	XMRMaker        XMRMaker        // nil on bootnodes
-	ProtocolBackend ProtocolBackend // nil on bootnodes
+	ProtocolBackend ProtocolBackend // nil on bootnodes and relayer-only nodes
+	Relayer         Relayer         // nil if we do not relay claims
 	RecoveryDB      RecoveryDB      // nil on bootnodes
+	Database        Database        // nil on bootnodes
 	Namespaces      map[string]struct{}
 }
 
@@ -72,6 +78,7 @@ func AllNamespaces() map[string]struct{} {
 		NetNamespace:      {},
 		PersonalName:      {},
 		SwapNamespace:     {},
+		RelayerNamespace:  {},
 	}
 }
 
@@ -81,21 +88,35 @@ func NewServer(cfg *Config) (*Server, error) {
 	rpcServer.RegisterCodec(NewCodec(), "application/json")
 
 	isBootnode := cfg.Env == common.Bootnode
+	hasSwaps := cfg.ProtocolBackend != nil // false on bootnodes and relayer-only nodes
 
 	serverCtx, serverCancel := context.WithCancel(cfg.Ctx)
-	var swapCreatorAddr *ethcommon.Address
+	var (
+		swapCreatorAddr *ethcommon.Address
+		ethChain        = cfg.EthChain
+		chainName       = common.ChainNameFromEnv(common.Bootnode)
+	)
 	if !isBootnode {
-		addr := cfg.ProtocolBackend.SwapCreatorAddr()
+		var addr ethcommon.Address
+		if hasSwaps {
+			addr = cfg.ProtocolBackend.SwapCreatorAddr()
+		} else {
+			addr = cfg.Relayer.SwapCreatorAddr()
+		}
 		swapCreatorAddr = &addr
+		if ethChain == nil {
+			ethChain = common.DefaultEthChainForEnv(cfg.Env)
+		}
+		chainName = ethChain.Name
 	}
-	daemonService := NewDaemonService(serverCancel, cfg.Env, swapCreatorAddr)
+	daemonService := NewDaemonService(serverCancel, cfg.Env, chainName, swapCreatorAddr)
 	err := rpcServer.RegisterService(daemonService, "daemon")
 	if err != nil {
 		return nil, err
 	}
 
 	var swapManager swap.Manager
-	if !isBootnode {
+	if hasSwaps {
 		swapManager = cfg.ProtocolBackend.SwapManager()
 	}
 
@@ -105,9 +126,9 @@ func NewServer(cfg *Config) (*Server, error) {
 		case DaemonNamespace:
 			continue
 		case DatabaseNamespace:
-			err = rpcServer.RegisterService(NewDatabaseService(cfg.RecoveryDB), DatabaseNamespace)
+			err = rpcServer.RegisterService(NewDatabaseService(cfg.RecoveryDB, cfg.Database), DatabaseNamespace)
 		case NetNamespace:
-			netService = NewNetService(cfg.Net, cfg.XMRTaker, cfg.XMRMaker, swapManager, isBootnode)
+			netService = NewNetService(cfg.Net, cfg.XMRTaker, cfg.XMRMaker, swapManager, !hasSwaps)
 			err = rpcServer.RegisterService(netService, NetNamespace)
 		case PersonalName:
 			err = rpcServer.RegisterService(NewPersonalService(serverCtx, cfg.XMRMaker, cfg.ProtocolBackend), PersonalName)
@@ -121,9 +142,12 @@ func NewServer(cfg *Config) (*Server, error) {
 					cfg.Net,
 					cfg.ProtocolBackend,
 					cfg.RecoveryDB,
+					ethChain,
 				),
 				SwapNamespace,
 			)
+		case RelayerNamespace:
+			err = rpcServer.RegisterService(NewRelayerService(cfg.Relayer), RelayerNamespace)
 		default:
 			err = fmt.Errorf("unknown namespace %s", ns)
 		}
@@ -147,9 +171,15 @@ func NewServer(cfg *Config) (*Server, error) {
 		return nil, err
 	}
 
-	if !isBootnode {
+	if hasSwaps {
 		SetupMetrics(serverCtx, reg, cfg.Net, cfg.ProtocolBackend, cfg.XMRMaker)
 	}
+	if cfg.Relayer != nil {
+		if err = SetupRelayerMetrics(reg, cfg.Relayer); err != nil {
+			serverCancel()
+			return nil, err
+		}
+	}
 	r := mux.NewRouter()
 	r.Handle("/", rpcServer)
 	r.Handle("/ws", wsServer)
@@ -232,30 +262,46 @@ type Protocol interface {
 type ProtocolBackend interface {
 	Ctx() context.Context
 	Env() common.Environment
+	Clock() clock.Clock
 	SetSwapTimeout(timeout time.Duration)
 	SwapTimeout() time.Duration
 	SwapManager() swap.Manager
 	SwapCreatorAddr() ethcommon.Address
+	PayoutAddress() ethcommon.Address
+	PayoutFallback() bool
 	SetXMRDepositAddress(*mcrypto.Address, types.Hash)
 	ClearXMRDepositAddress(types.Hash)
 	ETHClient() extethclient.EthClient
-	TransferXMR(to *mcrypto.Address, amount *coins.PiconeroAmount) (string, error)
-	SweepXMR(to *mcrypto.Address) ([]string, error)
+	ETHClientForChain(chainID uint64) (extethclient.EthClient, error)
+	SwapCreatorAddrForChain(chainID uint64) (ethcommon.Address, error)
+	TransferXMR(
+		to *mcrypto.Address,
+		amount *coins.PiconeroAmount,
+		priority types.MoneroTxPriority,
+	) (string, error)
+	SweepXMR(to *mcrypto.Address, priority types.MoneroTxPriority) ([]string, error)
 	TransferETH(to ethcommon.Address, amount *coins.WeiAmount, gasLimit *uint64) (*ethtypes.Receipt, error)
 	SweepETH(to ethcommon.Address) (*ethtypes.Receipt, error)
 }
 
+// Relayer represents relayer.Relayer
+type Relayer interface {
+	SwapCreatorAddr() ethcommon.Address
+	Stats() *relayer.Stats
+}
+
 // XMRTaker ...
 type XMRTaker interface {
 	Protocol
 	InitiateProtocol(peerID peer.ID, providesAmount *apd.Decimal, offer *types.Offer) (common.SwapState, error)
+	CheckOfferMinAmount(offer *types.Offer) error
 	ExternalSender(offerID types.Hash) (*txsender.ExternalSender, error)
 }
 
 // XMRMaker ...
 type XMRMaker interface {
 	Protocol
-	MakeOffer(offer *types.Offer, useRelayer bool) (*types.OfferExtra, error)
+	MakeOffer(offer *types.Offer, extra *types.OfferExtra) (*types.OfferExtra, error)
 	GetOffers() []*types.Offer
 	ClearOffers([]types.Hash) error
 	GetMoneroBalance() (*mcrypto.Address, *wallet.GetBalanceResponse, error)
@@ -6,19 +6,25 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"net/http"
 	"sort"
 	"time"
 
 	"github.com/cockroachdb/apd/v3"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
 	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/db"
 	contracts "github.com/athanorlabs/atomic-swap/ethereum"
+	"github.com/athanorlabs/atomic-swap/ethereum/extethclient"
 	"github.com/athanorlabs/atomic-swap/pricefeed"
 	"github.com/athanorlabs/atomic-swap/protocol/swap"
+	"github.com/athanorlabs/atomic-swap/protocol/txsender"
 )
 
 // SwapService handles information about ongoing or past swaps.
@@ -30,6 +36,7 @@ type SwapService struct {
 	net      Net
 	backend  ProtocolBackend
 	rdb      RecoveryDB
+	ethChain *common.EthChain
 }
 
 // NewSwapService ...
@@ -41,6 +48,7 @@ func NewSwapService(
 	net Net,
 	b ProtocolBackend,
 	rdb RecoveryDB,
+	ethChain *common.EthChain,
 ) *SwapService {
 	return &SwapService{
 		ctx:      ctx,
@@ -50,21 +58,23 @@ func NewSwapService(
 		net:      net,
 		backend:  b,
 		rdb:      rdb,
+		ethChain: ethChain,
 	}
 }
 
 // PastSwap represents a past swap returned by swap_getPast.
 type PastSwap struct {
-	ID             types.Hash          `json:"id" validate:"required"`
-	Provided       coins.ProvidesCoin  `json:"provided" validate:"required"`
-	EthAsset       types.EthAsset      `json:"ethAsset"`
-	ProvidedAmount *apd.Decimal        `json:"providedAmount" validate:"required"`
-	ExpectedAmount *apd.Decimal        `json:"expectedAmount" validate:"required"`
-	RelayerFee     *apd.Decimal        `json:"relayerFee"`
-	ExchangeRate   *coins.ExchangeRate `json:"exchangeRate" validate:"required"`
-	Status         types.Status        `json:"status" validate:"required"`
-	StartTime      time.Time           `json:"startTime" validate:"required"`
-	EndTime        *time.Time          `json:"endTime"`
+	ID             types.Hash            `json:"id" validate:"required"`
+	Provided       coins.ProvidesCoin    `json:"provided" validate:"required"`
+	EthAsset       types.EthAsset        `json:"ethAsset"`
+	ProvidedAmount *apd.Decimal          `json:"providedAmount" validate:"required"`
+	ExpectedAmount *apd.Decimal          `json:"expectedAmount" validate:"required"`
+	RelayerFee     *apd.Decimal          `json:"relayerFee"`
+	MoneroFee      *coins.PiconeroAmount `json:"moneroFee,omitempty"`
+	ExchangeRate   *coins.ExchangeRate   `json:"exchangeRate" validate:"required"`
+	Status         types.Status          `json:"status" validate:"required"`
+	StartTime      time.Time             `json:"startTime" validate:"required"`
+	EndTime        *time.Time            `json:"endTime"`
 }
 
 // GetPastRequest ...
@@ -115,6 +125,7 @@ func (s *SwapService) GetPast(_ *http.Request, req *GetPastRequest, resp *GetPas
 			ProvidedAmount: info.ProvidedAmount,
 			ExpectedAmount: info.ExpectedAmount,
 			RelayerFee:     info.RelayerFee,
+			MoneroFee:      info.MoneroFee,
 			ExchangeRate:   info.ExchangeRate,
 			Status:         info.Status,
 			StartTime:      info.StartTime,
@@ -132,6 +143,7 @@ func (s *SwapService) GetPast(_ *http.Request, req *GetPastRequest, resp *GetPas
 // OngoingSwap represents an ongoing swap returned by swap_getOngoing.
 type OngoingSwap struct {
 	ID                        types.Hash          `json:"id" validate:"required"`
+	ChainID                   uint64              `json:"chainID,omitempty"`
 	Provided                  coins.ProvidesCoin  `json:"provided" validate:"required"`
 	EthAsset                  types.EthAsset      `json:"ethAsset"`
 	ProvidedAmount            *apd.Decimal        `json:"providedAmount" validate:"required"`
@@ -143,6 +155,7 @@ type OngoingSwap struct {
 	Timeout1                  *time.Time          `json:"timeout1"`
 	Timeout2                  *time.Time          `json:"timeout2"`
 	EstimatedTimeToCompletion time.Duration       `json:"estimatedTimeToCompletion" validate:"required"`
+	LastTxError               string              `json:"lastTxError,omitempty"`
 }
 
 // GetOngoingRequest ...
@@ -184,6 +197,7 @@ func (s *SwapService) GetOngoing(_ *http.Request, req *GetOngoingRequest, resp *
 	for i, info := range swaps {
 		swap := new(OngoingSwap)
 		swap.ID = info.OfferID
+		swap.ChainID = info.ChainID
 		swap.Provided = info.Provides
 		swap.EthAsset = info.EthAsset
 		swap.ProvidedAmount = info.ProvidedAmount
@@ -194,7 +208,14 @@ func (s *SwapService) GetOngoing(_ *http.Request, req *GetOngoingRequest, resp *
 		swap.StartTime = info.StartTime
 		swap.Timeout1 = info.Timeout1
 		swap.Timeout2 = info.Timeout2
-		swap.EstimatedTimeToCompletion, err = estimatedTimeToCompletion(env, info.Status, info.LastStatusUpdateTime)
+		swap.LastTxError = info.LastTxError
+		swap.EstimatedTimeToCompletion, err = estimatedTimeToCompletion(
+			env,
+			s.ethBlockTime(info.ChainID),
+			info.Status,
+			info.LastStatusUpdateTime,
+			s.backend.Clock().Now(),
+		)
 		if err != nil {
 			return fmt.Errorf("failed to estimate time to completion for swap %s: %w", info.OfferID, err)
 		}
@@ -219,6 +240,7 @@ type GetStatusResponse struct {
 	Status      types.Status `json:"status" validate:"required"`
 	Description string       `json:"info" validate:"required"`
 	StartTime   time.Time    `json:"startTime" validate:"required"`
+	LastTxError string       `json:"lastTxError,omitempty"`
 }
 
 // GetStatus returns the status of the ongoing swap, if there is one.
@@ -231,6 +253,7 @@ func (s *SwapService) GetStatus(_ *http.Request, req *GetStatusRequest, resp *Ge
 	resp.Status = info.Status
 	resp.Description = info.Status.Description()
 	resp.StartTime = info.StartTime
+	resp.LastTxError = info.LastTxError
 	return nil
 }
 
@@ -335,8 +358,7 @@ func (s *SwapService) Claim(_ *http.Request, req *ManualTransactionRequest, resp
 		return err
 	}
 
-	ec := s.backend.ETHClient()
-	swapCreator, err := contracts.NewSwapCreator(contractSwapInfo.SwapCreatorAddr, ec.Raw())
+	ec, err := s.swapETHClient(req.OfferID)
 	if err != nil {
 		return err
 	}
@@ -349,7 +371,9 @@ func (s *SwapService) Claim(_ *http.Request, req *ManualTransactionRequest, resp
 		return err
 	}
 
-	tx, err := swapCreator.Claim(txOpts, *contractSwapInfo.Swap, [32]byte(common.Reverse(secret.Bytes())))
+	tx, err := contracts.ClaimWithPayout(s.backend.Ctx(), ec.Raw(), contractSwapInfo.SwapCreatorAddr, txOpts,
+		contractSwapInfo.Swap, [32]byte(common.Reverse(secret.Bytes())), s.backend.PayoutAddress(),
+		s.backend.PayoutFallback())
 	if err != nil {
 		return err
 	}
@@ -374,8 +398,7 @@ func (s *SwapService) Refund(_ *http.Request, req *ManualTransactionRequest, res
 		return err
 	}
 
-	ec := s.backend.ETHClient()
-	swapCreator, err := contracts.NewSwapCreator(contractSwapInfo.SwapCreatorAddr, ec.Raw())
+	ec, err := s.swapETHClient(req.OfferID)
 	if err != nil {
 		return err
 	}
@@ -388,7 +411,9 @@ func (s *SwapService) Refund(_ *http.Request, req *ManualTransactionRequest, res
 		return err
 	}
 
-	tx, err := swapCreator.Refund(txOpts, *contractSwapInfo.Swap, [32]byte(common.Reverse(secret.Bytes())))
+	tx, err := contracts.RefundWithPayout(s.backend.Ctx(), ec.Raw(), contractSwapInfo.SwapCreatorAddr, txOpts,
+		contractSwapInfo.Swap, [32]byte(common.Reverse(secret.Bytes())), s.backend.PayoutAddress(),
+		s.backend.PayoutFallback())
 	if err != nil {
 		return err
 	}
@@ -397,6 +422,429 @@ func (s *SwapService) Refund(_ *http.Request, req *ManualTransactionRequest, res
 	return nil
 }
 
+// maxBatchSize is the max number of swaps claimed or refunded by a single batch
+// transaction, which keeps its gas well under the block gas limit
+const maxBatchSize = 20
+
+// BatchTransactionRequest is used to call swap_claimBatch or swap_refundBatch.
+// If no offer IDs are passed, all swaps in the recovery database that can be
+// claimed or refunded are batched.
+type BatchTransactionRequest struct {
+	OfferIDs []types.Hash `json:"offerIDs"`
+}
+
+// BatchTransaction is a transaction that claimed or refunded a batch of swaps.
+type BatchTransaction struct {
+	TxHash   types.Hash   `json:"txHash" validate:"required"`
+	OfferIDs []types.Hash `json:"offerIDs" validate:"required"`
+}
+
+// BatchTransactionResponse is returned from swap_claimBatch or
+// swap_refundBatch. Transactions is empty if there were no swaps to batch.
+type BatchTransactionResponse struct {
+	Transactions []*BatchTransaction `json:"transactions" validate:"dive,required"`
+}
+
+// ClaimBatch claims many swaps using the swap recovery info stored in the
+// database, with one transaction for each batch of up to 20 swaps of the same
+// chain and contract. Like Claim, it is meant for swaps that the normal swap
+// process failed to claim, eg. after an outage.
+func (s *SwapService) ClaimBatch(_ *http.Request, req *BatchTransactionRequest, resp *BatchTransactionResponse) error {
+	return s.sendBatches(req.OfferIDs, false, resp)
+}
+
+// RefundBatch refunds many swaps using the swap recovery info stored in the
+// database, with one transaction for each batch of up to 20 swaps of the same
+// chain and contract. Like Refund, it is meant for swaps that the normal swap
+// process failed to refund, eg. after an outage.
+func (s *SwapService) RefundBatch(_ *http.Request, req *BatchTransactionRequest, resp *BatchTransactionResponse) error {
+	return s.sendBatches(req.OfferIDs, true, resp)
+}
+
+// swapBatch is a group of swaps on the same chain and contract that are
+// claimed or refunded together
+type swapBatch struct {
+	ec              extethclient.EthClient
+	swapCreatorAddr ethcommon.Address
+	offerIDs        []types.Hash
+	swaps           []*contracts.SwapCreatorSwap
+	secrets         [][32]byte
+}
+
+// batchSwap is a swap that we can claim or refund now
+type batchSwap struct {
+	info   *db.EthereumSwapInfo
+	secret [32]byte
+	ec     extethclient.EthClient
+}
+
+func (s *SwapService) sendBatches(offerIDs []types.Hash, refund bool, resp *BatchTransactionResponse) error {
+	explicit := len(offerIDs) > 0
+	if !explicit {
+		var err error
+		offerIDs, err = s.batchCandidates()
+		if err != nil {
+			return err
+		}
+	}
+
+	batches, err := s.groupBatches(offerIDs, refund, explicit)
+	if err != nil {
+		return err
+	}
+
+	resp.Transactions = []*BatchTransaction{}
+	for _, b := range batches {
+		swapCreator, err := contracts.NewSwapCreator(b.swapCreatorAddr, b.ec.Raw()) //nolint:govet
+		if err != nil {
+			return err
+		}
+		sender := txsender.NewSenderWithPrivateKey(s.backend.Ctx(), b.ec, b.swapCreatorAddr, swapCreator, nil)
+		sender.SetPayoutAddress(s.backend.PayoutAddress(), s.backend.PayoutFallback())
+
+		for start := 0; start < len(b.swaps); start += maxBatchSize {
+			end := start + maxBatchSize
+			if end > len(b.swaps) {
+				end = len(b.swaps)
+			}
+
+			receipt, err := sendBatch(sender, b.swaps[start:end], b.secrets[start:end], refund) //nolint:govet
+			if err != nil {
+				return fmt.Errorf("failed to send batch of swaps %v after %d batch transactions: %w",
+					b.offerIDs[start:end], len(resp.Transactions), err)
+			}
+
+			resp.Transactions = append(resp.Transactions, &BatchTransaction{
+				TxHash:   receipt.TxHash,
+				OfferIDs: b.offerIDs[start:end],
+			})
+		}
+	}
+
+	return nil
+}
+
+// sendBatch claims or refunds the swaps. A single swap is sent without the
+// batch entry point, so that it also works on contracts deployed before batch
+// support.
+func sendBatch(
+	sender txsender.Sender,
+	swaps []*contracts.SwapCreatorSwap,
+	secrets [][32]byte,
+	refund bool,
+) (*ethtypes.Receipt, error) {
+	switch {
+	case len(swaps) == 1 && refund:
+		return sender.Refund(swaps[0], secrets[0])
+	case len(swaps) == 1:
+		return sender.Claim(swaps[0], secrets[0])
+	case refund:
+		return sender.RefundBatch(swaps, secrets)
+	default:
+		return sender.ClaimBatch(swaps, secrets)
+	}
+}
+
+// batchCandidates returns the offer IDs of the ongoing swaps and of the past
+// swaps that did not complete with a claim or refund. Ongoing swaps that are
+// still run by their swap protocol are skipped by getBatchSwap.
+func (s *SwapService) batchCandidates() ([]types.Hash, error) {
+	ongoingIDs, err := s.sm.GetOngoingSwapOfferIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var offerIDs []types.Hash
+	for _, id := range ongoingIDs {
+		offerIDs = append(offerIDs, *id)
+	}
+
+	pastIDs, err := s.sm.GetPastIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range pastIDs {
+		info, err := s.sm.GetPastSwap(id) //nolint:govet
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Status == types.CompletedSuccess || info.Status == types.CompletedRefund {
+			continue
+		}
+
+		offerIDs = append(offerIDs, id)
+	}
+
+	return offerIDs, nil
+}
+
+// groupBatches groups the swaps that we can claim, or refund, by chain and
+// contract. Swaps that we can not claim or refund now are skipped, unless they
+// were explicitly requested, in which case an error is returned.
+func (s *SwapService) groupBatches(offerIDs []types.Hash, refund bool, explicit bool) ([]*swapBatch, error) {
+	var batches []*swapBatch
+	blockTimes := make(map[uint64]time.Time)
+
+	for _, offerID := range offerIDs {
+		bs, err := s.getBatchSwap(offerID, refund, blockTimes)
+		if err != nil {
+			if explicit {
+				return nil, err
+			}
+			log.Debugf("not batching swap %s: %s", offerID, err)
+			continue
+		}
+
+		var batch *swapBatch
+		for _, b := range batches {
+			if b.ec == bs.ec && b.swapCreatorAddr == bs.info.SwapCreatorAddr {
+				batch = b
+				break
+			}
+		}
+		if batch == nil {
+			batch = &swapBatch{ec: bs.ec, swapCreatorAddr: bs.info.SwapCreatorAddr}
+			batches = append(batches, batch)
+		}
+
+		batch.offerIDs = append(batch.offerIDs, offerID)
+		batch.swaps = append(batch.swaps, bs.info.Swap)
+		batch.secrets = append(batch.secrets, bs.secret)
+	}
+
+	return batches, nil
+}
+
+// getBatchSwap returns the swap with the given offer ID, if we can claim or
+// refund it now. Block timestamps are cached by chain ID in blockTimes.
+func (s *SwapService) getBatchSwap(
+	offerID types.Hash,
+	refund bool,
+	blockTimes map[uint64]time.Time,
+) (*batchSwap, error) {
+	// the swap protocol claims or refunds the swap itself, and a batch would
+	// race its transaction
+	if s.hasSwapState(offerID) {
+		return nil, fmt.Errorf("swap %s is still running, cancel it first", offerID)
+	}
+
+	info, err := s.rdb.GetContractSwapInfo(offerID)
+	if err != nil {
+		return nil, fmt.Errorf("no recovery info for swap %s: %w", offerID, err)
+	}
+
+	ec, err := s.swapETHClient(offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	action, party := "claim", info.Swap.Claimer
+	if refund {
+		action, party = "refund", info.Swap.Owner
+	}
+	if party != ec.Address() {
+		return nil, fmt.Errorf("only %s can %s swap %s", party, action, offerID)
+	}
+
+	swapCreator, err := contracts.NewSwapCreator(info.SwapCreatorAddr, ec.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := swapCreator.Swaps(ec.CallOpts(s.backend.Ctx()), info.SwapID)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := ec.ChainID().Uint64()
+	blockTime, ok := blockTimes[chainID]
+	if !ok {
+		blockTime, err = ec.LatestBlockTimestamp(s.backend.Ctx())
+		if err != nil {
+			return nil, err
+		}
+		blockTimes[chainID] = blockTime
+	}
+
+	canSend := info.Swap.CanClaim(stage, blockTime)
+	if refund {
+		canSend = info.Swap.CanRefund(stage, blockTime)
+	}
+	if !canSend {
+		return nil, fmt.Errorf("can not %s swap %s in contract stage %s at block time %s",
+			action, offerID, contracts.StageToString(stage), blockTime.Format(common.TimeFmtSecs))
+	}
+
+	secret, err := s.rdb.GetSwapPrivateKey(offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSwap{
+		info:   info,
+		secret: [32]byte(common.Reverse(secret.Bytes())),
+		ec:     ec,
+	}, nil
+}
+
+// hasSwapState returns whether the swap with the given offer ID is run by the
+// XMR taker or XMR maker's swap protocol
+func (s *SwapService) hasSwapState(offerID types.Hash) bool {
+	return s.xmrtaker.GetOngoingSwapState(offerID) != nil || s.xmrmaker.GetOngoingSwapState(offerID) != nil
+}
+
+// swapETHClient returns the ethereum client of the chain that the swap with
+// the given offer ID runs on. Swaps that are not found in the swap manager use
+// the primary chain.
+func (s *SwapService) swapETHClient(offerID types.Hash) (extethclient.EthClient, error) {
+	var chainID uint64
+
+	info, err := s.sm.GetOngoingSwapSnapshot(offerID)
+	if err != nil {
+		info, err = s.sm.GetPastSwap(offerID)
+	}
+	if err == nil {
+		chainID = info.ChainID
+	}
+
+	return s.backend.ETHClientForChain(chainID)
+}
+
+// ethBlockTime returns the block time of the chain with the given chain ID,
+// falling back to the block time of the primary chain.
+func (s *SwapService) ethBlockTime(chainID uint64) time.Duration {
+	if chainID != 0 && chainID != s.ethChain.ChainID {
+		if chain, err := common.EthChainByID(chainID); err == nil {
+			return chain.BlockTime
+		}
+	}
+
+	return s.ethChain.BlockTime
+}
+
+// GetContractEventsRequest is the request of swap_getContractEvents. The chain
+// ID defaults to the primary chain, the SwapCreator address to the contract
+// that we use on the chain, and the last block to the latest block.
+type GetContractEventsRequest struct {
+	ChainID         uint64             `json:"chainID,omitempty"`
+	SwapCreatorAddr *ethcommon.Address `json:"swapCreatorAddr,omitempty"`
+	FromBlock       uint64             `json:"fromBlock"`
+	ToBlock         *uint64            `json:"toBlock,omitempty"`
+}
+
+// ContractEvent is a SwapCreator event of a swap returned by
+// swap_getContractEvents.
+type ContractEvent struct {
+	Name        string      `json:"name" validate:"required"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      types.Hash  `json:"txHash" validate:"required"`
+	Secret      *types.Hash `json:"secret,omitempty"` // only set for Claimed and Refunded events
+}
+
+// ContractSwap is the lifecycle of a swap returned by swap_getContractEvents.
+// The values from the swap's New event are not set if it was emitted before
+// the scanned block range.
+type ContractSwap struct {
+	SwapID   types.Hash       `json:"swapID" validate:"required"`
+	Asset    *types.EthAsset  `json:"asset,omitempty"`
+	Value    *big.Int         `json:"value,omitempty"` // in the asset's smallest unit
+	Timeout1 *time.Time       `json:"timeout1,omitempty"`
+	Timeout2 *time.Time       `json:"timeout2,omitempty"`
+	Stage    string           `json:"stage" validate:"required"`
+	Expired  bool             `json:"expired"` // still pending or ready after timeout2
+	Events   []*ContractEvent `json:"events" validate:"dive,required"`
+}
+
+// GetContractEventsResponse ...
+type GetContractEventsResponse struct {
+	ChainID         uint64            `json:"chainID"`
+	SwapCreatorAddr ethcommon.Address `json:"swapCreatorAddr" validate:"required"`
+	FromBlock       uint64            `json:"fromBlock"`
+	ToBlock         uint64            `json:"toBlock"`
+	Swaps           []*ContractSwap   `json:"swaps" validate:"dive,required"`
+}
+
+// GetContractEvents scans a SwapCreator contract for the New, Ready, Claimed
+// and Refunded events in a block range and returns them joined by swap ID,
+// ordered by each swap's first event in the range. Swaps that are still
+// pending or ready after their timeout2 are flagged as expired.
+func (s *SwapService) GetContractEvents(
+	_ *http.Request,
+	req *GetContractEventsRequest,
+	resp *GetContractEventsResponse,
+) error {
+	extendedEC, err := s.backend.ETHClientForChain(req.ChainID)
+	if err != nil {
+		return err
+	}
+	ec := extendedEC.Raw()
+
+	var swapCreatorAddr ethcommon.Address
+	if req.SwapCreatorAddr != nil {
+		swapCreatorAddr = *req.SwapCreatorAddr
+	} else {
+		swapCreatorAddr, err = s.backend.SwapCreatorAddrForChain(req.ChainID)
+		if err != nil {
+			return err
+		}
+	}
+
+	var toBlock uint64
+	if req.ToBlock != nil {
+		toBlock = *req.ToBlock
+	} else {
+		latest, err := ec.BlockNumber(s.ctx)
+		if err != nil {
+			return err
+		}
+		toBlock = latest
+	}
+
+	lifecycles, err := contracts.GetSwapLifecycles(s.ctx, ec, swapCreatorAddr, req.FromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	resp.ChainID = extendedEC.ChainID().Uint64()
+	resp.SwapCreatorAddr = swapCreatorAddr
+	resp.FromBlock = req.FromBlock
+	resp.ToBlock = toBlock
+	resp.Swaps = make([]*ContractSwap, 0, len(lifecycles))
+	for _, lc := range lifecycles {
+		swap := &ContractSwap{
+			SwapID:  lc.SwapID,
+			Stage:   contracts.StageToString(lc.Stage),
+			Expired: lc.Expired,
+			Events:  make([]*ContractEvent, 0, len(lc.Events)),
+		}
+
+		if lc.New != nil {
+			asset := types.EthAsset(lc.New.Asset)
+			t1 := time.Unix(lc.New.Timeout1.Int64(), 0)
+			t2 := time.Unix(lc.New.Timeout2.Int64(), 0)
+			swap.Asset = &asset
+			swap.Value = lc.New.Value
+			swap.Timeout1 = &t1
+			swap.Timeout2 = &t2
+		}
+
+		for _, ev := range lc.Events {
+			swap.Events = append(swap.Events, &ContractEvent{
+				Name:        ev.Name,
+				BlockNumber: ev.BlockNumber,
+				TxHash:      types.Hash(ev.TxHash),
+				Secret:      ev.Secret,
+			})
+		}
+
+		resp.Swaps = append(resp.Swaps, swap)
+	}
+
+	return nil
+}
+
 // SuggestedExchangeRateResponse ...
 type SuggestedExchangeRateResponse struct {
 	ETHUpdatedAt time.Time           `json:"ethUpdatedAt" validate:"required"`
@@ -436,22 +884,25 @@ func (s *SwapService) SuggestedExchangeRate(_ *http.Request, _ *interface{}, res
 }
 
 // estimatedTimeToCompletion returns the estimated time for the swap to complete
-// in the optimistic case based on the given status and the time the status was updated.
+// in the optimistic case based on the given status, the time the status was updated
+// and the current time.
 func estimatedTimeToCompletion(
 	env common.Environment,
+	ethBlockTime time.Duration,
 	status types.Status,
 	lastStatusUpdateTime time.Time,
+	now time.Time,
 ) (time.Duration, error) {
-	if time.Until(lastStatusUpdateTime) > 0 {
+	if lastStatusUpdateTime.After(now) {
 		return 0, fmt.Errorf("last status update time must be less than now")
 	}
 
-	timeForStatus, err := estimatedTimeToCompletionForStatus(env, status)
+	timeForStatus, err := estimatedTimeToCompletionForStatus(env, ethBlockTime, status)
 	if err != nil {
 		return 0, err
 	}
 
-	estimatedTime := timeForStatus - time.Since(lastStatusUpdateTime)
+	estimatedTime := timeForStatus - now.Sub(lastStatusUpdateTime)
 	if estimatedTime < 0 {
 		// TODO: add explanation as to why time to completion can't be estimated,
 		// probably because we need to wait for the countparty to refund, or
@@ -464,19 +915,15 @@ func estimatedTimeToCompletion(
 
 // estimatedTimeToCompletionForStatus returns the estimated time for the swap to complete
 // in the optimistic case based on the given status, assuming the status was updated just now.
-func estimatedTimeToCompletionForStatus(env common.Environment, status types.Status) (time.Duration, error) {
-	var (
-		moneroBlockTime time.Duration
-		ethBlockTime    time.Duration
-	)
-
-	switch env {
-	case common.Development:
+// The ethereum block time is the block time of the chain being used.
+func estimatedTimeToCompletionForStatus(
+	env common.Environment,
+	ethBlockTime time.Duration,
+	status types.Status,
+) (time.Duration, error) {
+	moneroBlockTime := time.Minute * 2
+	if env == common.Development {
 		moneroBlockTime = time.Second
-		ethBlockTime = time.Second
-	default:
-		moneroBlockTime = time.Minute * 2
-		ethBlockTime = time.Second * 12
 	}
 
 	// we assume the Monero lock step will take 10 blocks, and for the taker,
@@ -45,3 +45,21 @@ func (c *Client) GetSwapSecret(offerID types.Hash) (*rpc.GetSwapSecretResponse,
 
 	return res, nil
 }
+
+// Backup calls database_backup.
+func (c *Client) Backup(archivePath string) (*rpc.BackupResponse, error) {
+	const (
+		method = "database_backup"
+	)
+
+	req := &rpc.BackupRequest{
+		Path: archivePath,
+	}
+
+	res := &rpc.BackupResponse{}
+	if err := c.post(method, req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -11,29 +11,59 @@ import (
 	"github.com/athanorlabs/atomic-swap/common/types"
 )
 
-// MakeOffer calls net_makeOffer.
+// MakeOffer calls net_makeOffer. The extra parameter is optional and can be
+// nil.
 func (c *Client) MakeOffer(
 	min, max *apd.Decimal,
 	exchangeRate *coins.ExchangeRate,
 	ethAsset types.EthAsset,
-	useRelayer bool,
+	extra *types.OfferExtra,
+) (*rpctypes.MakeOfferResponse, error) {
+	return c.MakeOfferForChain(0, min, max, exchangeRate, ethAsset, extra)
+}
+
+// MakeOfferForChain calls net_makeOffer with an offer on the EVM chain with the
+// given chain ID. A zero chain ID is the chain of swapd's p2p network.
+func (c *Client) MakeOfferForChain(
+	chainID uint64,
+	min, max *apd.Decimal,
+	exchangeRate *coins.ExchangeRate,
+	ethAsset types.EthAsset,
+	extra *types.OfferExtra,
 ) (*rpctypes.MakeOfferResponse, error) {
 	const (
 		method = "net_makeOffer"
 	)
 
+	req := newMakeOfferRequest(chainID, min, max, exchangeRate, ethAsset, extra)
+	res := &rpctypes.MakeOfferResponse{}
+
+	if err := c.post(method, req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func newMakeOfferRequest(
+	chainID uint64,
+	min, max *apd.Decimal,
+	exchangeRate *coins.ExchangeRate,
+	ethAsset types.EthAsset,
+	extra *types.OfferExtra,
+) *rpctypes.MakeOfferRequest {
 	req := &rpctypes.MakeOfferRequest{
 		MinAmount:    min,
 		MaxAmount:    max,
 		ExchangeRate: exchangeRate,
 		EthAsset:     ethAsset,
-		UseRelayer:   useRelayer,
+		ChainID:      chainID,
 	}
-	res := &rpctypes.MakeOfferResponse{}
 
-	if err := c.post(method, req, res); err != nil {
-		return nil, err
+	if extra != nil {
+		req.UseRelayer = extra.UseRelayer
+		req.XMRPriority = extra.XMRPriority
 	}
 
-	return res, nil
+	return req
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/athanorlabs/atomic-swap/coins"
 	"github.com/athanorlabs/atomic-swap/common"
+	"github.com/athanorlabs/atomic-swap/common/clock"
 	"github.com/athanorlabs/atomic-swap/common/types"
 	mcrypto "github.com/athanorlabs/atomic-swap/crypto/monero"
 	"github.com/athanorlabs/atomic-swap/db"
@@ -79,7 +80,7 @@ func mockSwapManager(t *testing.T) swap.Manager {
 	})
 	require.NoError(t, err)
 
-	sm, err := swap.NewManager(db)
+	sm, err := swap.NewManager(db, clock.Local())
 	require.NoError(t, err)
 
 	one := apd.New(1, 0)
@@ -94,6 +95,7 @@ func mockSwapManager(t *testing.T) swap.Manager {
 		types.EthAssetETH,
 		types.CompletedSuccess,
 		1,
+		clock.Local(),
 	))
 
 	sm.PushNewStatus(testSwapID, types.CompletedSuccess)
@@ -107,6 +109,10 @@ func (*mockXMRTaker) Provides() coins.ProvidesCoin {
 	panic("not implemented")
 }
 
+func (*mockXMRTaker) CheckOfferMinAmount(_ *types.Offer) error {
+	return nil
+}
+
 func (*mockXMRTaker) GetOngoingSwapState(_ types.Hash) common.SwapState {
 	return new(mockSwapState)
 }
@@ -137,7 +143,7 @@ func (m *mockXMRMaker) GetOngoingSwapState(_ types.Hash) common.SwapState {
 	panic("not implemented")
 }
 
-func (*mockXMRMaker) MakeOffer(_ *types.Offer, _ bool) (*types.OfferExtra, error) {
+func (*mockXMRMaker) MakeOffer(_ *types.Offer, _ *types.OfferExtra) (*types.OfferExtra, error) {
 	offerExtra := types.NewOfferExtra(false)
 	return offerExtra, nil
 }
@@ -192,6 +198,10 @@ func (*mockProtocolBackend) Env() common.Environment {
 	return common.Development
 }
 
+func (*mockProtocolBackend) Clock() clock.Clock {
+	return clock.Local()
+}
+
 func (*mockProtocolBackend) SetSwapTimeout(_ time.Duration) {
 	panic("not implemented")
 }
@@ -216,15 +226,35 @@ func (*mockProtocolBackend) ETHClient() extethclient.EthClient {
 	panic("not implemented")
 }
 
+func (*mockProtocolBackend) PayoutAddress() ethcommon.Address {
+	return ethcommon.Address{}
+}
+
+func (*mockProtocolBackend) PayoutFallback() bool {
+	return false
+}
+
+func (*mockProtocolBackend) ETHClientForChain(uint64) (extethclient.EthClient, error) {
+	panic("not implemented")
+}
+
 func (*mockProtocolBackend) SwapCreatorAddr() ethcommon.Address {
 	return ethcommon.Address{}
 }
 
-func (*mockProtocolBackend) TransferXMR(_ *mcrypto.Address, _ *coins.PiconeroAmount) (string, error) {
+func (*mockProtocolBackend) SwapCreatorAddrForChain(uint64) (ethcommon.Address, error) {
+	return ethcommon.Address{}, nil
+}
+
+func (*mockProtocolBackend) TransferXMR(
+	_ *mcrypto.Address,
+	_ *coins.PiconeroAmount,
+	_ types.MoneroTxPriority,
+) (string, error) {
 	panic("not implemented")
 }
 
-func (*mockProtocolBackend) SweepXMR(_ *mcrypto.Address) ([]string, error) {
+func (*mockProtocolBackend) SweepXMR(_ *mcrypto.Address, _ types.MoneroTxPriority) ([]string, error) {
 	panic("not implemented")
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/apd/v3"
 
 	"github.com/athanorlabs/atomic-swap/common/rpctypes"
+	"github.com/athanorlabs/atomic-swap/common/types"
 	"github.com/athanorlabs/atomic-swap/rpc"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +43,29 @@ func TestNet_Query(t *testing.T) {
 	require.Equal(t, 1, len(resp.Offers))
 }
 
+// mockLowOfferXMRTaker rejects all offers as below the relayer fee
+type mockLowOfferXMRTaker struct {
+	mockXMRTaker
+}
+
+func (*mockLowOfferXMRTaker) CheckOfferMinAmount(_ *types.Offer) error {
+	return types.ErrMinAmountBelowRelayerFee
+}
+
+func TestNet_Query_belowRelayerFee(t *testing.T) {
+	ns := rpc.NewNetService(new(mockNet), new(mockLowOfferXMRTaker), nil, mockSwapManager(t), false)
+
+	req := &rpctypes.QueryPeerRequest{
+		PeerID: "12D3KooWDqCzbjexHEa8Rut7bzxHFpRMZyDRW1L6TGkL1KY24JH5",
+	}
+
+	resp := new(rpctypes.QueryPeerResponse)
+
+	err := ns.QueryPeer(nil, req, resp)
+	require.NoError(t, err)
+	require.Empty(t, resp.Offers)
+}
+
 func TestNet_TakeOffer(t *testing.T) {
 	ns := rpc.NewNetService(new(mockNet), new(mockXMRTaker), nil, mockSwapManager(t), false)
 
@@ -0,0 +1,20 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpcclient
+
+import (
+	"github.com/athanorlabs/atomic-swap/rpc"
+)
+
+// RelayerStats returns the totals of the claims that the node relayed
+func (c *Client) RelayerStats() (*rpc.RelayerStatsResponse, error) {
+	const (
+		method = "relayer_stats"
+	)
+	resp := &rpc.RelayerStatsResponse{}
+	if err := c.post(method, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
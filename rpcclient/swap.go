@@ -120,6 +120,44 @@ func (c *Client) Refund(offerID types.Hash) (*rpc.ManualTransactionResponse, err
 	return res, nil
 }
 
+// ClaimBatch calls swap_claimBatch
+func (c *Client) ClaimBatch(offerIDs []types.Hash) (*rpc.BatchTransactionResponse, error) {
+	const (
+		method = "swap_claimBatch"
+	)
+
+	req := &rpc.BatchTransactionRequest{
+		OfferIDs: offerIDs,
+	}
+
+	res := &rpc.BatchTransactionResponse{}
+
+	if err := c.post(method, req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RefundBatch calls swap_refundBatch
+func (c *Client) RefundBatch(offerIDs []types.Hash) (*rpc.BatchTransactionResponse, error) {
+	const (
+		method = "swap_refundBatch"
+	)
+
+	req := &rpc.BatchTransactionRequest{
+		OfferIDs: offerIDs,
+	}
+
+	res := &rpc.BatchTransactionResponse{}
+
+	if err := c.post(method, req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // SuggestedExchangeRate calls swap_suggestedExchangeRate
 func (c *Client) SuggestedExchangeRate() (*rpc.SuggestedExchangeRateResponse, error) {
 	const (
@@ -133,3 +171,18 @@ func (c *Client) SuggestedExchangeRate() (*rpc.SuggestedExchangeRateResponse, er
 
 	return res, nil
 }
+
+// GetContractEvents calls swap_getContractEvents
+func (c *Client) GetContractEvents(req *rpc.GetContractEventsRequest) (*rpc.GetContractEventsResponse, error) {
+	const (
+		method = "swap_getContractEvents"
+	)
+
+	res := &rpc.GetContractEventsResponse{}
+
+	if err := c.post(method, req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
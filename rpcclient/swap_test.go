@@ -0,0 +1,61 @@
+// Copyright 2023 The AthanorLabs/atomic-swap Authors
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpcclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/athanorlabs/atomic-swap/coins"
+	"github.com/athanorlabs/atomic-swap/common/clock"
+	"github.com/athanorlabs/atomic-swap/common/types"
+	"github.com/athanorlabs/atomic-swap/protocol/swap"
+	"github.com/athanorlabs/atomic-swap/rpc"
+)
+
+// Ongoing swaps that are run by the swap protocol, which the mock XMR taker
+// has for every swap, must not be claimed or refunded by a batch too.
+func TestSwap_batchRunningSwap(t *testing.T) {
+	sm := mockSwapManager(t)
+	ongoingID := types.Hash{0x1}
+	one := apd.New(1, 0)
+	err := sm.AddSwap(swap.NewInfo(
+		testPeerID,
+		ongoingID,
+		coins.ProvidesETH,
+		one,
+		one,
+		coins.ToExchangeRate(one),
+		types.EthAssetETH,
+		types.ETHLocked,
+		1,
+		clock.Local(),
+	))
+	require.NoError(t, err)
+
+	ss := rpc.NewSwapService(
+		context.Background(),
+		sm,
+		new(mockXMRTaker),
+		new(mockXMRMaker),
+		new(mockNet),
+		newMockProtocolBackend(t),
+		nil,
+		nil,
+	)
+
+	req := &rpc.BatchTransactionRequest{OfferIDs: []types.Hash{ongoingID}}
+	err = ss.ClaimBatch(nil, req, new(rpc.BatchTransactionResponse))
+	require.ErrorContains(t, err, "is still running")
+	err = ss.RefundBatch(nil, req, new(rpc.BatchTransactionResponse))
+	require.ErrorContains(t, err, "is still running")
+
+	resp := new(rpc.BatchTransactionResponse)
+	err = ss.ClaimBatch(nil, new(rpc.BatchTransactionRequest), resp)
+	require.NoError(t, err)
+	require.Empty(t, resp.Transactions)
+}
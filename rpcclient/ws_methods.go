@@ -208,21 +208,30 @@ func (c *Client) readTakeOfferResponse(conn *websocket.Conn) (types.Status, erro
 }
 
 // MakeOfferAndSubscribe calls the server-side net_makeOfferAndSubscribe method
-// to make an offer and get status updates over websockets.
+// to make an offer and get status updates over websockets. The extra parameter
+// is optional and can be nil.
 func (c *Client) MakeOfferAndSubscribe(
 	min *apd.Decimal,
 	max *apd.Decimal,
 	exchangeRate *coins.ExchangeRate,
 	ethAsset types.EthAsset,
-	useRelayer bool,
+	extra *types.OfferExtra,
 ) (*rpctypes.MakeOfferResponse, <-chan types.Status, error) {
-	params := &rpctypes.MakeOfferRequest{
-		MinAmount:    min,
-		MaxAmount:    max,
-		ExchangeRate: exchangeRate,
-		EthAsset:     ethAsset,
-		UseRelayer:   useRelayer,
-	}
+	return c.MakeOfferForChainAndSubscribe(0, min, max, exchangeRate, ethAsset, extra)
+}
+
+// MakeOfferForChainAndSubscribe is the same as MakeOfferAndSubscribe, but
+// makes the offer on the EVM chain with the given chain ID. A zero chain ID is
+// the chain of swapd's p2p network.
+func (c *Client) MakeOfferForChainAndSubscribe(
+	chainID uint64,
+	min *apd.Decimal,
+	max *apd.Decimal,
+	exchangeRate *coins.ExchangeRate,
+	ethAsset types.EthAsset,
+	extra *types.OfferExtra,
+) (*rpctypes.MakeOfferResponse, <-chan types.Status, error) {
+	params := newMakeOfferRequest(chainID, min, max, exchangeRate, ethAsset, extra)
 
 	bz, err := vjson.MarshalStruct(params)
 	if err != nil {
@@ -89,7 +89,7 @@ func TestSubscribeMakeOffer(t *testing.T) {
 	min := coins.StrToDecimal("0.1")
 	max := coins.StrToDecimal("1")
 	exRate := coins.ToExchangeRate(coins.StrToDecimal("0.05"))
-	offerResp, ch, err := c.MakeOfferAndSubscribe(min, max, exRate, types.EthAssetETH, false)
+	offerResp, ch, err := c.MakeOfferAndSubscribe(min, max, exRate, types.EthAssetETH, nil)
 	require.NoError(t, err)
 	require.NotEqual(t, offerResp.OfferID, testSwapID)
 
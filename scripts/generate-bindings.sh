@@ -44,6 +44,10 @@ compile-contract() {
 
 compile-contract SwapCreator.sol SwapCreator swap_creator
 compile-contract TestERC20.sol TestERC20 erc20_token
+compile-contract TestERC20Permit.sol TestERC20Permit erc20_permit_token
+compile-contract TestERC20FeeOnTransfer.sol TestERC20FeeOnTransfer erc20_fee_on_transfer_token
+compile-contract ERC20TransferProbe.sol ERC20TransferProbe erc20_transfer_probe
+compile-contract TestWallet.sol TestWallet test_wallet
 compile-contract @openzeppelin/contracts/token/ERC20/extensions/IERC20Metadata.sol IERC20 ierc20
 compile-contract AggregatorV3Interface.sol AggregatorV3Interface aggregator_v3_interface
 
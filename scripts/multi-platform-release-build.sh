@@ -19,6 +19,7 @@ install_targets=(
 	"github.com/athanorlabs/atomic-swap/cmd/swapd@${version}"
 	"github.com/athanorlabs/atomic-swap/cmd/swapcli@${version}"
 	"github.com/athanorlabs/atomic-swap/cmd/bootnode@${version}"
+	"github.com/athanorlabs/atomic-swap/cmd/swaprelayer@${version}"
 )
 
 # turn on echo
@@ -38,6 +38,7 @@ var testPackages = []struct {
 }{
 	{"cmd/swapcli", 2},
 	{"cmd/swapd", 2},
+	{"cmd/swaprelayer", 1},
 	{"daemon", 2},
 	{"ethereum", 16},
 	{"ethereum/block", 2},
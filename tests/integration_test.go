@@ -109,7 +109,7 @@ func mineMinXMRMakerBalance(t *testing.T, minBalance *coins.PiconeroAmount) {
 func (s *IntegrationTestSuite) TestXMRTaker_Discover() {
 	ctx := context.Background()
 	bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
-	_, err := bc.MakeOffer(xmrmakerProvideAmount, xmrmakerProvideAmount, exchangeRate, types.EthAssetETH, false)
+	_, err := bc.MakeOffer(xmrmakerProvideAmount, xmrmakerProvideAmount, exchangeRate, types.EthAssetETH, nil)
 	require.NoError(s.T(), err)
 
 	// Give offer advertisement time to propagate
@@ -136,7 +136,7 @@ func (s *IntegrationTestSuite) TestXMRTaker_Query() {
 func (s *IntegrationTestSuite) testXMRTakerQuery(asset types.EthAsset) {
 	ctx := context.Background()
 	bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
-	offerResp, err := bc.MakeOffer(xmrmakerProvideAmount, xmrmakerProvideAmount, exchangeRate, asset, false)
+	offerResp, err := bc.MakeOffer(xmrmakerProvideAmount, xmrmakerProvideAmount, exchangeRate, asset, nil)
 	require.NoError(s.T(), err)
 
 	require.NoError(s.T(), common.SleepWithContext(ctx, time.Second)) // Give offer advertisement time to propagate
@@ -176,7 +176,7 @@ func (s *IntegrationTestSuite) testSuccessOneSwap(asset types.EthAsset, useRelay
 	bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
 	min := coins.StrToDecimal("0.21")
 	offerResp, statusCh, err := bc.MakeOfferAndSubscribe(min, xmrmakerProvideAmount,
-		exchangeRate, asset, useRelayer)
+		exchangeRate, asset, types.NewOfferExtra(useRelayer))
 	require.NoError(s.T(), err)
 
 	beforeResp, err := bc.GetOffers()
@@ -268,7 +268,7 @@ func (s *IntegrationTestSuite) testRefundXMRTakerCancels(asset types.EthAsset) {
 
 	bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
 	offerResp, statusCh, err := bc.MakeOfferAndSubscribe(xmrmakerProvideAmount, xmrmakerProvideAmount,
-		exchangeRate, asset, false)
+		exchangeRate, asset, nil)
 	require.NoError(s.T(), err)
 
 	beforeResp, err := bc.GetOffers()
@@ -402,7 +402,7 @@ func (s *IntegrationTestSuite) testRefundXMRMakerCancels(
 	bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
 
 	offerResp, statusCh, err := bc.MakeOfferAndSubscribe(xmrmakerProvideAmount, xmrmakerProvideAmount,
-		exchangeRate, types.EthAssetETH, false)
+		exchangeRate, types.EthAssetETH, nil)
 	require.NoError(s.T(), err)
 
 	beforeResp, err := bc.GetOffers()
@@ -512,7 +512,7 @@ func (s *IntegrationTestSuite) testAbortXMRTakerCancels(asset types.EthAsset) {
 
 	min := coins.StrToDecimal("0.21")
 	offerResp, statusCh, err := bc.MakeOfferAndSubscribe(min, xmrmakerProvideAmount,
-		exchangeRate, asset, false)
+		exchangeRate, asset, nil)
 	require.NoError(s.T(), err)
 
 	beforeResp, err := bc.GetOffers()
@@ -619,7 +619,7 @@ func (s *IntegrationTestSuite) testAbortXMRMakerCancels(asset types.EthAsset) {
 	bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
 
 	offerResp, statusCh, err := bc.MakeOfferAndSubscribe(xmrmakerProvideAmount, xmrmakerProvideAmount,
-		exchangeRate, asset, false)
+		exchangeRate, asset, nil)
 	require.NoError(s.T(), err)
 
 	beforeResp, err := bc.GetOffers()
@@ -716,7 +716,7 @@ func (s *IntegrationTestSuite) testErrorShouldOnlyTakeOfferOnce(asset types.EthA
 	defer cancel()
 
 	bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
-	offerResp, err := bc.MakeOffer(xmrmakerProvideAmount, xmrmakerProvideAmount, exchangeRate, asset, false)
+	offerResp, err := bc.MakeOffer(xmrmakerProvideAmount, xmrmakerProvideAmount, exchangeRate, asset, nil)
 	require.NoError(s.T(), err)
 
 	// Give offer advertisement time to propagate
@@ -835,7 +835,7 @@ func (s *IntegrationTestSuite) testSuccessConcurrentSwaps(asset types.EthAsset)
 	for i := 0; i < numConcurrentSwaps; i++ {
 		bc := rpcclient.NewClient(ctx, defaultXMRMakerSwapdPort)
 		offerResp, statusCh, err := bc.MakeOfferAndSubscribe(xmrmakerProvideAmount, xmrmakerProvideAmount, //nolint:govet
-			exchangeRate, asset, false)
+			exchangeRate, asset, nil)
 		require.NoError(s.T(), err)
 
 		s.T().Logf("XMRMaker[%d] made offer %s", i, offerResp.OfferID)